@@ -7,6 +7,26 @@ const (
 	// GangCardinalityAnnotation All jobs in a gang must specify the total number of jobs in the gang via this annotation.
 	// The cardinality should be expressed as an integer, e.g., "3".
 	GangCardinalityAnnotation = "armadaproject.io/gangCardinality"
+	// GangMinimumCardinalityAnnotation Jobs in a gang may optionally specify the minimum number of jobs in the gang
+	// that must be scheduled for the gang to be considered scheduled. Jobs beyond this minimum are scheduled if they fit.
+	// If not provided, all jobs in the gang must be scheduled. The value should be expressed as an integer, e.g., "2".
+	GangMinimumCardinalityAnnotation = "armadaproject.io/gangMinimumCardinality"
+	// GangUniqueNodesAnnotation If set to "true" for the jobs in a gang, each job in the gang is scheduled onto a different node.
+	GangUniqueNodesAnnotation = "armadaproject.io/gangUniqueNodes"
+	// GangMaxNodeSpanAnnotation Jobs in a gang may optionally specify the maximum number of distinct nodes the gang
+	// may be spread across. Placements requiring more nodes are rejected, even if the gang would otherwise fit.
+	// If not provided, the number of nodes is not limited. The value should be expressed as an integer, e.g., "4".
+	GangMaxNodeSpanAnnotation = "armadaproject.io/gangMaxNodeSpan"
+	// GangNodeSelectorAnnotation Jobs in a gang may optionally specify node labels that all nodes the gang is scheduled
+	// onto must have, in addition to the node selector of each job. Expressed as comma-separated label=value pairs,
+	// e.g., "node-pool=gpu-a100,zone=a". Invalid values are ignored.
+	GangNodeSelectorAnnotation = "armadaproject.io/gangNodeSelector"
+	// GangPreferredNodeAffinityAnnotation Jobs in a gang may optionally specify weighted node affinity terms
+	// preferred for all jobs in the gang, e.g., to co-locate the gang near a data source.
+	// Expressed as a JSON-encoded list of Kubernetes preferred scheduling terms,
+	// e.g., `[{"weight":10,"preference":{"matchExpressions":[{"key":"zone","operator":"In","values":["a"]}]}}]`.
+	// Invalid values are ignored.
+	GangPreferredNodeAffinityAnnotation = "armadaproject.io/gangPreferredNodeAffinity"
 	// Armada normally tries to re-schedule jobs for which a pod fails to start.
 	// Pods for which this annotation has value "true" are not retried.
 	// Instead, the job the pod is part of fails immediately.
@@ -16,6 +36,11 @@ const (
 var ArmadaManagedAnnotations = []string{
 	GangIdAnnotation,
 	GangCardinalityAnnotation,
+	GangMinimumCardinalityAnnotation,
+	GangUniqueNodesAnnotation,
+	GangMaxNodeSpanAnnotation,
+	GangNodeSelectorAnnotation,
+	GangPreferredNodeAffinityAnnotation,
 	FailFastAnnotation,
 }
 
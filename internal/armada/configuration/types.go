@@ -95,16 +95,51 @@ type SchedulingConfig struct {
 	MaximumResourceFractionToSchedule map[string]float64
 	// Overrides MaximalClusterFractionToSchedule if set for the current pool.
 	MaximumResourceFractionToScheduleByPool map[string]map[string]float64
+	// Maps pool name to the minimum resources each job scheduled in that pool must request.
+	// Gangs are rejected if any of their jobs requests less than this.
+	// Applies in addition to the minimum job size provided by each executor.
+	MinimumJobSizeByPool map[string]armadaresource.ComputeResources
 	// Max number of jobs to schedule in each invocation of the scheduler.
 	MaximumJobsToSchedule uint
 	// Max number of gangs to schedule in each invocation of the scheduler.
 	MaximumGangsToSchedule uint
 	// Armada stores contexts associated with recent job scheduling attempts.
-	// This setting limits the number of such contexts to store.
+	// This setting limits the number of such contexts to store for each executor.
 	// Contexts associated with the most recent scheduling attempt for each queue and cluster are always stored.
 	MaxJobSchedulingContextsPerExecutor uint
-	Lease                               LeaseSettings
-	DefaultJobLimits                    armadaresource.ComputeResources
+	// Maps executor id to the number of job scheduling contexts to store for that executor,
+	// overriding MaxJobSchedulingContextsPerExecutor, e.g., to retain more contexts for executors of large clusters.
+	MaxJobSchedulingContextsByExecutor map[string]uint
+	// Number of recent scheduling contexts to store for each executor.
+	// If zero, only the most recent, most recent successful, and most recent preempting contexts are stored.
+	SchedulingContextHistoryLength uint
+	// Scheduling contexts of executors that haven't been scheduled for within this duration are removed
+	// from scheduling reports. If zero, contexts are never removed.
+	SchedulingContextExecutorTtl time.Duration
+	// Number of job ids printed per list of jobs in queue reports at verbosity JOBS;
+	// at verbosity FULL, all job ids are printed. If zero, all job ids are printed.
+	MaxPrintedJobIdsPerVerbosityLevel uint
+	// Number of samples of the resources scheduled to each queue and its fair share to store per queue;
+	// a sample is stored for each scheduling attempt that considers the queue.
+	// If zero, no samples are stored. Each sample is small, but one is stored per queue and attempt.
+	QueueFairShareHistoryLength uint
+	// If set, scheduling contexts are periodically written to this file and reloaded from it on startup,
+	// such that scheduling reports survive restarts. Loading is best-effort; if the file is missing or corrupt,
+	// the server starts with no contexts. If empty, contexts are only stored in memory.
+	SchedulingContextSnapshotPath string
+	// Interval at which scheduling contexts are written to SchedulingContextSnapshotPath.
+	SchedulingContextSnapshotInterval time.Duration
+	// If non-zero, scheduling contexts are stored asynchronously: up to this many contexts are buffered and
+	// stored by a background goroutine, such that storing contexts doesn't slow down scheduling.
+	// Contexts produced while the buffer is full are discarded and counted in a metric.
+	// If zero, contexts are stored before each scheduling round completes.
+	SchedulingContextIngestionBufferSize uint
+	// If true, the resources scheduled and evicted and the number of jobs scheduled and not scheduled
+	// in the most recent scheduling attempt of each queue and executor are exported as Prometheus metrics.
+	// The number of series exported grows with the number of executors, queues, and priority classes.
+	ExposeSchedulingReportMetrics bool
+	Lease                         LeaseSettings
+	DefaultJobLimits              armadaresource.ComputeResources
 	// Set of tolerations added to all submitted pods.
 	DefaultJobTolerations []v1.Toleration
 	// Set of tolerations added to all submitted pods of a given priority class.
@@ -113,10 +148,38 @@ type SchedulingConfig struct {
 	DefaultJobTolerationsByResourceRequest map[string][]v1.Toleration
 	// Maximum number of times a job is retried before considered failed.
 	MaxRetries uint
+	// Ratio by which each resource type may be overcommitted, e.g., {"nvidia.com/gpu": 4} for GPUs shared
+	// via time-slicing. Resources of types with a ratio are considered available up to total * ratio.
+	// Applies only to the old scheduler.
+	ResourceOvercommitRatios map[string]float64
+	// Determines which node types are preferred when several nodes are equally suitable for a job.
+	// Must be one of:
+	// - "" (the default): no node type is preferred.
+	// - "spread": prefer the least allocated node types, such that load is spread evenly across node types.
+	// - "pack": prefer the most allocated node types, such that partially used node types are filled up
+	//   before empty node types are used.
+	// Node types are ranked once per lease request, based on the resources allocated at the time of the request.
+	// Applies only to the old scheduler.
+	NodeTypeAllocationStrategy string
+	// If true, node types are ranked by resources weighted by the resource scarcity of the pool;
+	// see GetResourceScarcity. With the default NodeTypeAllocationStrategy, node types with the fewest
+	// scarce resources remaining are then preferred, such that node types with many scarce resources,
+	// e.g., gpu nodes, are conserved for jobs that need them.
+	// Applies only to the old scheduler.
+	WeighNodeTypesByResourceScarcity bool
 	// Weights used when computing fair share.
 	// Overrides dynamic scarcity calculation if provided.
+	// Resource types not listed have a weight of 0; if empty, the new scheduler weighs all resource types equally.
 	// Applies to both the new and old scheduler.
 	ResourceScarcity map[string]float64
+	// Determines how the resource usage of queues is compared when deciding which queue to schedule from next.
+	// Must be one of:
+	// - "" (the default): usage is the sum of resources used weighted by ResourceScarcity.
+	// - "dominantResource": usage is the largest fraction of any resource type, scaled by its weight in ResourceScarcity
+	//   relative to the largest weight, i.e., weighted dominant resource fairness; with equal weights,
+	//   queues using mostly gpus and queues using mostly cpu are treated equally.
+	// Applies to both the new and old scheduler.
+	FairnessModel string
 	// Applies only to the old scheduler.
 	PoolResourceScarcity map[string]map[string]float64
 	MaxPodSpecSizeBytes  uint
@@ -127,6 +190,14 @@ type SchedulingConfig struct {
 	// In particular, the score expresses whether preemption is necessary to schedule a pod.
 	// Hence, a larger MaxExtraNodesToConsider would reduce the expected number of preemptions.
 	MaxExtraNodesToConsider uint
+	// Determines which node is selected when several of the considered nodes are equally good.
+	// Must be one of:
+	// - "" (the default): select the first node found.
+	// - "leastAvailable": select the node with the least allocatable resources, to pack jobs tightly.
+	// - "lowestNodeId": select the node with the lowest id.
+	// If set, all matching nodes are compared, regardless of MaxExtraNodesToConsider,
+	// such that the selected node is deterministic. This requires scanning every matching node per pod.
+	NodeTieBreakPolicy string
 	// Resources, e.g., "cpu", "memory", and "nvidia.com/gpu",
 	// for which the scheduler creates indexes for efficient lookup.
 	// Applies only to the new scheduler.
@@ -209,6 +280,15 @@ type PreemptionConfig struct {
 	// the probability of evicting jobs on oversubscribed nodes, i.e.,
 	// nodes on which the total resource requests are greater than the available resources.
 	NodeOversubscriptionEvictionProbability float64
+	// If true, jobs on the nodes selected for a new gang may be preempted to make room for it,
+	// if the gang doesn't otherwise fit onto those nodes.
+	// Only preemptible jobs of priority lower than both that of the gang and GangPreemptionPriorityThreshold are preempted.
+	EnableGangPreemption bool
+	// If EnableGangPreemption is true, only jobs of priority strictly lower than this threshold may be preempted.
+	GangPreemptionPriorityThreshold int32
+	// If EnableGangPreemption is true, jobs that have been running for less than this duration aren't preempted,
+	// such that jobs that have only just started aren't repeatedly preempted.
+	GangPreemptionMinimumRuntime time.Duration
 	// If true, the Armada scheduler will add to scheduled pods a node selector
 	// NodeIdLabel: <value of label on node selected by scheduler>.
 	// If true, NodeIdLabel must be non-empty.
@@ -240,6 +320,17 @@ type PriorityClass struct {
 	// jobs of this priority class are not scheduled if doing so would cause the total resources assigned
 	// to jobs of priority 10 or lower from the same queue to exceed 30% of the total.
 	MaximumResourceFractionPerQueue map[string]float64
+	// Like MaximumResourceFractionPerQueue, except limits are expressed as absolute quantities.
+	// Hence, unlike fractional limits, these don't change as the total amount of resources changes, e.g., due to autoscaling.
+	//
+	// If both are set for a resource, the more restrictive limit applies.
+	MaximumResourcesPerQueue armadaresource.ComputeResources
+	// Limits resources assigned to jobs of priority equal to that of this priority class in each invocation of the scheduler,
+	// across all queues. Jobs of this priority class are only scheduled if doing so does not exceed this limit.
+	//
+	// For example, if MaximumResourceFractionToSchedule is map[string]float64{"cpu": 0.1},
+	// at most 10% of the total cpu is assigned to new jobs at the priority of this priority class in each invocation.
+	MaximumResourceFractionToSchedule map[string]float64
 }
 
 func (p PreemptionConfig) PriorityByPriorityClassName() map[string]int32 {
@@ -20,4 +20,7 @@ const (
 	WatchAllEvents                            = "watch_all_events"
 	ExecuteJobs                               = "execute_jobs"
 	CordonNodes                               = "cordon_nodes"
+	DrainExecutors                            = "drain_executors"
+	ForgetJobs                                = "forget_jobs"
+	PauseQueues                               = "pause_queues"
 )
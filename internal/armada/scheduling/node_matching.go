@@ -116,11 +116,15 @@ func matchAnyNodeType(podSpec *v1.PodSpec, nodeTypes []*api.NodeType) (bool, err
 	return false, result
 }
 
+// matchAnyNodeTypePodAllocation returns the first node type the pod can be assigned to,
+// considering node types in the order given by strategy and resourceScarcity; see orderNodeTypeAllocations.
 func matchAnyNodeTypePodAllocation(
 	podSpec *v1.PodSpec,
 	nodeAllocations []*nodeTypeAllocation,
 	alreadyConsumed nodeTypeUsedResources,
 	newlyConsumed nodeTypeUsedResources,
+	strategy NodeTypeAllocationStrategy,
+	resourceScarcity map[string]float64,
 ) (*nodeTypeAllocation, bool, error) {
 	if len(nodeAllocations) == 0 {
 		return nil, false, errors.Errorf("no nodes available")
@@ -128,14 +132,14 @@ func matchAnyNodeTypePodAllocation(
 
 	podMatchingContext := NewPodMatchingContext(podSpec)
 	var result *armadaerrors.ErrPodUnschedulable
-	for _, node := range nodeAllocations {
-		available := node.availableResources.DeepCopy()
-		available.Sub(alreadyConsumed[node])
-		available.Sub(newlyConsumed[node])
+	for _, node := range orderNodeTypeAllocations(strategy, resourceScarcity, nodeAllocations, alreadyConsumed, newlyConsumed) {
+		available, _ := node.remainingResources(alreadyConsumed, newlyConsumed)
 		available.LimitWith(armadaresource.ComputeResources(node.nodeType.AllocatableResources).AsFloat())
+		// Round down to milli-unit precision, such that floating-point error accumulated when summing
+		// and subtracting resources never makes more resources appear available than there are.
+		available = available.AsQuantities(armadaresource.RoundingModeFloor).AsFloat()
 
-		resources := available
-		ok, err := podMatchingContext.Matches(&node.nodeType, resources)
+		ok, err := podMatchingContext.Matches(&node.nodeType, available)
 		switch {
 		case ok:
 			return node, true, nil
@@ -148,8 +152,33 @@ func matchAnyNodeTypePodAllocation(
 	return nil, false, result
 }
 
+// NodeTypeRankByNodeName returns, for each node, the position of its node type in the order in which node types
+// should be considered according to strategy and resourceScarcity; see orderNodeTypeAllocations.
+// Nodes of node types not in nodeAllocations are omitted.
+func NodeTypeRankByNodeName(
+	nodes []api.NodeInfo,
+	nodeAllocations []*nodeTypeAllocation,
+	strategy NodeTypeAllocationStrategy,
+	resourceScarcity map[string]float64,
+) map[string]int {
+	rankByDescription := make(map[string]int, len(nodeAllocations))
+	for i, node := range orderNodeTypeAllocations(strategy, resourceScarcity, nodeAllocations) {
+		rankByDescription[node.description] = i
+	}
+	result := make(map[string]int, len(nodes))
+	for i := range nodes {
+		if rank, ok := rankByDescription[createNodeDescription(&nodes[i])]; ok {
+			result[nodes[i].Name] = rank
+		}
+	}
+	return result
+}
+
 // AggregateNodeTypeAllocations computes the total available resources for each node type.
-func AggregateNodeTypeAllocations(nodes []api.NodeInfo) []*nodeTypeAllocation {
+// Node types without any resources, e.g., because all nodes of that type have been drained, are omitted.
+// For resource types with an entry in overcommitRatios, available resources are instead computed from the total
+// resources scaled by that ratio, less allocated resources; see nodeTypeAllocation.applyOvercommit.
+func AggregateNodeTypeAllocations(nodes []api.NodeInfo, overcommitRatios map[string]float64) []*nodeTypeAllocation {
 	nodeTypesIndex := map[string]*nodeTypeAllocation{}
 
 	for i, n := range nodes {
@@ -165,6 +194,7 @@ func AggregateNodeTypeAllocations(nodes []api.NodeInfo) []*nodeTypeAllocation {
 
 		if !exists {
 			typeDescription = &nodeTypeAllocation{
+				description: description,
 				nodeType: api.NodeType{
 					Taints:               n.Taints,
 					Labels:               n.Labels,
@@ -192,6 +222,10 @@ func AggregateNodeTypeAllocations(nodes []api.NodeInfo) []*nodeTypeAllocation {
 
 	var result []*nodeTypeAllocation
 	for _, n := range nodeTypesIndex {
+		if n.isEmpty() {
+			continue
+		}
+		n.applyOvercommit(overcommitRatios)
 		result = append(result, n)
 	}
 
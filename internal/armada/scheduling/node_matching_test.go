@@ -126,9 +126,10 @@ func Test_AggregateNodeTypesAllocations(t *testing.T) {
 		},
 	}
 
-	aggregated := AggregateNodeTypeAllocations(nodes)
+	aggregated := AggregateNodeTypeAllocations(nodes, nil)
 	expected := []*nodeTypeAllocation{
 		{
+			description: "tcpu=1|tmemory=3Gi",
 			nodeType: api.NodeType{
 				Taints:               nil,
 				Labels:               nil,
@@ -141,6 +142,7 @@ func Test_AggregateNodeTypesAllocations(t *testing.T) {
 			},
 		},
 		{
+			description: "tcpu=5|tmemory=5Gi",
 			nodeType: api.NodeType{
 				Taints:               nil,
 				Labels:               nil,
@@ -186,9 +188,10 @@ func Test_AggregateNodeTypesAllocations_NodesWithMoreTaintsGoFirst(t *testing.T)
 		},
 	}
 
-	aggregated := AggregateNodeTypeAllocations(nodes)
+	aggregated := AggregateNodeTypeAllocations(nodes, nil)
 	expected := []*nodeTypeAllocation{
 		{
+			description: "tcpu=5|tmemory=5Gi|tone=1|ttwo=2",
 			nodeType: api.NodeType{
 				Taints:               []v1.Taint{{Key: "one", Value: "1", Effect: "NoSchedule"}, {Key: "two", Value: "2", Effect: "NoSchedule"}},
 				Labels:               nil,
@@ -201,6 +204,7 @@ func Test_AggregateNodeTypesAllocations_NodesWithMoreTaintsGoFirst(t *testing.T)
 			},
 		},
 		{
+			description: "tcpu=1|tmemory=3Gi|tone=1",
 			nodeType: api.NodeType{
 				Taints:               []v1.Taint{{Key: "one", Value: "1", Effect: "NoSchedule"}},
 				Labels:               nil,
@@ -217,3 +221,133 @@ func Test_AggregateNodeTypesAllocations_NodesWithMoreTaintsGoFirst(t *testing.T)
 		assert.Equal(t, expected[i], aggregated[i])
 	}
 }
+
+func Test_AggregateNodeTypesAllocations_Overcommit(t *testing.T) {
+	nodes := []api.NodeInfo{
+		{
+			Name:                 "n1",
+			AllocatableResources: armadaresource.ComputeResources{"cpu": resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("2")},
+			AvailableResources:   armadaresource.ComputeResources{"cpu": resource.MustParse("3"), "nvidia.com/gpu": resource.MustParse("0")},
+			TotalResources:       armadaresource.ComputeResources{"cpu": resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("2")},
+			AllocatedResources: map[int32]api.ComputeResource{
+				0: {Resources: map[string]resource.Quantity{"cpu": resource.MustParse("0.5"), "nvidia.com/gpu": resource.MustParse("1.5")}},
+				1: {Resources: map[string]resource.Quantity{"cpu": resource.MustParse("0.5"), "nvidia.com/gpu": resource.MustParse("0.5")}},
+			},
+		},
+		{
+			Name:                 "n2",
+			AllocatableResources: armadaresource.ComputeResources{"cpu": resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("2")},
+			AvailableResources:   armadaresource.ComputeResources{"cpu": resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("2")},
+			TotalResources:       armadaresource.ComputeResources{"cpu": resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("2")},
+		},
+	}
+
+	aggregated := AggregateNodeTypeAllocations(nodes, map[string]float64{"nvidia.com/gpu": 2.5, "memory": 2})
+	assert.Len(t, aggregated, 1)
+	// Only gpu is overcommitted; there's no memory, so the memory ratio is ignored.
+	assert.Equal(t, armadaresource.ComputeResourcesFloat{"cpu": 7, "nvidia.com/gpu": 8}, aggregated[0].availableResources)
+	assert.Equal(t, armadaresource.ComputeResourcesFloat{"cpu": 8, "nvidia.com/gpu": 4}, aggregated[0].totalResources)
+	assert.True(t, resource.MustParse("5").Equal(aggregated[0].nodeType.AllocatableResources["nvidia.com/gpu"]))
+	assert.True(t, resource.MustParse("4").Equal(aggregated[0].nodeType.AllocatableResources["cpu"]))
+	// The allocatable resources of the input nodes must not be modified.
+	assert.True(t, resource.MustParse("2").Equal(nodes[0].AllocatableResources["nvidia.com/gpu"]))
+}
+
+func Test_AggregateNodeTypesAllocations_OvercommitNeverNegative(t *testing.T) {
+	nodes := []api.NodeInfo{
+		{
+			Name:                 "n1",
+			AllocatableResources: armadaresource.ComputeResources{"nvidia.com/gpu": resource.MustParse("3")},
+			AvailableResources:   armadaresource.ComputeResources{"nvidia.com/gpu": resource.MustParse("0")},
+			TotalResources:       armadaresource.ComputeResources{"nvidia.com/gpu": resource.MustParse("3")},
+			AllocatedResources: map[int32]api.ComputeResource{
+				0: {Resources: map[string]resource.Quantity{"nvidia.com/gpu": resource.MustParse("2")}},
+				1: {Resources: map[string]resource.Quantity{"nvidia.com/gpu": resource.MustParse("3")}},
+			},
+		},
+	}
+
+	// Allocated resources may exceed the overcommitted capacity, e.g., if the ratio has been reduced.
+	aggregated := AggregateNodeTypeAllocations(nodes, map[string]float64{"nvidia.com/gpu": 1.5})
+	assert.Len(t, aggregated, 1)
+	assert.Equal(t, 0.0, aggregated[0].availableResources["nvidia.com/gpu"])
+}
+
+func Test_AggregateNodeTypesAllocations_OmitsNodeTypesWithoutResources(t *testing.T) {
+	nodes := []api.NodeInfo{
+		{
+			Name:                 "n1",
+			Labels:               map[string]string{"type": "drained"},
+			AllocatableResources: armadaresource.ComputeResources{"cpu": resource.MustParse("1")},
+			AvailableResources:   armadaresource.ComputeResources{"cpu": resource.MustParse("0")},
+			TotalResources:       armadaresource.ComputeResources{"cpu": resource.MustParse("0")},
+		},
+		{
+			Name:                 "n2",
+			AllocatableResources: armadaresource.ComputeResources{"cpu": resource.MustParse("1")},
+			AvailableResources:   armadaresource.ComputeResources{"cpu": resource.MustParse("1")},
+			TotalResources:       armadaresource.ComputeResources{"cpu": resource.MustParse("1")},
+		},
+	}
+
+	aggregated := AggregateNodeTypeAllocations(nodes, nil)
+	assert.Len(t, aggregated, 1)
+	assert.Nil(t, aggregated[0].nodeType.Labels)
+}
+
+func Test_NodeTypeRankByNodeName(t *testing.T) {
+	nodeInfo := func(name, totalCpu, availableCpu string) api.NodeInfo {
+		return api.NodeInfo{
+			Name:                 name,
+			AllocatableResources: armadaresource.ComputeResources{"cpu": resource.MustParse(totalCpu)},
+			AvailableResources:   armadaresource.ComputeResources{"cpu": resource.MustParse(availableCpu)},
+			TotalResources:       armadaresource.ComputeResources{"cpu": resource.MustParse(totalCpu)},
+		}
+	}
+	// Nodes "a" and "b" are of the same, half allocated, node type. Node "c" is of an empty node type.
+	nodes := []api.NodeInfo{nodeInfo("a", "4", "2"), nodeInfo("b", "4", "2"), nodeInfo("c", "8", "8")}
+	nodeAllocations := AggregateNodeTypeAllocations(nodes, nil)
+
+	assert.Equal(
+		t,
+		map[string]int{"a": 0, "b": 0, "c": 1},
+		NodeTypeRankByNodeName(nodes, nodeAllocations, NodeTypeAllocationStrategyPack, nil),
+	)
+	assert.Equal(
+		t,
+		map[string]int{"a": 1, "b": 1, "c": 0},
+		NodeTypeRankByNodeName(nodes, nodeAllocations, NodeTypeAllocationStrategySpread, nil),
+	)
+}
+
+func Test_NodeTypeRankByNodeName_WeightedByResourceScarcity(t *testing.T) {
+	nodes := []api.NodeInfo{
+		{
+			Name:                 "cpu",
+			AllocatableResources: armadaresource.ComputeResources{"cpu": resource.MustParse("8")},
+			AvailableResources:   armadaresource.ComputeResources{"cpu": resource.MustParse("8")},
+			TotalResources:       armadaresource.ComputeResources{"cpu": resource.MustParse("8")},
+		},
+		{
+			Name:                 "gpu",
+			Taints:               []v1.Taint{{Key: "gpu", Value: "true", Effect: "NoSchedule"}},
+			AllocatableResources: armadaresource.ComputeResources{"cpu": resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("1")},
+			AvailableResources:   armadaresource.ComputeResources{"cpu": resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("1")},
+			TotalResources:       armadaresource.ComputeResources{"cpu": resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("1")},
+		},
+	}
+	nodeAllocations := AggregateNodeTypeAllocations(nodes, nil)
+
+	// Unweighted, node types are kept in the order given by AggregateNodeTypeAllocations, i.e., more tainted first.
+	assert.Equal(
+		t,
+		map[string]int{"gpu": 0, "cpu": 1},
+		NodeTypeRankByNodeName(nodes, nodeAllocations, NodeTypeAllocationStrategyDefault, nil),
+	)
+	// Weighted, the gpu node type is conserved, since it has more scarce resources remaining.
+	assert.Equal(
+		t,
+		map[string]int{"gpu": 1, "cpu": 0},
+		NodeTypeRankByNodeName(nodes, nodeAllocations, NodeTypeAllocationStrategyDefault, map[string]float64{"cpu": 1, "nvidia.com/gpu": 10}),
+	)
+}
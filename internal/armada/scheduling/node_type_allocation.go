@@ -1,28 +1,249 @@
 package scheduling
 
 import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
 	armadaresource "github.com/armadaproject/armada/internal/common/resource"
 	"github.com/armadaproject/armada/pkg/api"
 )
 
+// NodeTypeAllocationStrategy controls the order in which node types are considered when assigning pods to node types.
+type NodeTypeAllocationStrategy int
+
+const (
+	// NodeTypeAllocationStrategyDefault considers node types in the order they're provided in,
+	// i.e., as sorted by AggregateNodeTypeAllocations.
+	NodeTypeAllocationStrategyDefault NodeTypeAllocationStrategy = iota
+	// NodeTypeAllocationStrategySpread considers the least allocated node types first,
+	// such that load is spread evenly across node types.
+	NodeTypeAllocationStrategySpread
+	// NodeTypeAllocationStrategyPack considers the most allocated node types first,
+	// such that partially used node types are filled up before empty node types are used.
+	NodeTypeAllocationStrategyPack
+)
+
+// Names by which node type allocation strategies are referred to in config.
+const (
+	NodeTypeAllocationStrategyNameDefault = ""
+	NodeTypeAllocationStrategyNameSpread  = "spread"
+	NodeTypeAllocationStrategyNamePack    = "pack"
+)
+
+// NodeTypeAllocationStrategyFromName returns the strategy referred to by name.
+func NodeTypeAllocationStrategyFromName(name string) (NodeTypeAllocationStrategy, error) {
+	switch name {
+	case NodeTypeAllocationStrategyNameDefault:
+		return NodeTypeAllocationStrategyDefault, nil
+	case NodeTypeAllocationStrategyNameSpread:
+		return NodeTypeAllocationStrategySpread, nil
+	case NodeTypeAllocationStrategyNamePack:
+		return NodeTypeAllocationStrategyPack, nil
+	default:
+		return NodeTypeAllocationStrategyDefault, errors.WithStack(&armadaerrors.ErrInvalidArgument{
+			Name:  "name",
+			Value: name,
+			Message: fmt.Sprintf(
+				"must be one of %q, %q, or %q",
+				NodeTypeAllocationStrategyNameDefault, NodeTypeAllocationStrategyNameSpread, NodeTypeAllocationStrategyNamePack,
+			),
+		})
+	}
+}
+
 // nodeTypeAllocation stores the available resources for all nodes of a specific node type.
 type nodeTypeAllocation struct {
+	// Uniquely identifies the node type; see createNodeDescription.
+	description        string
 	nodeType           api.NodeType
 	availableResources armadaresource.ComputeResourcesFloat
 	totalResources     armadaresource.ComputeResourcesFloat
 	allocatedResources map[int32]armadaresource.ComputeResourcesFloat
 }
 
+// isEmpty returns true if the node type has no resources, e.g., because all its nodes have been drained.
+func (node *nodeTypeAllocation) isEmpty() bool {
+	for _, total := range node.totalResources {
+		if total > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// allocatedFraction returns the fraction of the resources of this node type that's allocated,
+// including resources consumed according to the provided nodeTypeUsedResources.
+// If resourceScarcity is empty, the fraction is computed for the resource type with the largest allocated fraction.
+// Otherwise, it's the fraction of the total resources, weighted by resourceScarcity, that's allocated.
+// Resource types for which totalResources is zero are ignored.
+func (node *nodeTypeAllocation) allocatedFraction(resourceScarcity map[string]float64, consumed ...nodeTypeUsedResources) float64 {
+	used := node.totalResources.DeepCopy()
+	remaining, _ := node.remainingResources(consumed...)
+	used.Sub(remaining)
+	maxFraction, weightedUsed, weightedTotal := 0.0, 0.0, 0.0
+	for t, total := range node.totalResources {
+		if total <= 0 {
+			continue
+		}
+		if fraction := used[t] / total; fraction > maxFraction {
+			maxFraction = fraction
+		}
+		weightedUsed += resourceScarcity[t] * used[t]
+		weightedTotal += resourceScarcity[t] * total
+	}
+	if len(resourceScarcity) == 0 || weightedTotal == 0 {
+		return maxFraction
+	}
+	return weightedUsed / weightedTotal
+}
+
+// scarceResourcesRemaining returns the resources of this node type that remain once the provided
+// nodeTypeUsedResources have been consumed, weighted by resourceScarcity and summed over all resource types.
+func (node *nodeTypeAllocation) scarceResourcesRemaining(resourceScarcity map[string]float64, consumed ...nodeTypeUsedResources) float64 {
+	result := 0.0
+	remainingResources, _ := node.remainingResources(consumed...)
+	for t, remaining := range remainingResources {
+		result += resourceScarcity[t] * remaining
+	}
+	return result
+}
+
+// remainingResources returns the available resources of this node type less those consumed, floored at zero,
+// together with the unclamped values of any resource types that went negative.
+// More resources than are available may be consumed, e.g., if consumed resources were computed from a stale snapshot;
+// see logOverconsumedNodeTypes. Use rawRemainingResources to get all unclamped values.
+func (node *nodeTypeAllocation) remainingResources(consumed ...nodeTypeUsedResources) (remaining, negative armadaresource.ComputeResourcesFloat) {
+	remaining = node.rawRemainingResources(consumed...)
+	for t, q := range remaining {
+		if q < 0 {
+			if negative == nil {
+				negative = make(armadaresource.ComputeResourcesFloat)
+			}
+			negative[t] = q
+			remaining[t] = 0
+		}
+	}
+	return remaining, negative
+}
+
+// rawRemainingResources returns the available resources of this node type less those consumed.
+// Unlike remainingResources, values may be negative.
+func (node *nodeTypeAllocation) rawRemainingResources(consumed ...nodeTypeUsedResources) armadaresource.ComputeResourcesFloat {
+	remaining := node.availableResources.DeepCopy()
+	for _, c := range consumed {
+		remaining.Sub(c[node])
+	}
+	return remaining
+}
+
+// orderNodeTypeAllocations returns the node types in the order they should be considered in according to strategy.
+// If resourceScarcity is provided, spread and pack weigh the allocated fraction of each resource type by its scarcity,
+// and the default strategy considers the node types with the fewest scarce resources remaining first,
+// such that node types with many scarce resources are conserved for pods that need them.
+// Node types that are equally preferable are kept in their original order.
+func orderNodeTypeAllocations(
+	strategy NodeTypeAllocationStrategy,
+	resourceScarcity map[string]float64,
+	nodeAllocations []*nodeTypeAllocation,
+	consumed ...nodeTypeUsedResources,
+) []*nodeTypeAllocation {
+	logOverconsumedNodeTypes(nodeAllocations, consumed...)
+	if strategy == NodeTypeAllocationStrategyDefault && len(resourceScarcity) == 0 {
+		return nodeAllocations
+	}
+	scores := make(map[*nodeTypeAllocation]float64, len(nodeAllocations))
+	for _, node := range nodeAllocations {
+		if strategy == NodeTypeAllocationStrategyDefault {
+			scores[node] = node.scarceResourcesRemaining(resourceScarcity, consumed...)
+		} else {
+			scores[node] = node.allocatedFraction(resourceScarcity, consumed...)
+		}
+	}
+	result := make([]*nodeTypeAllocation, len(nodeAllocations))
+	copy(result, nodeAllocations)
+	sort.SliceStable(result, func(i, j int) bool {
+		if strategy == NodeTypeAllocationStrategyPack {
+			return scores[result[i]] > scores[result[j]]
+		}
+		return scores[result[i]] < scores[result[j]]
+	})
+	return result
+}
+
+// logOverconsumedNodeTypes logs a single warning identifying all node types and resources
+// of which more has been consumed than is available, if any.
+// Remaining resources of such node types are treated as zero; see remainingResources.
+func logOverconsumedNodeTypes(nodeAllocations []*nodeTypeAllocation, consumed ...nodeTypeUsedResources) {
+	var overconsumed []string
+	for _, node := range nodeAllocations {
+		_, negative := node.remainingResources(consumed...)
+		for t, q := range negative {
+			overconsumed = append(overconsumed, fmt.Sprintf("%s (resource %s, remaining %f)", node.description, t, q))
+		}
+	}
+	if len(overconsumed) > 0 {
+		sort.Strings(overconsumed)
+		log.WithField("overconsumed", overconsumed).Warn(
+			"more resources consumed than available for some node types; treating remaining resources as zero",
+		)
+	}
+}
+
+// applyOvercommit allows resource types with an entry in overcommitRatios to be allocated beyond the physical capacity
+// of the node type, e.g., to account for GPUs shared via time-slicing.
+// For each such resource type, available resources are set to totalResources scaled by the ratio
+// less the resources allocated across all priorities, and the allocatable resources of the node type are scaled by the ratio.
+func (node *nodeTypeAllocation) applyOvercommit(overcommitRatios map[string]float64) {
+	if len(overcommitRatios) == 0 {
+		return
+	}
+	allocated := make(armadaresource.ComputeResourcesFloat)
+	for _, resources := range node.allocatedResources {
+		allocated.Add(resources)
+	}
+	allocatable := make(armadaresource.ComputeResources, len(node.nodeType.AllocatableResources))
+	for t, q := range node.nodeType.AllocatableResources {
+		allocatable[t] = q.DeepCopy()
+	}
+	for t, ratio := range overcommitRatios {
+		total, ok := node.totalResources[t]
+		if !ok {
+			continue
+		}
+		// Floor at zero, since rounding may cause availability to go slightly negative for fully allocated resources.
+		node.availableResources[t] = math.Max(total*ratio-allocated[t], 0)
+		if q, ok := allocatable[t]; ok {
+			q.SetMilli(int64(math.Round(float64(q.MilliValue()) * ratio)))
+			allocatable[t] = q
+		}
+	}
+	node.nodeType.AllocatableResources = allocatable
+}
+
 type nodeTypeUsedResources map[*nodeTypeAllocation]armadaresource.ComputeResourcesFloat
 
+// DeepCopy returns a copy of r, omitting node types that have no resources left.
 func (r nodeTypeUsedResources) DeepCopy() map[*nodeTypeAllocation]armadaresource.ComputeResourcesFloat {
 	result := map[*nodeTypeAllocation]armadaresource.ComputeResourcesFloat{}
 	for k, v := range r {
+		if k.isEmpty() {
+			continue
+		}
 		result[k] = v.DeepCopy()
 	}
 	return result
 }
 
+// Remove deletes the resources consumed on nodeType, e.g., once the node type no longer exists.
+func (r nodeTypeUsedResources) Remove(nodeType *nodeTypeAllocation) {
+	delete(r, nodeType)
+}
+
 func (r nodeTypeUsedResources) Add(consumed nodeTypeUsedResources) {
 	for nodeType, resources := range consumed {
 		newResources := resources.DeepCopy()
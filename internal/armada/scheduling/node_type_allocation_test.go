@@ -0,0 +1,206 @@
+package scheduling
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	armadaresource "github.com/armadaproject/armada/internal/common/resource"
+)
+
+func Test_matchAnyNodeTypePodAllocation_Strategy(t *testing.T) {
+	tests := map[string]struct {
+		strategy      NodeTypeAllocationStrategy
+		expectedIndex int
+	}{
+		"default considers node types in order": {
+			strategy:      NodeTypeAllocationStrategyDefault,
+			expectedIndex: 0,
+		},
+		"spread prefers the least allocated node type": {
+			strategy:      NodeTypeAllocationStrategySpread,
+			expectedIndex: 1,
+		},
+		"pack prefers the most allocated node type": {
+			strategy:      NodeTypeAllocationStrategyPack,
+			expectedIndex: 2,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Node types 0 and 2 are partially allocated; node type 1 is empty.
+			// Node type 2 becomes the most allocated once consumed resources are accounted for.
+			nodeAllocations := []*nodeTypeAllocation{
+				nodeTypeAllocationWithAvailable(6),
+				nodeTypeAllocationWithAvailable(8),
+				nodeTypeAllocationWithAvailable(7),
+			}
+			alreadyConsumed := nodeTypeUsedResources{nodeAllocations[2]: armadaresource.ComputeResourcesFloat{"cpu": 2}}
+			newlyConsumed := nodeTypeUsedResources{}
+
+			node, ok, err := matchAnyNodeTypePodAllocation(&v1.PodSpec{}, nodeAllocations, alreadyConsumed, newlyConsumed, tc.strategy, nil)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Same(t, nodeAllocations[tc.expectedIndex], node)
+		})
+	}
+}
+
+func Test_orderNodeTypeAllocations_KeepsOrderOfEquallyAllocatedNodeTypes(t *testing.T) {
+	nodeAllocations := []*nodeTypeAllocation{
+		nodeTypeAllocationWithAvailable(4),
+		nodeTypeAllocationWithAvailable(4),
+		nodeTypeAllocationWithAvailable(4),
+	}
+	for _, strategy := range []NodeTypeAllocationStrategy{NodeTypeAllocationStrategySpread, NodeTypeAllocationStrategyPack} {
+		assert.Equal(t, nodeAllocations, orderNodeTypeAllocations(strategy, nil, nodeAllocations))
+	}
+}
+
+func nodeTypeAllocationWithAvailable(cpu float64) *nodeTypeAllocation {
+	node := defaultNodeTypeAllocation()
+	node.totalResources = armadaresource.ComputeResourcesFloat{"cpu": 8, "memory": 8 * 1024 * 1024 * 1024}
+	node.availableResources = armadaresource.ComputeResourcesFloat{"cpu": cpu, "memory": 8 * 1024 * 1024 * 1024}
+	return node
+}
+
+func Test_nodeTypeUsedResources_Remove(t *testing.T) {
+	nodeAllocations := []*nodeTypeAllocation{nodeTypeAllocationWithAvailable(4), nodeTypeAllocationWithAvailable(4)}
+	used := nodeTypeUsedResources{
+		nodeAllocations[0]: armadaresource.ComputeResourcesFloat{"cpu": 1},
+		nodeAllocations[1]: armadaresource.ComputeResourcesFloat{"cpu": 2},
+	}
+	used.Remove(nodeAllocations[0])
+	assert.Equal(t, nodeTypeUsedResources{nodeAllocations[1]: armadaresource.ComputeResourcesFloat{"cpu": 2}}, used)
+
+	// Removing a node type that isn't present is a no-op.
+	used.Remove(nodeAllocations[0])
+	assert.Len(t, used, 1)
+}
+
+func Test_nodeTypeUsedResources_DeepCopyDropsEmptyNodeTypes(t *testing.T) {
+	nodeAllocations := []*nodeTypeAllocation{nodeTypeAllocationWithAvailable(4), nodeTypeAllocationWithAvailable(4)}
+	nodeAllocations[1].totalResources = armadaresource.ComputeResourcesFloat{"cpu": 0, "memory": 0}
+	used := nodeTypeUsedResources{
+		nodeAllocations[0]: armadaresource.ComputeResourcesFloat{"cpu": 1},
+		nodeAllocations[1]: armadaresource.ComputeResourcesFloat{"cpu": 2},
+	}
+
+	copied := nodeTypeUsedResources(used.DeepCopy())
+	assert.Equal(t, nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{"cpu": 1}}, copied)
+
+	// The copy must not share resources with the original.
+	copied[nodeAllocations[0]]["cpu"] = 3
+	assert.Equal(t, 1.0, used[nodeAllocations[0]]["cpu"])
+}
+
+func Test_orderNodeTypeAllocations_WeightedByResourceScarcity(t *testing.T) {
+	const gb = 1024 * 1024 * 1024
+	tests := map[string]struct {
+		strategy NodeTypeAllocationStrategy
+		// Available resources of the two node types, each of which has 8 cpu and 64Gi of memory in total.
+		available [2]armadaresource.ComputeResourcesFloat
+		// Index of the node type to be considered first without and with weighting by scarcity.
+		expectedUnweighted int
+		expectedWeighted   int
+	}{
+		"default conserves node types with more of the scarce resource": {
+			strategy: NodeTypeAllocationStrategyDefault,
+			available: [2]armadaresource.ComputeResourcesFloat{
+				{"cpu": 8, "memory": 64 * gb},
+				{"cpu": 8, "memory": 8 * gb},
+			},
+			expectedUnweighted: 0,
+			expectedWeighted:   1,
+		},
+		"pack prefers node types with more of the scarce resource allocated": {
+			strategy: NodeTypeAllocationStrategyPack,
+			available: [2]armadaresource.ComputeResourcesFloat{
+				{"cpu": 2, "memory": 64 * gb},
+				{"cpu": 8, "memory": 32 * gb},
+			},
+			expectedUnweighted: 0,
+			expectedWeighted:   1,
+		},
+		"spread prefers node types with less of the scarce resource allocated": {
+			strategy: NodeTypeAllocationStrategySpread,
+			available: [2]armadaresource.ComputeResourcesFloat{
+				{"cpu": 8, "memory": 32 * gb},
+				{"cpu": 2, "memory": 64 * gb},
+			},
+			expectedUnweighted: 0,
+			expectedWeighted:   1,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			nodeAllocations := make([]*nodeTypeAllocation, len(tc.available))
+			for i, available := range tc.available {
+				nodeAllocations[i] = defaultNodeTypeAllocation()
+				nodeAllocations[i].totalResources = armadaresource.ComputeResourcesFloat{"cpu": 8, "memory": 64 * gb}
+				nodeAllocations[i].availableResources = available
+			}
+			// Memory is scarce relative to cpu.
+			resourceScarcity := map[string]float64{"cpu": 1, "memory": 4.0 / gb}
+
+			unweighted := orderNodeTypeAllocations(tc.strategy, nil, nodeAllocations)
+			assert.Same(t, nodeAllocations[tc.expectedUnweighted], unweighted[0])
+			weighted := orderNodeTypeAllocations(tc.strategy, resourceScarcity, nodeAllocations)
+			assert.Same(t, nodeAllocations[tc.expectedWeighted], weighted[0])
+		})
+	}
+}
+
+func Test_nodeTypeAllocation_remainingResources_ClampsAtZero(t *testing.T) {
+	node := nodeTypeAllocationWithAvailable(4)
+	alreadyConsumed := nodeTypeUsedResources{node: armadaresource.ComputeResourcesFloat{"cpu": 3}}
+	newlyConsumed := nodeTypeUsedResources{node: armadaresource.ComputeResourcesFloat{"cpu": 2, "memory": 1024}}
+
+	remaining, negative := node.remainingResources(alreadyConsumed, newlyConsumed)
+	assert.Equal(t, armadaresource.ComputeResourcesFloat{"cpu": 0, "memory": 8*1024*1024*1024 - 1024}, remaining)
+	assert.Equal(t, armadaresource.ComputeResourcesFloat{"cpu": -1}, negative)
+	assert.Equal(
+		t,
+		armadaresource.ComputeResourcesFloat{"cpu": -1, "memory": 8*1024*1024*1024 - 1024},
+		node.rawRemainingResources(alreadyConsumed, newlyConsumed),
+	)
+	// Available resources must not be modified.
+	assert.Equal(t, 4.0, node.availableResources["cpu"])
+}
+
+func Test_orderNodeTypeAllocations_WarnsOnceForOverconsumedNodeTypes(t *testing.T) {
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+	nodeAllocations := []*nodeTypeAllocation{
+		nodeTypeAllocationWithAvailable(4),
+		nodeTypeAllocationWithAvailable(4),
+	}
+	consumed := nodeTypeUsedResources{
+		nodeAllocations[0]: armadaresource.ComputeResourcesFloat{"cpu": 5},
+		nodeAllocations[1]: armadaresource.ComputeResourcesFloat{"cpu": 6},
+	}
+
+	orderNodeTypeAllocations(NodeTypeAllocationStrategySpread, nil, nodeAllocations, consumed)
+	assert.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+	assert.Len(t, hook.LastEntry().Data["overconsumed"], 2)
+}
+
+func Test_NodeTypeAllocationStrategyFromName(t *testing.T) {
+	for name, expected := range map[string]NodeTypeAllocationStrategy{
+		NodeTypeAllocationStrategyNameDefault: NodeTypeAllocationStrategyDefault,
+		NodeTypeAllocationStrategyNameSpread:  NodeTypeAllocationStrategySpread,
+		NodeTypeAllocationStrategyNamePack:    NodeTypeAllocationStrategyPack,
+	} {
+		strategy, err := NodeTypeAllocationStrategyFromName(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, strategy)
+	}
+	_, err := NodeTypeAllocationStrategyFromName("foo")
+	var e *armadaerrors.ErrInvalidArgument
+	assert.ErrorAs(t, err, &e)
+}
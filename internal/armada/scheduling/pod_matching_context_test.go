@@ -149,7 +149,7 @@ func Test_matchAnyNodeTypePodAllocation_WhenFindsMatch_ReturnsMatch(t *testing.T
 	alreadyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{"cpu": 3, "memory": 1 * 1024 * 1024 * 1024}}
 	newlyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{"cpu": 3, "memory": 1 * 1024 * 1024 * 1024}}
 
-	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed)
+	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed, NodeTypeAllocationStrategyDefault, nil)
 	assert.Equal(t, nodeAllocations[0], resultNode)
 	assert.True(t, resultFlag)
 	assert.NoError(t, err)
@@ -169,7 +169,35 @@ func Test_matchAnyNodeTypePodAllocation_WhenAllAvailableCpuConsumed_ReturnsFalse
 	alreadyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{"cpu": 4, "memory": 1 * 1024 * 1024 * 1024}}
 	newlyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{"cpu": 4, "memory": 1 * 1024 * 1024 * 1024}}
 
-	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed)
+	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed, NodeTypeAllocationStrategyDefault, nil)
+	assert.Nil(t, resultNode)
+	assert.False(t, resultFlag)
+	assert.Error(t, err)
+}
+
+func Test_matchAnyNodeTypePodAllocation_RoundsAvailableCpuDownToMillicpu(t *testing.T) {
+	podSpecRequestingCpu := func(cpu string) *v1.PodSpec {
+		return &v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{"cpu": resource.MustParse(cpu)},
+					},
+				},
+			},
+		}
+	}
+	nodeAllocations := defaultNodeTypeAllocations()
+	// Leaves 2.5 millicpu available, which is rounded down to 2 millicpu.
+	alreadyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{"cpu": 6.9975}}
+	newlyConsumed := nodeTypeUsedResources{}
+
+	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpecRequestingCpu("2m"), nodeAllocations, alreadyConsumed, newlyConsumed, NodeTypeAllocationStrategyDefault, nil)
+	assert.Equal(t, nodeAllocations[0], resultNode)
+	assert.True(t, resultFlag)
+	assert.NoError(t, err)
+
+	resultNode, resultFlag, err = matchAnyNodeTypePodAllocation(podSpecRequestingCpu("2100u"), nodeAllocations, alreadyConsumed, newlyConsumed, NodeTypeAllocationStrategyDefault, nil)
 	assert.Nil(t, resultNode)
 	assert.False(t, resultFlag)
 	assert.Error(t, err)
@@ -181,7 +209,7 @@ func Test_matchAnyNodeTypePodAllocation_WhenNodeSelectorMatchesNoNodes_ReturnsFa
 	alreadyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{}}
 	newlyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{}}
 
-	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed)
+	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed, NodeTypeAllocationStrategyDefault, nil)
 	assert.Nil(t, resultNode)
 	assert.False(t, resultFlag)
 	assert.Error(t, err)
@@ -197,7 +225,7 @@ func Test_matchAnyNodeTypePodAllocation_WhenNodeSelectorRulesOutFirstNode_Return
 	alreadyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{}}
 	newlyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{}}
 
-	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed)
+	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed, NodeTypeAllocationStrategyDefault, nil)
 	assert.Equal(t, nodeAllocations[1], resultNode)
 	assert.True(t, resultFlag)
 	assert.NoError(t, err)
@@ -212,7 +240,7 @@ func Test_matchAnyNodeTypePodAllocation_WhenTaintRulesOutAllNodes_ReturnsFalse(t
 	alreadyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{}}
 	newlyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{}}
 
-	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed)
+	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed, NodeTypeAllocationStrategyDefault, nil)
 	assert.Nil(t, resultNode)
 	assert.False(t, resultFlag)
 	assert.Error(t, err)
@@ -228,7 +256,7 @@ func Test_matchAnyNodeTypePodAllocation_WhenTaintRulesOutFirstNode_ReturnsSecond
 	alreadyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{}}
 	newlyConsumed := nodeTypeUsedResources{nodeAllocations[0]: armadaresource.ComputeResourcesFloat{}}
 
-	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed)
+	resultNode, resultFlag, err := matchAnyNodeTypePodAllocation(podSpec, nodeAllocations, alreadyConsumed, newlyConsumed, NodeTypeAllocationStrategyDefault, nil)
 	assert.Equal(t, nodeAllocations[1], resultNode)
 	assert.True(t, resultFlag)
 	assert.NoError(t, err)
@@ -12,6 +12,7 @@ import (
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 
@@ -266,12 +267,25 @@ func Serve(ctx context.Context, config *configuration.ArmadaConfig, healthChecks
 		config.Pulsar.MaxAllowedMessageSize,
 		legacyExecutorRepo,
 	)
-	if schedulingContextRepository, err := scheduler.NewSchedulingContextRepository(
-		config.Scheduling.MaxJobSchedulingContextsPerExecutor,
-	); err != nil {
+	if schedulingContextRepository, err := scheduler.NewSchedulingContextRepositoryFromConfig(config.Scheduling); err != nil {
 		return err
 	} else {
+		if n := config.Scheduling.SchedulingContextIngestionBufferSize; n > 0 {
+			schedulingContextRepository.SetIngestionBufferSize(n)
+			services = append(services, func() error {
+				return schedulingContextRepository.Run(ctx)
+			})
+		}
+		if path := config.Scheduling.SchedulingContextSnapshotPath; path != "" {
+			if err := schedulingContextRepository.LoadSnapshot(path); err != nil {
+				log.WithError(err).Warnf("failed to load scheduling context snapshot from %s; starting with no scheduling contexts", path)
+			}
+		}
 		aggregatedQueueServer.SchedulingContextRepository = schedulingContextRepository
+		prometheus.MustRegister(schedulingContextRepository)
+		if config.Scheduling.ExposeSchedulingReportMetrics {
+			prometheus.MustRegister(scheduler.NewSchedulingReportCollector(schedulingContextRepository))
+		}
 	}
 
 	eventServer := server.NewEventServer(
@@ -294,6 +308,14 @@ func Serve(ctx context.Context, config *configuration.ArmadaConfig, healthChecks
 		metrics.ExposeDataMetrics(queueRepository, jobRepository, usageRepository, schedulingInfoRepository, queueCache)
 	}
 
+	if path := config.Scheduling.SchedulingContextSnapshotPath; path != "" && aggregatedQueueServer.SchedulingContextRepository != nil {
+		taskManager.Register(
+			func() { aggregatedQueueServer.SchedulingContextRepository.WriteSnapshotBestEffort(path) },
+			config.Scheduling.SchedulingContextSnapshotInterval,
+			"snapshot_scheduling_contexts",
+		)
+	}
+
 	api.RegisterSubmitServer(grpcServer, submitServerToRegister)
 	api.RegisterUsageServer(grpcServer, usageServer)
 	api.RegisterEventServer(grpcServer, eventServer)
@@ -302,6 +324,10 @@ func Serve(ctx context.Context, config *configuration.ArmadaConfig, healthChecks
 			grpcServer,
 			aggregatedQueueServer.SchedulingContextRepository,
 		)
+		schedulerobjects.RegisterSchedulerAdminServer(
+			grpcServer,
+			scheduler.NewSchedulerAdminServer(permissions, aggregatedQueueServer.SchedulingContextRepository),
+		)
 	}
 
 	api.RegisterAggregatedQueueServer(grpcServer, aggregatedQueueServer)
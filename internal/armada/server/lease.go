@@ -132,15 +132,30 @@ func (q *AggregatedQueueServer) StreamingLeaseJobs(stream api.AggregatedQueue_St
 	if err != nil {
 		return err
 	}
-	nodeResources := scheduling.AggregateNodeTypeAllocations(req.Nodes)
+	nodeResources := scheduling.AggregateNodeTypeAllocations(req.Nodes, q.schedulingConfig.ResourceOvercommitRatios)
 	clusterSchedulingInfo := scheduling.CreateClusterSchedulingInfoReport(req, nodeResources)
 	err = q.schedulingInfoRepository.UpdateClusterSchedulingInfo(clusterSchedulingInfo)
 	if err != nil {
 		return err
 	}
 
+	// Rank nodes by node type, such that the scheduler prefers node types according to the configured strategy.
+	var nodeTypeRankByNodeName map[string]int
+	if q.schedulingConfig.NodeTypeAllocationStrategy != scheduling.NodeTypeAllocationStrategyNameDefault ||
+		q.schedulingConfig.WeighNodeTypesByResourceScarcity {
+		strategy, err := scheduling.NodeTypeAllocationStrategyFromName(q.schedulingConfig.NodeTypeAllocationStrategy)
+		if err != nil {
+			return err
+		}
+		var resourceScarcity map[string]float64
+		if q.schedulingConfig.WeighNodeTypesByResourceScarcity {
+			resourceScarcity = q.schedulingConfig.GetResourceScarcity(req.Pool)
+		}
+		nodeTypeRankByNodeName = scheduling.NodeTypeRankByNodeName(req.Nodes, nodeResources, strategy, resourceScarcity)
+	}
+
 	// Get jobs to be leased.
-	jobs, err := q.getJobs(stream.Context(), req)
+	jobs, err := q.getJobs(stream.Context(), req, nodeTypeRankByNodeName)
 	if err != nil {
 		return err
 	}
@@ -251,7 +266,7 @@ func (repo *SchedulerJobRepositoryAdapter) GetExistingJobsByIds(ids []string) ([
 	return rv, nil
 }
 
-func (q *AggregatedQueueServer) getJobs(ctx context.Context, req *api.StreamingLeaseRequest) ([]*api.Job, error) {
+func (q *AggregatedQueueServer) getJobs(ctx context.Context, req *api.StreamingLeaseRequest, nodeTypeRankByNodeName map[string]int) ([]*api.Job, error) {
 	log := ctxlogrus.Extract(ctx)
 	log = log.WithFields(logrus.Fields{
 		"function": "getJobs",
@@ -287,6 +302,7 @@ func (q *AggregatedQueueServer) getJobs(ctx context.Context, req *api.StreamingL
 	jobIdsByGangId := make(map[string]map[string]bool)
 	gangIdByJobId := make(map[string]string)
 	nodeIdByJobId := make(map[string]string)
+	nodeRanks := make(map[string]int, len(nodeTypeRankByNodeName))
 	for _, nodeInfo := range req.Nodes {
 		node, err := api.NewNodeFromNodeInfo(
 			&nodeInfo,
@@ -380,6 +396,9 @@ func (q *AggregatedQueueServer) getJobs(ctx context.Context, req *api.StreamingL
 		for _, job := range jobs {
 			nodeIdByJobId[job.Id] = node.Id
 		}
+		if rank, ok := nodeTypeRankByNodeName[nodeInfo.Name]; ok {
+			nodeRanks[node.Id] = rank
+		}
 		nodes = append(nodes, node)
 	}
 	nodeDb, err := nodedb.NewNodeDb(
@@ -392,6 +411,10 @@ func (q *AggregatedQueueServer) getJobs(ctx context.Context, req *api.StreamingL
 	if err != nil {
 		return nil, err
 	}
+	if err := nodeDb.SetTieBreakPolicy(q.schedulingConfig.NodeTieBreakPolicy); err != nil {
+		return nil, err
+	}
+	nodeDb.SetNodeRanks(nodeRanks)
 	if err := nodeDb.UpsertMany(nodes); err != nil {
 		return nil, err
 	}
@@ -470,6 +493,9 @@ func (q *AggregatedQueueServer) getJobs(ctx context.Context, req *api.StreamingL
 		q.schedulingConfig.ResourceScarcity,
 		schedulerobjects.ResourceList{Resources: totalCapacity},
 	)
+	if err := sctx.SetFairnessModel(q.schedulingConfig.FairnessModel); err != nil {
+		return nil, err
+	}
 	for queue, priorityFactor := range priorityFactorByQueue {
 		if err := sctx.AddQueueSchedulingContext(queue, priorityFactor, allocatedByQueueForPool[queue]); err != nil {
 			return nil, err
@@ -500,6 +526,16 @@ func (q *AggregatedQueueServer) getJobs(ctx context.Context, req *api.StreamingL
 	if q.schedulingConfig.EnableAssertions {
 		sch.EnableAssertions()
 	}
+	if q.schedulingConfig.Preemption.EnableGangPreemption {
+		sch.EnableGangPreemption(
+			q.schedulingConfig.Preemption.GangPreemptionPriorityThreshold,
+			q.schedulingConfig.Preemption.GangPreemptionMinimumRuntime,
+		)
+	}
+	if q.SchedulingContextRepository != nil {
+		sch.SetDrainingExecutors(q.SchedulingContextRepository.DrainingExecutors())
+		sch.SetPausedQueues(q.SchedulingContextRepository.PausedQueues())
+	}
 	result, err := sch.Schedule(
 		ctxlogrus.ToContext(
 			ctx,
@@ -30,7 +30,7 @@ func (a *App) GetSchedulingReportForQueue(queueName string, verbosity int32) err
 				},
 			},
 
-			Verbosity: verbosity,
+			Verbosity: schedulerobjects.ReportVerbosity(verbosity),
 		},
 	)
 }
@@ -44,7 +44,7 @@ func (a *App) GetSchedulingReportForJob(jobId string, verbosity int32) error {
 				},
 			},
 
-			Verbosity: verbosity,
+			Verbosity: schedulerobjects.ReportVerbosity(verbosity),
 		},
 	)
 }
@@ -52,7 +52,7 @@ func (a *App) GetSchedulingReportForJob(jobId string, verbosity int32) error {
 func (a *App) GetSchedulingReport(verbosity int32) error {
 	return a.executeGetSchedulingReport(
 		&schedulerobjects.SchedulingReportRequest{
-			Verbosity: verbosity,
+			Verbosity: schedulerobjects.ReportVerbosity(verbosity),
 		},
 	)
 }
@@ -61,7 +61,7 @@ func (a *App) GetQueueSchedulingReport(queueName string, verbosity int32) error
 	return client.WithSchedulerReportingClient(a.Params.ApiConnectionDetails, func(c schedulerobjects.SchedulerReportingClient) error {
 		ctx, cancel := common.ContextWithDefaultTimeout()
 		defer cancel()
-		report, err := c.GetQueueReport(ctx, &schedulerobjects.QueueReportRequest{QueueName: queueName, Verbosity: verbosity})
+		report, err := c.GetQueueReport(ctx, &schedulerobjects.QueueReportRequest{QueueName: queueName, Verbosity: schedulerobjects.ReportVerbosity(verbosity)})
 		if err != nil {
 			return err
 		}
@@ -34,6 +34,40 @@ func QuantityAsFloat64(q resource.Quantity) float64 {
 	return unscaledFloat * math.Pow10(-int(scale))
 }
 
+// RoundingMode controls how float resource amounts are rounded when converted back to quantities.
+type RoundingMode int
+
+const (
+	// RoundingModeFloor rounds down to the nearest milli-unit.
+	// This is the default, since it never over-reports available resources.
+	RoundingModeFloor RoundingMode = iota
+	// RoundingModeCeil rounds up to the nearest milli-unit.
+	RoundingModeCeil
+)
+
+// maxMilliQuantity is the largest magnitude, in milli-units, that's converted with milli-unit precision.
+// Larger values are rounded to whole units to avoid overflowing int64;
+// float64 can't represent milli-units of such values exactly anyway.
+const maxMilliQuantity = float64(1 << 62)
+
+// QuantityFromFloat64 converts a float to a quantity with milli-unit precision, rounding according to mode.
+// Values within floating-point error of a milli-unit boundary are treated as being on the boundary,
+// such that, e.g., QuantityAsFloat64 followed by QuantityFromFloat64 returns the original quantity for either mode.
+func QuantityFromFloat64(v float64, mode RoundingMode) resource.Quantity {
+	round := math.Floor
+	if mode == RoundingModeCeil {
+		round = math.Ceil
+	}
+	millis := v * 1000
+	if math.Abs(millis) >= maxMilliQuantity {
+		return *resource.NewQuantity(int64(round(v)), resource.DecimalSI)
+	}
+	if nearest := math.Round(millis); math.Abs(millis-nearest) < 1e-6 {
+		millis = nearest
+	}
+	return *resource.NewMilliQuantity(int64(round(millis)), resource.DecimalSI)
+}
+
 type ComputeResources map[string]resource.Quantity
 
 // String function handles the string representation  of ComputeResources i.e.
@@ -235,6 +269,16 @@ func (a ComputeResources) AsFloat() ComputeResourcesFloat {
 // ComputeResourcesFloat is float version of compute resource, prefer calculations with quantity where possible
 type ComputeResourcesFloat map[string]float64
 
+// AsQuantities converts ComputeResourcesFloat to ComputeResources,
+// rounding each value to milli-unit precision according to mode; see QuantityFromFloat64.
+func (a ComputeResourcesFloat) AsQuantities(mode RoundingMode) ComputeResources {
+	targetComputeResource := make(ComputeResources, len(a))
+	for key, value := range a {
+		targetComputeResource[key] = QuantityFromFloat64(value, mode)
+	}
+	return targetComputeResource
+}
+
 // IsValid function checks if all the values in "a" is  greater than or equal to zero.
 // It returns true if all values are valid i.e. all values are greater than or equal to zero,
 // and false if any of the values are negative
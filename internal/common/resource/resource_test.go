@@ -228,3 +228,108 @@ func makeNodeWithResource(resources map[v1.ResourceName]resource.Quantity) v1.No
 	}
 	return node
 }
+
+func TestQuantityFromFloat64(t *testing.T) {
+	tests := map[string]struct {
+		value         float64
+		mode          RoundingMode
+		expectedMilli int64
+	}{
+		"exact millicpu floor": {
+			value:         0.002,
+			mode:          RoundingModeFloor,
+			expectedMilli: 2,
+		},
+		"exact millicpu ceil": {
+			value:         0.002,
+			mode:          RoundingModeCeil,
+			expectedMilli: 2,
+		},
+		"just below millicpu boundary floor": {
+			value:         0.0019,
+			mode:          RoundingModeFloor,
+			expectedMilli: 1,
+		},
+		"just below millicpu boundary ceil": {
+			value:         0.0019,
+			mode:          RoundingModeCeil,
+			expectedMilli: 2,
+		},
+		"just above millicpu boundary floor": {
+			value:         0.0021,
+			mode:          RoundingModeFloor,
+			expectedMilli: 2,
+		},
+		"just above millicpu boundary ceil": {
+			value:         0.0021,
+			mode:          RoundingModeCeil,
+			expectedMilli: 3,
+		},
+		"floating-point error above boundary ceil": {
+			value:         0.1 + 0.2,
+			mode:          RoundingModeCeil,
+			expectedMilli: 300,
+		},
+		"floating-point error below boundary floor": {
+			value:         1.1 - 0.9,
+			mode:          RoundingModeFloor,
+			expectedMilli: 200,
+		},
+		"less than one millicpu floor": {
+			value:         0.0009,
+			mode:          RoundingModeFloor,
+			expectedMilli: 0,
+		},
+		"less than one millicpu ceil": {
+			value:         0.0009,
+			mode:          RoundingModeCeil,
+			expectedMilli: 1,
+		},
+		"negative floor": {
+			value:         -0.0015,
+			mode:          RoundingModeFloor,
+			expectedMilli: -2,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			q := QuantityFromFloat64(tc.value, tc.mode)
+			assert.Equal(t, tc.expectedMilli, q.MilliValue())
+		})
+	}
+}
+
+func TestQuantityFromFloat64_RoundTrip(t *testing.T) {
+	for _, s := range []string{"1m", "999m", "1", "1500m", "64Gi", "64Ti"} {
+		q := resource.MustParse(s)
+		for _, mode := range []RoundingMode{RoundingModeFloor, RoundingModeCeil} {
+			actual := QuantityFromFloat64(QuantityAsFloat64(q), mode)
+			assert.True(t, q.Cmp(actual) == 0, "expected %s, but got %s", q.String(), actual.String())
+		}
+	}
+}
+
+func TestComputeResourcesFloat_AsQuantities(t *testing.T) {
+	data := ComputeResourcesFloat{
+		"cpu":    0.0019,
+		"memory": 1024,
+	}
+	assert.Equal(
+		t,
+		map[string]int64{"cpu": 1, "memory": 1024000},
+		milliValues(data.AsQuantities(RoundingModeFloor)),
+	)
+	assert.Equal(
+		t,
+		map[string]int64{"cpu": 2, "memory": 1024000},
+		milliValues(data.AsQuantities(RoundingModeCeil)),
+	)
+}
+
+func milliValues(resources ComputeResources) map[string]int64 {
+	result := make(map[string]int64, len(resources))
+	for key, q := range resources {
+		result[key] = q.MilliValue()
+	}
+	return result
+}
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"math/rand"
 	"sync"
 	"time"
 
@@ -12,10 +13,13 @@ import (
 // Task runtimes are recorded in Prometheus.
 // The Prometehus log name for each task is prepended with metricName.
 type task struct {
-	function    func()
-	interval    time.Duration
-	metricName  string
-	stopChannel chan bool
+	function func()
+	interval time.Duration
+	// If greater than zero, each interval is scaled by a factor drawn uniformly at random
+	// from [1-jitterFraction, 1+jitterFraction], such that tasks registered at the same time drift apart.
+	jitterFraction float64
+	metricName     string
+	stopChannel    chan bool
 }
 
 // BackgroundTaskManager is used for registering tasks (functions) to be run periodically.
@@ -41,11 +45,19 @@ func NewBackgroundTaskManager(metricsPrefix string) *BackgroundTaskManager {
 // Interval is the time between function returns and the next time it is called,
 // i.e., the time between calls to function is interval + the runtime of the function.
 func (m *BackgroundTaskManager) Register(function func(), interval time.Duration, metricName string) {
+	m.RegisterWithJitter(function, interval, 0, metricName)
+}
+
+// RegisterWithJitter is like Register, except that each interval is scaled by a factor drawn uniformly at random
+// from [1-jitterFraction, 1+jitterFraction]. This prevents processes started at the same time from calling,
+// e.g., a shared server in lockstep. jitterFraction is clamped to [0, 1].
+func (m *BackgroundTaskManager) RegisterWithJitter(function func(), interval time.Duration, jitterFraction float64, metricName string) {
 	task := &task{
-		function:    function,
-		interval:    interval,
-		metricName:  metricName,
-		stopChannel: make(chan bool),
+		function:       function,
+		interval:       interval,
+		jitterFraction: jitterFraction,
+		metricName:     metricName,
+		stopChannel:    make(chan bool),
 	}
 	m.startBackgroundTask(task)
 	m.tasks = append(m.tasks, task)
@@ -76,7 +88,7 @@ func (m *BackgroundTaskManager) startBackgroundTask(task *task) {
 
 		for {
 			select {
-			case <-time.After(task.interval):
+			case <-time.After(task.nextInterval()):
 			case <-task.stopChannel:
 				m.wg.Done()
 				return
@@ -89,6 +101,23 @@ func (m *BackgroundTaskManager) startBackgroundTask(task *task) {
 	}()
 }
 
+// nextInterval returns the time to wait between the function returning and the next time it's called.
+func (task *task) nextInterval() time.Duration {
+	return jitteredInterval(task.interval, task.jitterFraction, rand.Float64())
+}
+
+// jitteredInterval returns interval scaled by 1 + jitterFraction*(2r-1), where r is in [0, 1].
+// The result is hence in [interval*(1-jitterFraction), interval*(1+jitterFraction)].
+func jitteredInterval(interval time.Duration, jitterFraction float64, r float64) time.Duration {
+	if jitterFraction <= 0 {
+		return interval
+	}
+	if jitterFraction > 1 {
+		jitterFraction = 1
+	}
+	return time.Duration(float64(interval) * (1 + jitterFraction*(2*r-1)))
+}
+
 func (m *BackgroundTaskManager) waitForShutdownCompletion(timeout time.Duration) bool {
 	c := make(chan struct{})
 	go func() {
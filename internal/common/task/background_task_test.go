@@ -0,0 +1,29 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJitteredInterval(t *testing.T) {
+	interval := 10 * time.Second
+	assert.Equal(t, interval, jitteredInterval(interval, 0, 0))
+	assert.Equal(t, interval, jitteredInterval(interval, -0.5, 0))
+	assert.Equal(t, 9*time.Second, jitteredInterval(interval, 0.1, 0))
+	assert.Equal(t, interval, jitteredInterval(interval, 0.1, 0.5))
+	assert.Equal(t, 11*time.Second, jitteredInterval(interval, 0.1, 1))
+	// Fractions above 1 are clamped, such that intervals are never negative.
+	assert.Equal(t, time.Duration(0), jitteredInterval(interval, 2, 0))
+	assert.Equal(t, 20*time.Second, jitteredInterval(interval, 2, 1))
+}
+
+func TestNextIntervalWithinBounds(t *testing.T) {
+	task := &task{interval: 10 * time.Second, jitterFraction: 0.2}
+	for i := 0; i < 1000; i++ {
+		interval := task.nextInterval()
+		assert.GreaterOrEqual(t, interval, 8*time.Second)
+		assert.LessOrEqual(t, interval, 12*time.Second)
+	}
+}
@@ -1,8 +1,20 @@
 package util
 
+import "strings"
+
 func Truncate(s string, max int) string {
 	if max > len(s) {
 		return s
 	}
 	return s[:max]
 }
+
+// TrimTrailingWhitespace removes trailing whitespace from each line of s.
+// Useful, e.g., for removing the padding added by a tabwriter to cells at the end of a line.
+func TrimTrailingWhitespace(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -172,7 +172,7 @@ func setupExecutorApiComponents(
 	)
 
 	leaseRequester := service.NewJobLeaseRequester(
-		executorApiClient, clusterContext, config.Kubernetes.MinimumJobSize)
+		executorApiClient, clusterContext, config.Kubernetes.MinimumJobSize, config.Application.JobLeaseRequestMaxSizeBytes)
 	preemptRunProcessor := processors.NewRunPreemptedProcessor(clusterContext, jobRunState, eventReporter)
 	removeRunProcessor := processors.NewRemoveRunProcessor(clusterContext, jobRunState)
 
@@ -182,7 +182,16 @@ func setupExecutorApiComponents(
 		leaseRequester,
 		jobRunState,
 		clusterUtilisationService,
-		config.Kubernetes.PodDefaults)
+		config.Kubernetes.PodDefaults,
+		service.JobRequesterConfig{
+			MaxLeaseAttempts:      config.Application.JobLeaseRequestMaxAttempts,
+			InitialLeaseBackoff:   config.Application.JobLeaseRequestInitialBackoff,
+			MaxLeaseBackoff:       config.Application.JobLeaseRequestMaxBackoff,
+			LeaseRequestTimeout:   config.Application.JobLeaseRequestTimeout,
+			LeaseAttemptTimeout:   config.Application.JobLeaseRequestAttemptTimeout,
+			MaxLeasedRunsPerCycle: config.Application.MaxLeasedJobRunsPerCycle,
+			PreemptionGracePeriod: config.Kubernetes.PreemptionGracePeriod,
+		})
 	clusterAllocationService := service.NewClusterAllocationService(
 		clusterContext,
 		eventReporter,
@@ -198,7 +207,12 @@ func setupExecutorApiComponents(
 	taskManager.Register(podIssueService.HandlePodIssues, config.Task.PodIssueHandlingInterval, "pod_issue_handling")
 	taskManager.Register(preemptRunProcessor.Run, config.Task.StateProcessorInterval, "preempt_runs")
 	taskManager.Register(removeRunProcessor.Run, config.Task.StateProcessorInterval, "remove_runs")
-	taskManager.Register(jobRequester.RequestJobsRuns, config.Task.AllocateSpareClusterCapacityInterval, "request_runs")
+	taskManager.RegisterWithJitter(
+		jobRequester.RequestJobsRuns,
+		config.Task.AllocateSpareClusterCapacityInterval,
+		config.Application.JobLeaseRequestIntervalJitterFraction,
+		"request_runs",
+	)
 	taskManager.Register(clusterAllocationService.AllocateSpareClusterCapacity, config.Task.AllocateSpareClusterCapacityInterval, "submit_runs")
 	taskManager.Register(eventReporter.ReportMissingJobEvents, config.Task.MissingJobEventReconciliationInterval, "event_reconciliation")
 	pod_metrics.ExposeClusterContextMetrics(clusterContext, clusterUtilisationService, podUtilisationService, nodeInfoService)
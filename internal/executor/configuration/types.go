@@ -18,7 +18,34 @@ type ApplicationConfiguration struct {
 	DeleteConcurrencyLimit int
 	UseExecutorApi         bool
 	UseLegacyApi           bool
+	// Time after which a lease request is cancelled. When using the executor api, this bounds the whole lease cycle,
+	// i.e., determining cluster capacity and all attempts to lease job runs; if zero, a default of 30s is used.
 	JobLeaseRequestTimeout time.Duration
+	// If greater than zero, each attempt to lease job runs from the scheduler is cancelled after this long,
+	// such that a hung attempt can be retried within JobLeaseRequestTimeout.
+	JobLeaseRequestAttemptTimeout time.Duration
+	// Number of times the executor attempts to lease job runs from the scheduler in each cycle before giving up.
+	// Values less than 1 are treated as 1, i.e., failed requests are not retried.
+	JobLeaseRequestMaxAttempts int
+	// Time to wait before retrying a failed lease request.
+	// Doubled after each subsequent failure, up to JobLeaseRequestMaxBackoff.
+	JobLeaseRequestInitialBackoff time.Duration
+	JobLeaseRequestMaxBackoff     time.Duration
+	// If greater than zero, lease requests the encoded size of which would exceed this number of bytes
+	// are sent as several messages on the same stream, each covering a subset of the nodes and unassigned job runs.
+	// The scheduler joins these messages into a single request.
+	// Should be set below the max message size accepted by the scheduler.
+	// Only enable once all schedulers the executor may connect to support joining such messages.
+	JobLeaseRequestMaxSizeBytes int
+	// If greater than zero, at most this many newly leased job runs are accepted in each lease cycle,
+	// bounding the rate at which the executor creates pods. Runs leased in excess of this are ignored
+	// and are offered again by the scheduler in a later cycle.
+	MaxLeasedJobRunsPerCycle int
+	// Fraction by which the interval between lease cycles, i.e., Task.AllocateSpareClusterCapacityInterval, is randomly varied.
+	// E.g., with 0.1 and an interval of 5s, consecutive cycles are between 4.5s and 5.5s apart.
+	// Prevents executors started at the same time from requesting job runs from the scheduler in lockstep.
+	// Zero disables jitter; values above 1 are treated as 1.
+	JobLeaseRequestIntervalJitterFraction float64
 }
 
 type PodDefaults struct {
@@ -65,6 +92,9 @@ type KubernetesConfiguration struct {
 	// MinimumResourcesMarkedAllocatedToNonArmadaPodsPerNode, those resources are marked allocated at this priority.
 	MinimumResourcesMarkedAllocatedToNonArmadaPodsPerNodePriority int32
 	PodKillTimeout                                                time.Duration
+	// Time given to pods of preempted runs to shut down gracefully before they may be killed.
+	// Runs marked for preemption record a deadline this far in the future.
+	PreemptionGracePeriod time.Duration
 }
 
 type EtcdConfiguration struct {
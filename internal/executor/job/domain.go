@@ -1,6 +1,7 @@
 package job
 
 import (
+	"fmt"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -55,13 +56,35 @@ const (
 	Missing
 )
 
+func (phase RunPhase) String() string {
+	switch phase {
+	case Invalid:
+		return "Invalid"
+	case Leased:
+		return "Leased"
+	case SuccessfulSubmission:
+		return "SuccessfulSubmission"
+	case FailedSubmission:
+		return "FailedSubmission"
+	case Active:
+		return "Active"
+	case Missing:
+		return "Missing"
+	default:
+		return fmt.Sprintf("RunPhase(%d)", int(phase))
+	}
+}
+
 type RunState struct {
-	Meta                    *RunMeta
-	Job                     *SubmitJob
-	KubernetesId            string
-	Phase                   RunPhase
-	CancelRequested         bool
-	PreemptionRequested     bool
+	Meta                *RunMeta
+	Job                 *SubmitJob
+	KubernetesId        string
+	Phase               RunPhase
+	CancelRequested     bool
+	PreemptionRequested bool
+	// If PreemptionRequested, the time by which the run should have terminated.
+	// Pods of the run are given until then to shut down gracefully before they may be killed.
+	PreemptionDeadline      time.Time
 	LastPhaseTransitionTime time.Time
 }
 
@@ -73,6 +96,7 @@ func (r *RunState) DeepCopy() *RunState {
 		Phase:                   r.Phase,
 		CancelRequested:         r.CancelRequested,
 		PreemptionRequested:     r.PreemptionRequested,
+		PreemptionDeadline:      r.PreemptionDeadline,
 		LastPhaseTransitionTime: r.LastPhaseTransitionTime,
 	}
 }
@@ -18,7 +18,7 @@ type RunStateStore interface {
 	ReportSuccessfulSubmission(runId string)
 	ReportFailedSubmission(runId string)
 	RequestRunCancellation(runId string)
-	RequestRunPreemption(runId string)
+	RequestRunPreemption(runId string, deadline time.Time)
 	Delete(runId string)
 	Get(runId string) *RunState
 	GetAll() []*RunState
@@ -186,11 +186,16 @@ func (stateStore *JobRunStateStore) RequestRunCancellation(runId string) {
 	}
 }
 
-func (stateStore *JobRunStateStore) RequestRunPreemption(runId string) {
+// RequestRunPreemption marks the run as to be preempted, with its pods terminated by deadline.
+// If preemption was already requested, the earlier deadline is kept.
+func (stateStore *JobRunStateStore) RequestRunPreemption(runId string, deadline time.Time) {
 	stateStore.lock.Lock()
 	defer stateStore.lock.Unlock()
 
 	if currentState, present := stateStore.jobRunState[runId]; present {
+		if !currentState.PreemptionRequested || deadline.Before(currentState.PreemptionDeadline) {
+			currentState.PreemptionDeadline = deadline
+		}
 		currentState.PreemptionRequested = true
 	}
 }
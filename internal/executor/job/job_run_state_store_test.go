@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
@@ -101,9 +102,17 @@ func TestRequestRunPreemption(t *testing.T) {
 		"run-1": createRunState("run-1", Active),
 	}
 
-	jobRunStateManager.RequestRunPreemption("run-1")
+	deadline := time.Now().Add(time.Minute)
+	jobRunStateManager.RequestRunPreemption("run-1", deadline)
 	result := jobRunStateManager.Get("run-1")
 	assert.True(t, result.PreemptionRequested)
+	assert.Equal(t, deadline, result.PreemptionDeadline)
+
+	// A later deadline doesn't extend the grace period, but an earlier one shortens it.
+	jobRunStateManager.RequestRunPreemption("run-1", deadline.Add(time.Minute))
+	assert.Equal(t, deadline, jobRunStateManager.Get("run-1").PreemptionDeadline)
+	jobRunStateManager.RequestRunPreemption("run-1", deadline.Add(-time.Second))
+	assert.Equal(t, deadline.Add(-time.Second), jobRunStateManager.Get("run-1").PreemptionDeadline)
 }
 
 func TestRequestRunCancellation(t *testing.T) {
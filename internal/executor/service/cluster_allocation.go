@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
@@ -150,7 +151,7 @@ func (allocationService *LegacyClusterAllocationService) AllocateSpareClusterCap
 		return
 	}
 
-	capacityReport, err := allocationService.utilisationService.GetAvailableClusterCapacity(true)
+	capacityReport, err := allocationService.utilisationService.GetAvailableClusterCapacity(context.Background(), true)
 	if err != nil {
 		log.Errorf("Failed to allocate spare cluster capacity because %s", err)
 		return
@@ -2,24 +2,56 @@ package service
 
 import (
 	"context"
+	"sort"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/slices"
 	util2 "github.com/armadaproject/armada/internal/common/util"
 	"github.com/armadaproject/armada/internal/executor/configuration"
 	executorContext "github.com/armadaproject/armada/internal/executor/context"
 	"github.com/armadaproject/armada/internal/executor/job"
+	"github.com/armadaproject/armada/internal/executor/metrics"
 	"github.com/armadaproject/armada/internal/executor/reporter"
-	"github.com/armadaproject/armada/internal/executor/util"
 	"github.com/armadaproject/armada/internal/executor/utilisation"
 	"github.com/armadaproject/armada/pkg/api"
 	"github.com/armadaproject/armada/pkg/armadaevents"
 	"github.com/armadaproject/armada/pkg/executorapi"
 )
 
+const (
+	validityLabel = "validity"
+	reasonLabel   = "reason"
+
+	// Leased runs missing information necessary to identify them; these are skipped.
+	fullyInvalidLease = "fully_invalid"
+	// Leased runs that can be identified, but from which no pod can be created; these are reported as failed.
+	partiallyInvalidLease = "partially_invalid"
+
+	// Used to bound each lease cycle if no timeout is configured.
+	defaultLeaseRequestTimeout = 30 * time.Second
+)
+
+var invalidLeasedRunsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: metrics.ArmadaExecutorMetricsPrefix + "invalid_leased_runs_total",
+		Help: "Counter for leased job runs the executor could not submit, by validity and reason",
+	},
+	[]string{validityLabel, reasonLabel})
+
+var duplicateLeasedRunsCounter = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Name: metrics.ArmadaExecutorMetricsPrefix + "duplicate_leased_runs_total",
+		Help: "Counter for leased job runs dropped because another run of the same job was leased in the same response",
+	})
+
 type JobRequester struct {
 	leaseRequester     LeaseRequester
 	eventReporter      reporter.EventReporter
@@ -27,6 +59,34 @@ type JobRequester struct {
 	clusterId          executorContext.ClusterIdentity
 	podDefaults        *configuration.PodDefaults
 	jobRunStateStore   job.RunStateStore
+	// Set from JobRequesterConfig; see there for details.
+	maxLeaseAttempts      int
+	initialLeaseBackoff   time.Duration
+	maxLeaseBackoff       time.Duration
+	leaseRequestTimeout   time.Duration
+	leaseAttemptTimeout   time.Duration
+	maxLeasedRunsPerCycle int
+	preemptionGracePeriod time.Duration
+	clock                 clock.Clock
+}
+
+// JobRequesterConfig controls how a JobRequester leases job runs.
+// Zero values select the defaults noted for each field.
+type JobRequesterConfig struct {
+	// Max number of attempts made to lease job runs per call to RequestJobsRuns. At least one attempt is always made.
+	MaxLeaseAttempts int
+	// Time to wait before the first retry of a failed lease request; doubled after each failure up to MaxLeaseBackoff.
+	InitialLeaseBackoff time.Duration
+	MaxLeaseBackoff     time.Duration
+	// Bounds each call to RequestJobsRuns, including determining cluster capacity and all lease attempts.
+	// Defaults to defaultLeaseRequestTimeout.
+	LeaseRequestTimeout time.Duration
+	// If greater than zero, each lease attempt is cancelled after this long.
+	LeaseAttemptTimeout time.Duration
+	// If greater than zero, at most this many leased runs are accepted per call to RequestJobsRuns.
+	MaxLeasedRunsPerCycle int
+	// Time given to runs to terminate once marked for preemption.
+	PreemptionGracePeriod time.Duration
 }
 
 func NewJobRequester(
@@ -36,81 +96,196 @@ func NewJobRequester(
 	jobRunStateStore job.RunStateStore,
 	utilisationService utilisation.UtilisationService,
 	podDefaults *configuration.PodDefaults,
+	config JobRequesterConfig,
 ) *JobRequester {
+	maxLeaseAttempts := config.MaxLeaseAttempts
+	if maxLeaseAttempts < 1 {
+		maxLeaseAttempts = 1
+	}
+	leaseRequestTimeout := config.LeaseRequestTimeout
+	if leaseRequestTimeout <= 0 {
+		leaseRequestTimeout = defaultLeaseRequestTimeout
+	}
 	return &JobRequester{
-		leaseRequester:     leaseRequester,
-		eventReporter:      eventReporter,
-		utilisationService: utilisationService,
-		jobRunStateStore:   jobRunStateStore,
-		clusterId:          clusterId,
-		podDefaults:        podDefaults,
+		leaseRequester:        leaseRequester,
+		eventReporter:         eventReporter,
+		utilisationService:    utilisationService,
+		jobRunStateStore:      jobRunStateStore,
+		clusterId:             clusterId,
+		podDefaults:           podDefaults,
+		maxLeaseAttempts:      maxLeaseAttempts,
+		initialLeaseBackoff:   config.InitialLeaseBackoff,
+		maxLeaseBackoff:       config.MaxLeaseBackoff,
+		leaseRequestTimeout:   leaseRequestTimeout,
+		leaseAttemptTimeout:   config.LeaseAttemptTimeout,
+		maxLeasedRunsPerCycle: config.MaxLeasedRunsPerCycle,
+		preemptionGracePeriod: config.PreemptionGracePeriod,
+		clock:                 clock.RealClock{},
 	}
 }
 
+// RequestJobsRuns leases job runs from the scheduler for the available capacity of the cluster and handles the response.
+// The whole cycle is bounded by leaseRequestTimeout, such that a slow scheduler can't cause cycles to overlap;
+// if the timeout expires, runs leased so far are still handled.
 func (r *JobRequester) RequestJobsRuns() {
-	leaseRequest, err := r.createLeaseRequest()
+	ctx, cancel := context.WithTimeout(context.Background(), r.leaseRequestTimeout)
+	defer cancel()
+	leaseRequest, err := r.createLeaseRequest(ctx)
 	if err != nil {
 		log.Errorf("Failed to create lease request because %s", err)
 		return
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	leaseResponse, err := r.leaseRequester.LeaseJobRuns(ctx, leaseRequest)
+	logExcludedNodes(leaseRequest.ExcludedNodes)
+	leaseResponse, err := r.leaseJobRuns(ctx, leaseRequest)
 	if err != nil {
 		log.Errorf("Failed to request new jobs leases as because %s", err)
 		return
 	}
 	logAvailableResources(leaseRequest.AvailableResource, len(leaseResponse.LeasedRuns))
+	// Identifies the lease response in logs recording the changes it causes to the state of runs.
+	leaseResponseId := uuid.NewString()
+	log.WithField("leaseResponseId", leaseResponseId).Infof(
+		"Received lease response with %d leased runs, %d runs to cancel, and %d runs to preempt",
+		len(leaseResponse.LeasedRuns), len(leaseResponse.RunIdsToCancel), len(leaseResponse.RunIdsToPreempt),
+	)
 
-	jobs, failedJobCreations := r.createSubmitJobs(leaseResponse.LeasedRuns)
+	leasedRuns := r.limitLeasedRuns(leaseResponse.LeasedRuns)
+	jobs, failedJobCreations := r.createSubmitJobs(leasedRuns)
 	r.markJobRunsAsLeased(jobs)
-	r.markJobRunsAsCancelled(leaseResponse.RunIdsToCancel)
-	r.markJobRunsToPreempt(leaseResponse.RunIdsToPreempt)
+	r.markJobRunsAsCancelled(leaseResponse.RunIdsToCancel, leaseResponseId)
+	r.markJobRunsToPreempt(leaseResponse.RunIdsToPreempt, leaseResponseId)
 	r.handleFailedJobCreation(failedJobCreations)
 }
 
-func (r *JobRequester) createLeaseRequest() (*LeaseRequest, error) {
-	capacityReport, err := r.utilisationService.GetAvailableClusterCapacity(false)
-	if err != nil {
-		return nil, err
+// leaseJobRuns requests job runs from the scheduler, retrying with exponential backoff on failure.
+// If leaseAttemptTimeout is set, each attempt is cancelled after that long.
+// Gives up once maxLeaseAttempts attempts have failed, or if waiting before the next attempt would exceed the deadline of ctx.
+func (r *JobRequester) leaseJobRuns(ctx context.Context, request *LeaseRequest) (*LeaseResponse, error) {
+	backoff := r.initialLeaseBackoff
+	for attempt := 1; ; attempt++ {
+		response, err := r.leaseJobRunsOnce(ctx, request)
+		if err == nil {
+			return response, nil
+		}
+		if attempt >= r.maxLeaseAttempts || ctx.Err() != nil {
+			return nil, err
+		}
+		if deadline, ok := ctx.Deadline(); ok && deadline.Sub(r.clock.Now()) < backoff {
+			return nil, err
+		}
+		log.WithError(err).Warnf("Lease request failed (attempt %d of %d), will wait for %s before retrying", attempt, r.maxLeaseAttempts, backoff)
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-r.clock.After(backoff):
+		}
+		backoff *= 2
+		if r.maxLeaseBackoff > 0 && backoff > r.maxLeaseBackoff {
+			backoff = r.maxLeaseBackoff
+		}
 	}
+}
+
+// leaseJobRunsOnce makes a single attempt to lease job runs, bounded by leaseAttemptTimeout if set.
+func (r *JobRequester) leaseJobRunsOnce(ctx context.Context, request *LeaseRequest) (*LeaseResponse, error) {
+	if r.leaseAttemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.leaseAttemptTimeout)
+		defer cancel()
+	}
+	return r.leaseRequester.LeaseJobRuns(ctx, request)
+}
 
-	unassignedRunIds, err := r.getUnassignedRunIds(capacityReport)
+// limitLeasedRuns returns the first maxLeasedRunsPerCycle of leasedRuns, or all of them if no limit is configured.
+// The remaining runs aren't recorded in the state store, such that they're offered again in a later cycle.
+func (r *JobRequester) limitLeasedRuns(leasedRuns []*executorapi.JobRunLease) []*executorapi.JobRunLease {
+	if r.maxLeasedRunsPerCycle <= 0 || len(leasedRuns) <= r.maxLeasedRunsPerCycle {
+		return leasedRuns
+	}
+	log.Infof(
+		"Accepting %d of %d leased runs; the remaining runs will be leased in a later cycle",
+		r.maxLeasedRunsPerCycle, len(leasedRuns),
+	)
+	return leasedRuns[:r.maxLeasedRunsPerCycle]
+}
+
+func (r *JobRequester) createLeaseRequest(ctx context.Context) (*LeaseRequest, error) {
+	capacityReport, err := r.utilisationService.GetAvailableClusterCapacity(ctx, false)
 	if err != nil {
 		return nil, err
 	}
 
+	// We make the assumption here that JobRunStateStore knows about all job runs and don't reconcile again against kubernetes
+	// This should be a safe assumption - and would be a bug if it was ever not true
+	unassignedRunIds := computeUnassignedRunIds(r.jobRunStateStore.GetAll(), capacityReport)
+
 	nodes := make([]*api.NodeInfo, 0, len(capacityReport.Nodes))
 	for i := range capacityReport.Nodes {
 		nodes = append(nodes, &capacityReport.Nodes[i])
 	}
 
+	var excludedNodes map[string]string
+	if len(capacityReport.ExcludedNodes) > 0 {
+		excludedNodes = make(map[string]string, len(capacityReport.ExcludedNodes))
+		for _, node := range capacityReport.ExcludedNodes {
+			excludedNodes[node.Name] = node.Reason
+		}
+	}
+
 	return &LeaseRequest{
 		AvailableResource:   *capacityReport.AvailableCapacity,
 		Nodes:               nodes,
 		UnassignedJobRunIds: unassignedRunIds,
+		ExcludedNodes:       excludedNodes,
 	}, nil
 }
 
-// Returns the RunIds of all managed pods that haven't been assigned to a node
-func (r *JobRequester) getUnassignedRunIds(capacityReport *utilisation.ClusterAvailableCapacityReport) ([]armadaevents.Uuid, error) {
-	allAssignedRunIds := []string{}
-	allJobRunIds := []string{}
+// logExcludedNodes logs the nodes omitted from a lease request, if any.
+func logExcludedNodes(excludedNodes map[string]string) {
+	if len(excludedNodes) == 0 {
+		return
+	}
+	names := maps.Keys(excludedNodes)
+	sort.Strings(names)
+	for _, name := range names {
+		log.Warnf("Not including node %s in lease request because %s", name, excludedNodes[name])
+	}
+}
 
+// computeUnassignedRunIds returns the ids of the runs in stateRuns that capacityReport doesn't assign to a node,
+// in the order they appear in stateRuns.
+// Runs assigned to nodes excluded from the capacity report are considered assigned.
+// Run ids that aren't valid uuids are logged and skipped, and each run is included at most once.
+func computeUnassignedRunIds(stateRuns []*job.RunState, capacityReport *utilisation.ClusterAvailableCapacityReport) []armadaevents.Uuid {
+	allAssignedRunIds := []string{}
 	for _, node := range capacityReport.Nodes {
 		allAssignedRunIds = append(allAssignedRunIds, maps.Keys(node.RunIdsByState)...)
 	}
+	for _, node := range capacityReport.ExcludedNodes {
+		allAssignedRunIds = append(allAssignedRunIds, maps.Keys(node.RunIdsByState)...)
+	}
 
-	// We make the assumption here that JobRunStateStore knows about all job runs and don't reconcile again against kubernetes
-	// This should be a safe assumption - and would be a bug if it was ever not true
-	allJobRuns := r.jobRunStateStore.GetAll()
-	allJobRunIds = append(allJobRunIds, util2.Map(allJobRuns, func(val *job.RunState) string {
+	allJobRunIds := util2.Map(stateRuns, func(val *job.RunState) string {
 		return val.Meta.RunId
-	})...)
+	})
 
 	unassignedIds := slices.Subtract(allJobRunIds, allAssignedRunIds)
 
-	return util.StringUuidsToUuids(unassignedIds)
+	result := make([]armadaevents.Uuid, 0, len(unassignedIds))
+	seen := make(map[armadaevents.Uuid]bool, len(unassignedIds))
+	for _, runId := range unassignedIds {
+		uuid, err := armadaevents.ProtoUuidFromUuidString(runId)
+		if err != nil {
+			log.Warnf("Not reporting run %s as unassigned because its id is invalid: %s", runId, err)
+			continue
+		}
+		if seen[*uuid] {
+			continue
+		}
+		seen[*uuid] = true
+		result = append(result, *uuid)
+	}
+	return result
 }
 
 type failedJobCreationDetails struct {
@@ -121,15 +296,36 @@ type failedJobCreationDetails struct {
 func (r *JobRequester) createSubmitJobs(newJobRuns []*executorapi.JobRunLease) ([]*job.SubmitJob, []*failedJobCreationDetails) {
 	submitJobs := make([]*job.SubmitJob, 0, len(newJobRuns))
 	failedJobCreations := []*failedJobCreationDetails{}
+	// Job ids of leased runs already handled; at most one run per job is accepted from each lease response.
+	seenJobIds := make(map[string]string, len(newJobRuns))
 	for _, jobToSubmit := range newJobRuns {
 		jobMeta, err := ExtractEssentialJobMetadata(jobToSubmit)
 		if err != nil {
-			log.Errorf("received invalid job - %s", err)
+			reason := invalidLeaseReasonOther
+			var invalidLeaseErr *invalidLeaseError
+			if errors.As(err, &invalidLeaseErr) {
+				reason = invalidLeaseErr.reason
+			}
+			leaseLogger(jobToSubmit).WithField("reason", reason).Errorf("received invalid job - %s", err)
+			invalidLeasedRunsCounter.WithLabelValues(fullyInvalidLease, reason).Inc()
+			continue
+		}
+		logger := runLogger(jobMeta)
+		if runId, ok := seenJobIds[jobMeta.JobId]; ok {
+			logger.Warnf("Dropping run %s of job %s because run %s of the same job was already leased", jobMeta.RunId, jobMeta.JobId, runId)
+			duplicateLeasedRunsCounter.Inc()
 			continue
 		}
+		seenJobIds[jobMeta.JobId] = jobMeta.RunId
 
 		submitJob, err := job.CreateSubmitJobFromExecutorApiJobRunLease(jobToSubmit, r.podDefaults)
 		if err != nil {
+			reason := invalidLeaseReasonOther
+			if jobToSubmit.Job.GetMainObject().GetPodSpec() == nil {
+				reason = invalidLeaseReasonMissingPodSpec
+			}
+			logger.WithField("reason", reason).Errorf("Failed to create job from leased run %s because %s", jobMeta.RunId, err)
+			invalidLeasedRunsCounter.WithLabelValues(partiallyInvalidLease, reason).Inc()
 			failedJobCreations = append(failedJobCreations, &failedJobCreationDetails{
 				JobRunMeta: jobMeta,
 				Error:      err,
@@ -145,29 +341,111 @@ func (r *JobRequester) createSubmitJobs(newJobRuns []*executorapi.JobRunLease) (
 func (r *JobRequester) markJobRunsAsLeased(jobs []*job.SubmitJob) {
 	for _, j := range jobs {
 		r.jobRunStateStore.ReportRunLeased(j.Meta.RunMeta, j)
+		runLogger(j.Meta.RunMeta).Infof("Leased run %s of job %s", j.Meta.RunMeta.RunId, j.Meta.RunMeta.JobId)
 	}
 }
 
-func (r *JobRequester) markJobRunsAsCancelled(runIdsToCancel []*armadaevents.Uuid) {
+func (r *JobRequester) markJobRunsAsCancelled(runIdsToCancel []*armadaevents.Uuid, leaseResponseId string) {
 	for _, runToCancelId := range runIdsToCancel {
 		runIdStr, err := armadaevents.UuidStringFromProtoUuid(runToCancelId)
 		if err != nil {
 			log.Errorf("Skipping removing run because %s", err)
 			continue
 		}
+		previousState := r.jobRunStateStore.Get(runIdStr)
+		r.knownRunLogger(runIdStr).Infof("Requesting cancellation of run %s", runIdStr)
 		r.jobRunStateStore.RequestRunCancellation(runIdStr)
+		r.logRunStateTransition(runIdStr, previousState, leaseResponseId)
 	}
 }
 
-func (r *JobRequester) markJobRunsToPreempt(runIdsToPreempt []*armadaevents.Uuid) {
+func (r *JobRequester) markJobRunsToPreempt(runIdsToPreempt []*armadaevents.Uuid, leaseResponseId string) {
+	deadline := r.clock.Now().Add(r.preemptionGracePeriod)
 	for _, runToCancelId := range runIdsToPreempt {
 		runIdStr, err := armadaevents.UuidStringFromProtoUuid(runToCancelId)
 		if err != nil {
 			log.Errorf("Skipping preempting run because %s", err)
 			continue
 		}
-		r.jobRunStateStore.RequestRunPreemption(runIdStr)
+		previousState := r.jobRunStateStore.Get(runIdStr)
+		r.knownRunLogger(runIdStr).Infof("Requesting preemption of run %s with deadline %s", runIdStr, deadline)
+		r.jobRunStateStore.RequestRunPreemption(runIdStr, deadline)
+		r.logRunStateTransition(runIdStr, previousState, leaseResponseId)
+	}
+}
+
+// logRunStateTransition records, for audit, a change to the desired state of a run
+// caused by the lease response with the given id, e.g., from Active to Active/CancelRequested.
+// Nothing is logged if the run isn't known to the state store or if its desired state is unchanged.
+func (r *JobRequester) logRunStateTransition(runId string, previousState *job.RunState, leaseResponseId string) {
+	currentState := r.jobRunStateStore.Get(runId)
+	if previousState == nil || currentState == nil {
+		return
+	}
+	oldState := describeDesiredState(previousState)
+	newState := describeDesiredState(currentState)
+	if oldState == newState {
+		return
+	}
+	r.knownRunLogger(runId).WithFields(log.Fields{
+		"oldState":        oldState,
+		"newState":        newState,
+		"leaseResponseId": leaseResponseId,
+	}).Infof("Run %s transitioned from %s to %s", runId, oldState, newState)
+}
+
+// describeDesiredState returns the phase of a run followed by any action requested of it,
+// e.g., Active/CancelRequested or Active/PreemptionRequested.
+func describeDesiredState(state *job.RunState) string {
+	description := state.Phase.String()
+	if state.CancelRequested {
+		description += "/CancelRequested"
+	}
+	if state.PreemptionRequested {
+		description += "/PreemptionRequested"
+	}
+	return description
+}
+
+// runLogger returns a logger annotated with the identifiers of a run,
+// such that all logs relating to a run can be found by searching for its run id.
+func runLogger(meta *job.RunMeta) *log.Entry {
+	return log.WithFields(log.Fields{
+		"jobId":  meta.JobId,
+		"runId":  meta.RunId,
+		"queue":  meta.Queue,
+		"jobSet": meta.JobSet,
+	})
+}
+
+// knownRunLogger returns a logger annotated with the identifiers of the run with the given id.
+// Only the run id is included if the run isn't known to the state store.
+func (r *JobRequester) knownRunLogger(runId string) *log.Entry {
+	if state := r.jobRunStateStore.Get(runId); state != nil && state.Meta != nil {
+		return runLogger(state.Meta)
+	}
+	return log.WithField("runId", runId)
+}
+
+// leaseLogger returns a logger annotated with whichever identifiers of a leased run are valid.
+// Unlike runLogger, this can be used for leases from which ExtractEssentialJobMetadata fails.
+func leaseLogger(lease *executorapi.JobRunLease) *log.Entry {
+	fields := log.Fields{}
+	if lease.Job != nil {
+		if jobId, err := armadaevents.UlidStringFromProtoUuid(lease.Job.JobId); err == nil {
+			fields["jobId"] = jobId
+		}
+	}
+	if runId, err := armadaevents.UuidStringFromProtoUuid(lease.JobRunId); err == nil {
+		fields["runId"] = runId
+	}
+	if lease.Queue != "" {
+		fields["queue"] = lease.Queue
+	}
+	if lease.Jobset != "" {
+		fields["jobSet"] = lease.Jobset
 	}
+	return log.WithFields(fields)
 }
 
 func (r *JobRequester) handleFailedJobCreation(failedJobCreationDetails []*failedJobCreationDetails) {
@@ -184,10 +462,12 @@ func (r *JobRequester) handleFailedJobCreation(failedJobCreationDetails []*faile
 			Cause:             api.Cause_Error,
 		}
 		err := r.eventReporter.Report([]reporter.EventMessage{{Event: failedEvent, JobRunId: failedCreateDetails.JobRunMeta.RunId}})
+		logger := runLogger(failedCreateDetails.JobRunMeta)
 		if err == nil {
 			r.jobRunStateStore.ReportRunInvalid(failedCreateDetails.JobRunMeta)
+			logger.Infof("Reported failure of run %s of job %s", failedCreateDetails.JobRunMeta.RunId, failedCreateDetails.JobRunMeta.JobId)
 		} else {
-			log.Errorf("Failed to report job creation failed for job %s (run id %s) because %s",
+			logger.Errorf("Failed to report job creation failed for job %s (run id %s) because %s",
 				failedCreateDetails.JobRunMeta.JobId, failedCreateDetails.JobRunMeta.RunId, err)
 		}
 	}
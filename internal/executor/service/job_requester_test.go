@@ -3,13 +3,21 @@ package service
 import (
 	"context"
 	"fmt"
+	"math"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	armadaresource "github.com/armadaproject/armada/internal/common/resource"
 	"github.com/armadaproject/armada/internal/common/util"
@@ -28,12 +36,137 @@ func TestRequestJobsRuns_HandlesLeaseRequestError(t *testing.T) {
 	jobRequester, eventReporter, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{})
 	leaseRequester.LeaseJobRunError = fmt.Errorf("lease error")
 
-	jobRequester.RequestJobsRuns()
+	waited := requestJobsRunsAdvancingClock(jobRequester)
+	assert.Len(t, leaseRequester.ReceivedLeaseRequests, 3)
+	// Backed off for the initial backoff and then for twice that.
+	assert.Equal(t, 3*time.Second, waited)
 	assert.Len(t, eventReporter.ReceivedEvents, 0)
 	allJobRuns := stateStore.GetAll()
 	assert.Len(t, allJobRuns, 0)
 }
 
+func TestRequestJobsRuns_RetriesLeaseRequestError(t *testing.T) {
+	jobRequester, eventReporter, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{})
+	leaseRequester.LeaseJobRunErrors = []error{fmt.Errorf("lease error"), fmt.Errorf("lease error")}
+	leaseRequester.LeaseJobRunLeaseResponse = &LeaseResponse{
+		LeasedRuns: []*executorapi.JobRunLease{createSubmittableJobRunLease(t)},
+	}
+
+	requestJobsRunsAdvancingClock(jobRequester)
+	assert.Len(t, leaseRequester.ReceivedLeaseRequests, 3)
+	assert.Len(t, eventReporter.ReceivedEvents, 0)
+	allJobRuns := stateStore.GetAll()
+	assert.Len(t, allJobRuns, 1)
+	assert.Equal(t, allJobRuns[0].Phase, job.Leased)
+}
+
+func TestRequestJobsRuns_StopsRetryingIfBackoffExceedsDeadline(t *testing.T) {
+	jobRequester, _, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{})
+	jobRequester.initialLeaseBackoff = time.Hour
+	leaseRequester.LeaseJobRunErrors = []error{fmt.Errorf("lease error")}
+	leaseRequester.LeaseJobRunLeaseResponse = &LeaseResponse{
+		LeasedRuns: []*executorapi.JobRunLease{createSubmittableJobRunLease(t)},
+	}
+
+	jobRequester.RequestJobsRuns()
+	assert.Len(t, leaseRequester.ReceivedLeaseRequests, 1)
+	assert.Len(t, stateStore.GetAll(), 0)
+}
+
+func TestRequestJobsRuns_CancelsLeaseRequestAfterTimeout(t *testing.T) {
+	jobRequester, _, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{})
+	jobRequester.leaseRequestTimeout = 50 * time.Millisecond
+	leaseRequester.NumBlockingCalls = math.MaxInt
+	leaseRequester.LeaseJobRunLeaseResponse = &LeaseResponse{
+		LeasedRuns: []*executorapi.JobRunLease{createSubmittableJobRunLease(t)},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		jobRequester.RequestJobsRuns()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RequestJobsRuns didn't return after the lease request timed out")
+	}
+	// The cycle deadline has passed, so the failed request isn't retried.
+	assert.Len(t, leaseRequester.ReceivedLeaseRequests, 1)
+	assert.Len(t, stateStore.GetAll(), 0)
+}
+
+func TestRequestJobsRuns_CancelsCapacityLookupAfterTimeout(t *testing.T) {
+	jobRequester, _, leaseRequester, stateStore, utilisationService := setupJobRequesterTest([]*job.RunState{})
+	jobRequester.leaseRequestTimeout = 50 * time.Millisecond
+	utilisationService.BlockGetAvailableClusterCapacity = true
+
+	done := make(chan struct{})
+	go func() {
+		jobRequester.RequestJobsRuns()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RequestJobsRuns didn't return after determining cluster capacity timed out")
+	}
+	assert.Len(t, leaseRequester.ReceivedLeaseRequests, 0)
+	assert.Len(t, stateStore.GetAll(), 0)
+}
+
+func TestRequestJobsRuns_RetriesLeaseAttemptAfterTimeout(t *testing.T) {
+	jobRequester, _, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{})
+	jobRequester.leaseAttemptTimeout = 10 * time.Millisecond
+	leaseRequester.NumBlockingCalls = 2
+	leaseRequester.LeaseJobRunLeaseResponse = &LeaseResponse{
+		LeasedRuns: []*executorapi.JobRunLease{createSubmittableJobRunLease(t)},
+	}
+
+	requestJobsRunsAdvancingClock(jobRequester)
+	assert.Len(t, leaseRequester.ReceivedLeaseRequests, 3)
+	allJobRuns := stateStore.GetAll()
+	assert.Len(t, allJobRuns, 1)
+	assert.Equal(t, allJobRuns[0].Phase, job.Leased)
+}
+
+func TestRequestJobsRuns_LogsRunIdentifiers(t *testing.T) {
+	hook := logtest.NewGlobal()
+	defer hook.Reset()
+	jobRequester, _, leaseRequester, _, _ := setupJobRequesterTest([]*job.RunState{})
+	// A lease without a pod spec, from which no job can be created.
+	jobId, runId, lease := createValidJobRunLease(t, "queue", "job-set")
+	runIdToCancel := uuid.New()
+	leaseRequester.LeaseJobRunLeaseResponse = &LeaseResponse{
+		LeasedRuns:     []*executorapi.JobRunLease{lease},
+		RunIdsToCancel: []*armadaevents.Uuid{armadaevents.ProtoUuidFromUuid(runIdToCancel)},
+	}
+
+	jobRequester.RequestJobsRuns()
+
+	fieldsByRunId := make(map[string][]logrus.Fields)
+	for _, entry := range hook.AllEntries() {
+		if id, ok := entry.Data["runId"].(string); ok {
+			fieldsByRunId[id] = append(fieldsByRunId[id], entry.Data)
+		}
+	}
+	require.NotEmpty(t, fieldsByRunId[runId])
+	for _, fields := range fieldsByRunId[runId] {
+		assert.Equal(t, jobId, fields["jobId"])
+		assert.Equal(t, "queue", fields["queue"])
+		assert.Equal(t, "job-set", fields["jobSet"])
+	}
+	assert.Contains(t, fieldsByRunId[runId], logrus.Fields{
+		"jobId":  jobId,
+		"runId":  runId,
+		"queue":  "queue",
+		"jobSet": "job-set",
+		"reason": invalidLeaseReasonMissingPodSpec,
+	})
+	// Only the run id is known for runs not in the state store.
+	assert.Equal(t, []logrus.Fields{{"runId": runIdToCancel.String()}}, fieldsByRunId[runIdToCancel.String()])
+}
+
 func TestRequestJobsRuns_HandlesGetClusterCapacityError(t *testing.T) {
 	jobRequester, eventReporter, leaseRequester, stateStore, utilisationService := setupJobRequesterTest([]*job.RunState{})
 	utilisationService.GetClusterAvailableCapacityError = fmt.Errorf("capacity report error")
@@ -78,6 +211,198 @@ func TestRequestJobsRuns_ConstructsCorrectLeaseRequest(t *testing.T) {
 	assert.Equal(t, leaseRequester.ReceivedLeaseRequests[0], expectedRequest)
 }
 
+func TestRequestJobsRuns_ConstructsLeaseRequestWithArbitraryResources(t *testing.T) {
+	jobRequester, _, leaseRequester, _, utilisationService := setupJobRequesterTest([]*job.RunState{})
+
+	capacityReport := &utilisation.ClusterAvailableCapacityReport{
+		AvailableCapacity: &armadaresource.ComputeResources{
+			"cpu":               resource.MustParse("1000"),
+			"memory":            resource.MustParse("1000Gi"),
+			"ephemeral-storage": resource.MustParse("500Gi"),
+			"nvidia.com/gpu":    resource.MustParse("8"),
+			"example.com/fpga":  resource.MustParse("2"),
+		},
+		Nodes: []api.NodeInfo{
+			{
+				Name: "node-1",
+				AvailableResources: armadaresource.ComputeResources{
+					"cpu":               resource.MustParse("1000"),
+					"memory":            resource.MustParse("1000Gi"),
+					"ephemeral-storage": resource.MustParse("500Gi"),
+					"nvidia.com/gpu":    resource.MustParse("8"),
+					"example.com/fpga":  resource.MustParse("2"),
+				},
+			},
+		},
+	}
+	utilisationService.ClusterAvailableCapacityReport = capacityReport
+
+	jobRequester.RequestJobsRuns()
+
+	require.Len(t, leaseRequester.ReceivedLeaseRequests, 1)
+	request := leaseRequester.ReceivedLeaseRequests[0]
+	assert.Equal(t, *capacityReport.AvailableCapacity, request.AvailableResource)
+	gpu := request.AvailableResource["nvidia.com/gpu"]
+	assert.Equal(t, int64(8), gpu.Value())
+	require.Len(t, request.Nodes, 1)
+	assert.Equal(t, capacityReport.Nodes[0].AvailableResources, request.Nodes[0].AvailableResources)
+}
+
+func TestRequestJobsRuns_ExcludesNodesWithoutCapacity(t *testing.T) {
+	runId1 := uuid.New()
+	runId2 := uuid.New()
+	runId3 := uuid.New()
+	initialRuns := []*job.RunState{
+		createRun(runId1.String(), job.Active),
+		createRun(runId2.String(), job.Active),
+		createRun(runId3.String(), job.Leased),
+	}
+	jobRequester, _, leaseRequester, _, utilisationService := setupJobRequesterTest(initialRuns)
+
+	capacityReport := &utilisation.ClusterAvailableCapacityReport{
+		AvailableCapacity: &armadaresource.ComputeResources{
+			"cpu":    resource.MustParse("1000"),
+			"memory": resource.MustParse("1000Gi"),
+		},
+		Nodes: []api.NodeInfo{
+			{
+				Name:          "node-1",
+				RunIdsByState: map[string]api.JobState{runId1.String(): api.JobState_RUNNING},
+			},
+		},
+		ExcludedNodes: []utilisation.ExcludedNode{
+			{
+				Name:          "node-2",
+				Reason:        "node has not reported allocatable resources",
+				RunIdsByState: map[string]api.JobState{runId2.String(): api.JobState_RUNNING},
+			},
+		},
+	}
+	utilisationService.ClusterAvailableCapacityReport = capacityReport
+
+	expectedRequest := &LeaseRequest{
+		AvailableResource: *capacityReport.AvailableCapacity,
+		Nodes:             []*api.NodeInfo{&capacityReport.Nodes[0]},
+		// Runs on excluded nodes shouldn't be reported as unassigned.
+		UnassignedJobRunIds: []armadaevents.Uuid{*armadaevents.ProtoUuidFromUuid(runId3)},
+		ExcludedNodes:       map[string]string{"node-2": "node has not reported allocatable resources"},
+	}
+
+	jobRequester.RequestJobsRuns()
+
+	assert.Len(t, leaseRequester.ReceivedLeaseRequests, 1)
+	assert.Equal(t, expectedRequest, leaseRequester.ReceivedLeaseRequests[0])
+}
+
+func TestRequestJobsRuns_SkipsInvalidAndDuplicateUnassignedRunIds(t *testing.T) {
+	runId := uuid.New()
+	initialRuns := []*job.RunState{
+		createRun(runId.String(), job.Leased),
+		// Same run id in a different textual form.
+		createRun(strings.ToUpper(runId.String()), job.Leased),
+		createRun("not-a-uuid", job.Leased),
+	}
+	jobRequester, _, leaseRequester, _, utilisationService := setupJobRequesterTest(initialRuns)
+	capacityReport := &utilisation.ClusterAvailableCapacityReport{
+		AvailableCapacity: &armadaresource.ComputeResources{
+			"cpu":    resource.MustParse("1000"),
+			"memory": resource.MustParse("1000Gi"),
+		},
+	}
+	utilisationService.ClusterAvailableCapacityReport = capacityReport
+
+	expectedRequest := &LeaseRequest{
+		AvailableResource:   *capacityReport.AvailableCapacity,
+		Nodes:               []*api.NodeInfo{},
+		UnassignedJobRunIds: []armadaevents.Uuid{*armadaevents.ProtoUuidFromUuid(runId)},
+	}
+
+	jobRequester.RequestJobsRuns()
+
+	assert.Len(t, leaseRequester.ReceivedLeaseRequests, 1)
+	assert.Equal(t, expectedRequest, leaseRequester.ReceivedLeaseRequests[0])
+}
+
+func TestComputeUnassignedRunIds(t *testing.T) {
+	runId1 := uuid.New()
+	runId2 := uuid.New()
+	runId3 := uuid.New()
+	tests := map[string]struct {
+		stateRuns      []*job.RunState
+		capacityReport *utilisation.ClusterAvailableCapacityReport
+		expected       []armadaevents.Uuid
+	}{
+		"run in state but not report": {
+			stateRuns: []*job.RunState{createRun(runId1.String(), job.Active), createRun(runId2.String(), job.Leased)},
+			capacityReport: &utilisation.ClusterAvailableCapacityReport{
+				Nodes: []api.NodeInfo{
+					{Name: "node-1", RunIdsByState: map[string]api.JobState{runId1.String(): api.JobState_RUNNING}},
+				},
+			},
+			expected: []armadaevents.Uuid{*armadaevents.ProtoUuidFromUuid(runId2)},
+		},
+		"run in report but not state": {
+			stateRuns: []*job.RunState{createRun(runId1.String(), job.Active)},
+			capacityReport: &utilisation.ClusterAvailableCapacityReport{
+				Nodes: []api.NodeInfo{
+					{
+						Name: "node-1",
+						RunIdsByState: map[string]api.JobState{
+							runId1.String(): api.JobState_RUNNING,
+							runId2.String(): api.JobState_RUNNING,
+						},
+					},
+				},
+			},
+			expected: []armadaevents.Uuid{},
+		},
+		"run on excluded node": {
+			stateRuns: []*job.RunState{createRun(runId1.String(), job.Active), createRun(runId2.String(), job.Leased)},
+			capacityReport: &utilisation.ClusterAvailableCapacityReport{
+				ExcludedNodes: []utilisation.ExcludedNode{
+					{Name: "node-1", RunIdsByState: map[string]api.JobState{runId1.String(): api.JobState_RUNNING}},
+				},
+			},
+			expected: []armadaevents.Uuid{*armadaevents.ProtoUuidFromUuid(runId2)},
+		},
+		"no nodes": {
+			stateRuns: []*job.RunState{
+				createRun(runId1.String(), job.Leased),
+				createRun(runId2.String(), job.Leased),
+				createRun(runId3.String(), job.Leased),
+			},
+			capacityReport: &utilisation.ClusterAvailableCapacityReport{},
+			expected: []armadaevents.Uuid{
+				*armadaevents.ProtoUuidFromUuid(runId1),
+				*armadaevents.ProtoUuidFromUuid(runId2),
+				*armadaevents.ProtoUuidFromUuid(runId3),
+			},
+		},
+		"no runs": {
+			capacityReport: &utilisation.ClusterAvailableCapacityReport{
+				Nodes: []api.NodeInfo{
+					{Name: "node-1", RunIdsByState: map[string]api.JobState{runId1.String(): api.JobState_RUNNING}},
+				},
+			},
+			expected: []armadaevents.Uuid{},
+		},
+		"invalid and duplicate run ids": {
+			stateRuns: []*job.RunState{
+				createRun(runId1.String(), job.Leased),
+				createRun(strings.ToUpper(runId1.String()), job.Leased),
+				createRun("not-a-uuid", job.Leased),
+			},
+			capacityReport: &utilisation.ClusterAvailableCapacityReport{},
+			expected:       []armadaevents.Uuid{*armadaevents.ProtoUuidFromUuid(runId1)},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, computeUnassignedRunIds(tc.stateRuns, tc.capacityReport))
+		})
+	}
+}
+
 func TestRequestJobsRuns_HandlesLeasedJobs(t *testing.T) {
 	jobRequester, eventReporter, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{})
 
@@ -118,6 +443,57 @@ func TestRequestJobsRuns_HandlesLeasedJobs(t *testing.T) {
 	assert.Equal(t, allJobRuns[0].Meta.JobId, jobId)
 }
 
+func TestRequestJobsRuns_LimitsLeasedRunsPerCycle(t *testing.T) {
+	jobRequester, eventReporter, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{})
+	jobRequester.maxLeasedRunsPerCycle = 2
+
+	leases := make([]*executorapi.JobRunLease, 5)
+	for i := range leases {
+		leases[i] = createSubmittableJobRunLease(t)
+	}
+	leaseRequester.LeaseJobRunLeaseResponse = &LeaseResponse{LeasedRuns: leases}
+
+	jobRequester.RequestJobsRuns()
+
+	assert.Len(t, eventReporter.ReceivedEvents, 0)
+	allJobRuns := stateStore.GetAll()
+	require.Len(t, allJobRuns, 2)
+	expectedRunIds := make([]string, 0, 2)
+	for _, lease := range leases[:2] {
+		runId, err := armadaevents.UuidStringFromProtoUuid(lease.JobRunId)
+		require.NoError(t, err)
+		expectedRunIds = append(expectedRunIds, runId)
+	}
+	actualRunIds := util.Map(allJobRuns, func(run *job.RunState) string { return run.Meta.RunId })
+	assert.ElementsMatch(t, expectedRunIds, actualRunIds)
+	for _, run := range allJobRuns {
+		assert.Equal(t, job.Leased, run.Phase)
+	}
+}
+
+func TestRequestJobsRuns_DropsDuplicateLeasesForTheSameJob(t *testing.T) {
+	jobRequester, eventReporter, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{})
+
+	lease := createSubmittableJobRunLease(t)
+	duplicateLease := createSubmittableJobRunLease(t)
+	duplicateLease.Job.JobId = lease.Job.JobId
+	leaseRequester.LeaseJobRunLeaseResponse = &LeaseResponse{
+		LeasedRuns: []*executorapi.JobRunLease{lease, duplicateLease},
+	}
+	countBefore := testutil.ToFloat64(duplicateLeasedRunsCounter)
+
+	jobRequester.RequestJobsRuns()
+
+	assert.Equal(t, countBefore+1, testutil.ToFloat64(duplicateLeasedRunsCounter))
+	assert.Len(t, eventReporter.ReceivedEvents, 0)
+	allJobRuns := stateStore.GetAll()
+	assert.Len(t, allJobRuns, 1)
+	expectedRunId, err := armadaevents.UuidStringFromProtoUuid(lease.JobRunId)
+	require.NoError(t, err)
+	assert.Equal(t, expectedRunId, allJobRuns[0].Meta.RunId)
+	assert.Equal(t, job.Leased, allJobRuns[0].Phase)
+}
+
 func TestRequestJobsRuns_HandlesRunIdsToCancel(t *testing.T) {
 	runId := uuid.New()
 	activeRun := createRun(runId.String(), job.Active)
@@ -163,6 +539,7 @@ func TestRequestJobsRuns_HandlesRunIsToPreempt(t *testing.T) {
 
 	expectedRunState := activeRun.DeepCopy()
 	expectedRunState.PreemptionRequested = true
+	expectedRunState.PreemptionDeadline = jobRequester.clock.Now().Add(time.Minute)
 
 	jobRequester.RequestJobsRuns()
 
@@ -172,6 +549,90 @@ func TestRequestJobsRuns_HandlesRunIsToPreempt(t *testing.T) {
 	assert.Equal(t, allJobRuns[0], expectedRunState)
 }
 
+func TestRequestJobsRuns_LogsRunStateTransitions(t *testing.T) {
+	tests := map[string]struct {
+		initialRun       *job.RunState
+		cancel           bool
+		preempt          bool
+		expectedOldState string
+		expectedNewState string
+	}{
+		"cancel requested": {
+			initialRun:       createRun(uuid.NewString(), job.Active),
+			cancel:           true,
+			expectedOldState: "Active",
+			expectedNewState: "Active/CancelRequested",
+		},
+		"preemption requested": {
+			initialRun:       createRun(uuid.NewString(), job.Active),
+			preempt:          true,
+			expectedOldState: "Active",
+			expectedNewState: "Active/PreemptionRequested",
+		},
+		"cancel and preemption requested": {
+			initialRun:       createRun(uuid.NewString(), job.Leased),
+			cancel:           true,
+			preempt:          true,
+			expectedOldState: "Leased",
+			expectedNewState: "Leased/CancelRequested/PreemptionRequested",
+		},
+		"cancel already requested": {
+			initialRun: func() *job.RunState {
+				run := createRun(uuid.NewString(), job.Active)
+				run.CancelRequested = true
+				return run
+			}(),
+			cancel: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			hook := logtest.NewGlobal()
+			defer hook.Reset()
+			jobRequester, _, leaseRequester, _, _ := setupJobRequesterTest([]*job.RunState{tc.initialRun})
+			runId, err := armadaevents.ProtoUuidFromUuidString(tc.initialRun.Meta.RunId)
+			require.NoError(t, err)
+			leaseResponse := &LeaseResponse{}
+			if tc.cancel {
+				leaseResponse.RunIdsToCancel = []*armadaevents.Uuid{
+					nil, // Invalid should be skipped
+					armadaevents.ProtoUuidFromUuid(uuid.New()), // Belongs to no known runs, should be skipped
+					runId,
+				}
+			}
+			if tc.preempt {
+				leaseResponse.RunIdsToPreempt = []*armadaevents.Uuid{runId}
+			}
+			leaseRequester.LeaseJobRunLeaseResponse = leaseResponse
+
+			jobRequester.RequestJobsRuns()
+
+			var leaseResponseId string
+			var transitions []logrus.Fields
+			for _, entry := range hook.AllEntries() {
+				if id, ok := entry.Data["leaseResponseId"].(string); ok && entry.Data["runId"] == nil {
+					leaseResponseId = id
+				}
+				if _, ok := entry.Data["newState"]; ok {
+					transitions = append(transitions, entry.Data)
+				}
+			}
+			require.NotEmpty(t, leaseResponseId)
+			if tc.expectedNewState == "" {
+				assert.Empty(t, transitions)
+				return
+			}
+			require.NotEmpty(t, transitions)
+			for _, fields := range transitions {
+				assert.Equal(t, tc.initialRun.Meta.RunId, fields["runId"])
+				assert.Equal(t, leaseResponseId, fields["leaseResponseId"])
+			}
+			assert.Equal(t, tc.expectedOldState, transitions[0]["oldState"])
+			assert.Equal(t, tc.expectedNewState, transitions[len(transitions)-1]["newState"])
+		})
+	}
+}
+
 func TestRequestJobsRuns_HandlesPartiallyInvalidLeasedJobs(t *testing.T) {
 	jobRequester, eventReporter, leaseRequester, stateStore, _ := setupJobRequesterTest([]*job.RunState{})
 
@@ -192,8 +653,12 @@ func TestRequestJobsRuns_HandlesPartiallyInvalidLeasedJobs(t *testing.T) {
 		},
 	}
 
+	counter := invalidLeasedRunsCounter.WithLabelValues(partiallyInvalidLease, invalidLeaseReasonMissingPodSpec)
+	countBefore := testutil.ToFloat64(counter)
+
 	jobRequester.RequestJobsRuns()
 
+	assert.Equal(t, countBefore+1, testutil.ToFloat64(counter))
 	assert.Len(t, eventReporter.ReceivedEvents, 1)
 	event, ok := eventReporter.ReceivedEvents[0].Event.(*api.JobFailedEvent)
 	assert.True(t, ok)
@@ -216,8 +681,12 @@ func TestRequestJobsRuns_SkipsFullyInvalidLeasedJobs(t *testing.T) {
 		},
 	}
 
+	counter := invalidLeasedRunsCounter.WithLabelValues(fullyInvalidLease, invalidLeaseReasonMissingJob)
+	countBefore := testutil.ToFloat64(counter)
+
 	jobRequester.RequestJobsRuns()
 
+	assert.Equal(t, countBefore+1, testutil.ToFloat64(counter))
 	// Does not report events or record state
 	assert.Len(t, eventReporter.ReceivedEvents, 0)
 	assert.Len(t, stateStore.GetAll(), 0)
@@ -234,17 +703,86 @@ func setupJobRequesterTest(initialJobRuns []*job.RunState) (*JobRequester, *mock
 	utilisationService.ClusterAvailableCapacityReport = &utilisation.ClusterAvailableCapacityReport{
 		AvailableCapacity: &armadaresource.ComputeResources{},
 	}
-	jobRequester := NewJobRequester(clusterId, eventReporter, leaseRequester, stateStore, utilisationService, podDefaults)
+	jobRequester := NewJobRequester(
+		clusterId,
+		eventReporter,
+		leaseRequester,
+		stateStore,
+		utilisationService,
+		podDefaults,
+		JobRequesterConfig{
+			MaxLeaseAttempts:      3,
+			InitialLeaseBackoff:   time.Second,
+			MaxLeaseBackoff:       2 * time.Second,
+			LeaseRequestTimeout:   30 * time.Second,
+			PreemptionGracePeriod: time.Minute,
+		},
+	)
+	jobRequester.clock = clock.NewFakeClock(time.Now())
 	return jobRequester, eventReporter, leaseRequester, stateStore, utilisationService
 }
 
+// requestJobsRunsAdvancingClock calls RequestJobsRuns, advancing the fake clock of jobRequester by a second
+// whenever RequestJobsRuns waits on it, such that backoffs between lease attempts elapse immediately.
+// Returns the total time the clock was advanced by.
+func requestJobsRunsAdvancingClock(jobRequester *JobRequester) time.Duration {
+	fakeClock := jobRequester.clock.(*clock.FakeClock)
+	start := fakeClock.Now()
+	done := make(chan struct{})
+	go func() {
+		jobRequester.RequestJobsRuns()
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			return fakeClock.Since(start)
+		default:
+		}
+		if fakeClock.HasWaiters() {
+			fakeClock.Step(time.Second)
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+
+// createSubmittableJobRunLease returns a lease valid enough for a submit job to be created from it.
+func createSubmittableJobRunLease(t *testing.T) *executorapi.JobRunLease {
+	_, _, lease := createValidJobRunLease(t, "queue", "job-set")
+	lease.Job.ObjectMeta = &armadaevents.ObjectMeta{
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+		Namespace:   "test-namespace",
+	}
+	lease.Job.MainObject = &armadaevents.KubernetesMainObject{
+		Object: &armadaevents.KubernetesMainObject_PodSpec{
+			PodSpec: &armadaevents.PodSpecWithAvoidList{
+				PodSpec: &v1.PodSpec{},
+			},
+		},
+	}
+	return lease
+}
+
 type StubLeaseRequester struct {
-	ReceivedLeaseRequests    []*LeaseRequest
+	ReceivedLeaseRequests []*LeaseRequest
+	// Errors returned by the first len(LeaseJobRunErrors) calls, after which LeaseJobRunError is returned.
+	LeaseJobRunErrors        []error
 	LeaseJobRunError         error
 	LeaseJobRunLeaseResponse *LeaseResponse
+	// The first NumBlockingCalls calls block until ctx is cancelled, after which they return the error of ctx.
+	NumBlockingCalls int
 }
 
 func (s *StubLeaseRequester) LeaseJobRuns(ctx context.Context, request *LeaseRequest) (*LeaseResponse, error) {
 	s.ReceivedLeaseRequests = append(s.ReceivedLeaseRequests, request)
+	if len(s.ReceivedLeaseRequests) <= s.NumBlockingCalls {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if i := len(s.ReceivedLeaseRequests) - 1; i < len(s.LeaseJobRunErrors) {
+		return nil, s.LeaseJobRunErrors[i]
+	}
 	return s.LeaseJobRunLeaseResponse, s.LeaseJobRunError
 }
@@ -21,6 +21,9 @@ type LeaseRequest struct {
 	AvailableResource   armadaresource.ComputeResources
 	Nodes               []*api.NodeInfo
 	UnassignedJobRunIds []armadaevents.Uuid
+	// Names of nodes omitted from Nodes because their capacity couldn't be determined, with the reason for each.
+	// These aren't sent to the scheduler.
+	ExcludedNodes map[string]string
 }
 
 type LeaseResponse struct {
@@ -37,17 +40,22 @@ type JobLeaseRequester struct {
 	executorApiClient executorapi.ExecutorApiClient
 	clusterIdentity   clusterContext.ClusterIdentity
 	minimumJobSize    armadaresource.ComputeResources
+	// If greater than zero, each request is sent as several messages on the same stream,
+	// such that the encoded size of each message is at most this many bytes.
+	maxMessageSizeBytes int
 }
 
 func NewJobLeaseRequester(
 	executorApiClient executorapi.ExecutorApiClient,
 	clusterIdentity clusterContext.ClusterIdentity,
 	minimumJobSize armadaresource.ComputeResources,
+	maxMessageSizeBytes int,
 ) *JobLeaseRequester {
 	return &JobLeaseRequester{
-		executorApiClient: executorApiClient,
-		clusterIdentity:   clusterIdentity,
-		minimumJobSize:    minimumJobSize,
+		executorApiClient:   executorApiClient,
+		clusterIdentity:     clusterIdentity,
+		minimumJobSize:      minimumJobSize,
+		maxMessageSizeBytes: maxMessageSizeBytes,
 	}
 }
 
@@ -64,8 +72,10 @@ func (requester *JobLeaseRequester) LeaseJobRuns(ctx context.Context, request *L
 		Nodes:               request.Nodes,
 		UnassignedJobRunIds: request.UnassignedJobRunIds,
 	}
-	if err := stream.Send(leaseRequest); err != nil {
-		return nil, errors.WithStack(err)
+	for _, message := range requester.splitLeaseRequest(leaseRequest) {
+		if err := stream.Send(message); err != nil {
+			return nil, errors.WithStack(err)
+		}
 	}
 
 	leaseRuns := []*executorapi.JobRunLease{}
@@ -113,3 +123,44 @@ func (requester *JobLeaseRequester) LeaseJobRuns(ctx context.Context, request *L
 		RunIdsToPreempt: runIdsToPreempt,
 	}, nil
 }
+
+// splitLeaseRequest splits request into several messages, each covering a subset of its nodes and unassigned job run ids,
+// such that the encoded size of each message is at most maxMessageSizeBytes.
+// All other fields are only included in the first message,
+// and every message but the last sets MoreToFollow, such that the scheduler joins them into a single request.
+// A message may exceed the limit if it contains only a single node or job run id.
+func (requester *JobLeaseRequester) splitLeaseRequest(request *executorapi.LeaseRequest) []*executorapi.LeaseRequest {
+	if requester.maxMessageSizeBytes <= 0 || request.Size() <= requester.maxMessageSizeBytes {
+		return []*executorapi.LeaseRequest{request}
+	}
+	messages := []*executorapi.LeaseRequest{}
+	current := *request
+	current.Nodes = nil
+	current.UnassignedJobRunIds = nil
+	current.MoreToFollow = true
+	currentSize := current.Size()
+	// Start a new message if adding size bytes to the current one would exceed the limit.
+	// Each message contains at least one node or job run id.
+	makeRoomFor := func(size int) {
+		if len(current.Nodes)+len(current.UnassignedJobRunIds) > 0 && currentSize+size > requester.maxMessageSizeBytes {
+			message := current
+			messages = append(messages, &message)
+			current = executorapi.LeaseRequest{MoreToFollow: true}
+			currentSize = current.Size()
+		}
+	}
+	for _, node := range request.Nodes {
+		nodeSize := (&executorapi.LeaseRequest{Nodes: []*api.NodeInfo{node}}).Size()
+		makeRoomFor(nodeSize)
+		current.Nodes = append(current.Nodes, node)
+		currentSize += nodeSize
+	}
+	for _, runId := range request.UnassignedJobRunIds {
+		runIdSize := (&executorapi.LeaseRequest{UnassignedJobRunIds: []armadaevents.Uuid{runId}}).Size()
+		makeRoomFor(runIdSize)
+		current.UnassignedJobRunIds = append(current.UnassignedJobRunIds, runId)
+		currentSize += runIdSize
+	}
+	current.MoreToFollow = false
+	return append(messages, &current)
+}
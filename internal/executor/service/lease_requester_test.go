@@ -122,6 +122,108 @@ func TestLeaseJobRuns_Send(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestLeaseJobRuns_SendsGpuAndEphemeralStorage(t *testing.T) {
+	shortCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	leaseRequest := &LeaseRequest{
+		AvailableResource: armadaresource.ComputeResources{
+			"cpu":               resource.MustParse("2"),
+			"memory":            resource.MustParse("2Gi"),
+			"ephemeral-storage": resource.MustParse("10Gi"),
+			"nvidia.com/gpu":    resource.MustParse("1"),
+		},
+	}
+
+	jobRequester, mockExecutorApiClient, mockStream := setup(t)
+	mockExecutorApiClient.EXPECT().LeaseJobRuns(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockStream, nil)
+	mockStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(request *executorapi.LeaseRequest) error {
+		assert.Equal(t, leaseRequest.AvailableResource, armadaresource.ComputeResources(request.Resources))
+		return nil
+	})
+	mockStream.EXPECT().Recv().Return(endMarker, nil)
+
+	_, err := jobRequester.LeaseJobRuns(shortCtx, leaseRequest)
+	assert.NoError(t, err)
+}
+
+func TestLeaseJobRuns_SplitsLargeRequestsOnOneStream(t *testing.T) {
+	shortCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	leaseRequest := &LeaseRequest{
+		AvailableResource: armadaresource.ComputeResources{
+			"cpu":    resource.MustParse("1000"),
+			"memory": resource.MustParse("1000Gi"),
+		},
+		Nodes: []*api.NodeInfo{
+			{Name: "node-1", RunIdsByState: map[string]api.JobState{"id1": api.JobState_RUNNING}},
+			{Name: "node-2", RunIdsByState: map[string]api.JobState{"id2": api.JobState_RUNNING}},
+			{Name: "node-3", RunIdsByState: map[string]api.JobState{"id3": api.JobState_RUNNING}},
+		},
+		UnassignedJobRunIds: []armadaevents.Uuid{*id1},
+	}
+	firstMessage := &executorapi.LeaseRequest{
+		ExecutorId:     defaultClusterIdentity.GetClusterId(),
+		Pool:           defaultClusterIdentity.GetClusterPool(),
+		Resources:      leaseRequest.AvailableResource,
+		MinimumJobSize: defaultMinimumJobSize,
+		Nodes:          leaseRequest.Nodes[:1],
+		MoreToFollow:   true,
+	}
+
+	jobRequester, mockExecutorApiClient, mockStream := setup(t)
+	// Large enough for a single node only, since nodes are of equal size.
+	// The first message also includes the cluster-wide fields, and so exceeds the limit.
+	jobRequester.maxMessageSizeBytes = (&executorapi.LeaseRequest{Nodes: leaseRequest.Nodes[:1], MoreToFollow: true}).Size()
+	// All messages are sent on a single stream, such that the scheduler handles them as a single request.
+	mockExecutorApiClient.EXPECT().LeaseJobRuns(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockStream, nil).Times(1)
+	gomock.InOrder(
+		mockStream.EXPECT().Send(firstMessage).Return(nil),
+		mockStream.EXPECT().Send(&executorapi.LeaseRequest{Nodes: leaseRequest.Nodes[1:2], MoreToFollow: true}).Return(nil),
+		mockStream.EXPECT().Send(&executorapi.LeaseRequest{Nodes: leaseRequest.Nodes[2:], MoreToFollow: true}).Return(nil),
+		mockStream.EXPECT().Send(&executorapi.LeaseRequest{UnassignedJobRunIds: leaseRequest.UnassignedJobRunIds}).Return(nil),
+	)
+	mockStream.EXPECT().Recv().Return(endMarker, nil)
+
+	_, err := jobRequester.LeaseJobRuns(shortCtx, leaseRequest)
+	assert.NoError(t, err)
+}
+
+func TestLeaseJobRuns_SplitsUnassignedJobRunIds(t *testing.T) {
+	shortCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	leaseRequest := &LeaseRequest{
+		AvailableResource: armadaresource.ComputeResources{
+			"cpu":    resource.MustParse("1000"),
+			"memory": resource.MustParse("1000Gi"),
+		},
+		UnassignedJobRunIds: []armadaevents.Uuid{*id1, *id2, *id3},
+	}
+	firstMessage := &executorapi.LeaseRequest{
+		ExecutorId:          defaultClusterIdentity.GetClusterId(),
+		Pool:                defaultClusterIdentity.GetClusterPool(),
+		Resources:           leaseRequest.AvailableResource,
+		MinimumJobSize:      defaultMinimumJobSize,
+		UnassignedJobRunIds: leaseRequest.UnassignedJobRunIds[:1],
+		MoreToFollow:        true,
+	}
+
+	jobRequester, mockExecutorApiClient, mockStream := setup(t)
+	// Large enough for two job run ids, or for the cluster-wide fields and a single job run id.
+	jobRequester.maxMessageSizeBytes = (&executorapi.LeaseRequest{UnassignedJobRunIds: leaseRequest.UnassignedJobRunIds[:2], MoreToFollow: true}).Size()
+	mockExecutorApiClient.EXPECT().LeaseJobRuns(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockStream, nil).Times(1)
+	gomock.InOrder(
+		mockStream.EXPECT().Send(firstMessage).Return(nil),
+		mockStream.EXPECT().Send(&executorapi.LeaseRequest{UnassignedJobRunIds: leaseRequest.UnassignedJobRunIds[1:]}).Return(nil),
+	)
+	mockStream.EXPECT().Recv().Return(endMarker, nil)
+
+	_, err := jobRequester.LeaseJobRuns(shortCtx, leaseRequest)
+	assert.NoError(t, err)
+}
+
 func TestLeaseJobRuns_HandlesNoEndMarkerMessage(t *testing.T) {
 	leaseMessages := []*executorapi.JobRunLease{lease1, lease2}
 	shortCtx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
@@ -222,7 +324,7 @@ func setup(t *testing.T) (*JobLeaseRequester, *mocks.MockExecutorApiClient, *moc
 	ctrl := gomock.NewController(t)
 	mockExecutorApiClient := mocks.NewMockExecutorApiClient(ctrl)
 	mockStream := mocks.NewMockExecutorApi_LeaseJobRunsClient(ctrl)
-	jobLeaseRequester := NewJobLeaseRequester(mockExecutorApiClient, defaultClusterIdentity, defaultMinimumJobSize)
+	jobLeaseRequester := NewJobLeaseRequester(mockExecutorApiClient, defaultClusterIdentity, defaultMinimumJobSize, 0)
 
 	return jobLeaseRequester, mockExecutorApiClient, mockStream
 }
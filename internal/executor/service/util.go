@@ -77,23 +77,64 @@ func chunkJobs(jobs []*job.RunningJob, size int) [][]*job.RunningJob {
 	return chunks
 }
 
+// Reasons for which a leased job run may be invalid, used to label invalidLeasedRunsCounter.
+const (
+	invalidLeaseReasonMissingJob     = "missing_job"
+	invalidLeaseReasonInvalidJobId   = "invalid_job_id"
+	invalidLeaseReasonInvalidRunId   = "invalid_run_id"
+	invalidLeaseReasonMissingQueue   = "missing_queue"
+	invalidLeaseReasonMissingJobSet  = "missing_job_set"
+	invalidLeaseReasonMissingPodSpec = "missing_pod_spec"
+	invalidLeaseReasonOther          = "other"
+)
+
+// invalidLeaseError is returned by ExtractEssentialJobMetadata when a leased job run can't be identified.
+type invalidLeaseError struct {
+	// One of the invalidLeaseReason constants.
+	reason string
+	err    error
+}
+
+func (e *invalidLeaseError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidLeaseError) Unwrap() error {
+	return e.err
+}
+
 func ExtractEssentialJobMetadata(jobRun *executorapi.JobRunLease) (*job.RunMeta, error) {
 	if jobRun.Job == nil {
-		return nil, fmt.Errorf("job is invalid, job field is nil")
+		return nil, &invalidLeaseError{
+			reason: invalidLeaseReasonMissingJob,
+			err:    fmt.Errorf("job is invalid, job field is nil"),
+		}
 	}
 	jobId, err := armadaevents.UlidStringFromProtoUuid(jobRun.Job.JobId)
 	if err != nil {
-		return nil, fmt.Errorf("unable to extract jobId because %s", err)
+		return nil, &invalidLeaseError{
+			reason: invalidLeaseReasonInvalidJobId,
+			err:    fmt.Errorf("unable to extract jobId because %s", err),
+		}
 	}
 	runId, err := armadaevents.UuidStringFromProtoUuid(jobRun.JobRunId)
 	if err != nil {
-		return nil, fmt.Errorf("unable to extract runId because %s", err)
+		return nil, &invalidLeaseError{
+			reason: invalidLeaseReasonInvalidRunId,
+			err:    fmt.Errorf("unable to extract runId because %s", err),
+		}
 	}
 	if jobRun.Queue == "" {
-		return nil, fmt.Errorf("job is invalid, queue is empty")
+		return nil, &invalidLeaseError{
+			reason: invalidLeaseReasonMissingQueue,
+			err:    fmt.Errorf("job is invalid, queue is empty"),
+		}
 	}
 	if jobRun.Jobset == "" {
-		return nil, fmt.Errorf("job is invalid, jobset is empty")
+		return nil, &invalidLeaseError{
+			reason: invalidLeaseReasonMissingJobSet,
+			err:    fmt.Errorf("job is invalid, jobset is empty"),
+		}
 	}
 
 	return &job.RunMeta{
@@ -1,6 +1,7 @@
 package utilisation
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -10,7 +11,7 @@ import (
 
 	"github.com/armadaproject/armada/internal/common"
 	armadaresource "github.com/armadaproject/armada/internal/common/resource"
-	"github.com/armadaproject/armada/internal/executor/context"
+	clusterContext "github.com/armadaproject/armada/internal/executor/context"
 	"github.com/armadaproject/armada/internal/executor/domain"
 	"github.com/armadaproject/armada/internal/executor/node"
 	"github.com/armadaproject/armada/internal/executor/util"
@@ -19,12 +20,12 @@ import (
 )
 
 type UtilisationService interface {
-	GetAvailableClusterCapacity(legacy bool) (*ClusterAvailableCapacityReport, error)
+	GetAvailableClusterCapacity(ctx context.Context, legacy bool) (*ClusterAvailableCapacityReport, error)
 	GetAllNodeGroupAllocationInfo(legacy bool) ([]*NodeGroupAllocationInfo, error)
 }
 
 type ClusterUtilisationService struct {
-	clusterContext                                                context.ClusterContext
+	clusterContext                                                clusterContext.ClusterContext
 	queueUtilisationService                                       PodUtilisationService
 	nodeInfoService                                               node.NodeInfoService
 	usageClient                                                   api.UsageClient
@@ -35,7 +36,7 @@ type ClusterUtilisationService struct {
 }
 
 func NewClusterUtilisationService(
-	clusterContext context.ClusterContext,
+	clusterContext clusterContext.ClusterContext,
 	queueUtilisationService PodUtilisationService,
 	nodeInfoService node.NodeInfoService,
 	usageClient api.UsageClient,
@@ -116,18 +117,39 @@ func (clusterUtilisationService *ClusterUtilisationService) ReportClusterUtilisa
 type ClusterAvailableCapacityReport struct {
 	AvailableCapacity *armadaresource.ComputeResources
 	Nodes             []api.NodeInfo
+	// Nodes for which capacity couldn't be determined.
+	// These are omitted from Nodes and don't contribute to AvailableCapacity.
+	ExcludedNodes []ExcludedNode
 }
 
-func (cls *ClusterUtilisationService) GetAvailableClusterCapacity(legacy bool) (*ClusterAvailableCapacityReport, error) {
+// ExcludedNode is a node omitted from a ClusterAvailableCapacityReport, e.g., because it's temporarily not reporting
+// its resources.
+type ExcludedNode struct {
+	Name   string
+	Reason string
+	// Runs assigned to the node, such that these aren't mistaken for runs not yet assigned to any node.
+	RunIdsByState map[string]api.JobState
+}
+
+// GetAvailableClusterCapacity returns the capacity of the cluster available for running jobs.
+// Nodes and pods are read from informer caches, which don't take a context;
+// instead, ctx is checked between each step, such that the call returns early once ctx is cancelled.
+func (cls *ClusterUtilisationService) GetAvailableClusterCapacity(ctx context.Context, legacy bool) (*ClusterAvailableCapacityReport, error) {
 	allNodes, err := cls.nodeInfoService.GetAllNodes()
 	if err != nil {
 		return nil, errors.Errorf("Failed getting available cluster capacity due to: %s", err)
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
 
 	allPods, err := cls.clusterContext.GetAllPods()
 	if err != nil {
 		return nil, errors.Errorf("Failed getting available cluster capacity due to: %s", err)
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
 
 	allPodsRequiringResource := getAllPodsRequiringResourceOnNodes(allPods, allNodes)
 	allNonCompletePodsRequiringResource := util.FilterNonCompletedPods(allPodsRequiringResource)
@@ -136,8 +158,20 @@ func (cls *ClusterUtilisationService) GetAvailableClusterCapacity(legacy bool) (
 	runIdsByNode := cls.getRunIdsByNode(allNodes, allPods, legacy)
 
 	nodes := make([]api.NodeInfo, 0, len(allNodes))
+	var excludedNodes []ExcludedNode
 	totalAvailable := armadaresource.ComputeResources{}
 	for _, node := range allNodes {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if reason, excluded := capacityExclusionReason(node); excluded {
+			excludedNodes = append(excludedNodes, ExcludedNode{
+				Name:          node.Name,
+				Reason:        reason,
+				RunIdsByState: runIdsByNode[node.Name],
+			})
+			continue
+		}
 		isSchedulable := cls.nodeInfoService.IsAvailableProcessingNode(node)
 		allocatable := armadaresource.FromResourceList(node.Status.Allocatable)
 		available := allocatable.DeepCopy()
@@ -191,12 +225,30 @@ func (cls *ClusterUtilisationService) GetAvailableClusterCapacity(legacy bool) (
 		})
 	}
 
+	// Only fail if capacity couldn't be determined for any node; otherwise, report the nodes for which it could.
+	if len(allNodes) > 0 && len(nodes) == 0 {
+		return nil, errors.Errorf(
+			"Failed getting available cluster capacity because all %d nodes were excluded; first excluded node %s: %s",
+			len(excludedNodes), excludedNodes[0].Name, excludedNodes[0].Reason,
+		)
+	}
+
 	return &ClusterAvailableCapacityReport{
 		AvailableCapacity: &totalAvailable, // TODO: This should be the total - max job priority resources.
 		Nodes:             nodes,
+		ExcludedNodes:     excludedNodes,
 	}, nil
 }
 
+// capacityExclusionReason returns the reason capacity can't be determined for node, if any,
+// in which case the node should be excluded from capacity reports.
+func capacityExclusionReason(node *v1.Node) (string, bool) {
+	if len(node.Status.Allocatable) == 0 {
+		return "node has not reported allocatable resources", true
+	}
+	return "", false
+}
+
 // This returns all the pods assigned the node or soon to be assigned (via node-selector)
 // The server api expects job ids, the executor api expects run ids - the legacy flag controls which this returns
 func (clusterUtilisationService *ClusterUtilisationService) getRunIdsByNode(nodes []*v1.Node, pods []*v1.Pod, legacy bool) map[string]map[string]api.JobState {
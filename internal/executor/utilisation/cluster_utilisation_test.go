@@ -1,16 +1,20 @@
 package utilisation
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	armadaresource "github.com/armadaproject/armada/internal/common/resource"
 	util2 "github.com/armadaproject/armada/internal/common/util"
+	clusterContext "github.com/armadaproject/armada/internal/executor/context"
 	"github.com/armadaproject/armada/internal/executor/domain"
+	"github.com/armadaproject/armada/internal/executor/node"
 	"github.com/armadaproject/armada/pkg/api"
 )
 
@@ -418,6 +422,36 @@ func TestGetAllocationByQueueAndPriority_HandlesEmptyList(t *testing.T) {
 	assert.Equal(t, len(result), 0)
 }
 
+func TestCapacityExclusionReason(t *testing.T) {
+	tests := map[string]struct {
+		node             *v1.Node
+		expectedExcluded bool
+	}{
+		"node with allocatable resources": {
+			node: &v1.Node{
+				Status: v1.NodeStatus{
+					Allocatable: v1.ResourceList{"cpu": resource.MustParse("1")},
+				},
+			},
+		},
+		"node without allocatable resources": {
+			node:             &v1.Node{},
+			expectedExcluded: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			reason, excluded := capacityExclusionReason(tc.node)
+			assert.Equal(t, tc.expectedExcluded, excluded)
+			if excluded {
+				assert.NotEmpty(t, reason)
+			} else {
+				assert.Empty(t, reason)
+			}
+		})
+	}
+}
+
 func TestGetAllocatedResourceByNodeName(t *testing.T) {
 	var priority int32
 	podResource := makeResourceList(2, 50)
@@ -436,6 +470,80 @@ func TestGetAllocatedResourceByNodeName(t *testing.T) {
 	}, allocatedResource)
 }
 
+func TestGetAvailableClusterCapacity_ArbitraryResources(t *testing.T) {
+	allocatable := v1.ResourceList{
+		v1.ResourceCPU:              resource.MustParse("8"),
+		v1.ResourceMemory:           resource.MustParse("32Gi"),
+		v1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+		"nvidia.com/gpu":            resource.MustParse("4"),
+		"example.com/fpga":          resource.MustParse("2"),
+	}
+	gpuNode := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status:     v1.NodeStatus{Allocatable: allocatable},
+	}
+	var priority int32
+	pod := makePodWithResource("", v1.ResourceList{
+		v1.ResourceCPU:              resource.MustParse("1"),
+		v1.ResourceEphemeralStorage: resource.MustParse("10Gi"),
+		"nvidia.com/gpu":            resource.MustParse("1"),
+	}, &priority)
+	pod.Spec.NodeName = gpuNode.Name
+	clusterContext := &stubClusterContext{nodes: []*v1.Node{gpuNode}, pods: []*v1.Pod{&pod}}
+	utilisationService := &ClusterUtilisationService{
+		clusterContext:  clusterContext,
+		nodeInfoService: node.NewKubernetesNodeInfoService(clusterContext, nil),
+	}
+
+	report, err := utilisationService.GetAvailableClusterCapacity(context.Background(), false)
+	require.NoError(t, err)
+
+	expectedAvailable := armadaresource.ComputeResources{
+		"cpu":               resource.MustParse("7"),
+		"memory":            resource.MustParse("32Gi"),
+		"ephemeral-storage": resource.MustParse("90Gi"),
+		"nvidia.com/gpu":    resource.MustParse("3"),
+		"example.com/fpga":  resource.MustParse("2"),
+	}
+	assert.True(t, expectedAvailable.Equal(*report.AvailableCapacity), "expected %v, got %v", expectedAvailable, *report.AvailableCapacity)
+	require.Len(t, report.Nodes, 1)
+	assert.True(t, expectedAvailable.Equal(report.Nodes[0].AvailableResources))
+	assert.True(t, armadaresource.FromResourceList(allocatable).Equal(report.Nodes[0].AllocatableResources))
+}
+
+func TestGetAvailableClusterCapacity_ReturnsErrorIfCancelled(t *testing.T) {
+	clusterContext := &stubClusterContext{nodes: []*v1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}}}
+	utilisationService := &ClusterUtilisationService{
+		clusterContext:  clusterContext,
+		nodeInfoService: node.NewKubernetesNodeInfoService(clusterContext, nil),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := utilisationService.GetAvailableClusterCapacity(ctx, false)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// stubClusterContext is a ClusterContext with a fixed set of nodes and pods.
+// Methods other than those overridden here panic.
+type stubClusterContext struct {
+	clusterContext.ClusterContext
+	nodes []*v1.Node
+	pods  []*v1.Pod
+}
+
+func (c *stubClusterContext) GetNodes() ([]*v1.Node, error) {
+	return c.nodes, nil
+}
+
+func (c *stubClusterContext) GetAllPods() ([]*v1.Pod, error) {
+	return c.pods, nil
+}
+
+func (c *stubClusterContext) GetClusterPool() string {
+	return "pool"
+}
+
 func hasKey[K comparable, V any](m map[K]V, key K) bool {
 	_, ok := m[key]
 	return ok
@@ -1,15 +1,25 @@
 package mocks
 
-import "github.com/armadaproject/armada/internal/executor/utilisation"
+import (
+	"context"
+
+	"github.com/armadaproject/armada/internal/executor/utilisation"
+)
 
 type StubUtilisationService struct {
-	ClusterAvailableCapacityReport     *utilisation.ClusterAvailableCapacityReport
-	GetClusterAvailableCapacityError   error
+	ClusterAvailableCapacityReport   *utilisation.ClusterAvailableCapacityReport
+	GetClusterAvailableCapacityError error
+	// If true, GetAvailableClusterCapacity blocks until ctx is cancelled, after which it returns the error of ctx.
+	BlockGetAvailableClusterCapacity   bool
 	AllNodeGroupAllocationInfo         []*utilisation.NodeGroupAllocationInfo
 	GetAllNodeGroupAllocationInfoError error
 }
 
-func (f *StubUtilisationService) GetAvailableClusterCapacity(legacy bool) (*utilisation.ClusterAvailableCapacityReport, error) {
+func (f *StubUtilisationService) GetAvailableClusterCapacity(ctx context.Context, legacy bool) (*utilisation.ClusterAvailableCapacityReport, error) {
+	if f.BlockGetAvailableClusterCapacity {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
 	return f.ClusterAvailableCapacityReport, f.GetClusterAvailableCapacityError
 }
 
@@ -74,10 +74,10 @@ func NewExecutorApi(producer pulsar.Producer,
 func (srv *ExecutorApi) LeaseJobRuns(stream executorapi.ExecutorApi_LeaseJobRunsServer) error {
 	ctx := stream.Context()
 	log := ctxlogrus.Extract(ctx)
-	// Receive once to get info necessary to get jobs to lease.
-	req, err := stream.Recv()
+	// Receive the request, which may be split over several messages, to get info necessary to get jobs to lease.
+	req, err := receiveLeaseRequest(stream)
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
 
 	log.Infof("Handling lease request for executor %s", req.ExecutorId)
@@ -251,6 +251,27 @@ func (srv *ExecutorApi) createExecutorState(ctx context.Context, req *executorap
 	}
 }
 
+// receiveLeaseRequest receives a lease request from the stream.
+// Executors may split large requests over several messages, all but the last of which set MoreToFollow;
+// the nodes and unassigned job run ids of these messages are joined into the first.
+func receiveLeaseRequest(stream executorapi.ExecutorApi_LeaseJobRunsServer) (*executorapi.LeaseRequest, error) {
+	req, err := stream.Recv()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for more := req.MoreToFollow; more; {
+		next, err := stream.Recv()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		req.Nodes = append(req.Nodes, next.Nodes...)
+		req.UnassignedJobRunIds = append(req.UnassignedJobRunIds, next.UnassignedJobRunIds...)
+		more = next.MoreToFollow
+	}
+	req.MoreToFollow = false
+	return req, nil
+}
+
 // extractRunIds extracts all the job runs contained in the executor request
 func extractRunIds(req *executorapi.LeaseRequest) ([]uuid.UUID, error) {
 	runIds := make([]uuid.UUID, 0)
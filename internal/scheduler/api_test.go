@@ -216,6 +216,77 @@ func TestExecutorApi_LeaseJobRuns(t *testing.T) {
 	}
 }
 
+func TestExecutorApi_LeaseJobRuns_JoinsRequestSplitOverSeveralMessages(t *testing.T) {
+	const maxJobsPerCall = uint(100)
+	runId1 := uuid.New()
+	runId2 := uuid.New()
+	runId3 := uuid.New()
+	messages := []*executorapi.LeaseRequest{
+		{
+			ExecutorId: "test-executor",
+			Pool:       "test-pool",
+			Nodes: []*api.NodeInfo{
+				{Name: "test-node-1", RunIdsByState: map[string]api.JobState{runId1.String(): api.JobState_RUNNING}},
+			},
+			UnassignedJobRunIds: []armadaevents.Uuid{*armadaevents.ProtoUuidFromUuid(runId3)},
+			MoreToFollow:        true,
+		},
+		{
+			Nodes: []*api.NodeInfo{
+				{Name: "test-node-2", RunIdsByState: map[string]api.JobState{runId2.String(): api.JobState_RUNNING}},
+			},
+		},
+	}
+	expectedRunIds := []uuid.UUID{runId1, runId2, runId3}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockJobRepository := schedulermocks.NewMockJobRepository(ctrl)
+	mockExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockLegacyExecutorRepository := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockStream := schedulermocks.NewMockExecutorApi_LeaseJobRunsServer(ctrl)
+
+	// The executor state is stored, and leases fetched, once for the request as a whole.
+	assertJoinedExecutor := func(ctx context.Context, executor *schedulerobjects.Executor) error {
+		assert.Equal(t, "test-executor", executor.Id)
+		assert.Equal(t, "test-pool", executor.Pool)
+		nodeNames := make([]string, len(executor.Nodes))
+		for i, node := range executor.Nodes {
+			nodeNames[i] = node.Name
+		}
+		assert.Equal(t, []string{"test-node-1", "test-node-2"}, nodeNames)
+		assert.Equal(t, []string{runId3.String()}, executor.UnassignedJobRuns)
+		return nil
+	}
+	mockStream.EXPECT().Context().Return(ctx).AnyTimes()
+	gomock.InOrder(
+		mockStream.EXPECT().Recv().Return(messages[0], nil),
+		mockStream.EXPECT().Recv().Return(messages[1], nil),
+	)
+	mockExecutorRepository.EXPECT().StoreExecutor(ctx, gomock.Any()).DoAndReturn(assertJoinedExecutor).Times(1)
+	mockLegacyExecutorRepository.EXPECT().StoreExecutor(ctx, gomock.Any()).DoAndReturn(assertJoinedExecutor).Times(1)
+	mockJobRepository.EXPECT().FindInactiveRuns(gomock.Any(), schedulermocks.SliceMatcher[uuid.UUID]{Expected: expectedRunIds}).Return(nil, nil).Times(1)
+	mockJobRepository.EXPECT().FetchJobRunLeases(gomock.Any(), "test-executor", maxJobsPerCall, schedulermocks.SliceMatcher[uuid.UUID]{Expected: expectedRunIds}).Return(nil, nil).Times(1)
+	mockStream.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
+
+	server, err := NewExecutorApi(
+		mockPulsarProducer,
+		mockJobRepository,
+		mockExecutorRepository,
+		mockLegacyExecutorRepository,
+		[]int32{1000, 2000},
+		maxJobsPerCall,
+		"kubernetes.io/hostname",
+		nil,
+	)
+	require.NoError(t, err)
+
+	err = server.LeaseJobRuns(mockStream)
+	require.NoError(t, err)
+}
+
 func TestAddNodeSelector(t *testing.T) {
 	withNodeSelector := &armadaevents.PodSpecWithAvoidList{
 		PodSpec: &v1.PodSpec{
@@ -0,0 +1,169 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	commonmetrics "github.com/armadaproject/armada/internal/common/metrics"
+)
+
+// circuitBreakerState is the state of a circuitBreaker.
+// The numeric values are exported via circuitBreakerStateGauge.
+type circuitBreakerState int
+
+const (
+	// Calls are allowed; consecutive failures are counted.
+	circuitClosed circuitBreakerState = iota
+	// Calls are rejected until the cooldown has passed.
+	circuitOpen
+	// A single probe call is allowed; its outcome determines whether the breaker closes or opens again.
+	circuitHalfOpen
+)
+
+func (s circuitBreakerState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// errCircuitOpen is returned by circuitBreaker.allow if calls are currently being rejected.
+var errCircuitOpen = errors.New("circuit breaker open")
+
+var circuitBreakerStateGauge = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: commonmetrics.MetricPrefix + "scheduler_pulsar_circuit_breaker_state",
+		Help: "State of the circuit breaker around publishing to pulsar; 0 is closed, 1 is open, and 2 is half-open",
+	},
+	[]string{"name"},
+)
+
+// circuitBreaker rejects calls for a cooldown period once failureThreshold consecutive calls have failed,
+// such that calls to a dependency that's down fail fast instead of each waiting for timeouts.
+// Once the cooldown has passed, a single call is allowed through to probe whether the dependency has recovered;
+// the breaker closes if it succeeds and opens again otherwise.
+//
+// Each call allowed by allow must be matched by exactly one call to recordSuccess, recordFailure, or recordAbandoned.
+// A nil *circuitBreaker allows all calls.
+type circuitBreaker struct {
+	name             string
+	failureThreshold int
+	cooldown         time.Duration
+	clock            clock.Clock
+	// Protects all fields below.
+	mu                  sync.Mutex
+	state               circuitBreakerState
+	consecutiveFailures int
+	// Time at which the breaker last opened.
+	openedAt time.Time
+	// True if the probe call allowed in the half-open state hasn't completed yet.
+	probeInFlight bool
+}
+
+func newCircuitBreaker(name string, failureThreshold int, cooldown time.Duration, clock clock.Clock) *circuitBreaker {
+	cb := &circuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		clock:            clock,
+	}
+	circuitBreakerStateGauge.WithLabelValues(name).Set(float64(circuitClosed))
+	return cb
+}
+
+// allow returns an error wrapping errCircuitOpen if the call should be rejected.
+func (cb *circuitBreaker) allow() error {
+	if cb == nil {
+		return nil
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case circuitOpen:
+		if elapsed := cb.clock.Since(cb.openedAt); elapsed < cb.cooldown {
+			return errors.WithMessagef(
+				errCircuitOpen,
+				"%s failed %d consecutive times; not retrying for another %s",
+				cb.name, cb.consecutiveFailures, cb.cooldown-elapsed,
+			)
+		}
+		cb.setState(circuitHalfOpen)
+		cb.probeInFlight = true
+		return nil
+	case circuitHalfOpen:
+		if cb.probeInFlight {
+			return errors.WithMessagef(errCircuitOpen, "%s is being probed for recovery", cb.name)
+		}
+		cb.probeInFlight = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// recordSuccess records that a call allowed by allow succeeded, which closes the breaker.
+func (cb *circuitBreaker) recordSuccess() {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.consecutiveFailures = 0
+	cb.probeInFlight = false
+	cb.setState(circuitClosed)
+}
+
+// recordFailure records that a call allowed by allow failed.
+// Opens the breaker if the call was a probe or if failureThreshold consecutive calls have now failed.
+func (cb *circuitBreaker) recordFailure() {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.consecutiveFailures++
+	cb.probeInFlight = false
+	if cb.state == circuitHalfOpen || cb.consecutiveFailures >= cb.failureThreshold {
+		cb.openedAt = cb.clock.Now()
+		cb.setState(circuitOpen)
+	}
+}
+
+// recordAbandoned records that a call allowed by allow completed without indicating whether the dependency is healthy,
+// e.g., because it was cancelled. If the call was a probe, another call is allowed to probe in its place.
+func (cb *circuitBreaker) recordAbandoned() {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.probeInFlight = false
+}
+
+// currentState returns the state of the breaker. Exposed for testing.
+func (cb *circuitBreaker) currentState() circuitBreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+func (cb *circuitBreaker) setState(state circuitBreakerState) {
+	if state == cb.state {
+		return
+	}
+	log.Infof("circuit breaker for %s changed from %s to %s", cb.name, cb.state, state)
+	cb.state = state
+	circuitBreakerStateGauge.WithLabelValues(cb.name).Set(float64(state))
+}
@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	const cooldown = time.Minute
+	testClock := clock.NewFakeClock(time.Now())
+	cb := newCircuitBreaker("test", 2, cooldown, testClock)
+	assertState := func(expected circuitBreakerState) {
+		t.Helper()
+		assert.Equal(t, expected, cb.currentState())
+		assert.Equal(t, float64(expected), testutil.ToFloat64(circuitBreakerStateGauge.WithLabelValues("test")))
+	}
+
+	// Failures only open the breaker once consecutive.
+	require.NoError(t, cb.allow())
+	cb.recordFailure()
+	require.NoError(t, cb.allow())
+	cb.recordSuccess()
+	require.NoError(t, cb.allow())
+	cb.recordFailure()
+	assertState(circuitClosed)
+
+	// Abandoned calls don't count as failures or reset the count.
+	require.NoError(t, cb.allow())
+	cb.recordAbandoned()
+	assertState(circuitClosed)
+
+	require.NoError(t, cb.allow())
+	cb.recordFailure()
+	assertState(circuitOpen)
+
+	// Calls are rejected until the cooldown has passed.
+	err := cb.allow()
+	assert.True(t, errors.Is(err, errCircuitOpen))
+	testClock.Step(cooldown - time.Second)
+	assert.True(t, errors.Is(cb.allow(), errCircuitOpen))
+
+	// After the cooldown, a single probe is allowed.
+	testClock.Step(time.Second)
+	require.NoError(t, cb.allow())
+	assertState(circuitHalfOpen)
+	assert.True(t, errors.Is(cb.allow(), errCircuitOpen))
+
+	// A failed probe opens the breaker again for another cooldown.
+	cb.recordFailure()
+	assertState(circuitOpen)
+	assert.True(t, errors.Is(cb.allow(), errCircuitOpen))
+	testClock.Step(cooldown)
+
+	// An abandoned probe allows another probe.
+	require.NoError(t, cb.allow())
+	cb.recordAbandoned()
+	assertState(circuitHalfOpen)
+	require.NoError(t, cb.allow())
+
+	// A successful probe closes the breaker.
+	cb.recordSuccess()
+	assertState(circuitClosed)
+	require.NoError(t, cb.allow())
+	cb.recordFailure()
+	assertState(circuitClosed)
+}
+
+func TestCircuitBreaker_Nil(t *testing.T) {
+	var cb *circuitBreaker
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, cb.allow())
+		cb.recordFailure()
+	}
+	cb.recordSuccess()
+	cb.recordAbandoned()
+}
@@ -58,6 +58,31 @@ type Configuration struct {
 	DatabaseFetchSize int `validate:"required"`
 	// Timeout to use when sending messages to pulsar
 	PulsarSendTimeout time.Duration `validate:"required"`
+	// Number of times to retry sending a message to pulsar before giving up
+	PulsarSendMaxRetries uint
+	// Time to wait before retrying failed sends to pulsar; doubled for each subsequent retry
+	PulsarSendRetryBackoff time.Duration
+	// If true, messages of each jobset are sent to pulsar one at a time, such that they're never reordered,
+	// at the cost of lower throughput for jobsets with many messages
+	PulsarPreserveJobSetOrder bool
+	// Maximum number of messages being sent to pulsar at any one time.
+	// If zero, the number of concurrent sends is unbounded.
+	PulsarMaxInFlight int
+	// If positive, on losing leadership the scheduler waits up to this long for messages already being sent to pulsar
+	// to be confirmed before re-entering leader election. No new messages are sent once leadership is lost.
+	PulsarDemotionDrainTimeout time.Duration
+	// If true, event sequences are checked for basic invariants, e.g., that each has a jobset name and a non-empty
+	// set of events, before being published to pulsar. Cycles producing invalid sequences then fail without publishing.
+	PulsarValidateEventSequences bool
+	// If positive, once this many consecutive attempts to publish to pulsar have failed, further attempts fail
+	// immediately for PulsarCircuitBreakerCooldown, after which a single attempt is made to check whether pulsar has
+	// recovered. If zero, publishing is always attempted.
+	PulsarCircuitBreakerFailureThreshold int
+	PulsarCircuitBreakerCooldown         time.Duration
+	// Number of times to retry publishing partition markers to the partitions for which publishing failed,
+	// waiting PulsarMarkerRetryDelay before each retry. Partitions already published to aren't published to again.
+	PulsarMaxMarkerRetries uint
+	PulsarMarkerRetryDelay time.Duration
 }
 
 type LeaderConfig struct {
@@ -1,6 +1,7 @@
 package constraints
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/pkg/errors"
@@ -12,12 +13,53 @@ import (
 )
 
 const (
-	UnschedulableReasonMaximumResourcesScheduled        = "maximum resources scheduled"
-	UnschedulableReasonMaximumNumberOfJobsScheduled     = "maximum number of jobs scheduled"
-	UnschedulableReasonMaximumNumberOfGangsScheduled    = "maximum number of gangs scheduled"
-	UnschedulableReasonMaximumResourcesPerQueueExceeded = "maximum total resources for this queue exceeded"
+	UnschedulableReasonMaximumResourcesScheduled                 = "maximum resources scheduled"
+	UnschedulableReasonMaximumNumberOfJobsScheduled              = "maximum number of jobs scheduled"
+	UnschedulableReasonMaximumNumberOfGangsScheduled             = "maximum number of gangs scheduled"
+	UnschedulableReasonMaximumResourcesPerQueueExceeded          = "maximum total resources for this queue exceeded"
+	UnschedulableReasonMaximumResourcesPerPriorityClassScheduled = "maximum resources scheduled for this priority class"
+	UnschedulableReasonExecutorDraining                          = "executor is draining"
+	UnschedulableReasonQueuePaused                               = "queue paused"
 )
 
+// RejectionCode identifies the constraint that prevented a gang from being scheduled.
+type RejectionCode string
+
+const (
+	RejectionCodeMaximumJobsToSchedule                             RejectionCode = "MaximumJobsToSchedule"
+	RejectionCodeMaximumGangsToSchedule                            RejectionCode = "MaximumGangsToSchedule"
+	RejectionCodeMaximumResourceFractionToSchedule                 RejectionCode = "MaximumResourceFractionToSchedule"
+	RejectionCodeMaximumResourceFractionPerQueue                   RejectionCode = "MaximumResourceFractionPerQueue"
+	RejectionCodeMaximumResourceFractionToScheduleForPriorityClass RejectionCode = "MaximumResourceFractionToScheduleForPriorityClass"
+	RejectionCodeMinimumJobSize                                    RejectionCode = "MinimumJobSize"
+	RejectionCodePoolMinimumJobSize                                RejectionCode = "PoolMinimumJobSize"
+	RejectionCodeInsufficientNodeCapacity                          RejectionCode = "InsufficientNodeCapacity"
+	RejectionCodeInsufficientPreemptibleCapacity                   RejectionCode = "InsufficientPreemptibleCapacity"
+	RejectionCodeGangNodeSelectorConflict                          RejectionCode = "GangNodeSelectorConflict"
+	RejectionCodeGangMaxNodeSpanExceeded                           RejectionCode = "GangMaxNodeSpanExceeded"
+	RejectionCodeDeadlineExceeded                                  RejectionCode = "DeadlineExceeded"
+	RejectionCodeExecutorDraining                                  RejectionCode = "ExecutorDraining"
+	RejectionCodeQueuePaused                                       RejectionCode = "QueuePaused"
+)
+
+// RejectionReason describes why a gang could not be scheduled.
+type RejectionReason struct {
+	Code RejectionCode
+	// Resource for which a limit was exceeded.
+	// Empty if the constraint doesn't relate to a specific resource.
+	Resource string
+	// Human-readable explanation, e.g., for inclusion in text reports.
+	Message string
+}
+
+// String returns a short summary of the rejection, e.g., "rejected: MaximumResourceFractionPerQueue cpu".
+func (reason *RejectionReason) String() string {
+	if reason.Resource == "" {
+		return fmt.Sprintf("rejected: %s", reason.Code)
+	}
+	return fmt.Sprintf("rejected: %s %s", reason.Code, reason.Resource)
+}
+
 // IsTerminalUnschedulableReason returns true if reason indicates it's not possible to schedule any more jobs in this round.
 func IsTerminalUnschedulableReason(reason string) bool {
 	if reason == UnschedulableReasonMaximumResourcesScheduled {
@@ -29,6 +71,9 @@ func IsTerminalUnschedulableReason(reason string) bool {
 	if reason == UnschedulableReasonMaximumNumberOfGangsScheduled {
 		return true
 	}
+	if reason == UnschedulableReasonExecutorDraining {
+		return true
+	}
 	return false
 }
 
@@ -43,6 +88,9 @@ type SchedulingConstraints struct {
 	// Jobs leased to this executor must be at least this large.
 	// Used, e.g., to avoid scheduling CPU-only jobs onto clusters with GPUs.
 	MinimumJobSize schedulerobjects.ResourceList
+	// Each job scheduled in this pool must be at least this large.
+	// Unlike MinimumJobSize, which applies to the total request of a gang, this applies to each gang member.
+	PoolMinimumJobSize schedulerobjects.ResourceList
 	// Scheduling constraints for specific priority classes.
 	PriorityClassSchedulingConstraintsByPriorityClassName map[string]PriorityClassSchedulingConstraints
 	// Limits total resources scheduled per invocation.
@@ -58,6 +106,9 @@ type PriorityClassSchedulingConstraints struct {
 	//
 	// Cumulative resource usage at priority x includes resources allocated to jobs of priorityClassPriority x or lower.
 	MaximumCumulativeResourcesPerQueue schedulerobjects.ResourceList
+	// Prevents jobs of this priority class from being scheduled if doing so would exceed
+	// the total resources scheduled at priority priorityClassPriority in this round, across all queues.
+	MaximumResourcesToSchedule schedulerobjects.ResourceList
 }
 
 func SchedulingConstraintsFromSchedulingConfig(
@@ -69,9 +120,13 @@ func SchedulingConstraintsFromSchedulingConfig(
 	priorityClassSchedulingConstraintsByPriorityClassName := make(map[string]PriorityClassSchedulingConstraints, len(config.Preemption.PriorityClasses))
 	for name, priorityClass := range config.Preemption.PriorityClasses {
 		priorityClassSchedulingConstraintsByPriorityClassName[name] = PriorityClassSchedulingConstraints{
-			PriorityClassName:                  name,
-			PriorityClassPriority:              priorityClass.Priority,
-			MaximumCumulativeResourcesPerQueue: absoluteFromRelativeLimits(totalResources, priorityClass.MaximumResourceFractionPerQueue),
+			PriorityClassName:     name,
+			PriorityClassPriority: priorityClass.Priority,
+			MaximumCumulativeResourcesPerQueue: minResourceLimits(
+				absoluteFromRelativeLimits(totalResources, priorityClass.MaximumResourceFractionPerQueue),
+				schedulerobjects.ResourceList{Resources: priorityClass.MaximumResourcesPerQueue},
+			),
+			MaximumResourcesToSchedule: absoluteFromRelativeLimits(totalResources, priorityClass.MaximumResourceFractionToSchedule),
 		}
 	}
 	maximumResourceFractionToSchedule := config.MaximumResourceFractionToSchedule
@@ -83,6 +138,7 @@ func SchedulingConstraintsFromSchedulingConfig(
 		MaximumGangsToSchedule:     config.MaximumGangsToSchedule,
 		MaxQueueLookback:           config.MaxQueueLookback,
 		MinimumJobSize:             minimumJobSize,
+		PoolMinimumJobSize:         schedulerobjects.ResourceList{Resources: config.MinimumJobSizeByPool[pool]},
 		MaximumResourcesToSchedule: absoluteFromRelativeLimits(totalResources, maximumResourceFractionToSchedule),
 		PriorityClassSchedulingConstraintsByPriorityClassName: priorityClassSchedulingConstraintsByPriorityClassName,
 	}
@@ -96,32 +152,57 @@ func absoluteFromRelativeLimits(totalResources schedulerobjects.ResourceList, re
 	return absoluteLimits
 }
 
-func (constraints *SchedulingConstraints) CheckRoundConstraints(sctx *schedulercontext.SchedulingContext) (bool, string, error) {
+// minResourceLimits returns limits containing, for each resource limited by either a or b, the smaller of the two limits.
+// Neither a nor b is mutated.
+func minResourceLimits(a, b schedulerobjects.ResourceList) schedulerobjects.ResourceList {
+	rv := schedulerobjects.NewResourceList(len(a.Resources) + len(b.Resources))
+	for t, q := range a.Resources {
+		rv.Set(t, q.DeepCopy())
+	}
+	for t, q := range b.Resources {
+		if existing, ok := rv.Resources[t]; !ok || q.Cmp(existing) == -1 {
+			rv.Set(t, q.DeepCopy())
+		}
+	}
+	return rv
+}
+
+func (constraints *SchedulingConstraints) CheckRoundConstraints(sctx *schedulercontext.SchedulingContext) (bool, *RejectionReason, error) {
 	// MaximumJobsToSchedule check.
 	if constraints.MaximumJobsToSchedule != 0 && sctx.NumScheduledJobs == int(constraints.MaximumJobsToSchedule) {
-		return false, UnschedulableReasonMaximumNumberOfJobsScheduled, nil
+		return false, &RejectionReason{
+			Code:    RejectionCodeMaximumJobsToSchedule,
+			Message: UnschedulableReasonMaximumNumberOfJobsScheduled,
+		}, nil
 	}
 
 	// MaximumGangsToSchedule check.
 	if constraints.MaximumGangsToSchedule != 0 && sctx.NumScheduledGangs == int(constraints.MaximumGangsToSchedule) {
-		return false, UnschedulableReasonMaximumNumberOfGangsScheduled, nil
+		return false, &RejectionReason{
+			Code:    RejectionCodeMaximumGangsToSchedule,
+			Message: UnschedulableReasonMaximumNumberOfGangsScheduled,
+		}, nil
 	}
 
 	// MaximumResourcesToSchedule check.
-	if exceedsResourceLimits(sctx.ScheduledResources, constraints.MaximumResourcesToSchedule) {
-		return false, UnschedulableReasonMaximumResourcesScheduled, nil
+	if t, exceeded := exceededResourceLimit(sctx.ScheduledResources, constraints.MaximumResourcesToSchedule); exceeded {
+		return false, &RejectionReason{
+			Code:     RejectionCodeMaximumResourceFractionToSchedule,
+			Resource: t,
+			Message:  UnschedulableReasonMaximumResourcesScheduled,
+		}, nil
 	}
-	return true, "", nil
+	return true, nil, nil
 }
 
 func (constraints *SchedulingConstraints) CheckPerQueueAndPriorityClassConstraints(
 	sctx *schedulercontext.SchedulingContext,
 	queue string,
 	priorityClassName string,
-) (bool, string, error) {
+) (bool, *RejectionReason, error) {
 	qctx := sctx.QueueSchedulingContexts[queue]
 	if qctx == nil {
-		return false, "", errors.Errorf("no QueueSchedulingContext for queue %s", queue)
+		return false, nil, errors.Errorf("no QueueSchedulingContext for queue %s", queue)
 	}
 
 	// PriorityClassSchedulingConstraintsByPriorityClassName check.
@@ -130,20 +211,41 @@ func (constraints *SchedulingConstraints) CheckPerQueueAndPriorityClassConstrain
 		for p, rl := range qctx.AllocatedByPriority {
 			allocatedByPriorityAndResourceType.MarkAllocated(p, rl)
 		}
-		if exceedsResourceLimits(
+		if t, exceeded := exceededResourceLimit(
 			// TODO: Avoid allocation.
 			schedulerobjects.QuantityByPriorityAndResourceType(allocatedByPriorityAndResourceType).AggregateByResource(),
 			priorityClassConstraint.MaximumCumulativeResourcesPerQueue,
-		) {
-			return false, UnschedulableReasonMaximumResourcesPerQueueExceeded, nil
+		); exceeded {
+			return false, &RejectionReason{
+				Code:     RejectionCodeMaximumResourceFractionPerQueue,
+				Resource: t,
+				Message:  UnschedulableReasonMaximumResourcesPerQueueExceeded,
+			}, nil
+		}
+		if t, exceeded := exceededResourceLimit(
+			sctx.ScheduledResourcesByPriority[priorityClassConstraint.PriorityClassPriority],
+			priorityClassConstraint.MaximumResourcesToSchedule,
+		); exceeded {
+			return false, &RejectionReason{
+				Code:     RejectionCodeMaximumResourceFractionToScheduleForPriorityClass,
+				Resource: t,
+				Message:  UnschedulableReasonMaximumResourcesPerPriorityClassScheduled,
+			}, nil
 		}
 	}
-	return true, "", nil
+	return true, nil, nil
 }
 
-// exceedsResourceLimits returns true if used/total > limits for some resource.
-func exceedsResourceLimits(used, limits schedulerobjects.ResourceList) bool {
-	return !used.IsStrictlyLessOrEqual(limits)
+// exceededResourceLimit returns the name of a resource for which used > limits and true, if there is any such resource.
+// If several resources exceed their limits, the name that comes first in lexicographical order is returned.
+func exceededResourceLimit(used, limits schedulerobjects.ResourceList) (string, bool) {
+	exceeded := ""
+	for t, limit := range limits.Resources {
+		if limit.Cmp(used.Get(t)) == -1 && (exceeded == "" || t < exceeded) {
+			exceeded = t
+		}
+	}
+	return exceeded, exceeded != ""
 }
 
 // ScaleQuantity scales q in-place by a factor f.
@@ -7,29 +7,157 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	armadaresource "github.com/armadaproject/armada/internal/common/resource"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
 
 func TestConstraints(t *testing.T) {
 	tests := map[string]struct {
-		constraints                                 SchedulingConstraints
-		sctx                                        *schedulercontext.SchedulingContext
-		globalUnschedulableReason                   string
-		queue                                       string
-		priorityClassName                           string
-		perQueueAndPriorityClassUnschedulableReason string
+		constraints                             SchedulingConstraints
+		sctx                                    *schedulercontext.SchedulingContext
+		globalRejectionReason                   *RejectionReason
+		queue                                   string
+		priorityClassName                       string
+		perQueueAndPriorityClassRejectionReason *RejectionReason
 	}{} // TODO: Add tests.
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			ok, unschedulableReason, err := tc.constraints.CheckRoundConstraints(tc.sctx)
+			ok, rejectionReason, err := tc.constraints.CheckRoundConstraints(tc.sctx)
 			require.NoError(t, err)
-			require.Equal(t, tc.globalUnschedulableReason == "", ok)
-			require.Equal(t, tc.globalUnschedulableReason, unschedulableReason)
+			require.Equal(t, tc.globalRejectionReason == nil, ok)
+			require.Equal(t, tc.globalRejectionReason, rejectionReason)
 
-			ok, unschedulableReason, err = tc.constraints.CheckPerQueueAndPriorityClassConstraints(tc.sctx, tc.queue, tc.priorityClassName)
+			ok, rejectionReason, err = tc.constraints.CheckPerQueueAndPriorityClassConstraints(tc.sctx, tc.queue, tc.priorityClassName)
 			require.NoError(t, err)
-			require.Equal(t, tc.perQueueAndPriorityClassUnschedulableReason == "", ok)
-			require.Equal(t, tc.perQueueAndPriorityClassUnschedulableReason, unschedulableReason)
+			require.Equal(t, tc.perQueueAndPriorityClassRejectionReason == nil, ok)
+			require.Equal(t, tc.perQueueAndPriorityClassRejectionReason, rejectionReason)
+		})
+	}
+}
+
+func TestExceededResourceLimit(t *testing.T) {
+	tests := map[string]struct {
+		used             schedulerobjects.ResourceList
+		limits           schedulerobjects.ResourceList
+		expectedResource string
+	}{
+		"within limits": {
+			used:             schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+			limits:           schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+			expectedResource: "",
+		},
+		"no limits": {
+			used:             schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+			expectedResource: "",
+		},
+		"one exceeded": {
+			used: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("1"),
+				"memory": resource.MustParse("2Gi"),
+			}},
+			limits: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("1"),
+				"memory": resource.MustParse("1Gi"),
+			}},
+			expectedResource: "memory",
+		},
+		"several exceeded": {
+			used: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("2"),
+				"memory": resource.MustParse("2Gi"),
+			}},
+			limits: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("1"),
+				"memory": resource.MustParse("1Gi"),
+			}},
+			expectedResource: "cpu",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			actualResource, exceeded := exceededResourceLimit(tc.used, tc.limits)
+			assert.Equal(t, tc.expectedResource != "", exceeded)
+			assert.Equal(t, tc.expectedResource, actualResource)
+		})
+	}
+}
+
+func TestSchedulingConstraintsFromSchedulingConfig_ArbitraryResources(t *testing.T) {
+	totalResources := schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+		"cpu":               resource.MustParse("128"),
+		"nvidia.com/gpu":    resource.MustParse("16"),
+		"ephemeral-storage": resource.MustParse("2Ti"),
+	}}
+	config := configuration.SchedulingConfig{
+		MaximumResourceFractionToSchedule: map[string]float64{"nvidia.com/gpu": 0.5},
+		MinimumJobSizeByPool: map[string]armadaresource.ComputeResources{
+			"pool": {"ephemeral-storage": resource.MustParse("1Gi")},
+		},
+		Preemption: configuration.PreemptionConfig{
+			PriorityClasses: map[string]configuration.PriorityClass{
+				"pc": {
+					Priority:                        1,
+					MaximumResourceFractionPerQueue: map[string]float64{"ephemeral-storage": 0.25},
+					MaximumResourcesPerQueue:        armadaresource.ComputeResources{"nvidia.com/gpu": resource.MustParse("4")},
+				},
+			},
+		},
+	}
+	constraints := SchedulingConstraintsFromSchedulingConfig("pool", totalResources, schedulerobjects.ResourceList{}, config)
+
+	assert.True(t, constraints.MaximumResourcesToSchedule.Equal(schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+		"nvidia.com/gpu": resource.MustParse("8"),
+	}}))
+	assert.True(t, constraints.PoolMinimumJobSize.Equal(schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+		"ephemeral-storage": resource.MustParse("1Gi"),
+	}}))
+	assert.True(t, constraints.PriorityClassSchedulingConstraintsByPriorityClassName["pc"].MaximumCumulativeResourcesPerQueue.Equal(
+		schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+			"ephemeral-storage": resource.MustParse("512Gi"),
+			"nvidia.com/gpu":    resource.MustParse("4"),
+		}},
+	))
+}
+
+func TestMinResourceLimits(t *testing.T) {
+	tests := map[string]struct {
+		a        schedulerobjects.ResourceList
+		b        schedulerobjects.ResourceList
+		expected schedulerobjects.ResourceList
+	}{
+		"no limits": {
+			expected: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{}},
+		},
+		"only a": {
+			a:        schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+			expected: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+		},
+		"only b": {
+			b:        schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+			expected: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+		},
+		"more restrictive wins": {
+			a: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("1"),
+				"memory": resource.MustParse("2Gi"),
+			}},
+			b: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("2"),
+				"memory": resource.MustParse("1Gi"),
+				"gpu":    resource.MustParse("1"),
+			}},
+			expected: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+				"cpu":    resource.MustParse("1"),
+				"memory": resource.MustParse("1Gi"),
+				"gpu":    resource.MustParse("1"),
+			}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, tc.expected.Equal(minResourceLimits(tc.a, tc.b)))
 		})
 	}
 }
@@ -1,20 +1,27 @@
 package context
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
 
-	"github.com/openconfig/goyang/pkg/indent"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/common/armadaerrors"
 	armadamaps "github.com/armadaproject/armada/internal/common/maps"
 	armadaslices "github.com/armadaproject/armada/internal/common/slices"
+	"github.com/armadaproject/armada/internal/common/util"
 	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 	"github.com/armadaproject/armada/internal/scheduler/interfaces"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
@@ -35,7 +42,11 @@ type SchedulingContext struct {
 	// Default priority class.
 	DefaultPriorityClass string
 	// Weights used when computing total resource usage.
+	// If no weights are provided, each resource type in TotalResources is given weight 1.
 	ResourceScarcity map[string]float64
+	// Determines how the resource usage of queues is compared when computing fractions of fair share.
+	// One of FairnessModelAsset (the default) or FairnessModelDominantResource.
+	FairnessModel string
 	// Per-queue scheduling contexts.
 	QueueSchedulingContexts map[string]*QueueSchedulingContext
 	// Total resources across all clusters available at the start of the scheduling cycle.
@@ -55,14 +66,59 @@ type SchedulingContext struct {
 	// TODO(reports): Count the number of evicted gangs.
 	// Reason for why the scheduling round finished.
 	TerminationReason string
+	// Dominant reason nothing was scheduled in this round, as determined by the scheduler at the end of the round.
+	// Empty if any jobs were scheduled.
+	RoundOutcome RoundOutcome
 	// Used to efficiently generate scheduling keys.
 	SchedulingKeyGenerator *schedulerobjects.SchedulingKeyGenerator
 	// Record of job scheduling requirements known to be unfeasible.
 	// Used to immediately reject new jobs with identical reqirements.
 	// Maps to the JobSchedulingContext of a previous job attempted to schedule with the same key.
 	UnfeasibleSchedulingKeys map[schedulerobjects.SchedulingKey]*JobSchedulingContext
+	// Order in which queues were to be considered at the start of the most recent call to QueueScheduler.Schedule
+	// for this context, together with the fairness metric values determining it.
+	QueueOrder []QueueOrderEntry
 }
 
+// QueueOrderEntry describes the position of a queue in the order in which queues are considered for scheduling.
+// Queues are considered in increasing order of FractionOfFairShare, with ties broken by queue name.
+type QueueOrderEntry struct {
+	Queue string
+	// Fraction of total resources the queue is entitled to, relative to the other queues being scheduled.
+	FairShare float64
+	// Fraction of its fair share the queue would have if its next gang were scheduled.
+	FractionOfFairShare float64
+	// Number of jobs in the next gang of the queue.
+	NextGangCardinality int
+}
+
+// RoundOutcome describes the dominant reason nothing was scheduled in a scheduling round.
+type RoundOutcome string
+
+const (
+	// No jobs were eligible to be scheduled.
+	RoundOutcomeNoEligibleJobs RoundOutcome = "no eligible jobs"
+	// No resources with non-zero weight were available for scheduling.
+	RoundOutcomeNoSchedulableResources RoundOutcome = "no schedulable resources"
+	// Jobs didn't fit on any node, even after preempting the jobs eligible for preemption.
+	RoundOutcomeNodeCapacityExhausted RoundOutcome = "node capacity exhausted"
+	// A limit on the number of jobs, gangs, or resources scheduled per round was reached.
+	RoundOutcomeRoundLimitReached RoundOutcome = "round limit reached"
+	// Queues were at their per-queue or per-priority-class resource limits.
+	RoundOutcomeQueueLimitReached RoundOutcome = "queue limit reached"
+	// Gangs couldn't be placed before the gang scheduling deadline.
+	RoundOutcomeDeadlineExceeded RoundOutcome = "scheduling deadline exceeded"
+	// The executor is draining, so no new jobs are scheduled onto it.
+	RoundOutcomeExecutorDraining RoundOutcome = "executor draining"
+	// Scheduling was paused for the queues with jobs to schedule.
+	RoundOutcomeQueuesPaused RoundOutcome = "queues paused"
+	// Jobs were rejected for other reasons, e.g., because they're smaller than the minimum job size.
+	RoundOutcomeJobsUnschedulable RoundOutcome = "jobs unschedulable"
+)
+
+// The order in which queues were considered is only included in reports at this verbosity or higher.
+const queueOrderMinVerbosity = 2
+
 func NewSchedulingContext(
 	executorId string,
 	pool string,
@@ -77,7 +133,7 @@ func NewSchedulingContext(
 		Pool:                         pool,
 		PriorityClasses:              priorityClasses,
 		DefaultPriorityClass:         defaultPriorityClass,
-		ResourceScarcity:             resourceScarcity,
+		ResourceScarcity:             resourceScarcityOrDefault(resourceScarcity, totalResources),
 		QueueSchedulingContexts:      make(map[string]*QueueSchedulingContext),
 		TotalResources:               totalResources.DeepCopy(),
 		ScheduledResources:           schedulerobjects.NewResourceListWithDefaultSize(),
@@ -88,6 +144,100 @@ func NewSchedulingContext(
 	}
 }
 
+// resourceScarcityOrDefault returns resourceScarcity if it contains at least one weight.
+// Otherwise, it returns weights of 1 for each resource type in totalResources; with no weights at all,
+// resource usage would always be zero, which causes the scheduler to divide by zero.
+// Resource types missing from a non-empty resourceScarcity are not defaulted and keep a weight of 0,
+// since configs commonly list only the resource types that should count towards fair share, e.g., only cpu.
+func resourceScarcityOrDefault(resourceScarcity map[string]float64, totalResources schedulerobjects.ResourceList) map[string]float64 {
+	if len(resourceScarcity) > 0 {
+		return resourceScarcity
+	}
+	rv := make(map[string]float64, len(totalResources.Resources))
+	for t := range totalResources.Resources {
+		rv[t] = 1
+	}
+	return rv
+}
+
+// Models used to compute the fraction of total resources used by a queue.
+const (
+	// The sum over all resource types of the amount used weighted by ResourceScarcity,
+	// divided by the total resources weighted in the same way.
+	FairnessModelAsset = ""
+	// The dominant share, i.e., the largest fraction of the total used of any resource type, where the fraction of each
+	// resource type is scaled by its weight in ResourceScarcity relative to the largest weight; see dominant resource
+	// fairness (DRF). For example, with equal weights for cpu and gpu, a queue using mostly gpus and a queue using
+	// mostly cpu are considered to use equal fractions of resources if they use equal fractions of gpu and cpu.
+	FairnessModelDominantResource = "dominantResource"
+)
+
+// SetFairnessModel sets the model used to compute the fraction of total resources used by a queue.
+// Must be one of FairnessModelAsset or FairnessModelDominantResource.
+func (sctx *SchedulingContext) SetFairnessModel(model string) error {
+	switch model {
+	case FairnessModelAsset, FairnessModelDominantResource:
+		sctx.FairnessModel = model
+		return nil
+	default:
+		return errors.WithStack(&armadaerrors.ErrInvalidArgument{
+			Name:    "model",
+			Value:   model,
+			Message: fmt.Sprintf("must be one of %q or %q", FairnessModelAsset, FairnessModelDominantResource),
+		})
+	}
+}
+
+// UsageFraction returns the fraction of TotalResources represented by rl according to FairnessModel.
+func (sctx *SchedulingContext) UsageFraction(rl schedulerobjects.ResourceList) float64 {
+	if sctx.FairnessModel == FairnessModelDominantResource {
+		return sctx.dominantShare(rl)
+	}
+	total := weightedMillis(sctx.ResourceScarcity, sctx.TotalResources)
+	if total < 1 {
+		total = 1
+	}
+	return float64(weightedMillis(sctx.ResourceScarcity, rl)) / float64(total)
+}
+
+// dominantShare returns the largest fraction of TotalResources represented by rl of any resource type,
+// where the fraction of each resource type is multiplied by its weight in ResourceScarcity divided by the largest weight.
+// Hence, the result is between 0 and 1, and is the unweighted dominant share if all weights are equal.
+// Resource types with no total resources or a non-positive weight are ignored.
+func (sctx *SchedulingContext) dominantShare(rl schedulerobjects.ResourceList) float64 {
+	maxWeight := 0.0
+	for _, weight := range sctx.ResourceScarcity {
+		maxWeight = math.Max(maxWeight, weight)
+	}
+	if maxWeight <= 0 {
+		return 0
+	}
+	rv := 0.0
+	for t, weight := range sctx.ResourceScarcity {
+		if weight <= 0 {
+			continue
+		}
+		total := sctx.TotalResources.Get(t)
+		if total.MilliValue() <= 0 {
+			continue
+		}
+		used := rl.Get(t)
+		rv = math.Max(rv, weight/maxWeight*float64(used.MilliValue())/float64(total.MilliValue()))
+	}
+	return rv
+}
+
+// weightedMillis returns the sum over all resource types of the milli-value of rl weighted by weights.
+// Equivalent to scheduler.ResourceListAsWeightedMillis, which can't be imported here.
+func weightedMillis(weights map[string]float64, rl schedulerobjects.ResourceList) int64 {
+	var rv int64
+	for t, f := range weights {
+		q := rl.Get(t)
+		rv += int64(math.Round(float64(q.MilliValue()) * f))
+	}
+	return rv
+}
+
 func (sctx *SchedulingContext) SchedulingKeyFromLegacySchedulerJob(job interfaces.LegacySchedulerJob) schedulerobjects.SchedulingKey {
 	var priority int32
 	if priorityClass, ok := sctx.PriorityClasses[job.GetPriorityClassName()]; ok {
@@ -144,50 +294,88 @@ func (sctx *SchedulingContext) String() string {
 func (sctx *SchedulingContext) ReportString(verbosity int32) string {
 	var sb strings.Builder
 	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
-	fmt.Fprintf(w, "Started:\t%s\n", sctx.Started)
-	fmt.Fprintf(w, "Finished:\t%s\n", sctx.Finished)
-	fmt.Fprintf(w, "Duration:\t%s\n", sctx.Finished.Sub(sctx.Started))
-	fmt.Fprintf(w, "Termination reason:\t%s\n", sctx.TerminationReason)
-	fmt.Fprintf(w, "Total capacity:\t%s\n", sctx.TotalResources.CompactString())
-	fmt.Fprintf(w, "Scheduled resources:\t%s\n", sctx.ScheduledResources.CompactString())
-	fmt.Fprintf(w, "Preempted resources:\t%s\n", sctx.EvictedResources.CompactString())
-	fmt.Fprintf(w, "Number of gangs scheduled:\t%d\n", sctx.NumScheduledGangs)
-	fmt.Fprintf(w, "Number of jobs scheduled:\t%d\n", sctx.NumScheduledJobs)
-	fmt.Fprintf(w, "Number of jobs preempted:\t%d\n", sctx.NumEvictedJobs)
+	sctx.WriteReport(w, "", verbosity)
+	w.Flush()
+	return util.TrimTrailingWhitespace(sb.String())
+}
+
+// WriteReport writes a human-readable report of this context to w, prefixing each line with indent.
+// Each line written contains exactly one tab, which separates a label from its value, and w isn't flushed.
+// Hence, if w is a tabwriter, values are aligned across everything written to w before it's flushed,
+// e.g., across the reports of several scheduling contexts.
+//
+// Lines without a value end with a tab, which causes a tabwriter to pad them with trailing whitespace.
+func (sctx *SchedulingContext) WriteReport(w io.Writer, indent string, verbosity int32) {
+	sctx.WriteReportWithQueueFilter(w, indent, verbosity, nil)
+}
+
+// WriteReportWithQueueFilter is like WriteReport, but only includes queues for which includeQueue returns true.
+// Omitted queues are summarised by a count. All queues are included if includeQueue is nil.
+func (sctx *SchedulingContext) WriteReportWithQueueFilter(w io.Writer, indent string, verbosity int32, includeQueue func(*QueueSchedulingContext) bool) {
+	queueSchedulingContexts := sctx.QueueSchedulingContexts
+	if includeQueue != nil {
+		queueSchedulingContexts = armadamaps.Filter(
+			sctx.QueueSchedulingContexts,
+			func(_ string, qctx *QueueSchedulingContext) bool {
+				return includeQueue(qctx)
+			},
+		)
+	}
+	fmt.Fprintf(w, "%sStarted:\t%s\n", indent, sctx.Started)
+	fmt.Fprintf(w, "%sFinished:\t%s\n", indent, sctx.Finished)
+	fmt.Fprintf(w, "%sDuration:\t%s\n", indent, sctx.Finished.Sub(sctx.Started))
+	fmt.Fprintf(w, "%sTermination reason:\t%s\n", indent, sctx.TerminationReason)
+	fmt.Fprintf(w, "%sTotal capacity:\t%s\n", indent, sctx.TotalResources.CompactString())
+	fmt.Fprintf(w, "%sScheduled resources:\t%s\n", indent, sctx.ScheduledResources.CompactString())
+	fmt.Fprintf(w, "%sPreempted resources:\t%s\n", indent, sctx.EvictedResources.CompactString())
+	fmt.Fprintf(w, "%sNumber of gangs scheduled:\t%d\n", indent, sctx.NumScheduledGangs)
+	fmt.Fprintf(w, "%sNumber of jobs scheduled:\t%d\n", indent, sctx.NumScheduledJobs)
+	fmt.Fprintf(w, "%sNumber of jobs preempted:\t%d\n", indent, sctx.NumEvictedJobs)
 	if verbosity <= 0 {
-		fmt.Fprintf(
-			w,
-			"Scheduled queues:\t%v\n",
-			maps.Keys(
-				armadamaps.Filter(
-					sctx.QueueSchedulingContexts,
-					func(_ string, qctx *QueueSchedulingContext) bool {
-						return len(qctx.SuccessfulJobSchedulingContexts) > 0
-					},
-				),
+		scheduledQueues := maps.Keys(
+			armadamaps.Filter(
+				queueSchedulingContexts,
+				func(_ string, qctx *QueueSchedulingContext) bool {
+					return len(qctx.SuccessfulJobSchedulingContexts) > 0
+				},
 			),
 		)
-		fmt.Fprintf(
-			w,
-			"Preempted queues:\t%v\n",
-			maps.Keys(
-				armadamaps.Filter(
-					sctx.QueueSchedulingContexts,
-					func(_ string, qctx *QueueSchedulingContext) bool {
-						return len(qctx.EvictedJobsById) > 0
-					},
-				),
+		slices.Sort(scheduledQueues)
+		fmt.Fprintf(w, "%sScheduled queues:\t%v\n", indent, scheduledQueues)
+		preemptedQueues := maps.Keys(
+			armadamaps.Filter(
+				queueSchedulingContexts,
+				func(_ string, qctx *QueueSchedulingContext) bool {
+					return len(qctx.EvictedJobsById) > 0
+				},
 			),
 		)
+		slices.Sort(preemptedQueues)
+		fmt.Fprintf(w, "%sPreempted queues:\t%v\n", indent, preemptedQueues)
 	} else {
-		fmt.Fprint(w, "Queues:\n")
-		for queueName, qctx := range sctx.QueueSchedulingContexts {
-			fmt.Fprintf(w, "\t%s:\n", queueName)
-			fmt.Fprintf(w, indent.String("\t\t", qctx.ReportString(verbosity-1)))
+		fmt.Fprintf(w, "%sQueues:\t\n", indent)
+		queues := maps.Keys(queueSchedulingContexts)
+		slices.Sort(queues)
+		for _, queue := range queues {
+			fmt.Fprintf(w, "%s%s%s:\t\n", indent, reportIndent, queue)
+			queueSchedulingContexts[queue].WriteReport(w, indent+reportIndent+reportIndent, verbosity-1)
+		}
+	}
+	if numOmitted := len(sctx.QueueSchedulingContexts) - len(queueSchedulingContexts); numOmitted > 0 {
+		fmt.Fprintf(w, "%sQueues below minimum resources:\t%d\n", indent, numOmitted)
+	}
+	if verbosity >= queueOrderMinVerbosity && len(sctx.QueueOrder) > 0 {
+		fmt.Fprintf(w, "%sQueue order:\t\n", indent)
+		for _, entry := range sctx.QueueOrder {
+			if _, ok := queueSchedulingContexts[entry.Queue]; !ok {
+				continue
+			}
+			fmt.Fprintf(
+				w, "%s%s%s:\tfraction of fair share %f, fair share %f, next gang of %d job(s)\n",
+				indent, reportIndent, entry.Queue, entry.FractionOfFairShare, entry.FairShare, entry.NextGangCardinality,
+			)
 		}
 	}
-	w.Flush()
-	return sb.String()
 }
 
 func (sctx *SchedulingContext) AddGangSchedulingContext(gctx *GangSchedulingContext) (bool, error) {
@@ -276,6 +464,33 @@ func (sctx *SchedulingContext) ClearJobSpecs() {
 	}
 }
 
+// Clone returns a copy of sctx to which jobs can be added, or from which jobs can be evicted, without affecting sctx.
+// Queue scheduling contexts are copied as well, whereas job scheduling contexts are shared between sctx and the copy.
+func (sctx *SchedulingContext) Clone() *SchedulingContext {
+	rv := *sctx
+	rv.TotalResources = sctx.TotalResources.DeepCopy()
+	rv.ScheduledResources = sctx.ScheduledResources.DeepCopy()
+	rv.ScheduledResourcesByPriority = sctx.ScheduledResourcesByPriority.DeepCopy()
+	rv.EvictedResources = sctx.EvictedResources.DeepCopy()
+	rv.EvictedResourcesByPriority = sctx.EvictedResourcesByPriority.DeepCopy()
+	rv.UnfeasibleSchedulingKeys = maps.Clone(sctx.UnfeasibleSchedulingKeys)
+	rv.QueueOrder = slices.Clone(sctx.QueueOrder)
+	rv.QueueSchedulingContexts = make(map[string]*QueueSchedulingContext, len(sctx.QueueSchedulingContexts))
+	for queue, qctx := range sctx.QueueSchedulingContexts {
+		qctxCopy := *qctx
+		qctxCopy.SchedulingContext = &rv
+		qctxCopy.Allocated = qctx.Allocated.DeepCopy()
+		qctxCopy.AllocatedByPriority = qctx.AllocatedByPriority.DeepCopy()
+		qctxCopy.ScheduledResourcesByPriority = qctx.ScheduledResourcesByPriority.DeepCopy()
+		qctxCopy.EvictedResourcesByPriority = qctx.EvictedResourcesByPriority.DeepCopy()
+		qctxCopy.SuccessfulJobSchedulingContexts = maps.Clone(qctx.SuccessfulJobSchedulingContexts)
+		qctxCopy.UnsuccessfulJobSchedulingContexts = maps.Clone(qctx.UnsuccessfulJobSchedulingContexts)
+		qctxCopy.EvictedJobsById = maps.Clone(qctx.EvictedJobsById)
+		rv.QueueSchedulingContexts[queue] = &qctxCopy
+	}
+	return &rv
+}
+
 func (sctx *SchedulingContext) SuccessfulJobSchedulingContexts() []*JobSchedulingContext {
 	jctxs := make([]*JobSchedulingContext, 0)
 	for _, qctx := range sctx.QueueSchedulingContexts {
@@ -338,43 +553,99 @@ func GetSchedulingContextFromQueueSchedulingContext(qctx *QueueSchedulingContext
 	return qctx.SchedulingContext
 }
 
+// FairShare returns the fraction of total resources this queue is entitled to,
+// relative to the other queues in the same scheduling context.
+// Returns zero if the context doesn't belong to a scheduling context.
+func (qctx *QueueSchedulingContext) FairShare() float64 {
+	if qctx.SchedulingContext == nil {
+		return 0
+	}
+	weightSum := 0.0
+	for _, other := range qctx.SchedulingContext.QueueSchedulingContexts {
+		weightSum += 1 / math.Max(other.PriorityFactor, 1)
+	}
+	if weightSum == 0 {
+		return 0
+	}
+	return (1 / math.Max(qctx.PriorityFactor, 1)) / weightSum
+}
+
+// FractionOfFairShare returns the fraction of total resources allocated to this queue, computed according to the
+// fairness model of the scheduling context, divided by its fair share.
+// A queue allocated exactly its fair share has fraction 1.
+// Returns zero if the context doesn't belong to a scheduling context.
+func (qctx *QueueSchedulingContext) FractionOfFairShare() float64 {
+	fairShare := qctx.FairShare()
+	if fairShare == 0 {
+		return 0
+	}
+	return qctx.SchedulingContext.UsageFraction(qctx.Allocated) / fairShare
+}
+
 func (qctx *QueueSchedulingContext) String() string {
 	return qctx.ReportString(0)
 }
 
 const maxPrintedJobIdsByReason = 1
 
+// Added to the indentation of each nested level of human-readable reports.
+const reportIndent = "  "
+
 func (qctx *QueueSchedulingContext) ReportString(verbosity int32) string {
+	return qctx.ReportStringWithMaxPrintedJobIds(verbosity, defaultMaxPrintedJobIds(verbosity))
+}
+
+// defaultMaxPrintedJobIds returns the number of job ids printed per list of jobs in queue reports
+// at the given verbosity, or -1 if all job ids are printed.
+func defaultMaxPrintedJobIds(verbosity int32) int {
+	if verbosity <= 1 {
+		return maxPrintedJobIdsByReason
+	}
+	return -1
+}
+
+// ReportStringWithMaxPrintedJobIds is like ReportString, except at most maxPrintedJobIds job ids are printed
+// for each of the scheduled, unschedulable (by reason), and preempted jobs.
+// If maxPrintedJobIds is negative, all job ids are printed.
+func (qctx *QueueSchedulingContext) ReportStringWithMaxPrintedJobIds(verbosity int32, maxPrintedJobIds int) string {
 	var sb strings.Builder
 	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
+	qctx.WriteReportWithMaxPrintedJobIds(w, "", verbosity, maxPrintedJobIds)
+	w.Flush()
+	return util.TrimTrailingWhitespace(sb.String())
+}
+
+// WriteReport writes a human-readable report of this context to w, prefixing each line with indent.
+// See SchedulingContext.WriteReport for how lines are formatted.
+func (qctx *QueueSchedulingContext) WriteReport(w io.Writer, indent string, verbosity int32) {
+	qctx.WriteReportWithMaxPrintedJobIds(w, indent, verbosity, defaultMaxPrintedJobIds(verbosity))
+}
+
+// WriteReportWithMaxPrintedJobIds is like WriteReport, except at most maxPrintedJobIds job ids are printed
+// for each of the scheduled, unschedulable (by reason), and preempted jobs.
+// If maxPrintedJobIds is negative, all job ids are printed. Job ids and reasons are printed in sorted order.
+func (qctx *QueueSchedulingContext) WriteReportWithMaxPrintedJobIds(w io.Writer, indent string, verbosity int32, maxPrintedJobIds int) {
 	if verbosity > 0 {
-		fmt.Fprintf(w, "Created:\t%s\n", qctx.Created)
+		fmt.Fprintf(w, "%sCreated:\t%s\n", indent, qctx.Created)
 	}
-	fmt.Fprintf(w, "Scheduled resources:\t%s\n", qctx.ScheduledResourcesByPriority.AggregateByResource().CompactString())
-	fmt.Fprintf(w, "Scheduled resources (by priority):\t%s\n", qctx.ScheduledResourcesByPriority.String())
-	fmt.Fprintf(w, "Preempted resources:\t%s\n", qctx.EvictedResourcesByPriority.AggregateByResource().CompactString())
-	fmt.Fprintf(w, "Preempted resources (by priority):\t%s\n", qctx.EvictedResourcesByPriority.String())
+	fmt.Fprintf(w, "%sScheduled resources:\t%s\n", indent, qctx.ScheduledResourcesByPriority.AggregateByResource().CompactString())
+	fmt.Fprintf(w, "%sScheduled resources (by priority):\t%s\n", indent, qctx.ScheduledResourcesByPriority.String())
+	fmt.Fprintf(w, "%sPreempted resources:\t%s\n", indent, qctx.EvictedResourcesByPriority.AggregateByResource().CompactString())
+	fmt.Fprintf(w, "%sPreempted resources (by priority):\t%s\n", indent, qctx.EvictedResourcesByPriority.String())
 	if verbosity > 0 {
-		fmt.Fprintf(w, "Total allocated resources after scheduling:\t%s\n", qctx.AllocatedByPriority.AggregateByResource().CompactString())
-		fmt.Fprintf(w, "Total allocated resources after scheduling (by priority):\t%s\n", qctx.AllocatedByPriority.String())
-		fmt.Fprintf(w, "Number of jobs scheduled:\t%d\n", len(qctx.SuccessfulJobSchedulingContexts))
-		fmt.Fprintf(w, "Number of jobs that could not be scheduled:\t%d\n", len(qctx.UnsuccessfulJobSchedulingContexts))
-		fmt.Fprintf(w, "Number of jobs preempted:\t%d\n", len(qctx.EvictedJobsById))
+		fmt.Fprintf(w, "%sTotal allocated resources after scheduling:\t%s\n", indent, qctx.AllocatedByPriority.AggregateByResource().CompactString())
+		fmt.Fprintf(w, "%sTotal allocated resources after scheduling (by priority):\t%s\n", indent, qctx.AllocatedByPriority.String())
+		fmt.Fprintf(w, "%sNumber of jobs scheduled:\t%d\n", indent, len(qctx.SuccessfulJobSchedulingContexts))
+		fmt.Fprintf(w, "%sNumber of jobs that could not be scheduled:\t%d\n", indent, len(qctx.UnsuccessfulJobSchedulingContexts))
+		fmt.Fprintf(w, "%sNumber of jobs preempted:\t%d\n", indent, len(qctx.EvictedJobsById))
 		if len(qctx.SuccessfulJobSchedulingContexts) > 0 {
-			jobIdsToPrint := maps.Keys(qctx.SuccessfulJobSchedulingContexts)
-			if verbosity <= 1 && len(jobIdsToPrint) > maxPrintedJobIdsByReason {
-				jobIdsToPrint = jobIdsToPrint[0:maxPrintedJobIdsByReason]
-			}
-			fmt.Fprintf(w, "Scheduled jobs:\t%v", jobIdsToPrint)
-			if len(jobIdsToPrint) != len(qctx.SuccessfulJobSchedulingContexts) {
-				fmt.Fprintf(w, " (and %d others not shown)\n", len(qctx.SuccessfulJobSchedulingContexts)-len(jobIdsToPrint))
-			} else {
-				fmt.Fprint(w, "\n")
-			}
+			jobIds := maps.Keys(qctx.SuccessfulJobSchedulingContexts)
+			slices.Sort(jobIds)
+			fmt.Fprintf(w, "%sScheduled jobs:\t%s\n", indent, jobIdsReportString(jobIds, maxPrintedJobIds))
 		}
 		if len(qctx.UnsuccessfulJobSchedulingContexts) > 0 {
-			fmt.Fprint(w, "Unschedulable jobs:\n")
-			for reason, jobIds := range armadaslices.MapAndGroupByFuncs(
+			fmt.Fprintf(w, "%sUnschedulable jobs:\t\n", indent)
+			jobIdsByReason := armadaslices.MapAndGroupByFuncs(
 				maps.Values(qctx.UnsuccessfulJobSchedulingContexts),
 				func(jctx *JobSchedulingContext) string {
 					return jctx.UnschedulableReason
@@ -382,34 +653,57 @@ func (qctx *QueueSchedulingContext) ReportString(verbosity int32) string {
 				func(jctx *JobSchedulingContext) string {
 					return jctx.JobId
 				},
-			) {
-				jobIdsToPrint := jobIds
-				if verbosity <= 1 && len(jobIdsToPrint) > maxPrintedJobIdsByReason {
-					jobIdsToPrint = jobIds[0:maxPrintedJobIdsByReason]
-				}
-				fmt.Fprintf(w, "\t%d:\t%s jobs\t%v", len(qctx.UnsuccessfulJobSchedulingContexts), reason, jobIdsToPrint)
-				if len(jobIdsToPrint) != len(jobIds) {
-					fmt.Fprintf(w, " (and %d others not shown)\n", len(jobIds)-len(jobIdsToPrint))
-				} else {
-					fmt.Fprint(w, "\n")
-				}
+			)
+			reasons := maps.Keys(jobIdsByReason)
+			slices.Sort(reasons)
+			for _, reason := range reasons {
+				jobIds := jobIdsByReason[reason]
+				slices.Sort(jobIds)
+				fmt.Fprintf(w, "%s%s%d %s jobs:\t%s\n", indent, reportIndent, len(jobIds), reason, jobIdsReportString(jobIds, maxPrintedJobIds))
 			}
 		}
 		if len(qctx.EvictedJobsById) > 0 {
-			jobIdsToPrint := maps.Keys(qctx.EvictedJobsById)
-			if verbosity <= 1 && len(jobIdsToPrint) > maxPrintedJobIdsByReason {
-				jobIdsToPrint = jobIdsToPrint[0:maxPrintedJobIdsByReason]
-			}
-			fmt.Fprintf(w, "Preempted jobs:\t%v", jobIdsToPrint)
-			if len(jobIdsToPrint) != len(qctx.EvictedJobsById) {
-				fmt.Fprintf(w, " (and %d others not shown)\n", len(qctx.EvictedJobsById)-len(jobIdsToPrint))
-			} else {
-				fmt.Fprint(w, "\n")
-			}
+			jobIds := maps.Keys(qctx.EvictedJobsById)
+			slices.Sort(jobIds)
+			fmt.Fprintf(w, "%sPreempted jobs:\t%s\n", indent, jobIdsReportString(jobIds, maxPrintedJobIds))
 		}
 	}
-	w.Flush()
-	return sb.String()
+}
+
+// UnschedulableReasonCount is the number of jobs of a queue that could not be scheduled for a particular reason.
+type UnschedulableReasonCount struct {
+	Reason  string
+	NumJobs int
+}
+
+// UnschedulableReasonCounts returns, for each distinct reason among UnsuccessfulJobSchedulingContexts,
+// the number of jobs that could not be scheduled for that reason.
+// Counts are sorted by decreasing number of jobs, with ties broken by reason.
+func (qctx *QueueSchedulingContext) UnschedulableReasonCounts() []UnschedulableReasonCount {
+	numJobsByReason := make(map[string]int)
+	for _, jctx := range qctx.UnsuccessfulJobSchedulingContexts {
+		numJobsByReason[jctx.UnschedulableReason]++
+	}
+	rv := make([]UnschedulableReasonCount, 0, len(numJobsByReason))
+	for reason, numJobs := range numJobsByReason {
+		rv = append(rv, UnschedulableReasonCount{Reason: reason, NumJobs: numJobs})
+	}
+	slices.SortFunc(rv, func(a, b UnschedulableReasonCount) bool {
+		if a.NumJobs != b.NumJobs {
+			return a.NumJobs > b.NumJobs
+		}
+		return a.Reason < b.Reason
+	})
+	return rv
+}
+
+// jobIdsReportString returns a string representation of up to maxPrintedJobIds of the provided job ids,
+// noting how many were omitted. If maxPrintedJobIds is negative, all job ids are included.
+func jobIdsReportString(jobIds []string, maxPrintedJobIds int) string {
+	if maxPrintedJobIds < 0 || len(jobIds) <= maxPrintedJobIds {
+		return fmt.Sprintf("%v", jobIds)
+	}
+	return fmt.Sprintf("%v (and %d others not shown)", jobIds[0:maxPrintedJobIds], len(jobIds)-maxPrintedJobIds)
 }
 
 func (qctx *QueueSchedulingContext) AddGangSchedulingContext(gctx *GangSchedulingContext) error {
@@ -501,47 +795,198 @@ func (qctx *QueueSchedulingContext) ClearJobSpecs() {
 }
 
 type GangSchedulingContext struct {
-	Created               time.Time
+	Created time.Time
+	// Id of the gang, shared by all jobs in the gang via configuration.GangIdAnnotation.
+	// Empty for jobs not submitted as part of a gang.
+	GangId                string
 	Queue                 string
 	PriorityClassName     string
 	JobSchedulingContexts []*JobSchedulingContext
 	TotalResourceRequests schedulerobjects.ResourceList
 	AllJobsEvicted        bool
+	// Minimum number of jobs in the gang that must be scheduled for the gang to be considered scheduled.
+	// Equal to the number of jobs in the gang unless set via configuration.GangMinimumCardinalityAnnotation.
+	MinimumCardinality int
+	// If true, each job in the gang must be scheduled onto a different node.
+	// Set via configuration.GangUniqueNodesAnnotation.
+	RequireUniqueNodes bool
+	// Maximum number of distinct nodes the jobs in the gang may be scheduled onto.
+	// Zero if not limited. Set via configuration.GangMaxNodeSpanAnnotation.
+	MaxNodeSpan int
+	// If non-empty, each job in the gang may only be scheduled onto nodes with these labels,
+	// in addition to the node selector of the job itself. Set via configuration.GangNodeSelectorAnnotation.
+	NodeSelector map[string]string
+	// Weighted node affinity terms added to the preferred node affinity of each job in the gang.
+	// Nodes matching terms with a higher total weight are preferred, e.g., nodes close to a data source,
+	// but jobs may still be scheduled onto any node that meets their requirements.
+	// Set via configuration.GangPreferredNodeAffinityAnnotation.
+	PreferredNodeAffinityTerms []v1.PreferredSchedulingTerm
 }
 
 func NewGangSchedulingContext(jctxs []*JobSchedulingContext) *GangSchedulingContext {
 	// We assume that all jobs in a gang are in the same queue and have the same priority class
 	// (which we enforce at job submission).
+	gangId := ""
 	queue := ""
 	priorityClassName := ""
 	if len(jctxs) > 0 {
+		gangId = jctxs[0].Job.GetAnnotations()[configuration.GangIdAnnotation]
 		queue = jctxs[0].Job.GetQueue()
 		priorityClassName = jctxs[0].Job.GetPriorityClassName()
 	}
 	allJobsEvicted := true
 	totalResourceRequests := schedulerobjects.NewResourceList(4)
 	for _, jctx := range jctxs {
+		jctx.GangId = gangId
 		allJobsEvicted = allJobsEvicted && isEvictedJob(jctx.Job)
 		totalResourceRequests.AddV1ResourceList(jctx.Req.ResourceRequirements.Requests)
 	}
 	return &GangSchedulingContext{
-		Created:               time.Now(),
-		Queue:                 queue,
-		PriorityClassName:     priorityClassName,
-		JobSchedulingContexts: jctxs,
-		TotalResourceRequests: totalResourceRequests,
-		AllJobsEvicted:        allJobsEvicted,
+		Created:                    time.Now(),
+		GangId:                     gangId,
+		Queue:                      queue,
+		PriorityClassName:          priorityClassName,
+		JobSchedulingContexts:      jctxs,
+		TotalResourceRequests:      totalResourceRequests,
+		AllJobsEvicted:             allJobsEvicted,
+		MinimumCardinality:         gangMinimumCardinality(jctxs),
+		RequireUniqueNodes:         len(jctxs) > 0 && jctxs[0].Job.GetAnnotations()[configuration.GangUniqueNodesAnnotation] == "true",
+		MaxNodeSpan:                gangMaxNodeSpan(jctxs),
+		NodeSelector:               gangNodeSelector(jctxs),
+		PreferredNodeAffinityTerms: gangPreferredNodeAffinityTerms(jctxs),
 	}
 }
 
+// gangMaxNodeSpan returns the maximum node span specified by the first job in the gang.
+// Returns zero, i.e., no limit, if no valid maximum is specified.
+func gangMaxNodeSpan(jctxs []*JobSchedulingContext) int {
+	if len(jctxs) == 0 {
+		return 0
+	}
+	s, ok := jctxs[0].Job.GetAnnotations()[configuration.GangMaxNodeSpanAnnotation]
+	if !ok {
+		return 0
+	}
+	maxNodeSpan, err := strconv.Atoi(s)
+	if err != nil || maxNodeSpan <= 0 {
+		return 0
+	}
+	return maxNodeSpan
+}
+
+// gangNodeSelector returns the node selector specified by the first job in the gang.
+// Returns nil if no valid node selector is specified.
+func gangNodeSelector(jctxs []*JobSchedulingContext) map[string]string {
+	if len(jctxs) == 0 {
+		return nil
+	}
+	s, ok := jctxs[0].Job.GetAnnotations()[configuration.GangNodeSelectorAnnotation]
+	if !ok {
+		return nil
+	}
+	nodeSelector, err := labels.ConvertSelectorToLabelsMap(s)
+	if err != nil || len(nodeSelector) == 0 {
+		return nil
+	}
+	return nodeSelector
+}
+
+// gangPreferredNodeAffinityTerms returns the preferred node affinity terms specified by the first job in the gang.
+// Returns nil if no valid terms are specified.
+func gangPreferredNodeAffinityTerms(jctxs []*JobSchedulingContext) []v1.PreferredSchedulingTerm {
+	if len(jctxs) == 0 {
+		return nil
+	}
+	s, ok := jctxs[0].Job.GetAnnotations()[configuration.GangPreferredNodeAffinityAnnotation]
+	if !ok {
+		return nil
+	}
+	var terms []v1.PreferredSchedulingTerm
+	if err := json.Unmarshal([]byte(s), &terms); err != nil || len(terms) == 0 {
+		return nil
+	}
+	return terms
+}
+
+// gangMinimumCardinality returns the minimum cardinality specified by the first job in the gang.
+// Returns the number of jobs in the gang if no valid minimum is specified.
+func gangMinimumCardinality(jctxs []*JobSchedulingContext) int {
+	if len(jctxs) == 0 {
+		return 0
+	}
+	s, ok := jctxs[0].Job.GetAnnotations()[configuration.GangMinimumCardinalityAnnotation]
+	if !ok {
+		return len(jctxs)
+	}
+	minimumCardinality, err := strconv.Atoi(s)
+	if err != nil || minimumCardinality <= 0 || minimumCardinality > len(jctxs) {
+		return len(jctxs)
+	}
+	return minimumCardinality
+}
+
+// IsPartial returns true if the gang is considered scheduled even if only some of its jobs are scheduled.
+func (gctx *GangSchedulingContext) IsPartial() bool {
+	return gctx.MinimumCardinality < len(gctx.JobSchedulingContexts)
+}
+
+// PodRequirements returns the scheduling requirements of the jobs in the gang.
+// If the gang has a node selector or preferred node affinity terms,
+// the returned requirements are copies of those of the jobs, with the gang node selector merged into
+// the node selector of each job and the gang terms appended to the preferred node affinity of each job.
+// Labels set by both node selectors take the value of the gang node selector.
 func (gctx GangSchedulingContext) PodRequirements() []*schedulerobjects.PodRequirements {
 	rv := make([]*schedulerobjects.PodRequirements, len(gctx.JobSchedulingContexts))
 	for i, jctx := range gctx.JobSchedulingContexts {
 		rv[i] = jctx.Req
+		if !gctx.HasNodeRequirements() {
+			continue
+		}
+		req := *jctx.Req
+		if len(gctx.NodeSelector) > 0 {
+			req.NodeSelector = make(map[string]string, len(jctx.Req.NodeSelector)+len(gctx.NodeSelector))
+			maps.Copy(req.NodeSelector, jctx.Req.NodeSelector)
+			maps.Copy(req.NodeSelector, gctx.NodeSelector)
+		}
+		if len(gctx.PreferredNodeAffinityTerms) > 0 {
+			if req.Affinity == nil {
+				req.Affinity = &v1.Affinity{}
+			} else {
+				req.Affinity = req.Affinity.DeepCopy()
+			}
+			if req.Affinity.NodeAffinity == nil {
+				req.Affinity.NodeAffinity = &v1.NodeAffinity{}
+			}
+			req.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+				req.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+				gctx.PreferredNodeAffinityTerms...,
+			)
+		}
+		rv[i] = &req
 	}
 	return rv
 }
 
+// HasNodeRequirements returns true if the gang places requirements or preferences on the nodes its jobs
+// are scheduled onto in addition to those of the jobs themselves, i.e., if PodRequirements returns copies.
+func (gctx GangSchedulingContext) HasNodeRequirements() bool {
+	return len(gctx.NodeSelector) > 0 || len(gctx.PreferredNodeAffinityTerms) > 0
+}
+
+// ConflictingNodeSelectorLabel returns a label for which the gang node selector
+// and the node selector of some job in the gang require different values, and true, if there is any such label.
+// No node can satisfy both selectors in that case.
+func (gctx GangSchedulingContext) ConflictingNodeSelectorLabel() (string, bool) {
+	for _, jctx := range gctx.JobSchedulingContexts {
+		for label, value := range gctx.NodeSelector {
+			if jobValue, ok := jctx.Req.NodeSelector[label]; ok && jobValue != value {
+				return label, true
+			}
+		}
+	}
+	return "", false
+}
+
 func isEvictedJob(job interfaces.LegacySchedulerJob) bool {
 	return job.GetAnnotations()[schedulerconfig.IsEvictedAnnotation] == "true"
 }
@@ -557,6 +1002,9 @@ type JobSchedulingContext struct {
 	NumNodes int
 	// Id of the job this pod corresponds to.
 	JobId string
+	// Id of the gang this job was scheduled as part of; see GangSchedulingContext.GangId.
+	// Empty if the job isn't part of a gang.
+	GangId string
 	// Job spec.
 	Job interfaces.LegacySchedulerJob
 	// Scheduling requirements of this job.
@@ -565,26 +1013,59 @@ type JobSchedulingContext struct {
 	// Reason for why the job could not be scheduled.
 	// Empty if the job was scheduled successfully.
 	UnschedulableReason string
+	// Short machine-readable identifier of the constraint that prevented the job from being scheduled,
+	// e.g., "MaximumResourceFractionPerQueue". May be empty even if the job could not be scheduled.
+	UnschedulableReasonCode string
+	// Resource the unschedulable reason relates to, if any.
+	UnschedulableResource string
 	// Pod scheduling contexts for the individual pods that make up the job.
 	PodSchedulingContext *PodSchedulingContext
+	// Id of the node the job was assigned to.
+	// Empty if the job was not scheduled.
+	NodeId string
 }
 
 func (jctx *JobSchedulingContext) String() string {
 	var sb strings.Builder
 	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
-	fmt.Fprintf(w, "Time:\t%s\n", jctx.Created)
-	fmt.Fprintf(w, "Job id:\t%s\n", jctx.JobId)
-	fmt.Fprintf(w, "Number of nodes in cluster:\t%d\n", jctx.NumNodes)
+	jctx.WriteReport(w, "")
+	w.Flush()
+	return sb.String()
+}
+
+// WriteReport writes a human-readable report of this context to w, prefixing each line with indent.
+// See SchedulingContext.WriteReport for how lines are formatted.
+func (jctx *JobSchedulingContext) WriteReport(w io.Writer, indent string) {
+	fmt.Fprintf(w, "%sTime:\t%s\n", indent, jctx.Created)
+	fmt.Fprintf(w, "%sJob id:\t%s\n", indent, jctx.JobId)
+	if jctx.GangId != "" {
+		fmt.Fprintf(w, "%sGang id:\t%s\n", indent, jctx.GangId)
+	}
+	fmt.Fprintf(w, "%sNumber of nodes in cluster:\t%d\n", indent, jctx.NumNodes)
 	if jctx.UnschedulableReason != "" {
-		fmt.Fprintf(w, "UnschedulableReason:\t%s\n", jctx.UnschedulableReason)
+		fmt.Fprintf(w, "%sUnschedulableReason:\t%s\n", indent, jctx.UnschedulableReason)
 	} else {
-		fmt.Fprint(w, "UnschedulableReason:\tnone\n")
+		fmt.Fprintf(w, "%sUnschedulableReason:\tnone\n", indent)
+	}
+	if rejection := jctx.Rejection(); rejection != "" {
+		fmt.Fprintf(w, "%sRejection:\t%s\n", indent, rejection)
 	}
 	if jctx.PodSchedulingContext != nil {
-		fmt.Fprint(w, jctx.PodSchedulingContext.String())
+		jctx.PodSchedulingContext.WriteReport(w, indent)
 	}
-	w.Flush()
-	return sb.String()
+}
+
+// Rejection returns a short summary of why the job could not be scheduled,
+// e.g., "rejected: MaximumResourceFractionPerQueue cpu".
+// Returns the empty string if no UnschedulableReasonCode is set.
+func (jctx *JobSchedulingContext) Rejection() string {
+	if jctx.UnschedulableReasonCode == "" {
+		return ""
+	}
+	if jctx.UnschedulableResource == "" {
+		return fmt.Sprintf("rejected: %s", jctx.UnschedulableReasonCode)
+	}
+	return fmt.Sprintf("rejected: %s %s", jctx.UnschedulableReasonCode, jctx.UnschedulableResource)
 }
 
 func (jctx *JobSchedulingContext) IsSuccessful() bool {
@@ -607,24 +1088,67 @@ type PodSchedulingContext struct {
 	NumNodes int
 	// Number of nodes excluded by reason.
 	NumExcludedNodesByReason map[string]int
+	// If the pod could not be assigned to any node, a node that was excluded only because its available resources
+	// were rounded down to the resolution with which they're indexed. Nil if there's no such node.
+	// Only set once the gang the pod is part of has been rejected; see NodeDb.ResolutionRoundingWithTxn.
+	ResolutionRounding *ResolutionRounding
+}
+
+// ResolutionRounding describes a node with enough of a resource available to schedule a pod,
+// that was nevertheless not considered since the available amount is rounded down to a multiple of the resolution
+// with which that resource is indexed; see configuration.IndexedResource.
+type ResolutionRounding struct {
+	NodeId   string
+	Resource string
+	// Amount of the resource requested by the pod.
+	Requested resource.Quantity
+	// Amount of the resource available on the node.
+	Available resource.Quantity
+	// Available rounded down to a multiple of Resolution.
+	Rounded    resource.Quantity
+	Resolution resource.Quantity
+}
+
+func (r *ResolutionRounding) String() string {
+	return fmt.Sprintf(
+		"node %s has %s %s available, which is rounded down to %s due to the resolution %s, but %s is requested",
+		r.NodeId, r.Available.String(), r.Resource, r.Rounded.String(), r.Resolution.String(), r.Requested.String(),
+	)
 }
 
 func (pctx *PodSchedulingContext) String() string {
 	var sb strings.Builder
 	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
+	pctx.WriteReport(w, "")
+	w.Flush()
+	return sb.String()
+}
+
+// WriteReport writes a human-readable report of this context to w, prefixing each line with indent.
+// See SchedulingContext.WriteReport for how lines are formatted.
+func (pctx *PodSchedulingContext) WriteReport(w io.Writer, indent string) {
 	if pctx.Node != nil {
-		fmt.Fprintf(w, "Node:\t%s\n", pctx.Node.Id)
+		fmt.Fprintf(w, "%sNode:\t%s\n", indent, pctx.Node.Id)
 	} else {
-		fmt.Fprint(w, "Node:\tnone\n")
+		fmt.Fprintf(w, "%sNode:\tnone\n", indent)
+	}
+	if pctx.ResolutionRounding != nil {
+		fmt.Fprintf(w, "%sResolution rounding:\t%s\n", indent, pctx.ResolutionRounding)
 	}
 	if len(pctx.NumExcludedNodesByReason) == 0 {
-		fmt.Fprint(w, "Excluded nodes:\tnone\n")
+		fmt.Fprintf(w, "%sExcluded nodes:\tnone\n", indent)
 	} else {
-		fmt.Fprint(w, "Excluded nodes:\n")
-		for reason, count := range pctx.NumExcludedNodesByReason {
-			fmt.Fprintf(w, "\t%d:\t%s\n", count, reason)
+		fmt.Fprintf(w, "%sExcluded nodes:\t\n", indent)
+		// Sorted by decreasing count and then by reason, such that repeated calls produce identical reports.
+		reasons := maps.Keys(pctx.NumExcludedNodesByReason)
+		slices.SortFunc(reasons, func(a, b string) bool {
+			if countA, countB := pctx.NumExcludedNodesByReason[a], pctx.NumExcludedNodesByReason[b]; countA != countB {
+				return countA > countB
+			}
+			return a < b
+		})
+		for _, reason := range reasons {
+			fmt.Fprintf(w, "%s%s%d:\t%s\n", indent, reportIndent, pctx.NumExcludedNodesByReason[reason], reason)
 		}
 	}
-	w.Flush()
-	return sb.String()
 }
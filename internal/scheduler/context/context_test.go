@@ -1,14 +1,19 @@
 package context
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/maps"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
 	armadaslices "github.com/armadaproject/armada/internal/common/slices"
+	"github.com/armadaproject/armada/internal/scheduler/jobdb"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
 )
@@ -32,6 +37,96 @@ func TestNewGangSchedulingContext(t *testing.T) {
 	)
 }
 
+func TestNewGangSchedulingContextNodeSelector(t *testing.T) {
+	tests := map[string]struct {
+		annotations          map[string]string
+		expectedNodeSelector map[string]string
+	}{
+		"no annotation": {},
+		"single label": {
+			annotations:          map[string]string{configuration.GangNodeSelectorAnnotation: "node-pool=gpu-a100"},
+			expectedNodeSelector: map[string]string{"node-pool": "gpu-a100"},
+		},
+		"multiple labels": {
+			annotations:          map[string]string{configuration.GangNodeSelectorAnnotation: "node-pool=gpu-a100,zone=a"},
+			expectedNodeSelector: map[string]string{"node-pool": "gpu-a100", "zone": "a"},
+		},
+		"invalid": {
+			annotations: map[string]string{configuration.GangNodeSelectorAnnotation: "node-pool"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			jobs := testfixtures.WithAnnotationsJobs(tc.annotations, testfixtures.N1CpuJobs("A", testfixtures.TestDefaultPriorityClass, 2))
+			jctxs := make([]*JobSchedulingContext, len(jobs))
+			for i, job := range jobs {
+				jctxs[i] = testJobSchedulingContextFromJob(job)
+			}
+			gctx := NewGangSchedulingContext(jctxs)
+			assert.Equal(t, tc.expectedNodeSelector, gctx.NodeSelector)
+		})
+	}
+}
+
+func TestNewGangSchedulingContextPreferredNodeAffinityTerms(t *testing.T) {
+	tests := map[string]struct {
+		annotations   map[string]string
+		expectedTerms []v1.PreferredSchedulingTerm
+	}{
+		"no annotation": {},
+		"valid": {
+			annotations: map[string]string{
+				configuration.GangPreferredNodeAffinityAnnotation: `[{"weight":10,"preference":{"matchExpressions":[{"key":"zone","operator":"In","values":["near"]}]}}]`,
+			},
+			expectedTerms: []v1.PreferredSchedulingTerm{
+				{
+					Weight: 10,
+					Preference: v1.NodeSelectorTerm{
+						MatchExpressions: []v1.NodeSelectorRequirement{
+							{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"near"}},
+						},
+					},
+				},
+			},
+		},
+		"invalid": {
+			annotations: map[string]string{configuration.GangPreferredNodeAffinityAnnotation: "zone=near"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			jobs := testfixtures.WithAnnotationsJobs(tc.annotations, testfixtures.N1CpuJobs("A", testfixtures.TestDefaultPriorityClass, 2))
+			jctxs := make([]*JobSchedulingContext, len(jobs))
+			for i, job := range jobs {
+				jctxs[i] = testJobSchedulingContextFromJob(job)
+			}
+			gctx := NewGangSchedulingContext(jctxs)
+			assert.Equal(t, tc.expectedTerms, gctx.PreferredNodeAffinityTerms)
+		})
+	}
+}
+
+func TestGangSchedulingContextPodRequirements(t *testing.T) {
+	jctxs := testNSmallCpuJobSchedulingContext("A", testfixtures.TestDefaultPriorityClass, 2)
+	jctxs[0].Req.NodeSelector = map[string]string{"foo": "bar"}
+	gctx := NewGangSchedulingContext(jctxs)
+	assert.Equal(t, []*schedulerobjects.PodRequirements{jctxs[0].Req, jctxs[1].Req}, gctx.PodRequirements())
+
+	gctx.NodeSelector = map[string]string{"gpu": "true"}
+	reqs := gctx.PodRequirements()
+	assert.Equal(t, map[string]string{"foo": "bar", "gpu": "true"}, reqs[0].NodeSelector)
+	assert.Equal(t, map[string]string{"gpu": "true"}, reqs[1].NodeSelector)
+	// The requirements of the jobs themselves should be unchanged.
+	assert.Equal(t, map[string]string{"foo": "bar"}, jctxs[0].Req.NodeSelector)
+	_, conflict := gctx.ConflictingNodeSelectorLabel()
+	assert.False(t, conflict)
+
+	gctx.NodeSelector = map[string]string{"foo": "baz"}
+	label, conflict := gctx.ConflictingNodeSelectorLabel()
+	assert.True(t, conflict)
+	assert.Equal(t, "foo", label)
+}
+
 func TestSchedulingContextAccounting(t *testing.T) {
 	sctx := NewSchedulingContext(
 		"executor",
@@ -73,6 +168,195 @@ func TestSchedulingContextAccounting(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSchedulingContextClone(t *testing.T) {
+	sctx := NewSchedulingContext(
+		"executor",
+		"pool",
+		testfixtures.TestPriorityClasses,
+		testfixtures.TestDefaultPriorityClass,
+		map[string]float64{"cpu": 1},
+		schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+	)
+	require.NoError(t, sctx.AddQueueSchedulingContext("A", 1, nil))
+	scheduled := testNSmallCpuJobSchedulingContext("A", testfixtures.TestDefaultPriorityClass, 1)
+	_, err := sctx.AddGangSchedulingContext(NewGangSchedulingContext(scheduled))
+	require.NoError(t, err)
+	expected := sctx.ReportString(4)
+	expectedAllocated := sctx.AllocatedByQueueAndPriority()
+
+	// Adding and evicting jobs via the copy leaves the original unchanged.
+	clone := sctx.Clone()
+	assert.Same(t, clone, clone.QueueSchedulingContexts["A"].SchedulingContext)
+	_, err = clone.AddGangSchedulingContext(
+		NewGangSchedulingContext(testNSmallCpuJobSchedulingContext("A", testfixtures.TestDefaultPriorityClass, 2)),
+	)
+	require.NoError(t, err)
+	_, err = clone.EvictJob(scheduled[0].Job)
+	require.NoError(t, err)
+	assert.Equal(t, 2, clone.NumScheduledJobs)
+
+	assert.Equal(t, expected, sctx.ReportString(4))
+	assert.Equal(t, 1, sctx.NumScheduledJobs)
+	assert.Len(t, sctx.QueueSchedulingContexts["A"].SuccessfulJobSchedulingContexts, 1)
+	actualAllocated := sctx.AllocatedByQueueAndPriority()
+	assert.True(t, expectedAllocated["A"].Equal(actualAllocated["A"]))
+}
+
+func TestQueueSchedulingContextFairShare(t *testing.T) {
+	sctx := NewSchedulingContext(
+		"executor",
+		"pool",
+		testfixtures.TestPriorityClasses,
+		testfixtures.TestDefaultPriorityClass,
+		map[string]float64{"cpu": 1},
+		schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+	)
+	priorityFactorByQueue := map[string]float64{"A": 1, "B": 3}
+	for queue, priorityFactor := range priorityFactorByQueue {
+		err := sctx.AddQueueSchedulingContext(queue, priorityFactor, nil)
+		require.NoError(t, err)
+	}
+	assert.InDelta(t, 0.75, sctx.QueueSchedulingContexts["A"].FairShare(), 1e-9)
+	assert.InDelta(t, 0.25, sctx.QueueSchedulingContexts["B"].FairShare(), 1e-9)
+	assert.Equal(t, 0.0, (&QueueSchedulingContext{PriorityFactor: 1}).FairShare())
+}
+
+func TestQueueSchedulingContextUnschedulableReasonCounts(t *testing.T) {
+	qctx := &QueueSchedulingContext{UnsuccessfulJobSchedulingContexts: make(map[string]*JobSchedulingContext)}
+	assert.Empty(t, qctx.UnschedulableReasonCounts())
+
+	numJobsByReason := map[string]int{"insufficient cpu": 40, "node selector unmatched": 3, "a reason": 3}
+	for reason, numJobs := range numJobsByReason {
+		for i := 0; i < numJobs; i++ {
+			jobId := fmt.Sprintf("%s-%d", reason, i)
+			qctx.UnsuccessfulJobSchedulingContexts[jobId] = &JobSchedulingContext{JobId: jobId, UnschedulableReason: reason}
+		}
+	}
+	assert.Equal(
+		t,
+		[]UnschedulableReasonCount{
+			{Reason: "insufficient cpu", NumJobs: 40},
+			{Reason: "a reason", NumJobs: 3},
+			{Reason: "node selector unmatched", NumJobs: 3},
+		},
+		qctx.UnschedulableReasonCounts(),
+	)
+}
+
+func TestPodSchedulingContextWriteReportIsDeterministic(t *testing.T) {
+	pctx := &PodSchedulingContext{NumExcludedNodesByReason: make(map[string]int)}
+	for i := 0; i < 20; i++ {
+		pctx.NumExcludedNodesByReason[fmt.Sprintf("reason%02d", i)] = i % 3
+	}
+	render := func() string {
+		var sb strings.Builder
+		pctx.WriteReport(&sb, "")
+		return sb.String()
+	}
+	expected := render()
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, expected, render())
+	}
+	// Reasons are sorted by decreasing count and then by name.
+	assert.Contains(t, expected, "Excluded nodes:\t\n  2:\treason02\n  2:\treason05\n")
+	assert.True(t, strings.HasSuffix(expected, "  0:\treason15\n  0:\treason18\n"))
+	for _, line := range strings.Split(strings.TrimSuffix(expected, "\n"), "\n") {
+		assert.Equal(t, 1, strings.Count(line, "\t"), line)
+	}
+}
+
+func TestQueueSchedulingContextFractionOfFairShare(t *testing.T) {
+	// Queue A uses mostly cpu and queue B mostly gpu.
+	allocatedByQueue := map[string]schedulerobjects.QuantityByPriorityAndResourceType{
+		"A": {0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("50")}}},
+		"B": {0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("10"), "gpu": resource.MustParse("5")}}},
+	}
+	tests := map[string]struct {
+		fairnessModel                      string
+		resourceScarcity                   map[string]float64
+		expectedUsageFractionByQueue       map[string]float64
+		expectedFractionOfFairShareByQueue map[string]float64
+	}{
+		"asset": {
+			fairnessModel:    FairnessModelAsset,
+			resourceScarcity: map[string]float64{"cpu": 1, "gpu": 10, "memory": 0},
+			// Weighted total is 100 * 1 + 10 * 10 = 200.
+			expectedUsageFractionByQueue:       map[string]float64{"A": 50.0 / 200, "B": (10.0 + 5*10) / 200},
+			expectedFractionOfFairShareByQueue: map[string]float64{"A": 0.5, "B": 0.6},
+		},
+		"dominant resource": {
+			fairnessModel:    FairnessModelDominantResource,
+			resourceScarcity: map[string]float64{"cpu": 1, "gpu": 1, "memory": 0},
+			// Queue A uses half of all cpu and queue B half of all gpus.
+			expectedUsageFractionByQueue:       map[string]float64{"A": 0.5, "B": 0.5},
+			expectedFractionOfFairShareByQueue: map[string]float64{"A": 1, "B": 1},
+		},
+		"weighted dominant resource": {
+			fairnessModel:    FairnessModelDominantResource,
+			resourceScarcity: map[string]float64{"cpu": 1, "gpu": 10, "memory": 0},
+			// Fractions of cpu count a tenth as much as fractions of gpu.
+			// Queue B uses a tenth of all cpu, which counts as a hundredth, and half of all gpus.
+			expectedUsageFractionByQueue:       map[string]float64{"A": 0.05, "B": 0.5},
+			expectedFractionOfFairShareByQueue: map[string]float64{"A": 0.1, "B": 1},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sctx := NewSchedulingContext(
+				"executor",
+				"pool",
+				testfixtures.TestPriorityClasses,
+				testfixtures.TestDefaultPriorityClass,
+				tc.resourceScarcity,
+				schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+					"cpu":    resource.MustParse("100"),
+					"gpu":    resource.MustParse("10"),
+					"memory": resource.MustParse("1Ki"),
+				}},
+			)
+			require.NoError(t, sctx.SetFairnessModel(tc.fairnessModel))
+			for queue, allocated := range allocatedByQueue {
+				require.NoError(t, sctx.AddQueueSchedulingContext(queue, 1, allocated))
+			}
+			for queue, expected := range tc.expectedUsageFractionByQueue {
+				assert.InDelta(t, expected, sctx.UsageFraction(sctx.QueueSchedulingContexts[queue].Allocated), 1e-9, queue)
+			}
+			for queue, expected := range tc.expectedFractionOfFairShareByQueue {
+				assert.InDelta(t, expected, sctx.QueueSchedulingContexts[queue].FractionOfFairShare(), 1e-9, queue)
+			}
+		})
+	}
+}
+
+func TestSchedulingContextSetFairnessModel(t *testing.T) {
+	sctx := NewSchedulingContext("executor", "pool", testfixtures.TestPriorityClasses, testfixtures.TestDefaultPriorityClass, nil, schedulerobjects.ResourceList{})
+	assert.Equal(t, FairnessModelAsset, sctx.FairnessModel)
+	require.NoError(t, sctx.SetFairnessModel(FairnessModelDominantResource))
+	assert.Equal(t, FairnessModelDominantResource, sctx.FairnessModel)
+	assert.Error(t, sctx.SetFairnessModel("notAFairnessModel"))
+	assert.Equal(t, FairnessModelDominantResource, sctx.FairnessModel)
+}
+
+func TestSchedulingContextDefaultResourceScarcity(t *testing.T) {
+	totalResources := schedulerobjects.ResourceList{
+		Resources: map[string]resource.Quantity{
+			"cpu":    resource.MustParse("1"),
+			"memory": resource.MustParse("1Gi"),
+		},
+	}
+	for name, scarcity := range map[string]map[string]float64{"nil": nil, "empty": {}} {
+		t.Run(name, func(t *testing.T) {
+			sctx := NewSchedulingContext("executor", "pool", testfixtures.TestPriorityClasses, testfixtures.TestDefaultPriorityClass, scarcity, totalResources)
+			assert.Equal(t, map[string]float64{"cpu": 1, "memory": 1}, sctx.ResourceScarcity)
+		})
+	}
+	// Resource types missing from a non-empty map aren't defaulted, i.e., memory doesn't count towards usage.
+	scarcity := map[string]float64{"cpu": 1}
+	sctx := NewSchedulingContext("executor", "pool", testfixtures.TestPriorityClasses, testfixtures.TestDefaultPriorityClass, scarcity, totalResources)
+	assert.Equal(t, scarcity, sctx.ResourceScarcity)
+	assert.Equal(t, 0.0, sctx.UsageFraction(schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"memory": resource.MustParse("1Gi")}}))
+}
+
 func testNSmallCpuJobSchedulingContext(queue, priorityClassName string, n int) []*JobSchedulingContext {
 	rv := make([]*JobSchedulingContext, n)
 	for i := 0; i < n; i++ {
@@ -82,7 +366,10 @@ func testNSmallCpuJobSchedulingContext(queue, priorityClassName string, n int) [
 }
 
 func testSmallCpuJobSchedulingContext(queue, priorityClassName string) *JobSchedulingContext {
-	job := testfixtures.Test1CpuJob(queue, priorityClassName)
+	return testJobSchedulingContextFromJob(testfixtures.Test1CpuJob(queue, priorityClassName))
+}
+
+func testJobSchedulingContextFromJob(job *jobdb.Job) *JobSchedulingContext {
 	return &JobSchedulingContext{
 		ExecutorId: "executor",
 		NumNodes:   1,
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// DrainingExecutors is the set of executors being drained, e.g., ahead of being decommissioned.
+// No new gangs are scheduled onto the nodes of a draining executor.
+// Jobs already running on those nodes are unaffected and may be re-scheduled onto them after being evicted,
+// such that draining an executor never causes jobs to be preempted.
+//
+// DrainingExecutors is safe for concurrent use; the set is read once per scheduling round and written rarely.
+type DrainingExecutors struct {
+	// Ids of draining executors.
+	// Replaced rather than mutated, such that readers never need to take mu.
+	executorIdsP atomic.Pointer[map[string]bool]
+	// Protects against concurrent and dirty writes.
+	mu sync.Mutex
+}
+
+func NewDrainingExecutors() *DrainingExecutors {
+	d := &DrainingExecutors{}
+	executorIds := make(map[string]bool)
+	d.executorIdsP.Store(&executorIds)
+	return d
+}
+
+// SetDraining marks the executor with the given id as draining if draining is true,
+// and otherwise marks it as no longer draining.
+func (d *DrainingExecutors) SetDraining(executorId string, draining bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	executorIds := maps.Clone(*d.executorIdsP.Load())
+	if draining {
+		executorIds[executorId] = true
+	} else {
+		delete(executorIds, executorId)
+	}
+	d.executorIdsP.Store(&executorIds)
+}
+
+// IsDraining returns true if the executor with the given id is draining.
+// A nil DrainingExecutors contains no executors.
+func (d *DrainingExecutors) IsDraining(executorId string) bool {
+	if d == nil {
+		return false
+	}
+	return (*d.executorIdsP.Load())[executorId]
+}
+
+// ExecutorIds returns the sorted ids of all draining executors.
+func (d *DrainingExecutors) ExecutorIds() []string {
+	if d == nil {
+		return nil
+	}
+	executorIds := maps.Keys(*d.executorIdsP.Load())
+	slices.Sort(executorIds)
+	return executorIds
+}
@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainingExecutors(t *testing.T) {
+	d := NewDrainingExecutors()
+	assert.False(t, d.IsDraining("foo"))
+	assert.Empty(t, d.ExecutorIds())
+
+	d.SetDraining("foo", true)
+	d.SetDraining("bar", true)
+	d.SetDraining("bar", true)
+	assert.True(t, d.IsDraining("foo"))
+	assert.True(t, d.IsDraining("bar"))
+	assert.Equal(t, []string{"bar", "foo"}, d.ExecutorIds())
+
+	d.SetDraining("foo", false)
+	d.SetDraining("baz", false)
+	assert.False(t, d.IsDraining("foo"))
+	assert.Equal(t, []string{"bar"}, d.ExecutorIds())
+
+	// A nil set contains no executors, such that schedulers need not check whether one was provided.
+	var nilDrainingExecutors *DrainingExecutors
+	assert.False(t, nilDrainingExecutors.IsDraining("bar"))
+	assert.Empty(t, nilDrainingExecutors.ExecutorIds())
+}
+
+func TestDrainingExecutorsConcurrency(t *testing.T) {
+	d := NewDrainingExecutors()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		executorId := fmt.Sprintf("executor-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				d.SetDraining(executorId, j%2 == 0)
+				d.IsDraining(executorId)
+				d.ExecutorIds()
+			}
+		}()
+	}
+	wg.Wait()
+	// Each executor was last marked as no longer draining.
+	assert.Empty(t, d.ExecutorIds())
+}
@@ -3,10 +3,18 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/hashicorp/go-memdb"
 	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/common/util"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 	schedulerconstraints "github.com/armadaproject/armada/internal/scheduler/constraints"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/interfaces"
@@ -14,13 +22,52 @@ import (
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
 
-// GangScheduler schedules one gang at a time. GangScheduler is not aware of queues.
+// Rejection reason used when the deadline of the context passed to GangScheduler.Schedule
+// expires before a placement has been found for all jobs in the gang.
+var gangSchedulingDeadlineExceededRejectionReason = &schedulerconstraints.RejectionReason{
+	Code:    schedulerconstraints.RejectionCodeDeadlineExceeded,
+	Message: fmt.Sprintf("gave up looking for nodes for the gang: %s", context.DeadlineExceeded),
+}
+
+// Rejection reason used when preemption is enabled and the gang only fits if jobs that may not be preempted are preempted.
+var insufficientPreemptibleCapacityRejectionReason = &schedulerconstraints.RejectionReason{
+	Code:    schedulerconstraints.RejectionCodeInsufficientPreemptibleCapacity,
+	Message: "gang only fits if jobs that may not be preempted are preempted",
+}
+
+// Rejection reason used for new gangs when the executor of the scheduling context is draining.
+var executorDrainingRejectionReason = &schedulerconstraints.RejectionReason{
+	Code:    schedulerconstraints.RejectionCodeExecutorDraining,
+	Message: schedulerconstraints.UnschedulableReasonExecutorDraining,
+}
+
+// Rejection reason used for new gangs of a paused queue.
+var queuePausedRejectionReason = &schedulerconstraints.RejectionReason{
+	Code:    schedulerconstraints.RejectionCodeQueuePaused,
+	Message: schedulerconstraints.UnschedulableReasonQueuePaused,
+}
+
+// GangScheduler schedules one gang at a time.
+// GangScheduler is not aware of queues, except for rejecting gangs of paused queues.
 type GangScheduler struct {
 	constraints       schedulerconstraints.SchedulingConstraints
 	schedulingContext *schedulercontext.SchedulingContext
 	nodeDb            *nodedb.NodeDb
 	// If true, the unsuccessfulSchedulingKeys check is omitted.
 	skipUnsuccessfulSchedulingKeyCheck bool
+	// If true, gangs are scheduled using a copy of the SchedulingContext and a snapshot of the NodeDb; see DryRun.
+	dryRun bool
+	// If non-nil, jobs running on the nodes selected for a gang may be preempted to make room for it.
+	// Used to look up the jobs allocated to those nodes.
+	preemptionJobRepo JobRepository
+	// Only jobs with priority strictly below this threshold may be preempted.
+	preemptionPriorityThreshold int32
+	// Jobs whose active run started less than this long before the start of the scheduling round aren't preempted.
+	preemptionMinimumRuntime time.Duration
+	// If the executor of the scheduling context is in this set, no new gangs are scheduled onto its nodes.
+	drainingExecutors *DrainingExecutors
+	// No new gangs are scheduled from queues in this set.
+	pausedQueues *PausedQueues
 }
 
 func NewGangScheduler(
@@ -39,23 +86,132 @@ func (sch *GangScheduler) SkipUnsuccessfulSchedulingKeyCheck() {
 	sch.skipUnsuccessfulSchedulingKeyCheck = true
 }
 
-func (sch *GangScheduler) Schedule(ctx context.Context, gctx *schedulercontext.GangSchedulingContext) (ok bool, unschedulableReason string, err error) {
-	// Exit immediately if this is a new gang and we've hit any round limits.
+// DryRun configures the GangScheduler to only report whether gangs could be scheduled.
+// Placement is performed as usual, and the job scheduling contexts of the gang are updated accordingly,
+// but on a copy of the SchedulingContext and within a transaction over a snapshot of the NodeDb; see NodeDb.SnapshotTxn.
+// Both are discarded before Schedule returns. Hence, neither the SchedulingContext nor the NodeDb is ever modified.
+//
+// The NodeDb may be shared with other schedulers. The SchedulingContext is only read,
+// but must not be modified concurrently while it's being copied.
+func (sch *GangScheduler) DryRun() {
+	sch.dryRun = true
+}
+
+// EnablePreemption configures the GangScheduler to preempt jobs to make room for new gangs.
+// If placing a gang oversubscribes any of the nodes it's assigned to, jobs on those nodes are preempted until that is no longer the case.
+// Only preemptible jobs with priority lower than both priorityThreshold and the priority of the gang are considered,
+// and a minimal set of such jobs is preempted on each node.
+// If the oversubscription can't be resolved in this way, the gang is not scheduled and no jobs are preempted.
+//
+// Preempted jobs are recorded as evicted in the SchedulingContext and returned by Schedule, such that callers can act on them.
+// jobRepo is used to look up jobs allocated to nodes; it must contain all jobs running on the nodes in the NodeDb.
+func (sch *GangScheduler) EnablePreemption(jobRepo JobRepository, priorityThreshold int32) {
+	sch.preemptionJobRepo = jobRepo
+	sch.preemptionPriorityThreshold = priorityThreshold
+}
+
+// SetDrainingExecutors sets the executors onto whose nodes no new gangs are scheduled; see DrainingExecutors.
+// Since each scheduling context relates to a single executor, all nodes in the NodeDb belong to the same executor.
+// Hence, if that executor is draining, new gangs are rejected without considering any nodes.
+func (sch *GangScheduler) SetDrainingExecutors(drainingExecutors *DrainingExecutors) {
+	sch.drainingExecutors = drainingExecutors
+}
+
+// SetPausedQueues sets the queues from which no new gangs are scheduled; see PausedQueues.
+// New gangs of a paused queue are rejected without considering any nodes.
+func (sch *GangScheduler) SetPausedQueues(pausedQueues *PausedQueues) {
+	sch.pausedQueues = pausedQueues
+}
+
+// SetPreemptionMinimumRuntime protects jobs from being preempted to make room for a gang until their active run has
+// been running for at least minimumRuntime as of the start of the scheduling round, such that jobs that have only just
+// started aren't repeatedly preempted. Protected jobs are treated as non-preemptible for the round.
+// Run start times are read from jobs implementing ActiveRunStartTime, e.g., *jobdb.Job;
+// jobs that don't, or that have no run, aren't protected. See EnablePreemption.
+func (sch *GangScheduler) SetPreemptionMinimumRuntime(minimumRuntime time.Duration) {
+	sch.preemptionMinimumRuntime = minimumRuntime
+}
+
+// Schedule tries to schedule the gang.
+// If the gang is scheduled, the returned map contains the id of the node each scheduled job was assigned to, indexed by job id;
+// the node id is also recorded on the job scheduling context of each scheduled job.
+// If preemption is enabled, the returned slice contains any jobs preempted to make room for the gang,
+// and the map also contains the id of the node each of these jobs was preempted from; see EnablePreemption.
+// If the gang can't be scheduled, the returned string is a human-readable explanation;
+// the constraint that prevented the gang from being scheduled is also recorded on its job scheduling contexts.
+func (sch *GangScheduler) Schedule(ctx context.Context, gctx *schedulercontext.GangSchedulingContext) (
+	ok bool,
+	nodeIdByJobId map[string]string,
+	preemptedJobs []interfaces.LegacySchedulerJob,
+	unschedulableReason string,
+	err error,
+) {
+	if sch.dryRun {
+		// All changes to the scheduling context are made to a copy that is discarded once the gang has been scheduled.
+		dryRunSch := *sch
+		dryRunSch.schedulingContext = sch.schedulingContext.Clone()
+		return dryRunSch.schedule(ctx, gctx)
+	}
+	return sch.schedule(ctx, gctx)
+}
+
+func (sch *GangScheduler) schedule(ctx context.Context, gctx *schedulercontext.GangSchedulingContext) (
+	ok bool,
+	nodeIdByJobId map[string]string,
+	preemptedJobs []interfaces.LegacySchedulerJob,
+	unschedulableReason string,
+	err error,
+) {
+	var rejectionReason *schedulerconstraints.RejectionReason
+
+	// Exit immediately if this is a new gang and we've hit any round limits, the executor is draining, or the queue is paused.
 	if !gctx.AllJobsEvicted {
-		if ok, unschedulableReason, err = sch.constraints.CheckRoundConstraints(sch.schedulingContext); err != nil || !ok {
+		if sch.drainingExecutors.IsDraining(sch.schedulingContext.ExecutorId) {
+			ok, rejectionReason = false, executorDrainingRejectionReason
+		} else if sch.pausedQueues.IsPaused(gctx.Queue) {
+			ok, rejectionReason = false, queuePausedRejectionReason
+		} else {
+			ok, rejectionReason, err = sch.constraints.CheckRoundConstraints(sch.schedulingContext)
+		}
+		if err != nil || !ok {
+			if rejectionReason != nil {
+				// Round limits, draining, and pausing apply to all gangs alike (of the queue, in the case of pausing).
+				// Hence, the reason isn't qualified with the gang id, such that callers can recognise it,
+				// e.g., via IsTerminalUnschedulableReason.
+				unschedulableReason = rejectionReason.Message
+				for _, jctx := range gctx.JobSchedulingContexts {
+					setRejectionReason(jctx, rejectionReason)
+				}
+			}
 			return
 		}
 	}
 
-	// This deferred function ensures unschedulable jobs are registered as such
-	// and sets sch.queueScheduledInPreviousCall.
+	// This deferred function records the nodes scheduled jobs were assigned to
+	// and ensures unschedulable jobs are registered as such.
 	gangAddedToSchedulingContext := false
+	var preempted []preemptionCandidate
 	defer func() {
 		// Do nothing if an error occurred.
 		if err != nil {
 			return
 		}
-		if !ok {
+		if ok {
+			nodeIdByJobId = make(map[string]string, len(gctx.JobSchedulingContexts))
+			for _, jctx := range gctx.JobSchedulingContexts {
+				// Jobs in partially scheduled gangs that couldn't be scheduled aren't assigned to any node.
+				if !jctx.IsSuccessful() || jctx.PodSchedulingContext == nil || jctx.PodSchedulingContext.Node == nil {
+					continue
+				}
+				jctx.NodeId = jctx.PodSchedulingContext.Node.Id
+				nodeIdByJobId[jctx.JobId] = jctx.NodeId
+			}
+			for _, candidate := range preempted {
+				preemptedJobs = append(preemptedJobs, candidate.job)
+				nodeIdByJobId[candidate.job.GetId()] = candidate.nodeId
+			}
+		} else {
+			unschedulableReason = gangUnschedulableReason(gctx, rejectionReason.Message)
 			// Register the job as unschedulable. If the job was added to the context, remove it first.
 			if gangAddedToSchedulingContext {
 				jobs := util.Map(gctx.JobSchedulingContexts, func(jctx *schedulercontext.JobSchedulingContext) interfaces.LegacySchedulerJob { return jctx.Job })
@@ -64,7 +220,7 @@ func (sch *GangScheduler) Schedule(ctx context.Context, gctx *schedulercontext.G
 				}
 			}
 			for _, jctx := range gctx.JobSchedulingContexts {
-				jctx.UnschedulableReason = unschedulableReason
+				setRejectionReason(jctx, rejectionReason)
 			}
 			if _, err = sch.schedulingContext.AddGangSchedulingContext(gctx); err != nil {
 				return
@@ -74,7 +230,7 @@ func (sch *GangScheduler) Schedule(ctx context.Context, gctx *schedulercontext.G
 			//
 			// Only record unfeasible scheduling keys for single-job gangs.
 			// Since a gang may be unschedulable even if all its members are individually schedulable.
-			if !sch.skipUnsuccessfulSchedulingKeyCheck && len(gctx.JobSchedulingContexts) == 1 {
+			if !sch.skipUnsuccessfulSchedulingKeyCheck && !sch.dryRun && len(gctx.JobSchedulingContexts) == 1 {
 				jctx := gctx.JobSchedulingContexts[0]
 				schedulingKey := sch.schedulingContext.SchedulingKeyFromLegacySchedulerJob(jctx.Job)
 				if _, ok := sch.schedulingContext.UnfeasibleSchedulingKeys[schedulingKey]; !ok {
@@ -94,10 +250,13 @@ func (sch *GangScheduler) Schedule(ctx context.Context, gctx *schedulercontext.G
 		// Check that the job is large enough for this executor.
 		// This check needs to be here, since it relates to a specific job.
 		// Only perform limit checks for new jobs to avoid preempting jobs if, e.g., MinimumJobSize changes.
-		if ok, unschedulableReason = requestIsLargeEnough(gctx.TotalResourceRequests, sch.constraints.MinimumJobSize); !ok {
+		if ok, rejectionReason = requestIsLargeEnough(gctx.TotalResourceRequests, sch.constraints.MinimumJobSize); !ok {
+			return
+		}
+		if ok, rejectionReason = jobsAreLargeEnoughForPool(gctx.JobSchedulingContexts, sch.constraints.PoolMinimumJobSize); !ok {
 			return
 		}
-		if ok, unschedulableReason, err = sch.constraints.CheckPerQueueAndPriorityClassConstraints(
+		if ok, rejectionReason, err = sch.constraints.CheckPerQueueAndPriorityClassConstraints(
 			sch.schedulingContext,
 			gctx.Queue,
 			gctx.PriorityClassName,
@@ -105,19 +264,167 @@ func (sch *GangScheduler) Schedule(ctx context.Context, gctx *schedulercontext.G
 			return
 		}
 	}
-	if ok, unschedulableReason, err = sch.trySchedule(ctx, gctx); err != nil || ok {
+	if label, conflict := gctx.ConflictingNodeSelectorLabel(); conflict {
+		ok = false
+		rejectionReason = &schedulerconstraints.RejectionReason{
+			Code:    schedulerconstraints.RejectionCodeGangNodeSelectorConflict,
+			Message: fmt.Sprintf("node selector of the gang and of one of its jobs require different values for label %s", label),
+		}
+		return
+	}
+	if (gctx.IsPartial() || gctx.RequireUniqueNodes) && !gctx.AllJobsEvicted {
+		// Only new gangs may be scheduled partially or spread across nodes;
+		// evicted gangs are re-scheduled onto the nodes they were evicted from.
+		ok, preempted, rejectionReason, err = sch.tryScheduleAtLeast(ctx, gctx, gctx.MinimumCardinality)
+		return
+	}
+	if len(gctx.JobSchedulingContexts) == 1 {
+		ok, preempted, rejectionReason, err = sch.tryScheduleSingleJob(ctx, gctx)
 		return
 	}
+	ok, preempted, rejectionReason, err = sch.trySchedule(ctx, gctx)
 	return
 }
 
-func (sch *GangScheduler) trySchedule(ctx context.Context, gctx *schedulercontext.GangSchedulingContext) (bool, string, error) {
-	pctxs, ok, err := sch.nodeDb.ScheduleMany(gctx.PodRequirements())
+// txn returns a write transaction within which to bind gangs to nodes.
+// In dry-run mode, the transaction is over a snapshot of the NodeDb, such that committing it leaves the NodeDb unchanged.
+func (sch *GangScheduler) txn() *memdb.Txn {
+	if sch.dryRun {
+		return sch.nodeDb.SnapshotTxn()
+	}
+	return sch.nodeDb.Txn(true)
+}
+
+// gangUnschedulableReason returns reason qualified with the id of the gang, if any,
+// such that the reasons of different gangs from the same queue can be told apart.
+func gangUnschedulableReason(gctx *schedulercontext.GangSchedulingContext, reason string) string {
+	if gctx.GangId == "" {
+		return reason
+	}
+	return fmt.Sprintf("gang %s: %s", gctx.GangId, reason)
+}
+
+func setRejectionReason(jctx *schedulercontext.JobSchedulingContext, rejectionReason *schedulerconstraints.RejectionReason) {
+	jctx.UnschedulableReason = rejectionReason.Message
+	jctx.UnschedulableReasonCode = string(rejectionReason.Code)
+	jctx.UnschedulableResource = rejectionReason.Resource
+}
+
+// tryScheduleAtLeast tries to schedule each job in the gang independently.
+// If at least minimumCardinality jobs can be scheduled, the gang is considered scheduled;
+// jobs that could not be scheduled are marked as unsuccessful in the scheduling context.
+// Otherwise, no jobs are bound to nodes.
+// If gctx.RequireUniqueNodes is true, nodes already selected for a job in the gang are not considered for other jobs.
+func (sch *GangScheduler) tryScheduleAtLeast(
+	ctx context.Context,
+	gctx *schedulercontext.GangSchedulingContext,
+	minimumCardinality int,
+) (bool, []preemptionCandidate, *schedulerconstraints.RejectionReason, error) {
+	txn := sch.txn()
+	defer txn.Abort()
+	numScheduled := 0
+	var selectedNodeIds []string
+	reqs := gctx.PodRequirements()
+	for i, jctx := range gctx.JobSchedulingContexts {
+		if err := ctx.Err(); err != nil {
+			// Roll back any jobs bound to nodes so far; the transaction is aborted on return.
+			for _, jctx := range gctx.JobSchedulingContexts {
+				if jctx.PodSchedulingContext != nil {
+					jctx.PodSchedulingContext.Node = nil
+				}
+			}
+			if errors.Is(err, context.DeadlineExceeded) {
+				return false, nil, gangSchedulingDeadlineExceededRejectionReason, nil
+			}
+			return false, nil, nil, errors.WithStack(err)
+		}
+		req := reqs[i]
+		if gctx.RequireUniqueNodes && len(selectedNodeIds) > 0 {
+			req = withNodeIdNotIn(req, selectedNodeIds)
+		}
+		pctx, err := sch.nodeDb.SelectAndBindNodeToPodWithTxn(txn, req)
+		if err != nil {
+			return false, nil, nil, err
+		}
+		jctx.PodSchedulingContext = pctx
+		jctx.NumNodes = pctx.NumNodes
+		if pctx.Node != nil {
+			numScheduled++
+			selectedNodeIds = append(selectedNodeIds, pctx.Node.Id)
+		}
+	}
+	if numScheduled < minimumCardinality {
+		rejectionReason := &schedulerconstraints.RejectionReason{
+			Code: schedulerconstraints.RejectionCodeInsufficientNodeCapacity,
+			Message: fmt.Sprintf(
+				"only %d out of %d jobs in the gang fit, but the minimum is %d",
+				numScheduled, len(gctx.JobSchedulingContexts), minimumCardinality,
+			),
+		}
+		if gctx.RequireUniqueNodes {
+			rejectionReason.Message += " (each job must be scheduled onto a different node)"
+		}
+		rejectionReason = sch.withResolutionRounding(txn, rejectionReason, gctx.JobSchedulingContexts)
+		for _, jctx := range gctx.JobSchedulingContexts {
+			jctx.PodSchedulingContext.Node = nil
+		}
+		return false, nil, rejectionReason, nil
+	}
+	pctxs := pctxsFromJobSchedulingContexts(gctx.JobSchedulingContexts)
+	if ok, rejectionReason := checkMaxNodeSpan(gctx, pctxs); !ok {
+		clearNodes(pctxs)
+		return false, nil, rejectionReason, nil
+	}
+	preempted, ok, err := sch.preemptToFit(txn, gctx, pctxs)
 	if err != nil {
-		return false, "", err
+		return false, nil, nil, err
+	} else if !ok {
+		for _, jctx := range gctx.JobSchedulingContexts {
+			jctx.PodSchedulingContext.Node = nil
+		}
+		return false, nil, insufficientPreemptibleCapacityRejectionReason, nil
+	}
+
+	// Mark jobs that didn't fit as unsuccessful.
+	// This is done before committing, such that the nodes considered for these jobs can be explained using txn.
+	for _, jctx := range gctx.JobSchedulingContexts {
+		if jctx.PodSchedulingContext.Node != nil {
+			continue
+		}
+		if _, err := sch.schedulingContext.EvictJob(jctx.Job); err != nil {
+			return false, nil, nil, err
+		}
+		setRejectionReason(jctx, sch.withResolutionRounding(
+			txn,
+			&schedulerconstraints.RejectionReason{
+				Code:    schedulerconstraints.RejectionCodeInsufficientNodeCapacity,
+				Message: "job does not fit on any node; gang scheduled without it",
+			},
+			[]*schedulercontext.JobSchedulingContext{jctx},
+		))
+		if _, err := sch.schedulingContext.AddJobSchedulingContext(jctx); err != nil {
+			return false, nil, nil, err
+		}
+	}
+	txn.Commit()
+	if err := sch.recordPreemptedJobs(preempted); err != nil {
+		return false, nil, nil, err
+	}
+	return true, preempted, nil, nil
+}
+
+func (sch *GangScheduler) trySchedule(ctx context.Context, gctx *schedulercontext.GangSchedulingContext) (bool, []preemptionCandidate, *schedulerconstraints.RejectionReason, error) {
+	txn := sch.txn()
+	defer txn.Abort()
+	pctxs, ok, err := sch.nodeDb.ScheduleManyWithTxn(ctx, txn, gctx.PodRequirements())
+	if errors.Is(err, context.DeadlineExceeded) {
+		clearNodes(pctxs)
+		return false, nil, gangSchedulingDeadlineExceededRejectionReason, nil
+	} else if err != nil {
+		return false, nil, nil, err
 	}
 	if len(pctxs) > len(gctx.JobSchedulingContexts) {
-		return false, "", errors.Errorf(
+		return false, nil, nil, errors.Errorf(
 			"received %d pod scheduling context(s), but gang has cardinality %d",
 			len(pctxs), len(gctx.JobSchedulingContexts),
 		)
@@ -127,26 +434,435 @@ func (sch *GangScheduler) trySchedule(ctx context.Context, gctx *schedulercontex
 		gctx.JobSchedulingContexts[i].NumNodes = pctx.NumNodes
 	}
 	if !ok {
-		unschedulableReason := ""
+		rejectionReason := &schedulerconstraints.RejectionReason{Code: schedulerconstraints.RejectionCodeInsufficientNodeCapacity}
 		if len(gctx.JobSchedulingContexts) > 1 {
-			unschedulableReason = "at least one job in the gang does not fit on any node"
+			rejectionReason.Message = "at least one job in the gang does not fit on any node"
 		} else {
-			unschedulableReason = "job does not fit on any node"
+			rejectionReason.Message = "job does not fit on any node"
+		}
+		rejectionReason = sch.withResolutionRounding(txn, rejectionReason, gctx.JobSchedulingContexts[:len(pctxs)])
+		// The transaction is aborted on return; clear the bindings made so far.
+		clearNodes(pctxs)
+		return false, nil, rejectionReason, nil
+	}
+	if ok, rejectionReason := checkMaxNodeSpan(gctx, pctxs); !ok {
+		clearNodes(pctxs)
+		return false, nil, rejectionReason, nil
+	}
+	return sch.preemptToFitAndCommit(txn, gctx, pctxs)
+}
+
+// checkMaxNodeSpan returns false and a reason if the pods of a new gang have been bound to more distinct nodes
+// than allowed by gctx.MaxNodeSpan. Evicted gangs are exempt, such that changing the limit doesn't cause them to be preempted.
+func checkMaxNodeSpan(gctx *schedulercontext.GangSchedulingContext, pctxs []*schedulercontext.PodSchedulingContext) (bool, *schedulerconstraints.RejectionReason) {
+	if gctx.MaxNodeSpan <= 0 || gctx.AllJobsEvicted {
+		return true, nil
+	}
+	nodeIds := make(map[string]bool)
+	for _, pctx := range pctxs {
+		if pctx != nil && pctx.Node != nil {
+			nodeIds[pctx.Node.Id] = true
+		}
+	}
+	if len(nodeIds) <= gctx.MaxNodeSpan {
+		return true, nil
+	}
+	return false, &schedulerconstraints.RejectionReason{
+		Code:    schedulerconstraints.RejectionCodeGangMaxNodeSpanExceeded,
+		Message: fmt.Sprintf("gang was placed across %d nodes, but may span at most %d", len(nodeIds), gctx.MaxNodeSpan),
+	}
+}
+
+// tryScheduleSingleJob is equivalent to trySchedule for gangs made up of a single job,
+// which make up the bulk of the jobs in most rounds.
+// It binds the job via the NodeDb directly, thus avoiding the per-gang bookkeeping of trySchedule.
+func (sch *GangScheduler) tryScheduleSingleJob(ctx context.Context, gctx *schedulercontext.GangSchedulingContext) (bool, []preemptionCandidate, *schedulerconstraints.RejectionReason, error) {
+	if err := ctx.Err(); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return false, nil, gangSchedulingDeadlineExceededRejectionReason, nil
+		}
+		return false, nil, nil, errors.WithStack(err)
+	}
+	jctx := gctx.JobSchedulingContexts[0]
+	req := jctx.Req
+	if gctx.HasNodeRequirements() {
+		req = gctx.PodRequirements()[0]
+	}
+	txn := sch.txn()
+	defer txn.Abort()
+	pctx, err := sch.nodeDb.SelectAndBindNodeToPodWithTxn(txn, req)
+	if err != nil {
+		return false, nil, nil, err
+	}
+	jctx.PodSchedulingContext = pctx
+	jctx.NumNodes = pctx.NumNodes
+	pctxs := []*schedulercontext.PodSchedulingContext{pctx}
+	if pctx.Node == nil {
+		return false, nil, sch.withResolutionRounding(
+			txn,
+			&schedulerconstraints.RejectionReason{
+				Code:    schedulerconstraints.RejectionCodeInsufficientNodeCapacity,
+				Message: "job does not fit on any node",
+			},
+			gctx.JobSchedulingContexts,
+		), nil
+	}
+	return sch.preemptToFitAndCommit(txn, gctx, pctxs)
+}
+
+// preemptToFitAndCommit resolves any oversubscription caused by binding the gang to nodes within txn; see preemptToFit.
+// txn is then committed and any preempted jobs are recorded with the scheduling context.
+// Returns the preempted jobs, or, in dry-run mode, the jobs that would have been preempted.
+// If the oversubscription can't be resolved, the nodes of pctxs are cleared and txn is left for the caller to abort.
+func (sch *GangScheduler) preemptToFitAndCommit(
+	txn *memdb.Txn,
+	gctx *schedulercontext.GangSchedulingContext,
+	pctxs []*schedulercontext.PodSchedulingContext,
+) (bool, []preemptionCandidate, *schedulerconstraints.RejectionReason, error) {
+	preempted, ok, err := sch.preemptToFit(txn, gctx, pctxs)
+	if err != nil {
+		return false, nil, nil, err
+	} else if !ok {
+		clearNodes(pctxs)
+		return false, nil, insufficientPreemptibleCapacityRejectionReason, nil
+	}
+	txn.Commit()
+	if err := sch.recordPreemptedJobs(preempted); err != nil {
+		return false, nil, nil, err
+	}
+	return true, preempted, nil, nil
+}
+
+func clearNodes(pctxs []*schedulercontext.PodSchedulingContext) {
+	for _, pctx := range pctxs {
+		if pctx != nil {
+			pctx.Node = nil
+		}
+	}
+}
+
+// preemptToFit resolves any oversubscription of the nodes the gang has been bound to within txn
+// by evicting lower-priority jobs from those nodes; see EnablePreemption.
+// The nodes of pctxs are updated to reflect the evictions.
+// Returns the jobs to preempt, with the nodes they're preempted from, and true if the oversubscription could be resolved,
+// and false otherwise.
+// If preemption is not enabled, or if the gang is being re-scheduled after having been evicted, nothing is evicted.
+func (sch *GangScheduler) preemptToFit(
+	txn *memdb.Txn,
+	gctx *schedulercontext.GangSchedulingContext,
+	pctxs []*schedulercontext.PodSchedulingContext,
+) ([]preemptionCandidate, bool, error) {
+	if sch.preemptionJobRepo == nil || gctx.AllJobsEvicted {
+		return nil, true, nil
+	}
+	maxPriority := sch.preemptionPriorityThreshold
+	if p := sch.priorityClassFromName(gctx.PriorityClassName).Priority; p < maxPriority {
+		maxPriority = p
+	}
+	gangJobIds := make(map[string]bool, len(gctx.JobSchedulingContexts))
+	for _, jctx := range gctx.JobSchedulingContexts {
+		gangJobIds[jctx.JobId] = true
+	}
+	pctxsByNodeId := make(map[string][]*schedulercontext.PodSchedulingContext)
+	for _, pctx := range pctxs {
+		if pctx != nil && pctx.Node != nil {
+			pctxsByNodeId[pctx.Node.Id] = append(pctxsByNodeId[pctx.Node.Id], pctx)
+		}
+	}
+	nodeIds := maps.Keys(pctxsByNodeId)
+	slices.Sort(nodeIds)
+
+	var preempted []preemptionCandidate
+	for _, nodeId := range nodeIds {
+		node, err := sch.nodeDb.GetNodeWithTxn(txn, nodeId)
+		if err != nil {
+			return nil, false, err
+		}
+		if node == nil {
+			return nil, false, errors.Errorf("node %s not found", nodeId)
+		}
+		if !isOversubscribed(node) {
+			continue
+		}
+		candidates, err := sch.preemptionCandidates(node, gangJobIds, maxPriority)
+		if err != nil {
+			return nil, false, err
+		}
+
+		// Evict candidates greedily until the node is no longer oversubscribed.
+		var evicted []preemptionCandidate
+		evictedNode := node
+		for _, candidate := range candidates {
+			if !isOversubscribed(evictedNode) {
+				break
+			}
+			if evictedNode, err = nodedb.EvictPodFromNode(candidate.req, evictedNode); err != nil {
+				return nil, false, err
+			}
+			evicted = append(evicted, candidate)
+		}
+		if isOversubscribed(evictedNode) {
+			return nil, false, nil
+		}
+
+		// Then drop any evictions not necessary to resolve the oversubscription,
+		// such that the set of preempted jobs is minimal.
+		for i := len(evicted) - 1; i >= 0; i-- {
+			withoutI := slices.Delete(slices.Clone(evicted), i, i+1)
+			n, err := evictAll(withoutI, node)
+			if err != nil {
+				return nil, false, err
+			}
+			if !isOversubscribed(n) {
+				evicted = withoutI
+				evictedNode = n
+			}
+		}
+		if evictedNode, err = evictAll(evicted, node); err != nil {
+			return nil, false, err
+		}
+		if err := sch.nodeDb.UpsertWithTxn(txn, evictedNode); err != nil {
+			return nil, false, err
+		}
+		for _, pctx := range pctxsByNodeId[nodeId] {
+			pctx.Node = evictedNode
+		}
+		preempted = append(preempted, evicted...)
+	}
+	return preempted, true, nil
+}
+
+type preemptionCandidate struct {
+	job interfaces.LegacySchedulerJob
+	// Id of the node the job is running on.
+	nodeId   string
+	req      *schedulerobjects.PodRequirements
+	priority int32
+	// Sum over all resources of the fraction of the node's total resources requested by this job.
+	size float64
+}
+
+// preemptionCandidates returns the jobs on node that may be preempted to make room for a gang with the given job ids,
+// in the order they should be considered for preemption, i.e., by increasing priority and then from largest to smallest.
+// Jobs already evicted, scheduled in this round, or from queues not part of the scheduling context are never preempted.
+func (sch *GangScheduler) preemptionCandidates(node *schedulerobjects.Node, gangJobIds map[string]bool, maxPriority int32) ([]preemptionCandidate, error) {
+	jobIds := make([]string, 0, len(node.AllocatedByJobId))
+	for jobId := range node.AllocatedByJobId {
+		if gangJobIds[jobId] || node.EvictedJobRunIds[jobId] {
+			continue
+		}
+		jobIds = append(jobIds, jobId)
+	}
+	slices.Sort(jobIds)
+	jobs, err := sch.preemptionJobRepo.GetExistingJobsByIds(jobIds)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]preemptionCandidate, 0, len(jobs))
+	for _, job := range jobs {
+		priorityClass := sch.priorityClassFromName(job.GetPriorityClassName())
+		if !priorityClass.Preemptible || priorityClass.Priority >= maxPriority {
+			continue
+		}
+		if sch.protectedByMinimumRuntime(job) {
+			continue
+		}
+		qctx, ok := sch.schedulingContext.QueueSchedulingContexts[job.GetQueue()]
+		if !ok {
+			continue
+		}
+		if _, ok := qctx.SuccessfulJobSchedulingContexts[job.GetId()]; ok {
+			continue
+		}
+		req := PodRequirementFromLegacySchedulerJob(job, sch.schedulingContext.PriorityClasses)
+		size := 0.0
+		for t, q := range req.ResourceRequirements.Requests {
+			if total := node.TotalResources.Get(string(t)); total.Sign() > 0 {
+				size += q.AsApproximateFloat64() / total.AsApproximateFloat64()
+			}
+		}
+		candidates = append(candidates, preemptionCandidate{
+			job:      job,
+			nodeId:   node.Id,
+			req:      req,
+			priority: req.Priority,
+			size:     size,
+		})
+	}
+	slices.SortStableFunc(candidates, func(a, b preemptionCandidate) bool {
+		if a.priority != b.priority {
+			return a.priority < b.priority
+		}
+		return a.size > b.size
+	})
+	return candidates, nil
+}
+
+// jobWithRunStartTime is implemented by jobs that know when their active run started; see SetPreemptionMinimumRuntime.
+type jobWithRunStartTime interface {
+	ActiveRunStartTime() (time.Time, bool)
+}
+
+// protectedByMinimumRuntime returns true if job has been running for less than preemptionMinimumRuntime
+// as of the start of the scheduling round.
+func (sch *GangScheduler) protectedByMinimumRuntime(job interfaces.LegacySchedulerJob) bool {
+	if sch.preemptionMinimumRuntime <= 0 {
+		return false
+	}
+	runJob, ok := job.(jobWithRunStartTime)
+	if !ok {
+		return false
+	}
+	started, ok := runJob.ActiveRunStartTime()
+	if !ok {
+		return false
+	}
+	return sch.schedulingContext.Started.Sub(started) < sch.preemptionMinimumRuntime
+}
+
+// recordPreemptedJobs marks the jobs of the given candidates as evicted in the scheduling context.
+func (sch *GangScheduler) recordPreemptedJobs(preempted []preemptionCandidate) error {
+	for _, candidate := range preempted {
+		if _, err := sch.schedulingContext.EvictJob(candidate.job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sch *GangScheduler) priorityClassFromName(name string) configuration.PriorityClass {
+	if priorityClass, ok := sch.schedulingContext.PriorityClasses[name]; ok {
+		return priorityClass
+	}
+	return sch.schedulingContext.PriorityClasses[sch.schedulingContext.DefaultPriorityClass]
+}
+
+// evictAll returns a copy of node with all candidates evicted from it.
+func evictAll(candidates []preemptionCandidate, node *schedulerobjects.Node) (*schedulerobjects.Node, error) {
+	for _, candidate := range candidates {
+		var err error
+		if node, err = nodedb.EvictPodFromNode(candidate.req, node); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// isOversubscribed returns true if more resources are allocated to non-evicted jobs on node than are available.
+func isOversubscribed(node *schedulerobjects.Node) bool {
+	for p, rl := range node.AllocatableByPriorityAndResource {
+		if p < 0 {
+			// Negative priorities correspond to already evicted jobs.
+			continue
+		}
+		for _, q := range rl.Resources {
+			if q.Sign() == -1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withNodeIdNotIn returns a copy of req with a node affinity requirement added
+// that excludes the nodes with the given ids.
+func withNodeIdNotIn(req *schedulerobjects.PodRequirements, nodeIds []string) *schedulerobjects.PodRequirements {
+	req = proto.Clone(req).(*schedulerobjects.PodRequirements)
+	nodeSelectorRequirement := v1.NodeSelectorRequirement{
+		Key:      schedulerconfig.NodeIdLabel,
+		Operator: v1.NodeSelectorOpNotIn,
+		Values:   slices.Clone(nodeIds),
+	}
+	if req.Affinity == nil {
+		req.Affinity = &v1.Affinity{}
+	}
+	if req.Affinity.NodeAffinity == nil {
+		req.Affinity.NodeAffinity = &v1.NodeAffinity{}
+	}
+	if req.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		req.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{}
+	}
+	nodeSelector := req.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(nodeSelector.NodeSelectorTerms) == 0 {
+		nodeSelector.NodeSelectorTerms = []v1.NodeSelectorTerm{{}}
+	}
+	// Terms are ORed; add the requirement to each term to exclude the nodes regardless of which term matches.
+	for i := range nodeSelector.NodeSelectorTerms {
+		nodeSelector.NodeSelectorTerms[i].MatchExpressions = append(nodeSelector.NodeSelectorTerms[i].MatchExpressions, nodeSelectorRequirement)
+	}
+	return req
+}
+
+// withResolutionRounding notes in reason if the first of the provided jobs that wasn't assigned to any node
+// couldn't be scheduled only because the resources available on some node were rounded down to the resolution
+// with which they're indexed, naming the resource and the requested and rounded amounts. This helps tune
+// configuration.IndexedResource.Resolution. The explanation is also stored in the PodSchedulingContext of that job.
+// reason is mutated and returned.
+//
+// Finding such a node requires iterating over all nodes. Hence, this should only be called once the gang has been
+// rejected, and before the nodes of its jobs are cleared, such that jobs that were assigned to a node are skipped.
+func (sch *GangScheduler) withResolutionRounding(
+	txn *memdb.Txn,
+	reason *schedulerconstraints.RejectionReason,
+	jctxs []*schedulercontext.JobSchedulingContext,
+) *schedulerconstraints.RejectionReason {
+	for _, jctx := range jctxs {
+		pctx := jctx.PodSchedulingContext
+		if pctx == nil || pctx.Node != nil {
+			continue
+		}
+		pctx.ResolutionRounding = sch.nodeDb.ResolutionRoundingWithTxn(txn, pctx, jctx.Req)
+		if pctx.ResolutionRounding != nil {
+			reason.Resource = pctx.ResolutionRounding.Resource
+			reason.Message += "; " + pctx.ResolutionRounding.String()
 		}
-		return false, unschedulableReason, nil
+		return reason
 	}
-	return true, "", nil
+	return reason
 }
 
-func requestIsLargeEnough(totalResourceRequests, minRequest schedulerobjects.ResourceList) (bool, string) {
+func pctxsFromJobSchedulingContexts(jctxs []*schedulercontext.JobSchedulingContext) []*schedulercontext.PodSchedulingContext {
+	rv := make([]*schedulercontext.PodSchedulingContext, len(jctxs))
+	for i, jctx := range jctxs {
+		rv[i] = jctx.PodSchedulingContext
+	}
+	return rv
+}
+
+// jobsAreLargeEnoughForPool returns false and a reason if any of the provided jobs requests less than minRequest.
+func jobsAreLargeEnoughForPool(jctxs []*schedulercontext.JobSchedulingContext, minRequest schedulerobjects.ResourceList) (bool, *schedulerconstraints.RejectionReason) {
+	if len(minRequest.Resources) == 0 {
+		return true, nil
+	}
+	for _, jctx := range jctxs {
+		requests := schedulerobjects.ResourceListFromV1ResourceList(jctx.Req.ResourceRequirements.Requests)
+		for t, minQuantity := range minRequest.Resources {
+			q := requests.Get(t)
+			if minQuantity.Cmp(q) == 1 {
+				return false, &schedulerconstraints.RejectionReason{
+					Code:     schedulerconstraints.RejectionCodePoolMinimumJobSize,
+					Resource: t,
+					Message:  fmt.Sprintf("job %s requests %s %s, but the minimum for this pool is %s", jctx.JobId, q.String(), t, minQuantity.String()),
+				}
+			}
+		}
+	}
+	return true, nil
+}
+
+func requestIsLargeEnough(totalResourceRequests, minRequest schedulerobjects.ResourceList) (bool, *schedulerconstraints.RejectionReason) {
 	if len(minRequest.Resources) == 0 {
-		return true, ""
+		return true, nil
 	}
 	for t, minQuantity := range minRequest.Resources {
 		q := totalResourceRequests.Get(t)
 		if minQuantity.Cmp(q) == 1 {
-			return false, fmt.Sprintf("job requests %s %s, but the minimum is %s", q.String(), t, minQuantity.String())
+			return false, &schedulerconstraints.RejectionReason{
+				Code:     schedulerconstraints.RejectionCodeMinimumJobSize,
+				Resource: t,
+				Message:  fmt.Sprintf("job requests %s %s, but the minimum is %s", q.String(), t, minQuantity.String()),
+			}
 		}
 	}
-	return true, ""
+	return true, nil
 }
@@ -2,13 +2,22 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/armadaproject/armada/internal/armada/configuration"
+	armadaslices "github.com/armadaproject/armada/internal/common/slices"
+	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 	schedulerconstraints "github.com/armadaproject/armada/internal/scheduler/constraints"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/jobdb"
@@ -31,7 +40,379 @@ func TestGangScheduler(t *testing.T) {
 		Gangs [][]*jobdb.Job
 		// Indices of gangs expected to be scheduled.
 		ExpectedScheduledIndices []int
+		// If non-zero, the number of jobs expected to be scheduled across all gangs.
+		ExpectedNumScheduledJobs int
+		// If true, gangs are scheduled with a context whose deadline has already passed.
+		DeadlineExceeded bool
+		// Map from the index of an unschedulable gang to the rejection expected for each of its jobs.
+		ExpectedRejectionByIndex map[int]string
+		// Map from the index of an unschedulable gang to the rejection message expected for each of its jobs.
+		ExpectedRejectionMessageByIndex map[int]string
+		// Node selector applied to each gang.
+		GangNodeSelector map[string]string
+		// Preferred node affinity terms applied to each gang.
+		GangPreferredNodeAffinityTerms []v1.PreferredSchedulingTerm
+		// If non-nil, ids of the nodes successfully scheduled jobs are expected to be assigned to, in order.
+		ExpectedNodeIds []string
+		// If non-zero, the number of distinct nodes successfully scheduled jobs are expected to be assigned to.
+		ExpectedNumDistinctNodes int
 	}{
+		"lowestNodeId tie-break": {
+			SchedulingConfig: testfixtures.WithNodeTieBreakPolicyConfig(
+				nodedb.NodeTieBreakPolicyLowestNodeId,
+				testfixtures.TestSchedulingConfig(),
+			),
+			Nodes: append(
+				testfixtures.WithIdsNodes(
+					[]string{"b"},
+					testfixtures.WithUsedResourcesNodes(
+						3,
+						schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("16")}},
+						testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+					),
+				),
+				testfixtures.WithIdsNodes([]string{"a"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities))...,
+			),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+			ExpectedNodeIds:          []string{"a"},
+		},
+		"leastAvailable tie-break": {
+			// With a resolution of 10 cpu, both nodes are indexed as having 30 cpu available.
+			SchedulingConfig: testfixtures.WithNodeTieBreakPolicyConfig(
+				nodedb.NodeTieBreakPolicyLeastAvailable,
+				testfixtures.WithIndexedResourcesConfig(
+					[]configuration.IndexedResource{
+						{Name: "cpu", Resolution: resource.MustParse("10")},
+						{Name: "memory", Resolution: resource.MustParse("128Mi")},
+					},
+					testfixtures.TestSchedulingConfig(),
+				),
+			),
+			Nodes: append(
+				testfixtures.WithIdsNodes([]string{"a"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)),
+				testfixtures.WithIdsNodes(
+					[]string{"b"},
+					testfixtures.WithUsedResourcesNodes(
+						3,
+						schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+						testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+					),
+				)...,
+			),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+			ExpectedNodeIds:          []string{"b"},
+		},
+		"nil resource scarcity": {
+			SchedulingConfig: testfixtures.WithResourceScarcityConfig(nil, testfixtures.TestSchedulingConfig()),
+			Nodes:            testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+		},
+		"per-pool minimum job size": {
+			SchedulingConfig: testfixtures.WithPoolMinimumJobSizeConfig(
+				"pool",
+				map[string]resource.Quantity{"cpu": resource.MustParse("16")},
+				testfixtures.TestSchedulingConfig(),
+			),
+			Nodes: testfixtures.N32CpuNodes(2, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+				// The total request of the gang exceeds the minimum, but one of its members is too small.
+				testfixtures.WithGangAnnotationsJobs(
+					armadaslices.Concatenate(
+						testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 1),
+						testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+					),
+				),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+			ExpectedRejectionByIndex: map[int]string{
+				1: "rejected: PoolMinimumJobSize cpu",
+				2: "rejected: PoolMinimumJobSize cpu",
+			},
+		},
+		"per-pool minimum job size of another pool": {
+			SchedulingConfig: testfixtures.WithPoolMinimumJobSizeConfig(
+				"other",
+				map[string]resource.Quantity{"cpu": resource.MustParse("16")},
+				testfixtures.TestSchedulingConfig(),
+			),
+			Nodes: testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+		},
+		"gang node selector": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes: append(
+				testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+				testfixtures.WithLabelsNodes(map[string]string{"gpu": "true"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities))...,
+			),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+			},
+			GangNodeSelector:         map[string]string{"gpu": "true"},
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+		},
+		"gang node selector annotation": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes: append(
+				testfixtures.WithIdsNodes([]string{"a"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)),
+				testfixtures.WithLabelsNodes(
+					map[string]string{"gpu": "true"},
+					testfixtures.WithIdsNodes([]string{"b"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)),
+				)...,
+			),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{configuration.GangNodeSelectorAnnotation: "gpu=true"},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				),
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{configuration.GangNodeSelectorAnnotation: "gpu=true"},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				),
+			},
+			// The second gang doesn't fit onto the only node with the label.
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+			ExpectedNodeIds:          []string{"b", "b"},
+			ExpectedRejectionByIndex: map[int]string{1: "rejected: InsufficientNodeCapacity"},
+		},
+		"gang node selector failure": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes: append(
+				testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+				testfixtures.WithLabelsNodes(map[string]string{"gpu": "true"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities))...,
+			),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 3)),
+			},
+			GangNodeSelector:         map[string]string{"gpu": "true"},
+			ExpectedScheduledIndices: nil,
+			ExpectedRejectionByIndex: map[int]string{0: "rejected: InsufficientNodeCapacity"},
+		},
+		"gang node selector conflict": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.WithLabelsNodes(map[string]string{"gpu": "true"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithNodeSelectorJobs(
+					map[string]string{"gpu": "false"},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				),
+			},
+			GangNodeSelector:         map[string]string{"gpu": "true"},
+			ExpectedScheduledIndices: nil,
+			ExpectedRejectionByIndex: map[int]string{0: "rejected: GangNodeSelectorConflict"},
+		},
+		"gang preferred node affinity": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes: append(
+				testfixtures.WithIdsNodes([]string{"a", "b"}, testfixtures.N32CpuNodes(2, testfixtures.TestPriorities)),
+				testfixtures.WithLabelsNodes(
+					map[string]string{"zone": "near"},
+					testfixtures.WithIdsNodes([]string{"c"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)),
+				)...,
+			),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 1),
+			},
+			GangPreferredNodeAffinityTerms: preferredNodeAffinityTerms(10, "zone", "near"),
+			ExpectedScheduledIndices:       testfixtures.IntRange(0, 1),
+			// Once the preferred node is full, jobs are scheduled onto other nodes.
+			ExpectedNodeIds: []string{"c", "c", "a"},
+		},
+		"gang preferred node affinity annotation": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes: append(
+				testfixtures.WithIdsNodes([]string{"a", "b"}, testfixtures.N32CpuNodes(2, testfixtures.TestPriorities)),
+				testfixtures.WithLabelsNodes(
+					map[string]string{"zone": "near"},
+					testfixtures.WithIdsNodes([]string{"c"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)),
+				)...,
+			),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{
+						configuration.GangPreferredNodeAffinityAnnotation: `[{"weight":10,"preference":{"matchExpressions":[{"key":"zone","operator":"In","values":["near"]}]}}]`,
+					},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+			ExpectedNodeIds:          []string{"c", "c"},
+		},
+		"gang preferred node affinity prefers higher total weight": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes: append(
+				testfixtures.WithLabelsNodes(
+					map[string]string{"zone": "near"},
+					testfixtures.WithIdsNodes([]string{"a"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)),
+				),
+				testfixtures.WithLabelsNodes(
+					map[string]string{"zone": "near", "rack": "near"},
+					testfixtures.WithIdsNodes([]string{"b"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)),
+				)...,
+			),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+			},
+			GangPreferredNodeAffinityTerms: append(
+				preferredNodeAffinityTerms(10, "zone", "near"),
+				preferredNodeAffinityTerms(1, "rack", "near")...,
+			),
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+			ExpectedNodeIds:          []string{"b", "b"},
+		},
+		"gang preferred node affinity unavailable": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes: append(
+				testfixtures.WithIdsNodes([]string{"a", "b"}, testfixtures.N32CpuNodes(2, testfixtures.TestPriorities)),
+				testfixtures.WithLabelsNodes(
+					map[string]string{"zone": "near"},
+					testfixtures.WithIdsNodes(
+						[]string{"c"},
+						testfixtures.WithUsedResourcesNodes(
+							0,
+							schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("32")}},
+							testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+						),
+					),
+				)...,
+			),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+			},
+			GangPreferredNodeAffinityTerms: preferredNodeAffinityTerms(10, "zone", "near"),
+			ExpectedScheduledIndices:       testfixtures.IntRange(0, 0),
+			ExpectedNodeIds:                []string{"a", "a"},
+		},
+		"deadline exceeded": {
+			SchedulingConfig:         testfixtures.TestSchedulingConfig(),
+			Nodes:                    testfixtures.N32CpuNodes(2, testfixtures.TestPriorities),
+			Gangs:                    [][]*jobdb.Job{testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2))},
+			ExpectedScheduledIndices: nil,
+			DeadlineExceeded:         true,
+			ExpectedRejectionByIndex: map[int]string{0: "rejected: DeadlineExceeded"},
+		},
+		"partial gang deadline exceeded": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.N32CpuNodes(2, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{configuration.GangMinimumCardinalityAnnotation: "1"},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				),
+			},
+			ExpectedScheduledIndices: nil,
+			DeadlineExceeded:         true,
+		},
+		"partial gang success": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{configuration.GangMinimumCardinalityAnnotation: "1"},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N32CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+			ExpectedNumScheduledJobs: 1,
+		},
+		"unique nodes success": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.N32CpuNodes(2, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{configuration.GangUniqueNodesAnnotation: "true"},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+			ExpectedNumScheduledJobs: 2,
+		},
+		"unique nodes failure": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{configuration.GangUniqueNodesAnnotation: "true"},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+				),
+				// Without the annotation, both jobs fit on the same node.
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2)),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(1, 1),
+			ExpectedNumScheduledJobs: 2,
+		},
+		"max node span success": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.N32CpuNodes(4, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{configuration.GangMaxNodeSpanAnnotation: "2"},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 4)),
+				),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+			ExpectedNumScheduledJobs: 4,
+		},
+		"max node span exceeded": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.N32CpuNodes(4, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				// The gang fits only if spread across 3 nodes.
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{configuration.GangMaxNodeSpanAnnotation: "2"},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 6)),
+				),
+				// Nodes used by the gang should be released when it's rejected.
+				testfixtures.N32CpuJobs("A", testfixtures.PriorityClass0, 4),
+			},
+			ExpectedScheduledIndices: testfixtures.IntRange(1, 1),
+			ExpectedNumScheduledJobs: 4,
+			ExpectedRejectionByIndex: map[int]string{0: "rejected: GangMaxNodeSpanExceeded"},
+		},
+		"max node span exceeded with unique nodes": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.N32CpuNodes(4, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{
+						configuration.GangUniqueNodesAnnotation: "true",
+						configuration.GangMaxNodeSpanAnnotation: "2",
+					},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 3)),
+				),
+			},
+			ExpectedScheduledIndices: nil,
+			ExpectedRejectionByIndex: map[int]string{0: "rejected: GangMaxNodeSpanExceeded"},
+		},
+		"partial gang failure": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes:            testfixtures.N32CpuNodes(2, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithAnnotationsJobs(
+					map[string]string{configuration.GangMinimumCardinalityAnnotation: "3"},
+					testfixtures.WithGangAnnotationsJobs(testfixtures.N32CpuJobs("A", testfixtures.PriorityClass0, 4)),
+				),
+				testfixtures.N32CpuJobs("A", testfixtures.PriorityClass0, 2),
+			},
+			// Nodes used by the partial gang should be released when it fails.
+			ExpectedScheduledIndices: testfixtures.IntRange(1, 1),
+			ExpectedNumScheduledJobs: 2,
+		},
 		"simple success": {
 			SchedulingConfig: testfixtures.TestSchedulingConfig(),
 			Nodes:            testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
@@ -47,6 +428,7 @@ func TestGangScheduler(t *testing.T) {
 				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 33),
 			},
 			ExpectedScheduledIndices: nil,
+			ExpectedRejectionByIndex: map[int]string{0: "rejected: InsufficientNodeCapacity"},
 		},
 		"one success and one failure": {
 			SchedulingConfig: testfixtures.TestSchedulingConfig(),
@@ -64,6 +446,37 @@ func TestGangScheduler(t *testing.T) {
 				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 64),
 			},
 			ExpectedScheduledIndices: testfixtures.IntRange(0, 0),
+			ExpectedNumDistinctNodes: 2,
+		},
+		"nvidia gpu gangs": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Nodes: append(
+				testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+				testfixtures.N8NvidiaGpuNodes(2, testfixtures.TestPriorities)...,
+			),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N1NvidiaGpuJobs("A", testfixtures.PriorityClass0, 12)),
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N1NvidiaGpuJobs("A", testfixtures.PriorityClass0, 5)),
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N1NvidiaGpuJobs("A", testfixtures.PriorityClass0, 4)),
+			},
+			ExpectedScheduledIndices: []int{0, 2},
+			ExpectedNumScheduledJobs: 16,
+			ExpectedNumDistinctNodes: 2,
+			ExpectedRejectionByIndex: map[int]string{1: "rejected: InsufficientNodeCapacity"},
+		},
+		"MaximumResourceFractionToSchedule nvidia gpu": {
+			SchedulingConfig: testfixtures.WithRoundLimitsConfig(
+				map[string]float64{"nvidia.com/gpu": 0.5},
+				testfixtures.TestSchedulingConfig(),
+			),
+			Nodes: testfixtures.N8NvidiaGpuNodes(2, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				// The limit is checked before scheduling each gang; this gang brings the total above it.
+				testfixtures.WithGangAnnotationsJobs(testfixtures.N1NvidiaGpuJobs("A", testfixtures.PriorityClass0, 9)),
+				testfixtures.N1NvidiaGpuJobs("A", testfixtures.PriorityClass0, 1),
+			},
+			ExpectedScheduledIndices: []int{0},
+			ExpectedRejectionByIndex: map[int]string{1: "rejected: MaximumResourceFractionToSchedule nvidia.com/gpu"},
 		},
 		"MaximumResourceFractionToSchedule": {
 			SchedulingConfig: testfixtures.WithRoundLimitsConfig(
@@ -77,6 +490,7 @@ func TestGangScheduler(t *testing.T) {
 				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 8),
 			},
 			ExpectedScheduledIndices: []int{0, 1},
+			ExpectedRejectionByIndex: map[int]string{2: "rejected: MaximumResourceFractionToSchedule cpu"},
 		},
 		"MaximumResourceFractionToScheduleByPool": {
 			SchedulingConfig: testfixtures.WithRoundLimitsConfig(
@@ -136,6 +550,32 @@ func TestGangScheduler(t *testing.T) {
 				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 17),
 			},
 			ExpectedScheduledIndices: []int{1, 3, 5, 7},
+			ExpectedRejectionByIndex: map[int]string{
+				0: "rejected: MaximumResourceFractionPerQueue cpu",
+				2: "rejected: MaximumResourceFractionPerQueue cpu",
+				4: "rejected: MaximumResourceFractionPerQueue cpu",
+				6: "rejected: MaximumResourceFractionPerQueue cpu",
+			},
+		},
+		"MaximumResourceFractionToSchedule per priority class": {
+			SchedulingConfig: testfixtures.WithPerPriorityRoundLimitsConfig(
+				map[int32]map[string]float64{
+					3: {"cpu": 3.0 / 32.0},
+				},
+				testfixtures.TestSchedulingConfig(),
+			),
+			Nodes: testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Gangs: [][]*jobdb.Job{
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass3, 4),
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass3, 3),
+				testfixtures.N1CpuJobs("B", testfixtures.PriorityClass3, 1),
+				testfixtures.N1CpuJobs("B", testfixtures.PriorityClass2, 8),
+			},
+			ExpectedScheduledIndices: []int{1, 3},
+			ExpectedRejectionByIndex: map[int]string{
+				0: "rejected: MaximumResourceFractionToScheduleForPriorityClass cpu",
+				2: "rejected: MaximumResourceFractionToScheduleForPriorityClass cpu",
+			},
 		},
 		"resolution has no impact on jobs of size a multiple of the resolution": {
 			SchedulingConfig: testfixtures.WithIndexedResourcesConfig(
@@ -164,7 +604,7 @@ func TestGangScheduler(t *testing.T) {
 				},
 				testfixtures.TestSchedulingConfig(),
 			),
-			Nodes: testfixtures.N32CpuNodes(3, testfixtures.TestPriorities),
+			Nodes: testfixtures.WithIdsNodes([]string{"a", "b", "c"}, testfixtures.N32CpuNodes(3, testfixtures.TestPriorities)),
 			Gangs: [][]*jobdb.Job{
 				testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 1),
 				testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 1),
@@ -172,6 +612,11 @@ func TestGangScheduler(t *testing.T) {
 				testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 1),
 			},
 			ExpectedScheduledIndices: testfixtures.IntRange(0, 2),
+			// Each node has 16 cpu available, which is rounded down to 0.
+			ExpectedRejectionByIndex: map[int]string{3: "rejected: InsufficientNodeCapacity cpu"},
+			ExpectedRejectionMessageByIndex: map[int]string{
+				3: "job does not fit on any node; node a has 16 cpu available, which is rounded down to 0 due to the resolution 17, but 16 is requested",
+			},
 		},
 	}
 	for name, tc := range tests {
@@ -184,6 +629,7 @@ func TestGangScheduler(t *testing.T) {
 				testfixtures.TestIndexedNodeLabels,
 			)
 			require.NoError(t, err)
+			require.NoError(t, nodeDb.SetTieBreakPolicy(tc.SchedulingConfig.NodeTieBreakPolicy))
 			err = nodeDb.UpsertMany(tc.Nodes)
 			require.NoError(t, err)
 			if tc.TotalResources.Resources == nil {
@@ -216,21 +662,714 @@ func TestGangScheduler(t *testing.T) {
 			)
 			sch, err := NewGangScheduler(sctx, constraints, nodeDb)
 			require.NoError(t, err)
+			dryRunSch, err := NewGangScheduler(sctx, constraints, nodeDb)
+			require.NoError(t, err)
+			dryRunSch.DryRun()
+
+			ctx := context.Background()
+			if tc.DeadlineExceeded {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, time.Now().Add(-time.Second))
+				defer cancel()
+			}
 
 			var actualScheduledIndices []int
+			var actualNodeIds []string
 			for i, gang := range tc.Gangs {
+				// A dry run should give the same result as actually scheduling the gang, without side effects.
+				dryRunGctx := schedulercontext.NewGangSchedulingContext(jobSchedulingContextsFromJobs(gang, "", testfixtures.TestPriorityClasses))
+				if tc.GangNodeSelector != nil {
+					dryRunGctx.NodeSelector = tc.GangNodeSelector
+				}
+				if tc.GangPreferredNodeAffinityTerms != nil {
+					dryRunGctx.PreferredNodeAffinityTerms = tc.GangPreferredNodeAffinityTerms
+				}
+				stateBefore := getGangSchedulerState(t, sctx, nodeDb)
+				dryRunOk, dryRunNodeIdByJobId, _, dryRunReason, err := dryRunSch.Schedule(ctx, dryRunGctx)
+				require.NoError(t, err)
+				assertGangSchedulerStateEqual(t, stateBefore, getGangSchedulerState(t, sctx, nodeDb))
+
 				jctxs := jobSchedulingContextsFromJobs(gang, "", testfixtures.TestPriorityClasses)
 				gctx := schedulercontext.NewGangSchedulingContext(jctxs)
-				ok, reason, err := sch.Schedule(context.Background(), gctx)
+				if tc.GangNodeSelector != nil {
+					gctx.NodeSelector = tc.GangNodeSelector
+				}
+				if tc.GangPreferredNodeAffinityTerms != nil {
+					gctx.PreferredNodeAffinityTerms = tc.GangPreferredNodeAffinityTerms
+				}
+				ok, nodeIdByJobId, _, reason, err := sch.Schedule(ctx, gctx)
 				require.NoError(t, err)
+				assert.Equal(t, ok, dryRunOk)
+				assert.Equal(t, reason, dryRunReason)
+				assert.Equal(t, nodeIdByJobId, dryRunNodeIdByJobId)
+				if tc.DeadlineExceeded {
+					assert.Contains(t, reason, context.DeadlineExceeded.Error())
+				}
+				if expected, ok := tc.ExpectedRejectionByIndex[i]; ok {
+					for _, jctx := range gctx.JobSchedulingContexts {
+						assert.Equal(t, expected, jctx.Rejection())
+					}
+				}
+				if expected, ok := tc.ExpectedRejectionMessageByIndex[i]; ok {
+					for _, jctx := range gctx.JobSchedulingContexts {
+						assert.Equal(t, expected, jctx.UnschedulableReason)
+					}
+				}
 				if ok {
 					require.Empty(t, reason)
 					actualScheduledIndices = append(actualScheduledIndices, i)
+					if gctx.RequireUniqueNodes {
+						nodeIds := make(map[string]bool)
+						for _, jctx := range gctx.JobSchedulingContexts {
+							nodeIds[jctx.PodSchedulingContext.Node.Id] = true
+						}
+						assert.Len(t, nodeIds, len(gctx.JobSchedulingContexts))
+					}
+					if gctx.MaxNodeSpan > 0 {
+						nodeIds := make(map[string]bool)
+						for _, jctx := range gctx.JobSchedulingContexts {
+							nodeIds[jctx.PodSchedulingContext.Node.Id] = true
+						}
+						assert.LessOrEqual(t, len(nodeIds), gctx.MaxNodeSpan)
+					}
+					for _, jctx := range gctx.JobSchedulingContexts {
+						if jctx.IsSuccessful() {
+							actualNodeIds = append(actualNodeIds, jctx.PodSchedulingContext.Node.Id)
+							assert.Equal(t, jctx.PodSchedulingContext.Node.Id, jctx.NodeId)
+							assert.Equal(t, jctx.NodeId, nodeIdByJobId[jctx.JobId])
+						} else {
+							assert.Empty(t, jctx.NodeId)
+							assert.NotContains(t, nodeIdByJobId, jctx.JobId)
+						}
+						for label, value := range tc.GangNodeSelector {
+							assert.Equal(t, value, jctx.PodSchedulingContext.Node.Labels[label])
+						}
+					}
 				} else {
 					require.NotEmpty(t, reason)
+					assert.Nil(t, nodeIdByJobId)
 				}
 			}
 			assert.Equal(t, tc.ExpectedScheduledIndices, actualScheduledIndices)
+			if tc.ExpectedNodeIds != nil {
+				assert.Equal(t, tc.ExpectedNodeIds, actualNodeIds)
+			}
+			if tc.ExpectedNumDistinctNodes != 0 {
+				assert.Len(t, armadaslices.Unique(actualNodeIds), tc.ExpectedNumDistinctNodes)
+			}
+			if tc.ExpectedNumScheduledJobs != 0 {
+				assert.Equal(t, tc.ExpectedNumScheduledJobs, sctx.NumScheduledJobs)
+				assert.Equal(t, tc.ExpectedNumScheduledJobs, len(sctx.SuccessfulJobSchedulingContexts()))
+			}
+			if tc.DeadlineExceeded {
+				// No jobs should remain bound to nodes.
+				assert.Equal(t, 0, sctx.NumScheduledJobs)
+				for _, node := range tc.Nodes {
+					node, err := nodeDb.GetNode(node.Id)
+					require.NoError(t, err)
+					assert.Empty(t, node.AllocatedByJobId)
+				}
+			}
+		})
+	}
+}
+
+func TestGangSchedulerPreemption(t *testing.T) {
+	tests := map[string]struct {
+		// Jobs running on a single 32-core node before the gang is scheduled.
+		RunningJobs []*jobdb.Job
+		Gang        []*jobdb.Job
+		// Only jobs with priority below this threshold may be preempted.
+		PriorityThreshold int32
+		// For each of RunningJobs, how long ago its run started; jobs without a corresponding entry have no run.
+		RunningJobAges []time.Duration
+		// Jobs that have been running for less than this are not preempted.
+		MinimumRuntime  time.Duration
+		ExpectScheduled bool
+		// Indices into RunningJobs of jobs expected to be preempted.
+		ExpectedPreemptedIndices []int
+	}{
+		"no preemption necessary": {
+			RunningJobs:       testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 1),
+			Gang:              testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1),
+			PriorityThreshold: 3,
+			ExpectScheduled:   true,
+		},
+		"preempt largest lowest-priority job": {
+			RunningJobs: armadaslices.Concatenate(
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 16),
+				testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 1),
+			),
+			Gang:                     testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1),
+			PriorityThreshold:        3,
+			ExpectScheduled:          true,
+			ExpectedPreemptedIndices: []int{16},
+		},
+		"preempt gang": {
+			RunningJobs:              testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2),
+			Gang:                     testfixtures.WithGangAnnotationsJobs(testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 2)),
+			PriorityThreshold:        3,
+			ExpectScheduled:          true,
+			ExpectedPreemptedIndices: []int{0, 1},
+		},
+		"unnecessary evictions are undone": {
+			RunningJobs: armadaslices.Concatenate(
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+				testfixtures.N16CpuJobs("A", testfixtures.PriorityClass1, 1),
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass1, 15),
+			),
+			Gang:                     testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1),
+			PriorityThreshold:        3,
+			ExpectScheduled:          true,
+			ExpectedPreemptedIndices: []int{1},
+		},
+		"jobs at or above the threshold are not preempted": {
+			RunningJobs: armadaslices.Concatenate(
+				testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 1),
+				testfixtures.N16CpuJobs("A", testfixtures.PriorityClass1, 1),
+			),
+			Gang:                     testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1),
+			PriorityThreshold:        1,
+			ExpectScheduled:          true,
+			ExpectedPreemptedIndices: []int{0},
+		},
+		"gang does not fit without preempting jobs above the threshold": {
+			RunningJobs:       testfixtures.N32CpuJobs("A", testfixtures.PriorityClass1, 1),
+			Gang:              testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1),
+			PriorityThreshold: 1,
+			ExpectScheduled:   false,
+		},
+		"non-preemptible jobs are not preempted": {
+			RunningJobs:       testfixtures.N32CpuJobs("A", testfixtures.PriorityClass2NonPreemptible, 1),
+			Gang:              testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1),
+			PriorityThreshold: 3,
+			ExpectScheduled:   false,
+		},
+		"jobs running for less than the minimum runtime are not preempted": {
+			RunningJobs:              testfixtures.N16CpuJobs("A", testfixtures.PriorityClass0, 2),
+			RunningJobAges:           []time.Duration{time.Minute, time.Hour},
+			MinimumRuntime:           10 * time.Minute,
+			Gang:                     testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1),
+			PriorityThreshold:        3,
+			ExpectScheduled:          true,
+			ExpectedPreemptedIndices: []int{1},
+		},
+		"gang does not fit without preempting jobs below the minimum runtime": {
+			RunningJobs:       testfixtures.N32CpuJobs("A", testfixtures.PriorityClass0, 1),
+			RunningJobAges:    []time.Duration{time.Minute},
+			MinimumRuntime:    10 * time.Minute,
+			Gang:              testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1),
+			PriorityThreshold: 3,
+			ExpectScheduled:   false,
+		},
+		"jobs without a run are not protected by the minimum runtime": {
+			RunningJobs:              testfixtures.N32CpuJobs("A", testfixtures.PriorityClass0, 1),
+			MinimumRuntime:           10 * time.Minute,
+			Gang:                     testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1),
+			PriorityThreshold:        3,
+			ExpectScheduled:          true,
+			ExpectedPreemptedIndices: []int{0},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			for i, age := range tc.RunningJobAges {
+				tc.RunningJobs[i] = tc.RunningJobs[i].WithUpdatedRun(jobdb.MinimalRun(uuid.New(), now.Add(-age).UnixNano()))
+			}
+			node := testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)[0]
+			jobRepo := NewInMemoryJobRepository(testfixtures.TestPriorityClasses)
+			for _, job := range tc.RunningJobs {
+				var err error
+				node, err = nodedb.BindPodToNode(PodRequirementFromLegacySchedulerJob(job, testfixtures.TestPriorityClasses), node)
+				require.NoError(t, err)
+				jobRepo.Enqueue(job)
+			}
+			nodeDb, err := nodedb.NewNodeDb(
+				testfixtures.TestPriorityClasses,
+				testfixtures.TestMaxExtraNodesToConsider,
+				testfixtures.TestResources,
+				testfixtures.TestIndexedTaints,
+				testfixtures.TestIndexedNodeLabels,
+			)
+			require.NoError(t, err)
+			require.NoError(t, nodeDb.Upsert(node))
+
+			config := testfixtures.TestSchedulingConfig()
+			sctx := schedulercontext.NewSchedulingContext(
+				"executor",
+				"pool",
+				config.Preemption.PriorityClasses,
+				config.Preemption.DefaultPriorityClass,
+				config.ResourceScarcity,
+				nodeDb.TotalResources(),
+			)
+			require.NoError(t, sctx.AddQueueSchedulingContext("A", 1, nil))
+			require.NoError(t, sctx.AddQueueSchedulingContext("B", 1, nil))
+			constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+				"pool",
+				nodeDb.TotalResources(),
+				schedulerobjects.ResourceList{},
+				config,
+			)
+			sch, err := NewGangScheduler(sctx, constraints, nodeDb)
+			require.NoError(t, err)
+			sch.EnablePreemption(jobRepo, tc.PriorityThreshold)
+			sch.SetPreemptionMinimumRuntime(tc.MinimumRuntime)
+			dryRunSch, err := NewGangScheduler(sctx, constraints, nodeDb)
+			require.NoError(t, err)
+			dryRunSch.EnablePreemption(jobRepo, tc.PriorityThreshold)
+			dryRunSch.SetPreemptionMinimumRuntime(tc.MinimumRuntime)
+			dryRunSch.DryRun()
+
+			stateBefore := getGangSchedulerState(t, sctx, nodeDb)
+			dryRunGctx := schedulercontext.NewGangSchedulingContext(jobSchedulingContextsFromJobs(tc.Gang, "", testfixtures.TestPriorityClasses))
+			dryRunOk, dryRunNodeIdByJobId, dryRunPreemptedJobs, _, err := dryRunSch.Schedule(context.Background(), dryRunGctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectScheduled, dryRunOk)
+			assertGangSchedulerStateEqual(t, stateBefore, getGangSchedulerState(t, sctx, nodeDb))
+			assert.Equal(t, 0, sctx.NumEvictedJobs)
+
+			gctx := schedulercontext.NewGangSchedulingContext(jobSchedulingContextsFromJobs(tc.Gang, "", testfixtures.TestPriorityClasses))
+			ok, nodeIdByJobId, preemptedJobs, reason, err := sch.Schedule(context.Background(), gctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectScheduled, ok)
+			assert.Equal(t, dryRunNodeIdByJobId, nodeIdByJobId)
+			assert.Equal(t, dryRunPreemptedJobs, preemptedJobs)
+
+			expectedPreemptedJobIds := make(map[string]bool)
+			expectedPreemptedResources := schedulerobjects.QuantityByPriorityAndResourceType{}
+			for _, i := range tc.ExpectedPreemptedIndices {
+				job := tc.RunningJobs[i]
+				expectedPreemptedJobIds[job.GetId()] = true
+				req := PodRequirementFromLegacySchedulerJob(job, testfixtures.TestPriorityClasses)
+				expectedPreemptedResources.AddV1ResourceList(req.Priority, req.ResourceRequirements.Requests)
+			}
+			assert.Equal(t, expectedPreemptedJobIds, sctx.QueueSchedulingContexts["A"].EvictedJobsById)
+
+			// Preempted jobs are also returned to the caller, together with the node they were preempted from.
+			actualPreemptedJobIds := make(map[string]bool)
+			for _, job := range preemptedJobs {
+				actualPreemptedJobIds[job.GetId()] = true
+				assert.Equal(t, node.Id, nodeIdByJobId[job.GetId()])
+			}
+			assert.Equal(t, expectedPreemptedJobIds, actualPreemptedJobIds)
+			assert.True(t, expectedPreemptedResources.Equal(sctx.EvictedResourcesByPriority))
+			assert.True(t, expectedPreemptedResources.Equal(sctx.QueueSchedulingContexts["A"].EvictedResourcesByPriority))
+
+			node, err = nodeDb.GetNode(node.Id)
+			require.NoError(t, err)
+			if ok {
+				assert.Equal(t, len(expectedPreemptedJobIds), len(node.EvictedJobRunIds))
+				for jobId := range expectedPreemptedJobIds {
+					assert.True(t, node.EvictedJobRunIds[jobId])
+				}
+				assert.False(t, isOversubscribed(node))
+			} else {
+				assert.Equal(t, string(schedulerconstraints.RejectionCodeInsufficientPreemptibleCapacity), gctx.JobSchedulingContexts[0].UnschedulableReasonCode)
+				assert.NotEmpty(t, reason)
+				assert.Empty(t, node.EvictedJobRunIds)
+				for _, job := range tc.Gang {
+					assert.NotContains(t, node.AllocatedByJobId, job.GetId())
+				}
+			}
+		})
+	}
+}
+
+func TestGangSchedulerRejectionReasonsIncludeGangId(t *testing.T) {
+	// Neither gang fits onto the single 32-core node.
+	gangs := [][]*jobdb.Job{
+		testfixtures.WithGangAnnotationsJobs(testfixtures.N32CpuJobs("A", testfixtures.PriorityClass0, 2)),
+		testfixtures.WithGangAnnotationsJobs(testfixtures.N32CpuJobs("A", testfixtures.PriorityClass0, 2)),
+	}
+	nodeDb, err := nodedb.NewNodeDb(
+		testfixtures.TestPriorityClasses,
+		testfixtures.TestMaxExtraNodesToConsider,
+		testfixtures.TestResources,
+		testfixtures.TestIndexedTaints,
+		testfixtures.TestIndexedNodeLabels,
+	)
+	require.NoError(t, err)
+	require.NoError(t, nodeDb.Upsert(testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)[0]))
+
+	config := testfixtures.TestSchedulingConfig()
+	sctx := schedulercontext.NewSchedulingContext(
+		"executor",
+		"pool",
+		config.Preemption.PriorityClasses,
+		config.Preemption.DefaultPriorityClass,
+		config.ResourceScarcity,
+		nodeDb.TotalResources(),
+	)
+	require.NoError(t, sctx.AddQueueSchedulingContext("A", 1, nil))
+	constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+		"pool",
+		nodeDb.TotalResources(),
+		schedulerobjects.ResourceList{},
+		config,
+	)
+	sch, err := NewGangScheduler(sctx, constraints, nodeDb)
+	require.NoError(t, err)
+
+	var reasons []string
+	for _, gang := range gangs {
+		gangId := gang[0].GetAnnotations()[configuration.GangIdAnnotation]
+		gctx := schedulercontext.NewGangSchedulingContext(jobSchedulingContextsFromJobs(gang, "", testfixtures.TestPriorityClasses))
+		assert.Equal(t, gangId, gctx.GangId)
+
+		ok, _, _, reason, err := sch.Schedule(context.Background(), gctx)
+		require.NoError(t, err)
+		require.False(t, ok)
+		assert.Contains(t, reason, gangId)
+		reasons = append(reasons, reason)
+
+		for _, job := range gang {
+			jctx, ok := sctx.QueueSchedulingContexts["A"].UnsuccessfulJobSchedulingContexts[job.GetId()]
+			if assert.True(t, ok) {
+				assert.Equal(t, gangId, jctx.GangId)
+			}
+		}
+	}
+	assert.NotEqual(t, reasons[0], reasons[1])
+}
+
+func TestGangSchedulerDryRunWithConcurrentWriter(t *testing.T) {
+	sch := newDryRunGangScheduler(t, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)...)
+	stateBefore := getGangSchedulerState(t, sch.schedulingContext, sch.nodeDb)
+
+	// Dry runs don't need the write lock of the NodeDb. Hence, they complete while another writer holds it.
+	txn := sch.nodeDb.Txn(true)
+	defer txn.Abort()
+	done := make(chan error)
+	go func() {
+		gctx := schedulercontext.NewGangSchedulingContext(
+			jobSchedulingContextsFromJobs(testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1), "", testfixtures.TestPriorityClasses),
+		)
+		ok, nodeIdByJobId, _, _, err := sch.Schedule(context.Background(), gctx)
+		if err == nil && (!ok || len(nodeIdByJobId) != 1) {
+			err = fmt.Errorf("expected the job to be scheduled")
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("dry run blocked on concurrent writer")
+	}
+	txn.Abort()
+	assertGangSchedulerStateEqual(t, stateBefore, getGangSchedulerState(t, sch.schedulingContext, sch.nodeDb))
+}
+
+func TestGangSchedulerDrainingExecutor(t *testing.T) {
+	sch := newDryRunGangScheduler(t, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)...)
+	drainingExecutors := NewDrainingExecutors()
+	drainingExecutors.SetDraining(sch.schedulingContext.ExecutorId, true)
+	sch.SetDrainingExecutors(drainingExecutors)
+	ctx := context.Background()
+
+	// New gangs are rejected without considering any nodes.
+	gctx := schedulercontext.NewGangSchedulingContext(
+		jobSchedulingContextsFromJobs(testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1), "", testfixtures.TestPriorityClasses),
+	)
+	ok, _, _, reason, err := sch.Schedule(ctx, gctx)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, schedulerconstraints.UnschedulableReasonExecutorDraining, reason)
+	assert.True(t, schedulerconstraints.IsTerminalUnschedulableReason(reason))
+	assert.Equal(t, "rejected: ExecutorDraining", gctx.JobSchedulingContexts[0].Rejection())
+	assert.Nil(t, gctx.JobSchedulingContexts[0].PodSchedulingContext)
+
+	// Evicted jobs may be re-scheduled, such that draining doesn't cause jobs to be preempted.
+	gctx = schedulercontext.NewGangSchedulingContext(
+		jobSchedulingContextsFromJobs(
+			testfixtures.WithAnnotationsJobs(
+				map[string]string{schedulerconfig.IsEvictedAnnotation: "true"},
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+			),
+			"",
+			testfixtures.TestPriorityClasses,
+		),
+	)
+	require.True(t, gctx.AllJobsEvicted)
+	ok, _, _, reason, err = sch.Schedule(ctx, gctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+
+	// Other executors are unaffected.
+	drainingExecutors.SetDraining(sch.schedulingContext.ExecutorId, false)
+	drainingExecutors.SetDraining("other", true)
+	gctx = schedulercontext.NewGangSchedulingContext(
+		jobSchedulingContextsFromJobs(testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1), "", testfixtures.TestPriorityClasses),
+	)
+	ok, _, _, _, err = sch.Schedule(ctx, gctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestGangSchedulerPausedQueue(t *testing.T) {
+	sch := newDryRunGangScheduler(t, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)...)
+	pausedQueues := NewPausedQueues()
+	pausedQueues.SetPaused("A", true)
+	sch.SetPausedQueues(pausedQueues)
+	ctx := context.Background()
+
+	// New gangs of the paused queue are rejected without considering any nodes.
+	gctx := schedulercontext.NewGangSchedulingContext(
+		jobSchedulingContextsFromJobs(testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 2), "", testfixtures.TestPriorityClasses),
+	)
+	ok, _, _, reason, err := sch.Schedule(ctx, gctx)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, schedulerconstraints.UnschedulableReasonQueuePaused, reason)
+	// Other queues may still be scheduled.
+	assert.False(t, schedulerconstraints.IsTerminalUnschedulableReason(reason))
+	for _, jctx := range gctx.JobSchedulingContexts {
+		assert.Equal(t, "rejected: QueuePaused", jctx.Rejection())
+		assert.Nil(t, jctx.PodSchedulingContext)
+	}
+
+	// Evicted jobs may be re-scheduled, such that pausing a queue doesn't cause its jobs to be preempted.
+	gctx = schedulercontext.NewGangSchedulingContext(
+		jobSchedulingContextsFromJobs(
+			testfixtures.WithAnnotationsJobs(
+				map[string]string{schedulerconfig.IsEvictedAnnotation: "true"},
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+			),
+			"",
+			testfixtures.TestPriorityClasses,
+		),
+	)
+	require.True(t, gctx.AllJobsEvicted)
+	ok, _, _, reason, err = sch.Schedule(ctx, gctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, reason)
+
+	// Other queues are unaffected.
+	gctx = schedulercontext.NewGangSchedulingContext(
+		jobSchedulingContextsFromJobs(testfixtures.N1CpuJobs("B", testfixtures.PriorityClass0, 1), "", testfixtures.TestPriorityClasses),
+	)
+	ok, _, _, _, err = sch.Schedule(ctx, gctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Resuming the queue allows its gangs to be scheduled again.
+	pausedQueues.SetPaused("A", false)
+	gctx = schedulercontext.NewGangSchedulingContext(
+		jobSchedulingContextsFromJobs(testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1), "", testfixtures.TestPriorityClasses),
+	)
+	ok, _, _, _, err = sch.Schedule(ctx, gctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestGangSchedulerSingleJobFastPath(t *testing.T) {
+	tests := map[string]struct {
+		// Jobs running on a single 32-core node before the job is scheduled.
+		RunningJobs      []*jobdb.Job
+		Job              *jobdb.Job
+		GangNodeSelector map[string]string
+		DeadlineExceeded bool
+		ExpectScheduled  bool
+	}{
+		"job fits": {
+			Job:             testfixtures.N16CpuJobs("B", testfixtures.PriorityClass0, 1)[0],
+			ExpectScheduled: true,
+		},
+		"job does not fit": {
+			RunningJobs: testfixtures.N1CpuJobs("A", testfixtures.PriorityClass3, 1),
+			Job:         testfixtures.N32CpuJobs("B", testfixtures.PriorityClass0, 1)[0],
+		},
+		"job fits after preemption": {
+			RunningJobs:     testfixtures.N32CpuJobs("A", testfixtures.PriorityClass0, 1),
+			Job:             testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1)[0],
+			ExpectScheduled: true,
+		},
+		"job does not fit even with preemption": {
+			RunningJobs: testfixtures.N32CpuJobs("A", testfixtures.PriorityClass2NonPreemptible, 1),
+			Job:         testfixtures.N16CpuJobs("B", testfixtures.PriorityClass3, 1)[0],
+		},
+		"gang node selector": {
+			Job:              testfixtures.N16CpuJobs("B", testfixtures.PriorityClass0, 1)[0],
+			GangNodeSelector: map[string]string{"foo": "bar"},
+		},
+		"deadline exceeded": {
+			Job:              testfixtures.N16CpuJobs("B", testfixtures.PriorityClass0, 1)[0],
+			DeadlineExceeded: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			node := testfixtures.N32CpuNodes(1, testfixtures.TestPriorities)[0]
+			jobRepo := NewInMemoryJobRepository(testfixtures.TestPriorityClasses)
+			for _, job := range tc.RunningJobs {
+				var err error
+				node, err = nodedb.BindPodToNode(PodRequirementFromLegacySchedulerJob(job, testfixtures.TestPriorityClasses), node)
+				require.NoError(t, err)
+				jobRepo.Enqueue(job)
+			}
+			sch := newDryRunGangScheduler(t, node)
+			sch.EnablePreemption(jobRepo, 3)
+			// Calling trySchedule and tryScheduleSingleJob directly bypasses the copy of the scheduling context
+			// made by Schedule in dry-run mode. Hence, each is given its own copy to record preempted jobs with.
+			sctx := sch.schedulingContext
+
+			ctx := context.Background()
+			if tc.DeadlineExceeded {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, time.Now().Add(-time.Second))
+				defer cancel()
+			}
+
+			gctx := schedulercontext.NewGangSchedulingContext(jobSchedulingContextsFromJobs([]*jobdb.Job{tc.Job}, "", testfixtures.TestPriorityClasses))
+			gctx.NodeSelector = tc.GangNodeSelector
+			sch.schedulingContext = sctx.Clone()
+			expectedOk, _, expectedReason, err := sch.trySchedule(ctx, gctx)
+			require.NoError(t, err)
+			expectedPctx := gctx.JobSchedulingContexts[0].PodSchedulingContext
+
+			gctx = schedulercontext.NewGangSchedulingContext(jobSchedulingContextsFromJobs([]*jobdb.Job{tc.Job}, "", testfixtures.TestPriorityClasses))
+			gctx.NodeSelector = tc.GangNodeSelector
+			sch.schedulingContext = sctx.Clone()
+			ok, _, reason, err := sch.tryScheduleSingleJob(ctx, gctx)
+			require.NoError(t, err)
+			pctx := gctx.JobSchedulingContexts[0].PodSchedulingContext
+
+			assert.Equal(t, tc.ExpectScheduled, expectedOk)
+			assert.Equal(t, expectedOk, ok)
+			assert.Equal(t, expectedReason, reason)
+			if expectedPctx == nil {
+				assert.Nil(t, pctx)
+			} else if assert.NotNil(t, pctx) {
+				assert.Equal(t, expectedPctx.Node == nil, pctx.Node == nil)
+			}
 		})
 	}
 }
+
+func BenchmarkGangSchedulerSingleJob(b *testing.B) {
+	nodes := testfixtures.N32CpuNodes(100, testfixtures.TestPriorities)
+	job := testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1)[0]
+	for name, trySchedule := range map[string]func(*GangScheduler, context.Context, *schedulercontext.GangSchedulingContext) (bool, []preemptionCandidate, *schedulerconstraints.RejectionReason, error){
+		"general path": (*GangScheduler).trySchedule,
+		"fast path":    (*GangScheduler).tryScheduleSingleJob,
+	} {
+		b.Run(name, func(b *testing.B) {
+			sch := newDryRunGangScheduler(b, nodes...)
+			gctx := schedulercontext.NewGangSchedulingContext(jobSchedulingContextsFromJobs([]*jobdb.Job{job}, "", testfixtures.TestPriorityClasses))
+			ctx := context.Background()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if ok, _, _, err := trySchedule(sch, ctx, gctx); err != nil || !ok {
+					b.Fatalf("failed to schedule job: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// preferredNodeAffinityTerms returns a single preferred node affinity term with the given weight,
+// matching nodes for which label is set to value.
+func preferredNodeAffinityTerms(weight int32, label, value string) []v1.PreferredSchedulingTerm {
+	return []v1.PreferredSchedulingTerm{
+		{
+			Weight: weight,
+			Preference: v1.NodeSelectorTerm{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{
+						Key:      label,
+						Operator: v1.NodeSelectorOpIn,
+						Values:   []string{value},
+					},
+				},
+			},
+		},
+	}
+}
+
+// newDryRunGangScheduler returns a dry-run GangScheduler backed by a NodeDb containing nodes,
+// such that scheduling leaves the NodeDb unchanged.
+func newDryRunGangScheduler(t require.TestingT, nodes ...*schedulerobjects.Node) *GangScheduler {
+	nodeDb, err := nodedb.NewNodeDb(
+		testfixtures.TestPriorityClasses,
+		testfixtures.TestMaxExtraNodesToConsider,
+		testfixtures.TestResources,
+		testfixtures.TestIndexedTaints,
+		testfixtures.TestIndexedNodeLabels,
+	)
+	require.NoError(t, err)
+	for _, node := range nodes {
+		require.NoError(t, nodeDb.Upsert(node))
+	}
+	config := testfixtures.TestSchedulingConfig()
+	sctx := schedulercontext.NewSchedulingContext(
+		"executor",
+		"pool",
+		config.Preemption.PriorityClasses,
+		config.Preemption.DefaultPriorityClass,
+		config.ResourceScarcity,
+		nodeDb.TotalResources(),
+	)
+	require.NoError(t, sctx.AddQueueSchedulingContext("A", 1, nil))
+	require.NoError(t, sctx.AddQueueSchedulingContext("B", 1, nil))
+	constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+		"pool",
+		nodeDb.TotalResources(),
+		schedulerobjects.ResourceList{},
+		config,
+	)
+	sch, err := NewGangScheduler(sctx, constraints, nodeDb)
+	require.NoError(t, err)
+	sch.DryRun()
+	return sch
+}
+
+// gangSchedulerState summarises the state of the SchedulingContext and NodeDb modified by the GangScheduler.
+type gangSchedulerState struct {
+	summary                      string
+	scheduledResourcesByPriority schedulerobjects.QuantityByPriorityAndResourceType
+	allocatedByQueueAndPriority  map[string]schedulerobjects.QuantityByPriorityAndResourceType
+}
+
+func getGangSchedulerState(t *testing.T, sctx *schedulercontext.SchedulingContext, nodeDb *nodedb.NodeDb) gangSchedulerState {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "scheduled jobs: %d, scheduled gangs: %d, evicted jobs: %d\n", sctx.NumScheduledJobs, sctx.NumScheduledGangs, sctx.NumEvictedJobs)
+	queues := maps.Keys(sctx.QueueSchedulingContexts)
+	slices.Sort(queues)
+	for _, queue := range queues {
+		qctx := sctx.QueueSchedulingContexts[queue]
+		fmt.Fprintf(
+			&sb, "%s: successful: %d, unsuccessful: %d, evicted: %d\n",
+			queue, len(qctx.SuccessfulJobSchedulingContexts), len(qctx.UnsuccessfulJobSchedulingContexts), len(qctx.EvictedJobsById),
+		)
+	}
+	txn := nodeDb.Txn(false)
+	defer txn.Abort()
+	it, err := nodedb.NewNodesIterator(txn)
+	require.NoError(t, err)
+	for node := it.NextNode(); node != nil; node = it.NextNode() {
+		fmt.Fprintf(&sb, "%s: %d jobs\n", node.Id, len(node.AllocatedByJobId))
+	}
+	return gangSchedulerState{
+		summary:                      sb.String(),
+		scheduledResourcesByPriority: sctx.ScheduledResourcesByPriority.DeepCopy(),
+		allocatedByQueueAndPriority:  sctx.AllocatedByQueueAndPriority(),
+	}
+}
+
+func assertGangSchedulerStateEqual(t *testing.T, expected, actual gangSchedulerState) {
+	assert.Equal(t, expected.summary, actual.summary)
+	assert.True(
+		t, expected.scheduledResourcesByPriority.Equal(actual.scheduledResourcesByPriority),
+		"expected %s, but got %s", expected.scheduledResourcesByPriority, actual.scheduledResourcesByPriority,
+	)
+	for _, queue := range append(maps.Keys(expected.allocatedByQueueAndPriority), maps.Keys(actual.allocatedByQueueAndPriority)...) {
+		assert.True(
+			t, expected.allocatedByQueueAndPriority[queue].Equal(actual.allocatedByQueueAndPriority[queue]),
+			"expected %s, but got %s for queue %s", expected.allocatedByQueueAndPriority[queue], actual.allocatedByQueueAndPriority[queue], queue,
+		)
+	}
+}
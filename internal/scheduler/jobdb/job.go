@@ -372,6 +372,15 @@ func (job *Job) LatestRun() *JobRun {
 	return job.activeRun
 }
 
+// ActiveRunStartTime returns the time at which the currently active run of the job was created, i.e., when the job
+// was last leased, and false if there are no runs yet.
+func (job *Job) ActiveRunStartTime() (time.Time, bool) {
+	if job.activeRun == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, job.activeRun.created), true
+}
+
 // RunById returns the Run corresponding to the provided run id or nil if no such Run exists.
 func (job *Job) RunById(id uuid.UUID) *JobRun {
 	return job.runsById[id]
@@ -193,6 +193,14 @@ func TestJob_TestWithUpdatedRun_AdditionalEarlierRun(t *testing.T) {
 	assert.Equal(t, baseRun, jobWithAdditionalRun.LatestRun())
 }
 
+func TestJob_TestActiveRunStartTime(t *testing.T) {
+	_, ok := baseJob.ActiveRunStartTime()
+	assert.False(t, ok)
+	startTime, ok := baseJob.WithUpdatedRun(baseRun).ActiveRunStartTime()
+	assert.True(t, ok)
+	assert.Equal(t, baseRun.created, startTime.UnixNano())
+}
+
 func TestJob_TestNumReturned(t *testing.T) {
 	returnedRun := func() *JobRun {
 		return &JobRun{
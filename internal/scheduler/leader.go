@@ -6,14 +6,33 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus/ctxlogrus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 
+	commonmetrics "github.com/armadaproject/armada/internal/common/metrics"
 	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 )
 
+var isLeaderGauge = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: commonmetrics.MetricPrefix + "scheduler_is_leader",
+		Help: "1 if this scheduler instance currently believes it is leader and 0 otherwise",
+	},
+)
+
+// setIsLeaderGauge updates the leader metric to reflect tok.
+func setIsLeaderGauge(tok LeaderToken) {
+	if tok.leader {
+		isLeaderGauge.Set(1)
+	} else {
+		isLeaderGauge.Set(0)
+	}
+}
+
 // LeaderController is an interface to be implemented by structs that control which scheduler is leader
 type LeaderController interface {
 	// GetToken returns a LeaderToken which allows you to determine if you are leader or not
@@ -21,6 +40,10 @@ type LeaderController interface {
 	// ValidateToken allows a caller to determine whether a previously obtained token is still valid.
 	// Returns true if the token is a leader and false otherwise
 	ValidateToken(tok LeaderToken) bool
+	// IsLeader returns true if this instance currently believes it is leader, e.g., for use in readiness probes.
+	// Callers that act on leadership should instead obtain a token and validate it, since leadership may be lost
+	// between calling IsLeader and acting on the result.
+	IsLeader() bool
 	// Run starts the controller.  This is a blocking call which will return when the provided context is cancelled
 	Run(ctx context.Context) error
 }
@@ -50,26 +73,60 @@ func NewLeaderToken() LeaderToken {
 // StandaloneLeaderController returns a token that always indicates you are leader
 // This can be used when only a single instance of the scheduler is needed
 type StandaloneLeaderController struct {
-	token LeaderToken
+	token atomic.Value
 }
 
 func NewStandaloneLeaderController() *StandaloneLeaderController {
-	return &StandaloneLeaderController{
-		token: NewLeaderToken(),
-	}
+	controller := &StandaloneLeaderController{}
+	controller.setToken(NewLeaderToken())
+	return controller
 }
 
 func (lc *StandaloneLeaderController) GetToken() LeaderToken {
-	return lc.token
+	return lc.token.Load().(LeaderToken)
+}
+
+// CurrentToken is an alias for GetToken. It exists alongside GetToken for callers that only inspect the controller's
+// state, e.g., readiness probes, for which "get" would wrongly suggest a token is being handed out.
+func (lc *StandaloneLeaderController) CurrentToken() LeaderToken {
+	return lc.GetToken()
 }
 
 func (lc *StandaloneLeaderController) ValidateToken(tok LeaderToken) bool {
 	if tok.leader {
-		return lc.token.id == tok.id
+		return lc.token.Load().(LeaderToken).id == tok.id
 	}
 	return false
 }
 
+// IsLeader returns true if the current token indicates this instance is leader.
+func (lc *StandaloneLeaderController) IsLeader() bool {
+	return lc.token.Load().(LeaderToken).leader
+}
+
+// Demote simulates losing leadership, e.g., for testing or failover drills.
+// Tokens handed out before calling Demote are no longer valid,
+// and tokens handed out afterwards indicate this instance is not leader until Promote is called.
+func (lc *StandaloneLeaderController) Demote() {
+	lc.setToken(InvalidLeaderToken())
+}
+
+// Promote reverses Demote, such that a new token indicating this instance is leader is handed out.
+// Tokens handed out before the preceding call to Demote remain invalid.
+// Does nothing if this instance is already leader, such that the current token remains valid.
+func (lc *StandaloneLeaderController) Promote() {
+	if lc.IsLeader() {
+		return
+	}
+	lc.setToken(NewLeaderToken())
+}
+
+// setToken replaces the current token and updates the leader metric accordingly.
+func (lc *StandaloneLeaderController) setToken(tok LeaderToken) {
+	lc.token.Store(tok)
+	setIsLeaderGauge(tok)
+}
+
 func (lc *StandaloneLeaderController) Run(ctx context.Context) error {
 	return nil
 }
@@ -87,10 +144,10 @@ type LeaseListener interface {
 //
 // TODO: Move into package in common.
 type KubernetesLeaderController struct {
-	client   coordinationv1client.LeasesGetter
-	token    atomic.Value
-	config   schedulerconfig.LeaderConfig // TODO: Move necessary config into this struct.
-	listener LeaseListener
+	client    coordinationv1client.LeasesGetter
+	token     atomic.Value
+	config    schedulerconfig.LeaderConfig // TODO: Move necessary config into this struct.
+	listeners []LeaseListener
 }
 
 func NewKubernetesLeaderController(config schedulerconfig.LeaderConfig, client coordinationv1client.LeasesGetter) *KubernetesLeaderController {
@@ -99,14 +156,41 @@ func NewKubernetesLeaderController(config schedulerconfig.LeaderConfig, client c
 		token:  atomic.Value{},
 		config: config,
 	}
-	controller.token.Store(InvalidLeaderToken())
+	controller.setToken(InvalidLeaderToken())
 	return controller
 }
 
+// RegisterListener configures the controller to notify listener whenever this instance starts or stops leading.
+// Listeners are notified after the token has been replaced. Hence, a listener notified of losing leadership
+// may rely on tokens obtained earlier no longer being valid.
+// Must be called before Run.
+func (lc *KubernetesLeaderController) RegisterListener(listener LeaseListener) {
+	lc.listeners = append(lc.listeners, listener)
+}
+
 func (lc *KubernetesLeaderController) GetToken() LeaderToken {
 	return lc.token.Load().(LeaderToken)
 }
 
+// CurrentToken is an alias for GetToken; see StandaloneLeaderController.CurrentToken.
+// The token is replaced whenever leadership changes.
+func (lc *KubernetesLeaderController) CurrentToken() LeaderToken {
+	return lc.GetToken()
+}
+
+// IsLeader returns true if the current token indicates this instance is leader.
+// The token is loaded atomically. Hence, the result is consistent with that of GetToken at some point during the call,
+// even if leadership changes concurrently.
+func (lc *KubernetesLeaderController) IsLeader() bool {
+	return lc.token.Load().(LeaderToken).leader
+}
+
+// setToken replaces the current token and updates the leader metric accordingly.
+func (lc *KubernetesLeaderController) setToken(tok LeaderToken) {
+	lc.token.Store(tok)
+	setIsLeaderGauge(tok)
+}
+
 func (lc *KubernetesLeaderController) ValidateToken(tok LeaderToken) bool {
 	if tok.leader {
 		return lc.token.Load().(LeaderToken).id == tok.id
@@ -135,16 +219,16 @@ func (lc *KubernetesLeaderController) Run(ctx context.Context) error {
 				Callbacks: leaderelection.LeaderCallbacks{
 					OnStartedLeading: func(c context.Context) {
 						log.Infof("I am now leader")
-						lc.token.Store(NewLeaderToken())
-						if lc.listener != nil {
-							lc.listener.onStartedLeading(ctx)
+						lc.setToken(NewLeaderToken())
+						for _, listener := range lc.listeners {
+							listener.onStartedLeading(ctx)
 						}
 					},
 					OnStoppedLeading: func() {
 						log.Infof("I am no longer leader")
-						lc.token.Store(InvalidLeaderToken())
-						if lc.listener != nil {
-							lc.listener.onStoppedLeading()
+						lc.setToken(InvalidLeaderToken())
+						for _, listener := range lc.listeners {
+							listener.onStoppedLeading()
 						}
 					},
 				},
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/coordination/v1"
@@ -107,7 +108,7 @@ func TestK8sLeaderController_BecomingLeader(t *testing.T) {
 			// Run the test
 			controller := NewKubernetesLeaderController(testLeaderConfig(), client)
 			testListener := NewTestLeaseListener(controller)
-			controller.listener = testListener
+			controller.RegisterListener(testListener)
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			go func() {
 				err := controller.Run(ctx)
@@ -145,6 +146,7 @@ func TestK8sLeaderController_BecomingLeader(t *testing.T) {
 					assert.False(t, validation)
 				}
 			}
+			assert.Equal(t, controller.CurrentToken().leader, controller.IsLeader())
 
 			// cancel the context to ensure we clean up the goroutine
 			cancel()
@@ -152,6 +154,75 @@ func TestK8sLeaderController_BecomingLeader(t *testing.T) {
 	}
 }
 
+func TestStandaloneLeaderController(t *testing.T) {
+	controller := NewStandaloneLeaderController()
+	assert.True(t, controller.IsLeader())
+	assert.Equal(t, controller.GetToken(), controller.CurrentToken())
+	assert.True(t, controller.ValidateToken(controller.CurrentToken()))
+	assert.Equal(t, 1.0, testutil.ToFloat64(isLeaderGauge))
+
+	controller.setToken(InvalidLeaderToken())
+	assert.False(t, controller.IsLeader())
+	assert.False(t, controller.ValidateToken(controller.CurrentToken()))
+	assert.Equal(t, 0.0, testutil.ToFloat64(isLeaderGauge))
+
+	oldToken := controller.CurrentToken()
+	controller.setToken(NewLeaderToken())
+	assert.True(t, controller.IsLeader())
+	assert.NotEqual(t, oldToken, controller.CurrentToken())
+	assert.Equal(t, 1.0, testutil.ToFloat64(isLeaderGauge))
+}
+
+func TestStandaloneLeaderController_DemotePromote(t *testing.T) {
+	controller := NewStandaloneLeaderController()
+	leaderToken := controller.GetToken()
+
+	// Promoting a leader doesn't invalidate its token.
+	controller.Promote()
+	assert.True(t, controller.ValidateToken(leaderToken))
+	assert.Equal(t, leaderToken, controller.GetToken())
+
+	controller.Demote()
+	assert.False(t, controller.IsLeader())
+	assert.False(t, controller.ValidateToken(leaderToken))
+	assert.False(t, controller.ValidateToken(controller.GetToken()))
+	assert.Equal(t, 0.0, testutil.ToFloat64(isLeaderGauge))
+
+	controller.Promote()
+	assert.True(t, controller.IsLeader())
+	assert.True(t, controller.ValidateToken(controller.GetToken()))
+	assert.NotEqual(t, leaderToken, controller.GetToken())
+	assert.False(t, controller.ValidateToken(leaderToken))
+	assert.Equal(t, 1.0, testutil.ToFloat64(isLeaderGauge))
+}
+
+func TestStandaloneLeaderController_ConcurrentTokenRotation(t *testing.T) {
+	controller := NewStandaloneLeaderController()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			if i%2 == 0 {
+				controller.setToken(InvalidLeaderToken())
+			} else {
+				controller.setToken(NewLeaderToken())
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			// Tokens that don't indicate leadership must never be considered valid.
+			if tok := controller.CurrentToken(); !tok.leader {
+				assert.False(t, controller.ValidateToken(tok))
+			}
+			controller.IsLeader()
+		}
+	}()
+	wg.Wait()
+}
+
 func testLeaderConfig() schedulerconfig.LeaderConfig {
 	return schedulerconfig.LeaderConfig{
 		LeaseLockName:      lockName,
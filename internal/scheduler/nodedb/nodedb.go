@@ -1,6 +1,7 @@
 package nodedb
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strings"
@@ -23,6 +24,16 @@ import (
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
 
+// Policies for selecting between nodes that are equally suitable for a pod.
+const (
+	// Select the first suitable node found.
+	NodeTieBreakPolicyNone = ""
+	// Select the suitable node with the least allocatable resources, comparing indexed resources in order.
+	NodeTieBreakPolicyLeastAvailable = "leastAvailable"
+	// Select the suitable node with the lowest id.
+	NodeTieBreakPolicyLowestNodeId = "lowestNodeId"
+)
+
 // evictedPriority is the priority class priority resources consumed by evicted jobs are accounted for at.
 // This helps avoid scheduling new jobs onto nodes that make it impossible to re-schedule evicted jobs.
 const evictedPriority int32 = -1
@@ -39,6 +50,16 @@ type NodeDb struct {
 	//
 	// TODO: Currently gives no benefit. Since all nodes are given the same score.
 	maxExtraNodesToConsider uint
+	// Determines which of the considered nodes is selected if several have the same score.
+	// If NodeTieBreakPolicyNone, the first node with the best possible score is selected
+	// without considering any further nodes.
+	// Otherwise, all matching nodes are considered, ignoring maxExtraNodesToConsider,
+	// such that the selected node doesn't depend on iteration order.
+	tieBreakPolicy string
+	// Rank of each node by id. Nodes with a lower rank are selected over equally scored nodes
+	// with a higher rank or without a rank, before applying the tie-break policy.
+	// If non-empty, all matching nodes are considered, as for tie-break policies.
+	nodeRanks map[string]int
 	// Allowed priority classes.
 	// Because the number of database indices scales linearly with the number of distinct priorities,
 	// the efficiency of the NodeDb relies on the number of distinct priorities being small.
@@ -164,6 +185,25 @@ func NewNodeDb(
 	}, nil
 }
 
+// SetTieBreakPolicy sets the policy used to select between equally suitable nodes.
+// Must be one of NodeTieBreakPolicyNone, NodeTieBreakPolicyLeastAvailable, or NodeTieBreakPolicyLowestNodeId.
+func (nodeDb *NodeDb) SetTieBreakPolicy(policy string) error {
+	switch policy {
+	case NodeTieBreakPolicyNone, NodeTieBreakPolicyLeastAvailable, NodeTieBreakPolicyLowestNodeId:
+		nodeDb.tieBreakPolicy = policy
+		return nil
+	default:
+		return errors.WithStack(&armadaerrors.ErrInvalidArgument{
+			Name:  "policy",
+			Value: policy,
+			Message: fmt.Sprintf(
+				"must be one of %q, %q, or %q",
+				NodeTieBreakPolicyNone, NodeTieBreakPolicyLeastAvailable, NodeTieBreakPolicyLowestNodeId,
+			),
+		})
+	}
+}
+
 func (nodeDb *NodeDb) String() string {
 	var sb strings.Builder
 	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
@@ -199,6 +239,13 @@ func (nodeDb *NodeDb) Txn(write bool) *memdb.Txn {
 	return nodeDb.db.Txn(write)
 }
 
+// SnapshotTxn returns a write transaction over a point-in-time snapshot of the db.
+// Changes made within the transaction are never visible via the NodeDb, even if the transaction is committed,
+// and the transaction doesn't wait for, or block, other write transactions.
+func (nodeDb *NodeDb) SnapshotTxn() *memdb.Txn {
+	return nodeDb.db.Snapshot().Txn(true)
+}
+
 // GetNode returns a node in the db with given id.
 func (nodeDb *NodeDb) GetNode(id string) (*schedulerobjects.Node, error) {
 	return nodeDb.GetNodeWithTxn(nodeDb.Txn(false), id)
@@ -266,11 +313,12 @@ func NodeJobDiff(txnA, txnB *memdb.Txn) (map[string]*schedulerobjects.Node, map[
 // ScheduleMany assigns a set of pods to nodes.
 // The assignment is atomic, i.e., either all pods are successfully assigned to nodes or none are.
 // The returned bool indicates whether assignment succeeded or not.
-// TODO: Pass through contexts to support timeouts.
-func (nodeDb *NodeDb) ScheduleMany(reqs []*schedulerobjects.PodRequirements) ([]*schedulercontext.PodSchedulingContext, bool, error) {
+// If ctx is cancelled or its deadline expires before all pods have been assigned,
+// no pods are assigned and the context error is returned.
+func (nodeDb *NodeDb) ScheduleMany(ctx context.Context, reqs []*schedulerobjects.PodRequirements) ([]*schedulercontext.PodSchedulingContext, bool, error) {
 	txn := nodeDb.db.Txn(true)
 	defer txn.Abort()
-	pctxs, ok, err := nodeDb.ScheduleManyWithTxn(txn, reqs)
+	pctxs, ok, err := nodeDb.ScheduleManyWithTxn(ctx, txn, reqs)
 	if ok && err == nil {
 		// All pods can be scheduled; commit the transaction.
 		txn.Commit()
@@ -283,10 +331,13 @@ func (nodeDb *NodeDb) ScheduleMany(reqs []*schedulerobjects.PodRequirements) ([]
 	return pctxs, ok, err
 }
 
-func (nodeDb *NodeDb) ScheduleManyWithTxn(txn *memdb.Txn, reqs []*schedulerobjects.PodRequirements) ([]*schedulercontext.PodSchedulingContext, bool, error) {
+func (nodeDb *NodeDb) ScheduleManyWithTxn(ctx context.Context, txn *memdb.Txn, reqs []*schedulerobjects.PodRequirements) ([]*schedulercontext.PodSchedulingContext, bool, error) {
 	// Attempt to schedule pods one by one in a transaction.
 	pctxs := make([]*schedulercontext.PodSchedulingContext, 0, len(reqs))
 	for _, req := range reqs {
+		if err := ctx.Err(); err != nil {
+			return pctxs, false, errors.WithStack(err)
+		}
 		pctx, err := nodeDb.SelectNodeForPodWithTxn(txn, req)
 		if err != nil {
 			return nil, false, err
@@ -421,6 +472,97 @@ func (nodeDb *NodeDb) SelectNodeForPodWithTxn(txn *memdb.Txn, req *schedulerobje
 	return pctx, nil
 }
 
+// ResolutionRoundingWithTxn returns a description of a node on which a pod that SelectNodeForPodWithTxn couldn't
+// assign to any node would have fit if not for resources being rounded down to the resolution with which they're indexed,
+// where pctx is the context returned by SelectNodeForPodWithTxn. Returns nil if the pod was assigned to a node
+// or if there's no such node.
+//
+// All nodes are iterated over. Hence, this method should only be called once it's been decided that the pod can't be
+// scheduled, and not for every attempt to schedule it.
+func (nodeDb *NodeDb) ResolutionRoundingWithTxn(
+	txn *memdb.Txn,
+	pctx *schedulercontext.PodSchedulingContext,
+	req *schedulerobjects.PodRequirements,
+) *schedulercontext.ResolutionRounding {
+	if pctx.Node != nil {
+		return nil
+	}
+	return nodeDb.resolutionRounding(txn, pctx.MatchingNodeTypes, nodeDb.highestPriorityTried(req), req)
+}
+
+// highestPriorityTried returns the highest priority at which SelectNodeForPodWithTxn tries to schedule the pod.
+func (nodeDb *NodeDb) highestPriorityTried(req *schedulerobjects.PodRequirements) int32 {
+	rv := req.Priority
+	if _, ok := req.NodeSelector[schedulerconfig.NodeIdLabel]; ok {
+		return rv
+	}
+	for _, priority := range nodeDb.prioritiesToTryAssigningAt {
+		if priority > req.Priority {
+			break
+		}
+		rv = priority
+	}
+	return rv
+}
+
+// resolutionRounding returns a description of a node of one of the provided types that has enough of each indexed
+// resource available at the given priority to schedule the pod, but for which the amount available of at least one
+// of those resources is less than requested once rounded down to the resolution with which that resource is indexed.
+// Such nodes are never considered for the pod. Returns nil if there's no such node.
+//
+// All nodes are iterated over. Nodes are iterated over in order of id, such that the result is deterministic.
+func (nodeDb *NodeDb) resolutionRounding(
+	txn *memdb.Txn,
+	nodeTypes []*schedulerobjects.NodeType,
+	priority int32,
+	req *schedulerobjects.PodRequirements,
+) *schedulercontext.ResolutionRounding {
+	if slices.IndexFunc(nodeDb.indexedResourceResolutionMillis, func(resolution int64) bool { return resolution > 1 }) == -1 {
+		return nil
+	}
+	nodeTypeIds := make(map[uint64]bool, len(nodeTypes))
+	for _, nodeType := range nodeTypes {
+		nodeTypeIds[nodeType.Id] = true
+	}
+	it, err := txn.Get("nodes", "id")
+	if err != nil {
+		return nil
+	}
+	for obj := it.Next(); obj != nil; obj = it.Next() {
+		node := obj.(*schedulerobjects.Node)
+		if !nodeTypeIds[node.NodeTypeId] {
+			continue
+		}
+		allocatable := node.AllocatableByPriorityAndResource[priority]
+		var rv *schedulercontext.ResolutionRounding
+		fits := true
+		for i, t := range nodeDb.indexedResources {
+			requested := req.ResourceRequirements.Requests[v1.ResourceName(t)]
+			available := allocatable.Get(t)
+			if available.Cmp(requested) == -1 {
+				fits = false
+				break
+			}
+			resolution := nodeDb.indexedResourceResolutionMillis[i]
+			rounded := resource.NewMilliQuantity((available.MilliValue()/resolution)*resolution, available.Format)
+			if rv == nil && rounded.Cmp(requested) == -1 {
+				rv = &schedulercontext.ResolutionRounding{
+					NodeId:     node.Id,
+					Resource:   t,
+					Requested:  requested.DeepCopy(),
+					Available:  available.DeepCopy(),
+					Rounded:    *rounded,
+					Resolution: *resource.NewMilliQuantity(resolution, available.Format),
+				}
+			}
+		}
+		if fits && rv != nil {
+			return rv
+		}
+	}
+	return nil
+}
+
 func (nodeDb *NodeDb) selectNodeForPodAtPriority(
 	txn *memdb.Txn,
 	pctx *schedulercontext.PodSchedulingContext,
@@ -471,10 +613,19 @@ func (nodeDb *NodeDb) selectNodeForPodWithIt(
 	var selectedNode *schedulerobjects.Node
 	var selectedNodeScore int
 	var numConsideredNodes uint
+	// Nodes only score higher than SchedulableBestScore by matching preferred node affinity terms of the pod.
+	// Until a node with the best attainable score is found, keep considering further nodes,
+	// such that preferred nodes are selected whenever possible.
+	bestScore := schedulerobjects.SchedulableBestScore
+	if !onlyCheckDynamicRequirements {
+		bestScore += schedulerobjects.MaxPreferredNodeAffinityScore(req)
+	}
 	for obj := it.Next(); obj != nil; obj = it.Next() {
 		node := obj.(*schedulerobjects.Node)
 		if node == nil {
-			return nil, nil
+			// Some iterators signal exhaustion by returning a nil node rather than nil.
+			// Keep any node selected so far.
+			break
 		}
 		var matches bool
 		var score int
@@ -488,10 +639,10 @@ func (nodeDb *NodeDb) selectNodeForPodWithIt(
 		if err != nil {
 			return nil, err
 		} else if matches {
-			if selectedNode == nil || score > selectedNodeScore {
+			if selectedNode == nil || score > selectedNodeScore || (score == selectedNodeScore && nodeDb.preferNode(node, selectedNode, priority)) {
 				selectedNode = node
 				selectedNodeScore = score
-				if selectedNodeScore == schedulerobjects.SchedulableBestScore {
+				if selectedNodeScore >= bestScore && !nodeDb.considersAllNodes() {
 					break
 				}
 			}
@@ -499,7 +650,9 @@ func (nodeDb *NodeDb) selectNodeForPodWithIt(
 			s := nodeDb.stringFromPodRequirementsNotMetReason(reason)
 			pctx.NumExcludedNodesByReason[s] += 1
 		}
-		if selectedNode != nil {
+		// With a tie-break policy or node ranks, keep scanning all matching nodes rather than only those within the
+		// maxExtraNodesToConsider window, since any later node with the same score may be preferred.
+		if selectedNode != nil && selectedNodeScore >= bestScore && !nodeDb.considersAllNodes() {
 			numConsideredNodes++
 			if numConsideredNodes == nodeDb.maxExtraNodesToConsider+1 {
 				break
@@ -511,6 +664,50 @@ func (nodeDb *NodeDb) selectNodeForPodWithIt(
 	return selectedNode, nil
 }
 
+// SetNodeRanks sets the rank of each node by id, used to select between nodes that are equally suitable for a pod.
+// Nodes with a lower rank are preferred, and nodes with a rank are preferred over nodes without one.
+// The tie-break policy is applied only between nodes of equal rank.
+func (nodeDb *NodeDb) SetNodeRanks(nodeRanks map[string]int) {
+	nodeDb.nodeRanks = nodeRanks
+}
+
+// considersAllNodes returns true if the node selected for a pod depends on the tie-break policy or node ranks,
+// in which case all matching nodes must be considered rather than stopping at the first with the best score.
+func (nodeDb *NodeDb) considersAllNodes() bool {
+	return nodeDb.tieBreakPolicy != NodeTieBreakPolicyNone || len(nodeDb.nodeRanks) > 0
+}
+
+// preferNode returns true if, according to the node ranks and tie-break policy of the NodeDb,
+// node should be selected over selectedNode when both are equally suitable.
+func (nodeDb *NodeDb) preferNode(node, selectedNode *schedulerobjects.Node, priority int32) bool {
+	if len(nodeDb.nodeRanks) > 0 {
+		rank, ok := nodeDb.nodeRanks[node.Id]
+		selectedRank, selectedOk := nodeDb.nodeRanks[selectedNode.Id]
+		if ok != selectedOk {
+			return ok
+		} else if rank != selectedRank {
+			return rank < selectedRank
+		}
+	}
+	switch nodeDb.tieBreakPolicy {
+	case NodeTieBreakPolicyLeastAvailable:
+		allocatable := node.AllocatableByPriorityAndResource[priority]
+		selectedAllocatable := selectedNode.AllocatableByPriorityAndResource[priority]
+		for _, t := range nodeDb.indexedResources {
+			q := allocatable.Get(t)
+			if c := q.Cmp(selectedAllocatable.Get(t)); c != 0 {
+				return c == -1
+			}
+		}
+		// Fall back to node id to make the choice deterministic.
+		return node.Id < selectedNode.Id
+	case NodeTieBreakPolicyLowestNodeId:
+		return node.Id < selectedNode.Id
+	default:
+		return false
+	}
+}
+
 // BindPodToNode returns a copy of node with req bound to it.
 func BindPodToNode(req *schedulerobjects.PodRequirements, node *schedulerobjects.Node) (*schedulerobjects.Node, error) {
 	jobId, err := JobIdFromPodRequirements(req)
@@ -747,6 +944,20 @@ func (nodeDb *NodeDb) Upsert(node *schedulerobjects.Node) error {
 	return nil
 }
 
+// hasEvictedResources returns true if less of any resource is allocatable at evictedPriority than the node has in total,
+// i.e., if any resources are allocated to evicted jobs. All resources are considered,
+// since jobs may request only some of them, e.g., only "nvidia.com/gpu".
+func hasEvictedResources(node *schedulerobjects.Node) bool {
+	allocatable := schedulerobjects.AllocatableByPriorityAndResourceType(node.AllocatableByPriorityAndResource)
+	for t, total := range node.TotalResources.Resources {
+		q := allocatable.Get(evictedPriority, t)
+		if q.Cmp(total) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (nodeDb *NodeDb) UpsertWithTxn(txn *memdb.Txn, node *schedulerobjects.Node) error {
 	if len(node.AllocatableByPriorityAndResource) == 0 {
 		return errors.Errorf("can't upsert node with AllocatableByPriorityAndResource: %v", node.AllocatableByPriorityAndResource)
@@ -758,11 +969,8 @@ func (nodeDb *NodeDb) UpsertWithTxn(txn *memdb.Txn, node *schedulerobjects.Node)
 
 	// Add an evictedPriority record to the node.
 	// TODO: We should make NodeDb responsible for creating new nodes and add this record at creation instead of upsert.
-	if len(node.EvictedJobRunIds) != 0 {
-		q := schedulerobjects.AllocatableByPriorityAndResourceType(node.AllocatableByPriorityAndResource).Get(evictedPriority, "cpu")
-		if q.Cmp(node.TotalResources.Get("cpu")) == 0 {
-			return errors.Errorf("inconsistent node accounting: node %s has evicted jobs but no evicted resources", node.Id)
-		}
+	if len(node.EvictedJobRunIds) != 0 && !hasEvictedResources(node) {
+		return errors.Errorf("inconsistent node accounting: node %s has evicted jobs but no evicted resources", node.Id)
 	}
 
 	// Ensure we track allocated resources at evictedPriority.
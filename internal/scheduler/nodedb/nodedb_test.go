@@ -1,6 +1,7 @@
 package nodedb
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -10,8 +11,12 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
 	armadamaps "github.com/armadaproject/armada/internal/common/maps"
+	"github.com/armadaproject/armada/internal/common/util"
 	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
 )
@@ -102,6 +107,80 @@ func TestSelectNodeForPod_NodeIdLabel_Failure(t *testing.T) {
 	}
 }
 
+func TestResolutionRoundingWithTxn(t *testing.T) {
+	tests := map[string]struct {
+		cpuResolution string
+		reqs          []*schedulerobjects.PodRequirements
+		expected      *schedulercontext.ResolutionRounding
+	}{
+		"excluded due to rounding": {
+			cpuResolution: "17",
+			reqs:          testfixtures.N16CpuPodReqs("A", 0, 1),
+			expected: &schedulercontext.ResolutionRounding{
+				NodeId:     "a",
+				Resource:   "cpu",
+				Requested:  resource.MustParse("16"),
+				Available:  resource.MustParse("16"),
+				Rounded:    resource.MustParse("0"),
+				Resolution: resource.MustParse("17"),
+			},
+		},
+		"no rounding": {
+			cpuResolution: "1",
+			reqs:          testfixtures.N32CpuPodReqs("A", 0, 1),
+		},
+		"insufficient resources regardless of rounding": {
+			cpuResolution: "17",
+			reqs:          testfixtures.N32CpuPodReqs("A", 0, 1),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			nodeDb, err := NewNodeDb(
+				testfixtures.TestPriorityClasses,
+				testfixtures.TestMaxExtraNodesToConsider,
+				[]configuration.IndexedResource{
+					{Name: "cpu", Resolution: resource.MustParse(tc.cpuResolution)},
+					{Name: "memory", Resolution: resource.MustParse("128Mi")},
+				},
+				testfixtures.TestIndexedTaints,
+				testfixtures.TestIndexedNodeLabels,
+			)
+			require.NoError(t, err)
+			nodes := testfixtures.WithIdsNodes(
+				[]string{"a", "b"},
+				testfixtures.WithUsedResourcesNodes(
+					3,
+					schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("16")}},
+					testfixtures.N32CpuNodes(2, testfixtures.TestPriorities),
+				),
+			)
+			require.NoError(t, nodeDb.UpsertMany(nodes))
+			for _, req := range tc.reqs {
+				txn := nodeDb.Txn(false)
+				pctx, err := nodeDb.SelectNodeForPodWithTxn(txn, req)
+				require.NoError(t, err)
+				require.Nil(t, pctx.Node)
+				// Finding such nodes is expensive and is only done when asked for explicitly.
+				assert.Nil(t, pctx.ResolutionRounding)
+				actual := nodeDb.ResolutionRoundingWithTxn(txn, pctx, req)
+				if tc.expected == nil {
+					assert.Nil(t, actual)
+					continue
+				}
+				if assert.NotNil(t, actual) {
+					assert.Equal(t, tc.expected.NodeId, actual.NodeId)
+					assert.Equal(t, tc.expected.Resource, actual.Resource)
+					assert.True(t, tc.expected.Requested.Equal(actual.Requested))
+					assert.True(t, tc.expected.Available.Equal(actual.Available))
+					assert.True(t, tc.expected.Rounded.Equal(actual.Rounded))
+					assert.True(t, tc.expected.Resolution.Equal(actual.Resolution))
+				}
+			}
+		})
+	}
+}
+
 func TestNodeBindingEvictionUnbinding(t *testing.T) {
 	node := testfixtures.Test8GpuNode(append(testfixtures.TestPriorities, evictedPriority))
 	req := testfixtures.N1GpuPodReqs("A", 0, 1)[0]
@@ -184,6 +263,44 @@ func TestNodeBindingEvictionUnbinding(t *testing.T) {
 	assert.Empty(t, unboundNode.EvictedJobRunIds)
 }
 
+func TestUpsertNodeWithEvictedJob(t *testing.T) {
+	tests := map[string]struct {
+		Node     *schedulerobjects.Node
+		Requests v1.ResourceList
+	}{
+		"cpu": {
+			Node:     testfixtures.Test32CpuNode(append(testfixtures.TestPriorities, evictedPriority)),
+			Requests: v1.ResourceList{"cpu": resource.MustParse("1")},
+		},
+		// Jobs may request resources other than cpu only.
+		"nvidia gpu only": {
+			Node:     testfixtures.Test8NvidiaGpuNode(append(testfixtures.TestPriorities, evictedPriority)),
+			Requests: v1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+		},
+		"ephemeral storage only": {
+			Node:     testfixtures.Test8NvidiaGpuNode(append(testfixtures.TestPriorities, evictedPriority)),
+			Requests: v1.ResourceList{"ephemeral-storage": resource.MustParse("100Gi")},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := testfixtures.TestPodReqs("A", util.ULID(), 0, tc.Requests)
+			node, err := BindPodToNode(req, tc.Node)
+			require.NoError(t, err)
+			node, err = EvictPodFromNode(req, node)
+			require.NoError(t, err)
+
+			nodeDb, err := createNodeDb([]*schedulerobjects.Node{node})
+			require.NoError(t, err)
+
+			pctx, err := nodeDb.SelectNodeForPod(req)
+			require.NoError(t, err)
+			require.NotNil(t, pctx.Node)
+			assert.Equal(t, node.Id, pctx.Node.Id)
+		})
+	}
+}
+
 func assertNodeAccountingEqual(t *testing.T, node1, node2 *schedulerobjects.Node) bool {
 	rv := true
 	rv = rv && assert.True(
@@ -426,7 +543,7 @@ func TestScheduleMany(t *testing.T) {
 				return
 			}
 			for i, reqs := range tc.Reqs {
-				reports, ok, err := nodeDb.ScheduleMany(reqs)
+				reports, ok, err := nodeDb.ScheduleMany(context.Background(), reqs)
 				if !assert.NoError(t, err) {
 					return
 				}
@@ -599,6 +716,56 @@ func BenchmarkSelectAndBindNodeToPodResourceConstrained(b *testing.B) {
 	)
 }
 
+func TestSetTieBreakPolicy(t *testing.T) {
+	nodeDb, err := createNodeDb(nil)
+	require.NoError(t, err)
+	for _, policy := range []string{NodeTieBreakPolicyNone, NodeTieBreakPolicyLeastAvailable, NodeTieBreakPolicyLowestNodeId} {
+		assert.NoError(t, nodeDb.SetTieBreakPolicy(policy))
+	}
+	err = nodeDb.SetTieBreakPolicy("foo")
+	var e *armadaerrors.ErrInvalidArgument
+	assert.ErrorAs(t, err, &e)
+}
+
+func TestSelectNodeForPod_TieBreakConsidersAllNodes(t *testing.T) {
+	// Nodes "b" and "c" have slightly less memory available than "a" and are hence iterated over first.
+	// With TestMaxExtraNodesToConsider = 1, node "a" is outside the window of considered nodes.
+	nodes := append(
+		testfixtures.WithIdsNodes(
+			[]string{"b", "c"},
+			testfixtures.WithUsedResourcesNodes(
+				0,
+				schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"memory": resource.MustParse("1Mi")}},
+				testfixtures.N32CpuNodes(2, testfixtures.TestPriorities),
+			),
+		),
+		testfixtures.WithIdsNodes([]string{"a"}, testfixtures.N32CpuNodes(1, testfixtures.TestPriorities))...,
+	)
+	nodeDb, err := createNodeDb(nodes)
+	require.NoError(t, err)
+	require.NoError(t, nodeDb.SetTieBreakPolicy(NodeTieBreakPolicyLowestNodeId))
+	for _, req := range testfixtures.N1CpuPodReqs("A", 0, 1) {
+		pctx, err := nodeDb.SelectNodeForPod(req)
+		require.NoError(t, err)
+		require.NotNil(t, pctx.Node)
+		assert.Equal(t, "a", pctx.Node.Id)
+	}
+}
+
+func TestSelectNodeForPod_NodeRanks(t *testing.T) {
+	nodes := testfixtures.WithIdsNodes([]string{"a", "b", "c"}, testfixtures.N32CpuNodes(3, testfixtures.TestPriorities))
+	nodeDb, err := createNodeDb(nodes)
+	require.NoError(t, err)
+	// Node "b" has no rank and is hence considered last.
+	nodeDb.SetNodeRanks(map[string]int{"a": 1, "c": 0})
+	for _, req := range testfixtures.N1CpuPodReqs("A", 0, 1) {
+		pctx, err := nodeDb.SelectNodeForPod(req)
+		require.NoError(t, err)
+		require.NotNil(t, pctx.Node)
+		assert.Equal(t, "c", pctx.Node.Id)
+	}
+}
+
 func createNodeDb(nodes []*schedulerobjects.Node) (*NodeDb, error) {
 	db, err := NewNodeDb(
 		testfixtures.TestPriorityClasses,
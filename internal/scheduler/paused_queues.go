@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// PausedQueues is the set of queues for which scheduling is paused, e.g., while investigating a misbehaving workload.
+// No new gangs are scheduled from a paused queue.
+// Jobs of a paused queue that are already running are unaffected and may be re-scheduled after being evicted,
+// such that pausing a queue never causes its jobs to be preempted.
+//
+// PausedQueues is safe for concurrent use; the set is read once per scheduling round and written rarely.
+type PausedQueues struct {
+	// Names of paused queues.
+	// Replaced rather than mutated, such that readers never need to take mu.
+	queuesP atomic.Pointer[map[string]bool]
+	// Protects against concurrent and dirty writes.
+	mu sync.Mutex
+}
+
+func NewPausedQueues() *PausedQueues {
+	p := &PausedQueues{}
+	queues := make(map[string]bool)
+	p.queuesP.Store(&queues)
+	return p
+}
+
+// SetPaused marks the given queue as paused if paused is true, and otherwise marks it as no longer paused.
+func (p *PausedQueues) SetPaused(queue string, paused bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	queues := maps.Clone(*p.queuesP.Load())
+	if paused {
+		queues[queue] = true
+	} else {
+		delete(queues, queue)
+	}
+	p.queuesP.Store(&queues)
+}
+
+// IsPaused returns true if scheduling is paused for the given queue.
+// A nil PausedQueues contains no queues.
+func (p *PausedQueues) IsPaused(queue string) bool {
+	if p == nil {
+		return false
+	}
+	return (*p.queuesP.Load())[queue]
+}
+
+// Queues returns the sorted names of all paused queues.
+func (p *PausedQueues) Queues() []string {
+	if p == nil {
+		return nil
+	}
+	queues := maps.Keys(*p.queuesP.Load())
+	slices.Sort(queues)
+	return queues
+}
@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPausedQueues(t *testing.T) {
+	p := NewPausedQueues()
+	assert.False(t, p.IsPaused("A"))
+	assert.Empty(t, p.Queues())
+
+	p.SetPaused("B", true)
+	p.SetPaused("A", true)
+	p.SetPaused("A", true)
+	assert.True(t, p.IsPaused("A"))
+	assert.True(t, p.IsPaused("B"))
+	assert.Equal(t, []string{"A", "B"}, p.Queues())
+
+	p.SetPaused("A", false)
+	p.SetPaused("C", false)
+	assert.False(t, p.IsPaused("A"))
+	assert.Equal(t, []string{"B"}, p.Queues())
+
+	// A nil set contains no queues, such that schedulers need not check whether one was provided.
+	var nilPausedQueues *PausedQueues
+	assert.False(t, nilPausedQueues.IsPaused("B"))
+	assert.Empty(t, nilPausedQueues.Queues())
+}
@@ -46,6 +46,16 @@ type PreemptingQueueScheduler struct {
 	skipUnsuccessfulSchedulingKeyCheck bool
 	// If true, asserts that the nodeDb state is consistent with expected changes.
 	enableAssertions bool
+	// If the executor of the scheduling context is in this set, no new jobs are scheduled; see DrainingExecutors.
+	drainingExecutors *DrainingExecutors
+	// No new jobs are scheduled from queues in this set; see PausedQueues.
+	pausedQueues *PausedQueues
+	// If true, jobs may be preempted to make room for new gangs; see GangScheduler.EnablePreemption.
+	enableGangPreemption bool
+	// Only jobs with priority strictly below this threshold are preempted to make room for new gangs.
+	gangPreemptionPriorityThreshold int32
+	// Jobs that have been running for less than this are not preempted to make room for new gangs.
+	gangPreemptionMinimumRuntime time.Duration
 }
 
 func NewPreemptingQueueScheduler(
@@ -93,6 +103,23 @@ func (sch *PreemptingQueueScheduler) SkipUnsuccessfulSchedulingKeyCheck() {
 	sch.skipUnsuccessfulSchedulingKeyCheck = true
 }
 
+func (sch *PreemptingQueueScheduler) SetDrainingExecutors(drainingExecutors *DrainingExecutors) {
+	sch.drainingExecutors = drainingExecutors
+}
+
+func (sch *PreemptingQueueScheduler) SetPausedQueues(pausedQueues *PausedQueues) {
+	sch.pausedQueues = pausedQueues
+}
+
+// EnableGangPreemption allows jobs to be preempted to make room for new gangs;
+// see GangScheduler.EnablePreemption and GangScheduler.SetPreemptionMinimumRuntime.
+// Jobs preempted in this way are included in the PreemptedJobs of the result returned by Schedule.
+func (sch *PreemptingQueueScheduler) EnableGangPreemption(priorityThreshold int32, minimumRuntime time.Duration) {
+	sch.enableGangPreemption = true
+	sch.gangPreemptionPriorityThreshold = priorityThreshold
+	sch.gangPreemptionMinimumRuntime = minimumRuntime
+}
+
 // Schedule
 // - preempts jobs belonging to queues with total allocation above their fair share and
 // - schedules new jobs belonging to queues with total allocation less than their fair share.
@@ -106,6 +133,7 @@ func (sch *PreemptingQueueScheduler) Schedule(ctx context.Context) (*SchedulerRe
 			"no resources with non-zero weight available for scheduling on any cluster: resource scarcity %v, total resources %v",
 			sch.schedulingContext.ResourceScarcity, sch.schedulingContext.TotalResources,
 		)
+		sch.schedulingContext.RoundOutcome = schedulercontext.RoundOutcomeNoSchedulableResources
 		return &SchedulerResult{}, nil
 	}
 	if ResourceListAsWeightedMillis(sch.schedulingContext.ResourceScarcity, sch.nodeDb.TotalResources()) == 0 {
@@ -114,6 +142,7 @@ func (sch *PreemptingQueueScheduler) Schedule(ctx context.Context) (*SchedulerRe
 			"no resources with non-zero weight available for scheduling in NodeDb: resource scarcity %v, total resources %v",
 			sch.schedulingContext.ResourceScarcity, sch.nodeDb.TotalResources(),
 		)
+		sch.schedulingContext.RoundOutcome = schedulercontext.RoundOutcomeNoSchedulableResources
 		return &SchedulerResult{}, nil
 	}
 	defer func() {
@@ -170,6 +199,9 @@ func (sch *PreemptingQueueScheduler) Schedule(ctx context.Context) (*SchedulerRe
 			scheduledJobsById[job.GetId()] = job
 		}
 	}
+	for _, job := range schedulerResult.PreemptedJobs {
+		preemptedJobsById[job.GetId()] = job
+	}
 	maps.Copy(sch.nodeIdByJobId, schedulerResult.NodeIdByJobId)
 
 	// Evict jobs on oversubscribed nodes.
@@ -231,6 +263,9 @@ func (sch *PreemptingQueueScheduler) Schedule(ctx context.Context) (*SchedulerRe
 			}
 			delete(scheduledAndEvictedJobsById, job.GetId())
 		}
+		for _, job := range schedulerResult.PreemptedJobs {
+			preemptedJobsById[job.GetId()] = job
+		}
 		maps.Copy(sch.nodeIdByJobId, schedulerResult.NodeIdByJobId)
 	}
 
@@ -496,16 +531,25 @@ func (sch *PreemptingQueueScheduler) schedule(ctx context.Context, inMemoryJobRe
 	if sch.skipUnsuccessfulSchedulingKeyCheck {
 		sched.SkipUnsuccessfulSchedulingKeyCheck()
 	}
+	sched.SetDrainingExecutors(sch.drainingExecutors)
+	sched.SetPausedQueues(sch.pausedQueues)
+	if sch.enableGangPreemption {
+		sched.EnablePreemption(sch.jobRepo, sch.gangPreemptionPriorityThreshold)
+		sched.SetPreemptionMinimumRuntime(sch.gangPreemptionMinimumRuntime)
+	}
 	result, err := sched.Schedule(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if len(result.PreemptedJobs) != 0 {
+	if len(result.PreemptedJobs) != 0 && !sch.enableGangPreemption {
 		return nil, errors.New("unexpected preemptions during scheduling")
 	}
-	if err := sch.updateGangAccounting(nil, result.ScheduledJobs); err != nil {
+	if err := sch.updateGangAccounting(result.PreemptedJobs, result.ScheduledJobs); err != nil {
 		return nil, err
 	}
+	if s := JobsSummary(result.PreemptedJobs); s != "" {
+		log.Infof("preempted %d jobs to make room for gangs; %s", len(result.PreemptedJobs), s)
+	}
 	if s := JobsSummary(result.ScheduledJobs); s != "" {
 		log.Infof("re-scheduled %d jobs; %s", len(result.ScheduledJobs), s)
 	}
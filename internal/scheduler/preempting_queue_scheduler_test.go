@@ -309,6 +309,45 @@ func TestPreemptingQueueScheduler(t *testing.T) {
 				"B": 1,
 			},
 		},
+		"preempting to make room for new gangs": {
+			// With oversubscription eviction disabled, the gang only fits if the gang scheduler preempts jobs for it.
+			SchedulingConfig: testfixtures.WithGangPreemptionConfig(
+				3,
+				testfixtures.WithNodeOversubscriptionEvictionProbabilityConfig(
+					0,
+					testfixtures.WithNodeEvictionProbabilityConfig(0, testfixtures.TestSchedulingConfig()),
+				),
+			),
+			Nodes: testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Rounds: []SchedulingRound{
+				{
+					JobsByQueue: map[string][]*jobdb.Job{
+						"A": testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 32),
+					},
+					ExpectedScheduledIndices: map[string][]int{
+						"A": testfixtures.IntRange(0, 31),
+					},
+				},
+				{
+					JobsByQueue: map[string][]*jobdb.Job{
+						"B": testfixtures.WithGangAnnotationsJobs(testfixtures.N1CpuJobs("B", testfixtures.PriorityClass3, 2)),
+					},
+					ExpectedScheduledIndices: map[string][]int{
+						"B": testfixtures.IntRange(0, 1),
+					},
+					ExpectedPreemptedIndices: map[string]map[int][]int{
+						"A": {
+							0: testfixtures.IntRange(0, 1),
+						},
+					},
+				},
+				{}, // Empty round to make sure nothing changes.
+			},
+			PriorityFactorByQueue: map[string]float64{
+				"A": 1,
+				"B": 1,
+			},
+		},
 		"urgency-based preemption stability": {
 			SchedulingConfig: testfixtures.TestSchedulingConfig(),
 			Nodes:            testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
@@ -1210,6 +1249,12 @@ func TestPreemptingQueueScheduler(t *testing.T) {
 					gangIdByJobId,
 				)
 				sch.EnableAssertions()
+				if tc.SchedulingConfig.Preemption.EnableGangPreemption {
+					sch.EnableGangPreemption(
+						tc.SchedulingConfig.Preemption.GangPreemptionPriorityThreshold,
+						tc.SchedulingConfig.Preemption.GangPreemptionMinimumRuntime,
+					)
+				}
 				result, err := sch.Schedule(ctxlogrus.ToContext(context.Background(), log))
 				require.NoError(t, err)
 				jobIdsByGangId = sch.jobIdsByGangId
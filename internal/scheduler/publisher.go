@@ -10,10 +10,17 @@ import (
 	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+	"golang.org/x/sync/semaphore"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/eventutil"
+	commonmetrics "github.com/armadaproject/armada/internal/common/metrics"
 	"github.com/armadaproject/armada/internal/common/schedulers"
 	"github.com/armadaproject/armada/pkg/armadaevents"
 )
@@ -22,33 +29,173 @@ const (
 	// This is half the default pulsar BatchingMaxSize
 	defaultMaxMessageBatchSize = 64 * 1024
 	explicitPartitionKey       = "armada_pulsar_partition"
+
+	eventsMessageType = "events"
+	markerMessageType = "marker"
+	publishSucceeded  = "success"
+	publishFailed     = "failure"
+
+	invalidSequenceReasonNil            = "nil_sequence"
+	invalidSequenceReasonMissingJobSet  = "missing_jobset"
+	invalidSequenceReasonNoEvents       = "no_events"
+	invalidSequenceReasonMissingPayload = "missing_event_payload"
+)
+
+var (
+	publishLatencyHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    commonmetrics.MetricPrefix + "scheduler_pulsar_publish_latency_seconds",
+			Help:    "Time from sending a message to pulsar until the send is confirmed or fails",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15),
+		},
+		[]string{"type", "result"},
+	)
+	publishedMessagesCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: commonmetrics.MetricPrefix + "scheduler_pulsar_published_messages_total",
+			Help: "Number of messages sent to pulsar",
+		},
+		[]string{"type", "result"},
+	)
+	publishedEventsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: commonmetrics.MetricPrefix + "scheduler_pulsar_published_events_total",
+			Help: "Number of events contained in messages sent to pulsar",
+		},
+		[]string{"type", "result"},
+	)
+	publishedBytesCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: commonmetrics.MetricPrefix + "scheduler_pulsar_published_bytes_total",
+			Help: "Number of payload bytes of messages sent to pulsar",
+		},
+		[]string{"type", "result"},
+	)
+	invalidEventSequencesCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: commonmetrics.MetricPrefix + "scheduler_pulsar_invalid_event_sequences_total",
+			Help: "Number of event sequences not published to pulsar because they failed validation",
+		},
+		[]string{"reason"},
+	)
 )
 
+// recordPublish updates publish metrics for a send of a message of the given type started at start.
+// err is the error returned by the send, if any.
+func recordPublish(messageType string, start time.Time, numEvents int, numBytes int, err error) {
+	result := publishSucceeded
+	if err != nil {
+		result = publishFailed
+	}
+	publishLatencyHistogram.WithLabelValues(messageType, result).Observe(time.Since(start).Seconds())
+	publishedMessagesCounter.WithLabelValues(messageType, result).Inc()
+	publishedEventsCounter.WithLabelValues(messageType, result).Add(float64(numEvents))
+	publishedBytesCounter.WithLabelValues(messageType, result).Add(float64(numBytes))
+}
+
+// outgoingMessage is a message to be published, together with the number of events it contains
+// and the producer it should be sent with.
+type outgoingMessage struct {
+	msg       *pulsar.ProducerMessage
+	numEvents int
+	producer  pulsar.Producer
+}
+
+// TopicSelector returns the Pulsar topic an event sequence should be published to.
+// The empty string indicates the publisher's primary topic.
+type TopicSelector func(sequence *armadaevents.EventSequence) string
+
+// MarkerPartitionSelector returns the partitions PublishMarkers publishes a marker to,
+// given the number of partitions of the publisher's primary topic.
+type MarkerPartitionSelector func(numPartitions int) []uint32
+
+// MarkerKeyFunc returns the Pulsar key of the marker published to partition as part of the group with the given id.
+// Markers are always routed to their partition explicitly; the key only affects, e.g., ordering on Key_Shared subscriptions.
+// The empty string indicates no key.
+type MarkerKeyFunc func(groupId uuid.UUID, partition uint32) string
+
+// AllPartitions is the default MarkerPartitionSelector; it selects every partition of the topic.
+func AllPartitions(numPartitions int) []uint32 {
+	partitions := make([]uint32, numPartitions)
+	for i := range partitions {
+		partitions[i] = uint32(i)
+	}
+	return partitions
+}
+
 // Publisher is an interface to be implemented by structs that handle publishing messages to pulsar
 type Publisher interface {
 	// PublishMessages will publish the supplied messages. A LeaderToken is provided and the
 	// implementor may decide whether to publish based on the status of this token
 	PublishMessages(ctx context.Context, events []*armadaevents.EventSequence, shouldPublish func() bool) error
 
-	// PublishMarkers publishes a single marker message for each Pulsar partition.  Each marker
+	// PublishMarkers publishes a single marker message for each selected Pulsar partition.  Each marker
 	// massage contains the supplied group id, which allows all marker messages for a given call
-	// to be identified.  The uint32 returned is the number of messages published
-	PublishMarkers(ctx context.Context, groupId uuid.UUID) (uint32, error)
+	// to be identified.  The result records which partitions were targeted and which of those a marker was published to.
+	PublishMarkers(ctx context.Context, groupId uuid.UUID) (*MarkerPublishResult, error)
 }
 
 // PulsarPublisher is the default implementation of Publisher
 type PulsarPublisher struct {
-	// Used to send messages to pulsar
+	// Used to send messages to the primary pulsar topic, i.e., producerOptions.Topic.
 	producer pulsar.Producer
+	// Used to create producers for topics other than the primary topic.
+	pulsarClient    pulsar.Client
+	producerOptions pulsar.ProducerOptions
+	// If non-nil, selects the topic each event sequence is published to.
+	// If nil, all event sequences are published to the primary topic.
+	topicSelector TopicSelector
+	// Selects the partitions PublishMarkers publishes to. AllPartitions if nil.
+	markerPartitionSelector MarkerPartitionSelector
+	// If non-nil, determines the key of each marker message. If nil, markers have no key.
+	markerKeyFunc MarkerKeyFunc
+	// Number of times markers that failed to publish are retried before PublishMarkersToPartitions returns.
+	maxMarkerRetries uint
+	// Time to wait before each retry of failed markers.
+	markerRetryDelay time.Duration
+	// Producers for topics returned by topicSelector, created lazily.
+	// Protected by producersMu.
+	producersByTopic map[string]pulsar.Producer
+	producersMu      sync.Mutex
 	// Number of partitions on the pulsar topic
 	numPartitions int
-	// Timeout after which async messages sends will be considered failed
+	// Timeout after which async messages sends will be considered failed.
+	// Applies to each message separately, from when it's handed to the producer.
 	pulsarSendTimeout time.Duration
+	// Number of times sending a message is retried before PublishMessages returns an error; see SetSendRetries.
+	maxSendRetries uint
+	// Time to wait before retrying failed sends; doubled for each subsequent retry.
+	sendRetryBackoff time.Duration
+	// If true, the messages of each jobset are sent one at a time; see SetPreserveJobSetOrder.
+	preserveJobSetOrder bool
+	// Bounds the number of sends outstanding at any one time; see SetMaxInFlightSends.
+	// Nil if the number of outstanding sends is unbounded.
+	inFlight *semaphore.Weighted
 	// Maximum size (in bytes) of produced pulsar messages.
 	// This must be below 4MB which is the pulsar message size limit
 	maxMessageBatchSize uint
+	// If positive, onStoppedLeading waits up to this long for outstanding sends to complete.
+	demotionDrainTimeout time.Duration
+	// If true, event sequences are validated before publishing; see SetValidateEventSequences.
+	validateEventSequences bool
+	// If non-nil, PublishMessages fails fast while Pulsar is failing; see SetCircuitBreaker.
+	circuitBreaker *circuitBreaker
+	// Protects demoted, outstandingSends, and sendsSettled.
+	sendsMu sync.Mutex
+	// True if leadership has been lost since the publisher was last notified of becoming leader.
+	// No new sends are started while demoted.
+	demoted bool
+	// Number of sends started but not yet completed.
+	outstandingSends int
+	// Closed once outstandingSends drops to zero; replaced when a send is started with none outstanding.
+	sendsSettled chan struct{}
 }
 
+// NewPulsarPublisher returns a PulsarPublisher that publishes to producerOptions.Topic.
+// Messages are compressed according to producerOptions.CompressionType and producerOptions.CompressionLevel,
+// which the scheduler sets from its Pulsar configuration.
+// Compression applies to all messages sent by the producer; it can't be enabled for large messages only,
+// since doing so would require a second producer, and messages sent via different producers may be reordered.
 func NewPulsarPublisher(
 	pulsarClient pulsar.Client,
 	producerOptions pulsar.ProducerOptions,
@@ -67,104 +214,662 @@ func NewPulsarPublisher(
 	if maxMessageBatchSize <= 0 {
 		maxMessageBatchSize = defaultMaxMessageBatchSize
 	}
+	sendsSettled := make(chan struct{})
+	close(sendsSettled)
 	return &PulsarPublisher{
 		producer:            producer,
+		pulsarClient:        pulsarClient,
+		producerOptions:     producerOptions,
+		producersByTopic:    map[string]pulsar.Producer{producerOptions.Topic: producer},
 		pulsarSendTimeout:   pulsarSendTimeout,
 		maxMessageBatchSize: maxMessageBatchSize,
 		numPartitions:       len(partitions),
+		sendsSettled:        sendsSettled,
 	}, nil
 }
 
+// SetSendRetries configures PublishMessages to retry messages that failed to send up to maxRetries times,
+// waiting for backoff before the first retry and doubling the wait for each subsequent retry.
+// Leadership is checked before each retry. By default, failed messages aren't retried.
+func (p *PulsarPublisher) SetSendRetries(maxRetries uint, backoff time.Duration) {
+	p.maxSendRetries = maxRetries
+	p.sendRetryBackoff = backoff
+}
+
+// SetPreserveJobSetOrder configures PublishMessages to send the messages of each jobset one at a time,
+// each only once the previous one has been sent, such that messages are never reordered within a jobset,
+// even if sends need to be retried. Messages of different jobsets are still sent concurrently.
+// By default, all messages are sent concurrently.
+func (p *PulsarPublisher) SetPreserveJobSetOrder(preserve bool) {
+	p.preserveJobSetOrder = preserve
+}
+
+// SetMaxInFlightSends bounds the number of sends outstanding at any one time to maxInFlight.
+// A maxInFlight of zero or less removes the bound, which is the default.
+// Must not be called while messages are being published.
+func (p *PulsarPublisher) SetMaxInFlightSends(maxInFlight int) {
+	if maxInFlight <= 0 {
+		p.inFlight = nil
+		return
+	}
+	p.inFlight = semaphore.NewWeighted(int64(maxInFlight))
+}
+
+// SetTopicSelector configures the publisher to publish each event sequence to the topic returned by selector,
+// e.g., to publish different types of events to topics with different retention.
+// Producers for topics other than the primary topic are created the first time a message is published to them,
+// using the same options as the primary producer. Markers are always published to the primary topic.
+func (p *PulsarPublisher) SetTopicSelector(selector TopicSelector) {
+	p.topicSelector = selector
+}
+
+// SetMarkerPartitionSelector configures the partitions PublishMarkers publishes a marker to,
+// e.g., only some partitions for a recovery scoped to those partitions. By default, markers are published to all partitions.
+func (p *PulsarPublisher) SetMarkerPartitionSelector(selector MarkerPartitionSelector) {
+	p.markerPartitionSelector = selector
+}
+
+// SetMarkerKeyFunc configures the key of each marker message, e.g., a correlation id shared with other messages
+// that should be ordered with respect to the markers. By default, markers have no key.
+func (p *PulsarPublisher) SetMarkerKeyFunc(keyFunc MarkerKeyFunc) {
+	p.markerKeyFunc = keyFunc
+}
+
+// SetMarkerRetries configures PublishMarkers and PublishMarkersToPartitions to, if publishing to some partitions fails,
+// wait for delay and then retry only those partitions, up to maxRetries times, before returning the partitions that
+// still failed. Partitions are never published to again once their marker has been published successfully.
+// By default, failed partitions aren't retried.
+func (p *PulsarPublisher) SetMarkerRetries(maxRetries uint, delay time.Duration) {
+	p.maxMarkerRetries = maxRetries
+	p.markerRetryDelay = delay
+}
+
+// SetDemotionDrainTimeout configures the publisher to, on losing leadership, wait up to timeout for sends already
+// started to complete, e.g., such that callbacks for the old term have fired before this instance re-enters
+// leader election. Sends are never started once leadership is lost, regardless of timeout.
+// The publisher is only notified of leadership changes if registered as a listener with the leader controller;
+// see KubernetesLeaderController.RegisterListener.
+func (p *PulsarPublisher) SetDemotionDrainTimeout(timeout time.Duration) {
+	p.demotionDrainTimeout = timeout
+}
+
+// SetValidateEventSequences configures the publisher to check that event sequences satisfy basic invariants
+// before publishing them, such that malformed sequences are caught here rather than by downstream consumers.
+// Each sequence must be non-nil, have a jobset name, and contain at least one event, each with its payload set.
+// If any sequence is invalid, nothing is published and an error describing all invalid sequences is returned.
+func (p *PulsarPublisher) SetValidateEventSequences(validate bool) {
+	p.validateEventSequences = validate
+}
+
+// SetCircuitBreaker configures the publisher to, once failureThreshold consecutive calls to PublishMessages
+// have failed to send messages, fail further calls immediately for cooldown, after which a single call is allowed
+// through to probe whether Pulsar has recovered. This avoids every cycle waiting for send timeouts while Pulsar is down.
+// Only calls made while leader count towards the threshold, and calls abandoned because leadership was lost
+// or the context was cancelled don't count as failures. A failureThreshold of zero or less disables the breaker.
+// Markers are unaffected by the breaker.
+func (p *PulsarPublisher) SetCircuitBreaker(failureThreshold int, cooldown time.Duration) {
+	if failureThreshold <= 0 {
+		p.circuitBreaker = nil
+		return
+	}
+	p.circuitBreaker = newCircuitBreaker("pulsar_publisher", failureThreshold, cooldown, clock.RealClock{})
+}
+
+// validateEventSequences returns an error describing each of the provided sequences that's invalid;
+// see SetValidateEventSequences. Invalid sequences are counted by invalidEventSequencesCounter.
+func validateEventSequences(sequences []*armadaevents.EventSequence) error {
+	var result *multierror.Error
+	for i, sequence := range sequences {
+		if reason, err := validateEventSequence(sequence); err != nil {
+			invalidEventSequencesCounter.WithLabelValues(reason).Inc()
+			result = multierror.Append(result, errors.WithMessagef(err, "event sequence %d", i))
+		}
+	}
+	if err := result.ErrorOrNil(); err != nil {
+		return errors.WithMessagef(err, "refusing to publish %d invalid event sequence(s) to Pulsar", len(result.Errors))
+	}
+	return nil
+}
+
+// validateEventSequence returns an error and a short reason, for use as a metric label, if sequence is invalid.
+func validateEventSequence(sequence *armadaevents.EventSequence) (string, error) {
+	if sequence == nil {
+		return invalidSequenceReasonNil, errors.New("sequence is nil")
+	}
+	if sequence.JobSetName == "" {
+		return invalidSequenceReasonMissingJobSet, errors.Errorf("sequence of queue %q has no jobset name", sequence.Queue)
+	}
+	if len(sequence.Events) == 0 {
+		return invalidSequenceReasonNoEvents, errors.Errorf("sequence for jobset %s contains no events", sequence.JobSetName)
+	}
+	for i, event := range sequence.Events {
+		if event == nil || event.Event == nil {
+			return invalidSequenceReasonMissingPayload, errors.Errorf("event %d of sequence for jobset %s has no payload", i, sequence.JobSetName)
+		}
+	}
+	return "", nil
+}
+
+// onStartedLeading allows the publisher to start sends again after having lost leadership.
+func (p *PulsarPublisher) onStartedLeading(_ context.Context) {
+	p.sendsMu.Lock()
+	defer p.sendsMu.Unlock()
+	p.demoted = false
+}
+
+// onStoppedLeading stops the publisher from starting new sends, such that batches being published when leadership
+// is lost are abandoned. If a demotion drain timeout is set, blocks until all sends already started have completed
+// or the timeout expires.
+func (p *PulsarPublisher) onStoppedLeading() {
+	p.sendsMu.Lock()
+	p.demoted = true
+	outstandingSends := p.outstandingSends
+	sendsSettled := p.sendsSettled
+	p.sendsMu.Unlock()
+	if p.demotionDrainTimeout <= 0 || outstandingSends == 0 {
+		return
+	}
+	log.Infof("lost leadership with %d send(s) to Pulsar outstanding; waiting up to %s for them to complete", outstandingSends, p.demotionDrainTimeout)
+	select {
+	case <-sendsSettled:
+	case <-time.After(p.demotionDrainTimeout):
+		log.Warnf("timed out after %s waiting for sends to Pulsar to complete after losing leadership", p.demotionDrainTimeout)
+	}
+}
+
+// startSend records that a send is about to start. Returns an error if leadership has been lost,
+// in which case the send must not be started. Otherwise, the call must be matched by a call to finishSend.
+func (p *PulsarPublisher) startSend() error {
+	p.sendsMu.Lock()
+	defer p.sendsMu.Unlock()
+	if p.demoted {
+		return errors.New("lost leadership; not sending message to Pulsar")
+	}
+	if p.outstandingSends == 0 {
+		p.sendsSettled = make(chan struct{})
+	}
+	p.outstandingSends++
+	return nil
+}
+
+// finishSend records that a send started by startSend has completed.
+func (p *PulsarPublisher) finishSend() {
+	p.sendsMu.Lock()
+	defer p.sendsMu.Unlock()
+	p.outstandingSends--
+	if p.outstandingSends == 0 {
+		close(p.sendsSettled)
+	}
+}
+
+// producerForTopic returns the producer for topic, creating one if none exists yet.
+// The empty string indicates the primary topic.
+func (p *PulsarPublisher) producerForTopic(topic string) (pulsar.Producer, error) {
+	if topic == "" {
+		return p.producer, nil
+	}
+	p.producersMu.Lock()
+	defer p.producersMu.Unlock()
+	if producer, ok := p.producersByTopic[topic]; ok {
+		return producer, nil
+	}
+	options := p.producerOptions
+	options.Topic = topic
+	options.MessageRouter = createMessageRouter(options)
+	producer, err := p.pulsarClient.CreateProducer(options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create producer for topic %s", topic)
+	}
+	p.producersByTopic[topic] = producer
+	return producer, nil
+}
+
+// sequencesByTopic partitions sequences by the topic returned by topicSelector.
+// Topics are returned in the order in which they're first selected, such that publishing is deterministic.
+func (p *PulsarPublisher) sequencesByTopic(sequences []*armadaevents.EventSequence) ([]string, map[string][]*armadaevents.EventSequence) {
+	if p.topicSelector == nil {
+		return []string{""}, map[string][]*armadaevents.EventSequence{"": sequences}
+	}
+	var topics []string
+	rv := make(map[string][]*armadaevents.EventSequence)
+	for _, sequence := range sequences {
+		topic := p.topicSelector(sequence)
+		if _, ok := rv[topic]; !ok {
+			topics = append(topics, topic)
+		}
+		rv[topic] = append(rv[topic], sequence)
+	}
+	return topics, rv
+}
+
 // PublishMessages publishes all event sequences to pulsar. Event sequences for a given jobset will be combined into
 // single event sequences up to maxMessageBatchSize.
+// Messages that fail to send are retried up to maxSendRetries times with exponential backoff;
+// an error is returned only if some message still hasn't been sent after that.
+// Messages are keyed by jobset, such that all messages of a jobset are routed to the same partition.
+// Unless preserveJobSetOrder is set, retried messages may be published after later messages of the same jobset.
+// If maxInFlight is positive, at most that many sends are outstanding at any one time.
+// If a topic selector is set, each event sequence is published to the selected topic
+// and sequences are only combined with others published to the same topic.
 func (p *PulsarPublisher) PublishMessages(ctx context.Context, events []*armadaevents.EventSequence, shouldPublish func() bool) error {
-	sequences := eventutil.CompactEventSequences(events)
-	sequences, err := eventutil.LimitSequencesByteSize(sequences, p.maxMessageBatchSize, true)
-	if err != nil {
-		return err
+	_, err := p.PublishMessagesWithIds(ctx, events, shouldPublish)
+	return err
+}
+
+// PublishMessagesWithIds publishes messages in the same way as PublishMessages, but blocks until all sends have been
+// confirmed and returns the id pulsar assigned to each published message, e.g., to checkpoint the last published message.
+// Because event sequences are combined before publishing, ids correspond to published messages rather than to the
+// provided event sequences; messages of a given jobset appear in the order they were produced in.
+// If shouldPublish returns false before anything is sent, nothing is published and no ids are returned.
+// If an error is returned, the ids of messages that were published successfully are still returned,
+// with nil entries for messages that failed to send.
+func (p *PulsarPublisher) PublishMessagesWithIds(
+	ctx context.Context,
+	events []*armadaevents.EventSequence,
+	shouldPublish func() bool,
+) ([]pulsar.MessageID, error) {
+	return p.publishMessages(ctx, events, shouldPublish, nil)
+}
+
+// PublishMessagesWithSequenceId publishes messages in the same way as PublishMessagesWithIds, but sets the Pulsar
+// sequence id of each published message, such that Pulsar discards messages already published by an earlier call,
+// e.g., when republishing events after a crash. Messages are assigned consecutive sequence ids starting from
+// firstSequenceId; since event sequences are combined deterministically, republishing the same events with the same
+// firstSequenceId results in the same messages with the same sequence ids.
+//
+// Pulsar only discards a message if its sequence id is at most the highest sequence id it has seen from the producer.
+// Hence, this requires deduplication to be enabled for the namespace or topic, the producer to have a name
+// that's stable across restarts (see pulsar.ProducerOptions.Name), and callers to use sequence ids that increase
+// across calls, e.g., derived from a persisted counter; ids derived from hashing message contents would not increase
+// and would cause Pulsar to discard messages that aren't duplicates.
+// Returns the sequence id following the last one assigned, which may be used as firstSequenceId for the next call;
+// if nothing was published because shouldPublish returned false, firstSequenceId is returned.
+func (p *PulsarPublisher) PublishMessagesWithSequenceId(
+	ctx context.Context,
+	events []*armadaevents.EventSequence,
+	shouldPublish func() bool,
+	firstSequenceId int64,
+) ([]pulsar.MessageID, int64, error) {
+	if firstSequenceId < 0 {
+		return nil, firstSequenceId, errors.Errorf("sequence id must be non-negative, but got %d", firstSequenceId)
 	}
-	msgs := make([]*pulsar.ProducerMessage, len(sequences))
-	for i, sequence := range sequences {
-		bytes, err := proto.Marshal(sequence)
+	nextSequenceId := firstSequenceId
+	ids, err := p.publishMessages(ctx, events, shouldPublish, &nextSequenceId)
+	if ids == nil && err == nil {
+		// Nothing was published, so the sequence ids assigned may be reused.
+		return nil, firstSequenceId, nil
+	}
+	return ids, nextSequenceId, err
+}
+
+// publishMessages implements PublishMessagesWithIds and PublishMessagesWithSequenceId.
+// If nextSequenceId is non-nil, messages are assigned consecutive sequence ids starting from *nextSequenceId,
+// which is then updated to the sequence id following the last one assigned.
+func (p *PulsarPublisher) publishMessages(
+	ctx context.Context,
+	events []*armadaevents.EventSequence,
+	shouldPublish func() bool,
+	nextSequenceId *int64,
+) ([]pulsar.MessageID, error) {
+	if p.validateEventSequences {
+		if err := validateEventSequences(events); err != nil {
+			return nil, err
+		}
+	}
+	var msgs []*outgoingMessage
+	topics, eventsByTopic := p.sequencesByTopic(events)
+	for _, topic := range topics {
+		producer, err := p.producerForTopic(topic)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		msgs[i] = &pulsar.ProducerMessage{
-			Payload: bytes,
-			Key:     sequences[i].JobSetName,
-			Properties: map[string]string{
-				schedulers.PropertyName: schedulers.PulsarSchedulerAttribute,
-			},
+		sequences := eventutil.CompactEventSequences(eventsByTopic[topic])
+		sequences, err = eventutil.LimitSequencesByteSize(sequences, p.maxMessageBatchSize, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, sequence := range sequences {
+			bytes, err := proto.Marshal(sequence)
+			if err != nil {
+				return nil, err
+			}
+			msg := &pulsar.ProducerMessage{
+				Payload: bytes,
+				Key:     sequence.JobSetName,
+				Properties: map[string]string{
+					schedulers.PropertyName: schedulers.PulsarSchedulerAttribute,
+				},
+			}
+			if nextSequenceId != nil {
+				sequenceId := *nextSequenceId
+				msg.SequenceID = &sequenceId
+				*nextSequenceId++
+			}
+			msgs = append(msgs, &outgoingMessage{
+				msg:       msg,
+				numEvents: len(sequence.Events),
+				producer:  producer,
+			})
 		}
 	}
 
-	wg := sync.WaitGroup{}
-	wg.Add(len(msgs))
+	if !shouldPublish() {
+		log.Debugf("No longer leader so not publishing")
+		return nil, nil
+	}
+	log.Debugf("Am leader so will publish")
+	// Only consulted once leadership has been checked, such that a scheduler that isn't leader never trips the breaker.
+	if err := p.circuitBreaker.allow(); err != nil {
+		return nil, errors.WithMessage(err, "not publishing to Pulsar")
+	}
+	ids, err := p.sendMessages(ctx, msgs, shouldPublish)
+	if p.circuitBreaker != nil {
+		switch {
+		case err == nil:
+			p.circuitBreaker.recordSuccess()
+		case ctx.Err() != nil || !shouldPublish():
+			p.circuitBreaker.recordAbandoned()
+		default:
+			p.circuitBreaker.recordFailure()
+		}
+	}
+	return ids, err
+}
 
-	// Send messages
-	if shouldPublish() {
+// sendMessages sends msgs to Pulsar, retrying failed sends; see PublishMessages.
+// Returns the id of each message sent successfully at the same index as the message.
+func (p *PulsarPublisher) sendMessages(ctx context.Context, msgs []*outgoingMessage, shouldPublish func() bool) ([]pulsar.MessageID, error) {
+	ids := make([]pulsar.MessageID, len(msgs))
+	if p.preserveJobSetOrder {
+		return ids, p.sendAllInOrder(ctx, msgs, ids, shouldPublish)
+	}
+
+	// Send messages, retrying those that failed to send up to maxSendRetries times.
+	// Leadership is checked before each retry, such that a scheduler that's no longer leader stops publishing.
+	pending := make([]int, len(msgs))
+	for i := range pending {
+		pending[i] = i
+	}
+	backoff := p.sendRetryBackoff
+	for attempt := uint(0); ; attempt++ {
+		pending = p.sendAll(ctx, msgs, pending, ids)
+		if len(pending) == 0 {
+			return ids, nil
+		}
+		if attempt >= p.maxSendRetries {
+			return ids, errors.New("One or more messages failed to send to Pulsar")
+		}
+		log.Warnf("%d message(s) failed to send to Pulsar, will wait for %s before retrying", len(pending), backoff)
+		select {
+		case <-ctx.Done():
+			return ids, errors.WithStack(ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if !shouldPublish() {
+			return ids, errors.Errorf("lost leadership with %d message(s) not yet sent to Pulsar", len(pending))
+		}
 		log.Debugf("Am leader so will publish")
-		sendCtx, cancel := context.WithTimeout(ctx, p.pulsarSendTimeout)
-		errored := false
-		for _, msg := range msgs {
-			p.producer.SendAsync(sendCtx, msg, func(_ pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
-				if err != nil {
-					log.WithError(err).Error("error sending message to Pulsar")
-					errored = true
-				}
+	}
+}
+
+// sendAll sends the messages at the given indices of msgs to Pulsar asynchronously and waits for all sends to complete.
+// The id of each message sent successfully is stored at the same index of ids.
+// Returns the indices of the messages that failed to send.
+// Waiting for a send slot is bounded only by ctx; each send is bounded by pulsarSendTimeout once it has a slot.
+func (p *PulsarPublisher) sendAll(ctx context.Context, msgs []*outgoingMessage, indices []int, ids []pulsar.MessageID) []int {
+	wg := sync.WaitGroup{}
+	wg.Add(len(indices))
+	failed := make([]bool, len(indices))
+	for i, index := range indices {
+		i, index := i, index
+		if err := p.acquireSendSlot(ctx); err != nil {
+			log.WithError(err).Errorf("error waiting to send %d message(s) to Pulsar", len(indices)-i)
+			for j := i; j < len(indices); j++ {
+				failed[j] = true
 				wg.Done()
-			})
+			}
+			break
 		}
-		wg.Wait()
-		cancel()
-		if errored {
-			return errors.New("One or more messages failed to send to Pulsar")
+		sendCtx, cancel := context.WithTimeout(ctx, p.pulsarSendTimeout)
+		start := time.Now()
+		msgs[index].producer.SendAsync(sendCtx, msgs[index].msg, func(id pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+			cancel()
+			p.releaseSendSlot()
+			recordPublish(eventsMessageType, start, msgs[index].numEvents, len(msgs[index].msg.Payload), err)
+			if err != nil {
+				log.WithError(err).Error("error sending message to Pulsar")
+				failed[i] = true
+			} else {
+				ids[index] = id
+			}
+			wg.Done()
+		})
+	}
+	wg.Wait()
+	var failedIndices []int
+	for i, index := range indices {
+		if failed[i] {
+			failedIndices = append(failedIndices, index)
+		}
+	}
+	return failedIndices
+}
+
+// sendAllInOrder sends the messages of each jobset one at a time, retrying each message up to maxSendRetries times
+// before moving on to the next message of that jobset. Jobsets are processed concurrently.
+// If a message can't be sent, no later messages of its jobset are sent.
+// The id of each message sent successfully is stored at the same index of ids.
+func (p *PulsarPublisher) sendAllInOrder(ctx context.Context, msgs []*outgoingMessage, ids []pulsar.MessageID, shouldPublish func() bool) error {
+	indicesByJobSet := make(map[string][]int)
+	for i, msg := range msgs {
+		indicesByJobSet[msg.msg.Key] = append(indicesByJobSet[msg.msg.Key], i)
+	}
+	var mu sync.Mutex
+	var result *multierror.Error
+	wg := sync.WaitGroup{}
+	wg.Add(len(indicesByJobSet))
+	for _, indices := range indicesByJobSet {
+		indices := indices
+		go func() {
+			defer wg.Done()
+			if err := p.sendInOrder(ctx, msgs, indices, ids, shouldPublish); err != nil {
+				mu.Lock()
+				result = multierror.Append(result, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return result.ErrorOrNil()
+}
+
+// sendInOrder sends the messages at the given indices of msgs one at a time, retrying failed sends; see sendAllInOrder.
+func (p *PulsarPublisher) sendInOrder(
+	ctx context.Context,
+	msgs []*outgoingMessage,
+	indices []int,
+	ids []pulsar.MessageID,
+	shouldPublish func() bool,
+) error {
+	for i, index := range indices {
+		msg := msgs[index]
+		backoff := p.sendRetryBackoff
+		for attempt := uint(0); ; attempt++ {
+			id, err := p.send(ctx, msg)
+			if err == nil {
+				ids[index] = id
+				break
+			}
+			log.WithError(err).Error("error sending message to Pulsar")
+			if attempt >= p.maxSendRetries {
+				return errors.WithMessagef(err, "failed to send message for jobset %s to Pulsar; %d later message(s) not sent", msg.msg.Key, len(indices)-i-1)
+			}
+			select {
+			case <-ctx.Done():
+				return errors.WithStack(ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if !shouldPublish() {
+				return errors.Errorf("lost leadership with %d message(s) for jobset %s not yet sent to Pulsar", len(indices)-i, msg.msg.Key)
+			}
 		}
-	} else {
-		log.Debugf("No longer leader so not publishing")
 	}
 	return nil
 }
 
-// PublishMarkers sends one pulsar message (containing an armadaevents.PartitionMarker) to each partition
-// of the producer's Pulsar topic.
-func (p *PulsarPublisher) PublishMarkers(ctx context.Context, groupId uuid.UUID) (uint32, error) {
-	for i := 0; i < p.numPartitions; i++ {
-		pm := &armadaevents.PartitionMarker{
-			GroupId:   armadaevents.ProtoUuidFromUuid(groupId),
-			Partition: uint32(i),
-		}
-		es := &armadaevents.EventSequence{
-			Queue:      "armada-scheduler",
-			JobSetName: "armada-scheduler",
-			Events: []*armadaevents.EventSequence_Event{
-				{
-					Created: now(),
-					Event: &armadaevents.EventSequence_Event_PartitionMarker{
-						PartitionMarker: pm,
-					},
-				},
-			},
+// send sends a single message to Pulsar and waits for the send to complete.
+// As with sendAll, the send is bounded by pulsarSendTimeout only once a send slot has been acquired.
+func (p *PulsarPublisher) send(ctx context.Context, msg *outgoingMessage) (pulsar.MessageID, error) {
+	if err := p.acquireSendSlot(ctx); err != nil {
+		return nil, err
+	}
+	sendCtx, cancel := context.WithTimeout(ctx, p.pulsarSendTimeout)
+	defer cancel()
+	type sendResult struct {
+		id  pulsar.MessageID
+		err error
+	}
+	done := make(chan sendResult, 1)
+	start := time.Now()
+	msg.producer.SendAsync(sendCtx, msg.msg, func(id pulsar.MessageID, _ *pulsar.ProducerMessage, err error) {
+		p.releaseSendSlot()
+		recordPublish(eventsMessageType, start, msg.numEvents, len(msg.msg.Payload), err)
+		done <- sendResult{id: id, err: err}
+	})
+	result := <-done
+	return result.id, result.err
+}
+
+// acquireSendSlot blocks until fewer than maxInFlight sends are outstanding or ctx is cancelled.
+// Returns an error without blocking if leadership has been lost; see onStoppedLeading.
+// Each successful call must be matched by a call to releaseSendSlot once the send completes.
+func (p *PulsarPublisher) acquireSendSlot(ctx context.Context) error {
+	if p.inFlight != nil {
+		if err := p.inFlight.Acquire(ctx, 1); err != nil {
+			return errors.WithStack(err)
 		}
-		bytes, err := proto.Marshal(es)
-		if err != nil {
-			return 0, err
+	}
+	// Checked after acquiring a slot, since leadership may have been lost while waiting for one.
+	if err := p.startSend(); err != nil {
+		if p.inFlight != nil {
+			p.inFlight.Release(1)
 		}
-		msg := &pulsar.ProducerMessage{
-			Properties: map[string]string{
-				explicitPartitionKey:    fmt.Sprintf("%d", i),
-				schedulers.PropertyName: schedulers.PulsarSchedulerAttribute,
-			},
-			Payload: bytes,
+		return err
+	}
+	return nil
+}
+
+func (p *PulsarPublisher) releaseSendSlot() {
+	if p.inFlight != nil {
+		p.inFlight.Release(1)
+	}
+	p.finishSend()
+}
+
+// PublishMarkers sends one pulsar message (containing an armadaevents.PartitionMarker) to each partition
+// of the producer's Pulsar topic selected by the publisher's MarkerPartitionSelector; see SetMarkerPartitionSelector.
+// Equivalent to calling PublishMarkersToPartitions with the selected partitions.
+func (p *PulsarPublisher) PublishMarkers(ctx context.Context, groupId uuid.UUID) (*MarkerPublishResult, error) {
+	selector := p.markerPartitionSelector
+	if selector == nil {
+		selector = AllPartitions
+	}
+	return p.PublishMarkersToPartitions(ctx, groupId, selector(p.numPartitions))
+}
+
+// MarkerPublishResult records which partitions a call to PublishMarkersToPartitions sent a marker to.
+type MarkerPublishResult struct {
+	// Partitions a marker was to be published to, in the order they were attempted.
+	// Each is in exactly one of Published and Failed.
+	Targeted []uint32
+	// Partitions for which the marker was published successfully.
+	Published []uint32
+	// Partitions for which publishing the marker failed.
+	Failed []uint32
+}
+
+// PublishMarkersToPartitions sends one pulsar message (containing an armadaevents.PartitionMarker) to each of the
+// provided partitions. Unlike PublishMarkers, a failure to publish to one partition doesn't prevent publishing to
+// the others; the returned MarkerPublishResult records the outcome for each partition, such that callers can retry
+// only those partitions that failed. If any partition failed, an error is returned alongside the result.
+// Failed partitions are first retried internally if configured via SetMarkerRetries; the result then records
+// partitions in the order their markers were published, and the error only relates to partitions that still failed.
+func (p *PulsarPublisher) PublishMarkersToPartitions(ctx context.Context, groupId uuid.UUID, partitions []uint32) (*MarkerPublishResult, error) {
+	result := &MarkerPublishResult{Targeted: slices.Clone(partitions)}
+	pending := partitions
+	for attempt := uint(0); ; attempt++ {
+		var errs *multierror.Error
+		var failed []uint32
+		for _, partition := range pending {
+			if err := p.publishMarker(ctx, groupId, partition); err != nil {
+				failed = append(failed, partition)
+				errs = multierror.Append(errs, errors.WithMessagef(err, "failed to publish marker to partition %d", partition))
+			} else {
+				result.Published = append(result.Published, partition)
+			}
 		}
-		// use a synchronous send here as the logic is simpler.
-		// We send relatively few position markers so the performance penalty shouldn't be meaningful
-		_, err = p.producer.Send(ctx, msg)
-		if err != nil {
-			return 0, err
+		if len(failed) == 0 || attempt >= p.maxMarkerRetries {
+			result.Failed = failed
+			return result, errs.ErrorOrNil()
+		}
+		log.Warnf("failed to publish markers to %d partition(s), will wait for %s before retrying", len(failed), p.markerRetryDelay)
+		select {
+		case <-ctx.Done():
+			result.Failed = failed
+			return result, multierror.Append(errs, errors.WithStack(ctx.Err())).ErrorOrNil()
+		case <-time.After(p.markerRetryDelay):
 		}
+		pending = failed
+	}
+}
+
+// publishMarker sends a single marker message to the given partition.
+func (p *PulsarPublisher) publishMarker(ctx context.Context, groupId uuid.UUID, partition uint32) error {
+	if int(partition) >= p.numPartitions {
+		return errors.Errorf("partition %d out of range; topic has %d partitions", partition, p.numPartitions)
+	}
+	pm := &armadaevents.PartitionMarker{
+		GroupId:   armadaevents.ProtoUuidFromUuid(groupId),
+		Partition: partition,
+	}
+	es := &armadaevents.EventSequence{
+		Queue:      "armada-scheduler",
+		JobSetName: "armada-scheduler",
+		Events: []*armadaevents.EventSequence_Event{
+			{
+				Created: now(),
+				Event: &armadaevents.EventSequence_Event_PartitionMarker{
+					PartitionMarker: pm,
+				},
+			},
+		},
+	}
+	bytes, err := proto.Marshal(es)
+	if err != nil {
+		return err
+	}
+	msg := &pulsar.ProducerMessage{
+		Properties: map[string]string{
+			explicitPartitionKey:    fmt.Sprintf("%d", partition),
+			schedulers.PropertyName: schedulers.PulsarSchedulerAttribute,
+		},
+		Payload: bytes,
+	}
+	if p.markerKeyFunc != nil {
+		msg.Key = p.markerKeyFunc(groupId, partition)
+	}
+	// use a synchronous send here as the logic is simpler.
+	// We send relatively few position markers so the performance penalty shouldn't be meaningful
+	if err := p.startSend(); err != nil {
+		return err
 	}
-	return uint32(p.numPartitions), nil
+	defer p.finishSend()
+	start := time.Now()
+	_, err = p.producer.Send(ctx, msg)
+	recordPublish(markerMessageType, start, len(es.Events), len(bytes), err)
+	return err
 }
 
 // createMessageRouter returns a custom Pulsar message router that routes the message to the partition given by the
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,8 +13,12 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/armadaproject/armada/internal/common/mocks"
 	"github.com/armadaproject/armada/internal/common/pulsarutils"
@@ -29,8 +34,11 @@ func TestPulsarPublisher_TestPublish(t *testing.T) {
 	tests := map[string]struct {
 		eventSequences         []*armadaevents.EventSequence
 		numSuccessfulPublishes int
-		amLeader               bool
-		expectedError          bool
+		// If non-zero, the first numFailedPublishes sends fail, regardless of numSuccessfulPublishes.
+		numFailedPublishes int
+		maxSendRetries     uint
+		amLeader           bool
+		expectedError      bool
 	}{
 		"Publish if leader": {
 			amLeader:               true,
@@ -86,6 +94,34 @@ func TestPulsarPublisher_TestPublish(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		"Retry failed publishes": {
+			amLeader:               true,
+			numSuccessfulPublishes: math.MaxInt,
+			numFailedPublishes:     2,
+			maxSendRetries:         2,
+			eventSequences: []*armadaevents.EventSequence{
+				{
+					JobSetName: "jobset1",
+					Events:     []*armadaevents.EventSequence_Event{{}},
+				},
+				{
+					JobSetName: "jobset2",
+					Events:     []*armadaevents.EventSequence_Event{{}},
+				},
+			},
+		},
+		"Return error if publishes fail after all retries": {
+			amLeader:               true,
+			numSuccessfulPublishes: 0,
+			maxSendRetries:         2,
+			eventSequences: []*armadaevents.EventSequence{
+				{
+					JobSetName: "jobset1",
+					Events:     []*armadaevents.EventSequence_Event{{}},
+				},
+			},
+			expectedError: true,
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -98,6 +134,7 @@ func TestPulsarPublisher_TestPublish(t *testing.T) {
 			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
 			numPublished := 0
 			var capturedEvents []*armadaevents.EventSequence
+			var publishedEvents []*armadaevents.EventSequence
 			expectedCounts := make(map[string]int)
 			if tc.amLeader {
 				expectedCounts = countEvents(tc.eventSequences)
@@ -112,9 +149,10 @@ func TestPulsarPublisher_TestPublish(t *testing.T) {
 					require.NoError(t, err)
 					capturedEvents = append(capturedEvents, es)
 					numPublished++
-					if numPublished > tc.numSuccessfulPublishes {
+					if numPublished > tc.numSuccessfulPublishes || numPublished <= tc.numFailedPublishes {
 						callback(pulsarutils.NewMessageId(numPublished), msg, errors.New("error from mock pulsar producer"))
 					} else {
+						publishedEvents = append(publishedEvents, es)
 						callback(pulsarutils.NewMessageId(numPublished), msg, nil)
 					}
 				}).AnyTimes()
@@ -122,6 +160,7 @@ func TestPulsarPublisher_TestPublish(t *testing.T) {
 			options := pulsar.ProducerOptions{Topic: topic}
 			publisher, err := NewPulsarPublisher(mockPulsarClient, options, 5*time.Second)
 			require.NoError(t, err)
+			publisher.SetSendRetries(tc.maxSendRetries, time.Millisecond)
 			err = publisher.PublishMessages(ctx, tc.eventSequences, func() bool { return tc.amLeader })
 
 			// Check that we get an error if one is expected
@@ -132,29 +171,833 @@ func TestPulsarPublisher_TestPublish(t *testing.T) {
 			}
 
 			// Check that we got the messages that we expect
-			if tc.amLeader {
+			if tc.amLeader && tc.maxSendRetries == 0 {
 				capturedCounts := countEvents(capturedEvents)
 				assert.Equal(t, expectedCounts, capturedCounts)
 			}
+			if tc.amLeader && !tc.expectedError {
+				assert.Equal(t, expectedCounts, countEvents(publishedEvents))
+			}
+		})
+	}
+}
+
+func TestPulsarPublisher_TestValidateEventSequences(t *testing.T) {
+	validEvent := &armadaevents.EventSequence_Event{
+		Event: &armadaevents.EventSequence_Event_CancelJob{CancelJob: &armadaevents.CancelJob{}},
+	}
+	tests := map[string]struct {
+		eventSequences []*armadaevents.EventSequence
+		// If empty, the sequences are expected to be published.
+		expectedReason       string
+		expectedErrorMessage string
+	}{
+		"valid": {
+			eventSequences: []*armadaevents.EventSequence{
+				{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{validEvent, validEvent}},
+				{JobSetName: "jobset2", Events: []*armadaevents.EventSequence_Event{validEvent}},
+			},
+		},
+		"nil sequence": {
+			eventSequences:       []*armadaevents.EventSequence{nil},
+			expectedReason:       invalidSequenceReasonNil,
+			expectedErrorMessage: "event sequence 0: sequence is nil",
+		},
+		"missing jobset": {
+			eventSequences: []*armadaevents.EventSequence{
+				{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{validEvent}},
+				{Queue: "queue", Events: []*armadaevents.EventSequence_Event{validEvent}},
+			},
+			expectedReason:       invalidSequenceReasonMissingJobSet,
+			expectedErrorMessage: `event sequence 1: sequence of queue "queue" has no jobset name`,
+		},
+		"no events": {
+			eventSequences:       []*armadaevents.EventSequence{{JobSetName: "jobset1"}},
+			expectedReason:       invalidSequenceReasonNoEvents,
+			expectedErrorMessage: "event sequence 0: sequence for jobset jobset1 contains no events",
+		},
+		"event without payload": {
+			eventSequences: []*armadaevents.EventSequence{
+				{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{validEvent, {}}},
+			},
+			expectedReason:       invalidSequenceReasonMissingPayload,
+			expectedErrorMessage: "event sequence 0: event 1 of sequence for jobset jobset1 has no payload",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPulsarClient := mocks.NewMockClient(ctrl)
+			mockPulsarProducer := mocks.NewMockProducer(ctrl)
+			mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+			numSent := 0
+			mockPulsarProducer.
+				EXPECT().
+				SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+					numSent++
+					callback(pulsarutils.NewMessageId(numSent), msg, nil)
+				}).AnyTimes()
+
+			publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+			require.NoError(t, err)
+			publisher.SetValidateEventSequences(true)
+			var numInvalidBefore float64
+			if tc.expectedReason != "" {
+				numInvalidBefore = testutil.ToFloat64(invalidEventSequencesCounter.WithLabelValues(tc.expectedReason))
+			}
+
+			err = publisher.PublishMessages(context.Background(), tc.eventSequences, func() bool { return true })
+			if tc.expectedReason == "" {
+				assert.NoError(t, err)
+				assert.Greater(t, numSent, 0)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedErrorMessage)
+			}
+			assert.Equal(t, 0, numSent)
+			assert.Equal(t, numInvalidBefore+1, testutil.ToFloat64(invalidEventSequencesCounter.WithLabelValues(tc.expectedReason)))
 		})
 	}
 }
 
+func TestPulsarPublisher_TestPublishStopsRetryingIfNoLongerLeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+	mockPulsarProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			callback(pulsarutils.NewMessageId(1), msg, errors.New("error from mock pulsar producer"))
+		}).Times(1)
+
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+	publisher.SetSendRetries(3, time.Millisecond)
+	numLeaderChecks := 0
+	err = publisher.PublishMessages(
+		context.Background(),
+		[]*armadaevents.EventSequence{{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}}},
+		func() bool {
+			numLeaderChecks++
+			return numLeaderChecks == 1
+		},
+	)
+	assert.Error(t, err)
+	assert.Equal(t, 2, numLeaderChecks)
+}
+
+func TestPulsarPublisher_TestCircuitBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+	pulsarAvailable := false
+	numSendAttempts := 0
+	mockPulsarProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			numSendAttempts++
+			if !pulsarAvailable {
+				callback(nil, msg, errors.New("error from mock pulsar producer"))
+				return
+			}
+			callback(pulsarutils.NewMessageId(numSendAttempts), msg, nil)
+		}).AnyTimes()
+
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+	const cooldown = time.Minute
+	testClock := clock.NewFakeClock(time.Now())
+	publisher.circuitBreaker = newCircuitBreaker("test_publisher", 2, cooldown, testClock)
+	sequences := []*armadaevents.EventSequence{{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}}}
+	amLeader := func() bool { return true }
+	notLeader := func() bool { return false }
+
+	// Calls made while not leader never reach the breaker.
+	for i := 0; i < 5; i++ {
+		require.NoError(t, publisher.PublishMessages(context.Background(), sequences, notLeader))
+	}
+	assert.Equal(t, 0, numSendAttempts)
+	assert.Equal(t, circuitClosed, publisher.circuitBreaker.currentState())
+
+	// Consecutive failures open the breaker, after which calls fail without attempting to send.
+	for i := 0; i < 2; i++ {
+		err := publisher.PublishMessages(context.Background(), sequences, amLeader)
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, errCircuitOpen))
+	}
+	assert.Equal(t, 2, numSendAttempts)
+	assert.Equal(t, circuitOpen, publisher.circuitBreaker.currentState())
+	err = publisher.PublishMessages(context.Background(), sequences, amLeader)
+	assert.True(t, errors.Is(err, errCircuitOpen))
+	assert.Equal(t, 2, numSendAttempts)
+
+	// Once the cooldown has passed, a call probes whether Pulsar has recovered.
+	pulsarAvailable = true
+	testClock.Step(cooldown)
+	require.NoError(t, publisher.PublishMessages(context.Background(), sequences, amLeader))
+	assert.Equal(t, 3, numSendAttempts)
+	assert.Equal(t, circuitClosed, publisher.circuitBreaker.currentState())
+}
+
+func TestPulsarPublisher_TestLeaderGuardWithStandaloneLeaderController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+	controller := NewStandaloneLeaderController()
+
+	// The first send fails and leadership is lost before it's retried.
+	numSent := 0
+	mockPulsarProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			numSent++
+			if numSent == 1 {
+				controller.Demote()
+				callback(pulsarutils.NewMessageId(numSent), msg, errors.New("error from mock pulsar producer"))
+			} else {
+				callback(pulsarutils.NewMessageId(numSent), msg, nil)
+			}
+		}).AnyTimes()
+
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+	publisher.SetSendRetries(3, time.Millisecond)
+	eventSequences := []*armadaevents.EventSequence{{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}}}
+	publish := func() error {
+		leaderToken := controller.GetToken()
+		return publisher.PublishMessages(
+			context.Background(),
+			eventSequences,
+			func() bool { return controller.ValidateToken(leaderToken) },
+		)
+	}
+
+	assert.Error(t, publish())
+	assert.Equal(t, 1, numSent)
+
+	// Nothing is sent while not leader.
+	assert.NoError(t, publish())
+	assert.Equal(t, 1, numSent)
+
+	controller.Promote()
+	assert.NoError(t, publish())
+	assert.Equal(t, 2, numSent)
+}
+
+func TestPulsarPublisher_TestDemotionMidBatchStopsNewSends(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+	publisher.SetSendRetries(3, time.Millisecond)
+	publisher.SetDemotionDrainTimeout(5 * time.Second)
+
+	// Lose leadership while the first message of the batch is being sent;
+	// that send is only confirmed after the publisher has been notified.
+	var mu sync.Mutex
+	leader, demoted := true, false
+	numSent, numConfirmed, numConfirmedOnDemotion := 0, 0, 0
+	mockPulsarProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			mu.Lock()
+			numSent++
+			demote := !demoted
+			if demote {
+				leader, demoted = false, true
+			}
+			mu.Unlock()
+			go func() {
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				numConfirmed++
+				mu.Unlock()
+				callback(pulsarutils.NewMessageId(1), msg, nil)
+			}()
+			if demote {
+				// Blocks until the send above has been confirmed.
+				publisher.onStoppedLeading()
+				mu.Lock()
+				numConfirmedOnDemotion = numConfirmed
+				mu.Unlock()
+			}
+		}).AnyTimes()
+
+	shouldPublish := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return leader
+	}
+	eventSequences := []*armadaevents.EventSequence{
+		{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}},
+		{JobSetName: "jobset2", Events: []*armadaevents.EventSequence_Event{{}}},
+		{JobSetName: "jobset3", Events: []*armadaevents.EventSequence_Event{{}}},
+	}
+	err = publisher.PublishMessages(context.Background(), eventSequences, shouldPublish)
+	assert.Error(t, err)
+
+	mu.Lock()
+	assert.Equal(t, 1, numSent)
+	assert.Equal(t, 1, numConfirmedOnDemotion)
+	mu.Unlock()
+
+	// Markers aren't sent either.
+	_, err = publisher.PublishMarkers(context.Background(), uuid.New())
+	assert.Error(t, err)
+
+	// Sends resume once leadership is regained.
+	mu.Lock()
+	leader = true
+	mu.Unlock()
+	publisher.onStartedLeading(context.Background())
+	err = publisher.PublishMessages(context.Background(), eventSequences, func() bool { return true })
+	assert.NoError(t, err)
+	mu.Lock()
+	assert.Equal(t, 1+len(eventSequences), numSent)
+	mu.Unlock()
+}
+
+func TestPulsarPublisher_TestPublishMessagesWithIds(t *testing.T) {
+	tests := map[string]struct {
+		amLeader      bool
+		failedJobSets map[string]bool
+		// Jobsets for which we expect the id of a published message to be returned.
+		expectedJobSets map[string]bool
+		expectedNumNils int
+		expectedError   bool
+	}{
+		"Return ids of published messages": {
+			amLeader:        true,
+			expectedJobSets: map[string]bool{"jobset1": true, "jobset2": true},
+		},
+		"Don't publish or return ids if not leader": {
+			amLeader: false,
+		},
+		"Return ids of messages published before an error": {
+			amLeader:        true,
+			failedJobSets:   map[string]bool{"jobset2": true},
+			expectedJobSets: map[string]bool{"jobset1": true},
+			expectedNumNils: 1,
+			expectedError:   true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPulsarClient := mocks.NewMockClient(ctrl)
+			mockPulsarProducer := mocks.NewMockProducer(ctrl)
+			mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+			idsByJobSet := map[string]pulsar.MessageID{
+				"jobset1": pulsarutils.NewMessageId(1),
+				"jobset2": pulsarutils.NewMessageId(2),
+			}
+			expectedSends := 0
+			if tc.amLeader {
+				expectedSends = len(idsByJobSet)
+			}
+			mockPulsarProducer.
+				EXPECT().
+				SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+					if tc.failedJobSets[msg.Key] {
+						callback(nil, msg, errors.New("error from mock pulsar producer"))
+					} else {
+						callback(idsByJobSet[msg.Key], msg, nil)
+					}
+				}).Times(expectedSends)
+
+			publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+			require.NoError(t, err)
+			ids, err := publisher.PublishMessagesWithIds(
+				context.Background(),
+				[]*armadaevents.EventSequence{
+					{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}},
+					{JobSetName: "jobset2", Events: []*armadaevents.EventSequence_Event{{}}},
+					{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}},
+				},
+				func() bool { return tc.amLeader },
+			)
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if !tc.amLeader {
+				assert.Empty(t, ids)
+				return
+			}
+			assert.Len(t, ids, len(idsByJobSet))
+			numNils := 0
+			actualJobSets := make(map[string]bool)
+			for _, id := range ids {
+				if id == nil {
+					numNils++
+					continue
+				}
+				for jobSet, expectedId := range idsByJobSet {
+					if id == expectedId {
+						actualJobSets[jobSet] = true
+					}
+				}
+			}
+			assert.Equal(t, tc.expectedNumNils, numNils)
+			assert.Equal(t, tc.expectedJobSets, actualJobSets)
+		})
+	}
+}
+
+func TestPulsarPublisher_TestPublishPreservesJobSetOrder(t *testing.T) {
+	const numSequencesPerJobSet = 5
+	start := time.Now()
+	for name, preserveJobSetOrder := range map[string]bool{"ordered": true, "unordered": false} {
+		t.Run(name, func(t *testing.T) {
+			var eventSequences []*armadaevents.EventSequence
+			for _, jobSet := range []string{"jobset1", "jobset2"} {
+				for i := 0; i < numSequencesPerJobSet; i++ {
+					created := start.Add(time.Duration(i) * time.Second)
+					eventSequences = append(eventSequences, &armadaevents.EventSequence{
+						JobSetName: jobSet,
+						Events:     []*armadaevents.EventSequence_Event{{Created: &created}},
+					})
+				}
+			}
+			ctrl := gomock.NewController(t)
+			mockPulsarClient := mocks.NewMockClient(ctrl)
+			mockPulsarProducer := mocks.NewMockProducer(ctrl)
+			mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+
+			// Fail the first attempt at sending the second message of each jobset.
+			var mu sync.Mutex
+			numSendsByJobSet := make(map[string]int)
+			publishedByJobSet := make(map[string][]time.Time)
+			mockPulsarProducer.
+				EXPECT().
+				SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+					es := &armadaevents.EventSequence{}
+					require.NoError(t, proto.Unmarshal(msg.Payload, es))
+					assert.Equal(t, es.JobSetName, msg.Key)
+					mu.Lock()
+					numSendsByJobSet[es.JobSetName]++
+					fail := numSendsByJobSet[es.JobSetName] == 2
+					if !fail {
+						for _, event := range es.Events {
+							publishedByJobSet[es.JobSetName] = append(publishedByJobSet[es.JobSetName], *event.Created)
+						}
+					}
+					mu.Unlock()
+					if fail {
+						callback(pulsarutils.NewMessageId(1), msg, errors.New("error from mock pulsar producer"))
+					} else {
+						callback(pulsarutils.NewMessageId(1), msg, nil)
+					}
+				}).AnyTimes()
+
+			// Limit message size such that each event is sent in a separate message.
+			options := pulsar.ProducerOptions{Topic: topic, BatchingMaxSize: uint(2 * proto.Size(eventSequences[0]))}
+			publisher, err := NewPulsarPublisher(mockPulsarClient, options, 5*time.Second)
+			require.NoError(t, err)
+			publisher.SetSendRetries(1, time.Millisecond)
+			publisher.SetPreserveJobSetOrder(preserveJobSetOrder)
+			err = publisher.PublishMessages(context.Background(), eventSequences, func() bool { return true })
+			require.NoError(t, err)
+
+			for jobSet, published := range publishedByJobSet {
+				assert.Len(t, published, numSequencesPerJobSet, jobSet)
+				isSorted := slices.IsSortedFunc(published, func(a, b time.Time) bool { return a.Before(b) })
+				if preserveJobSetOrder {
+					assert.True(t, isSorted, jobSet)
+				} else {
+					// The failed message is retried only once all other messages have been sent.
+					assert.False(t, isSorted, jobSet)
+				}
+			}
+		})
+	}
+}
+
+func TestPulsarPublisher_TestPublishBoundsInFlightSends(t *testing.T) {
+	const numJobSets = 50
+	const maxInFlight = 3
+	for name, preserveJobSetOrder := range map[string]bool{"ordered": true, "unordered": false} {
+		t.Run(name, func(t *testing.T) {
+			var eventSequences []*armadaevents.EventSequence
+			for i := 0; i < numJobSets; i++ {
+				eventSequences = append(eventSequences, &armadaevents.EventSequence{
+					JobSetName: fmt.Sprintf("jobset%d", i),
+					Events:     []*armadaevents.EventSequence_Event{{Created: now()}},
+				})
+			}
+			ctrl := gomock.NewController(t)
+			mockPulsarClient := mocks.NewMockClient(ctrl)
+			mockPulsarProducer := mocks.NewMockProducer(ctrl)
+			mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+
+			// Complete each send asynchronously after a short delay, recording the max number of outstanding sends.
+			var mu sync.Mutex
+			inFlight, maxObservedInFlight, numSent := 0, 0, 0
+			mockPulsarProducer.
+				EXPECT().
+				SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+					mu.Lock()
+					inFlight++
+					if inFlight > maxObservedInFlight {
+						maxObservedInFlight = inFlight
+					}
+					mu.Unlock()
+					go func() {
+						time.Sleep(time.Millisecond)
+						mu.Lock()
+						inFlight--
+						numSent++
+						mu.Unlock()
+						callback(pulsarutils.NewMessageId(1), msg, nil)
+					}()
+				}).AnyTimes()
+
+			options := pulsar.ProducerOptions{Topic: topic}
+			publisher, err := NewPulsarPublisher(mockPulsarClient, options, 5*time.Second)
+			require.NoError(t, err)
+			publisher.SetPreserveJobSetOrder(preserveJobSetOrder)
+			publisher.SetMaxInFlightSends(maxInFlight)
+			err = publisher.PublishMessages(context.Background(), eventSequences, func() bool { return true })
+			require.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, numJobSets, numSent)
+			assert.LessOrEqual(t, maxObservedInFlight, maxInFlight)
+			assert.Equal(t, 0, inFlight)
+		})
+	}
+}
+
+func TestPulsarPublisher_TestSendTimeoutExcludesWaitingForInFlightSends(t *testing.T) {
+	const numJobSets = 10
+	const sendTimeout = 50 * time.Millisecond
+	for name, preserveJobSetOrder := range map[string]bool{"ordered": true, "unordered": false} {
+		t.Run(name, func(t *testing.T) {
+			var eventSequences []*armadaevents.EventSequence
+			for i := 0; i < numJobSets; i++ {
+				eventSequences = append(eventSequences, &armadaevents.EventSequence{
+					JobSetName: fmt.Sprintf("jobset%d", i),
+					Events:     []*armadaevents.EventSequence_Event{{Created: now()}},
+				})
+			}
+			ctrl := gomock.NewController(t)
+			mockPulsarClient := mocks.NewMockClient(ctrl)
+			mockPulsarProducer := mocks.NewMockProducer(ctrl)
+			mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+
+			// Each send takes well within the timeout, but sending all messages one at a time takes longer than it.
+			mockPulsarProducer.
+				EXPECT().
+				SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+					go func() {
+						select {
+						case <-ctx.Done():
+							callback(nil, msg, ctx.Err())
+						case <-time.After(sendTimeout / 5):
+							callback(pulsarutils.NewMessageId(1), msg, nil)
+						}
+					}()
+				}).Times(numJobSets)
+
+			options := pulsar.ProducerOptions{Topic: topic}
+			publisher, err := NewPulsarPublisher(mockPulsarClient, options, sendTimeout)
+			require.NoError(t, err)
+			publisher.SetPreserveJobSetOrder(preserveJobSetOrder)
+			publisher.SetMaxInFlightSends(1)
+			err = publisher.PublishMessages(context.Background(), eventSequences, func() bool { return true })
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPulsarPublisher_TestPublishMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+
+	// Fail all sends for jobset2.
+	numBytesByResult := make(map[string]int)
+	mockPulsarProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			if msg.Key == "jobset2" {
+				numBytesByResult[publishFailed] += len(msg.Payload)
+				callback(nil, msg, errors.New("error from mock pulsar producer"))
+			} else {
+				numBytesByResult[publishSucceeded] += len(msg.Payload)
+				callback(pulsarutils.NewMessageId(1), msg, nil)
+			}
+		}).Times(2)
+	mockPulsarProducer.
+		EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		Return(pulsarutils.NewMessageId(1), nil).
+		Times(numPartitions)
+
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+
+	counterValue := func(counter *prometheus.CounterVec, messageType, result string) float64 {
+		return testutil.ToFloat64(counter.WithLabelValues(messageType, result))
+	}
+	initialSucceededEvents := counterValue(publishedEventsCounter, eventsMessageType, publishSucceeded)
+	initialFailedEvents := counterValue(publishedEventsCounter, eventsMessageType, publishFailed)
+	initialSucceededBytes := counterValue(publishedBytesCounter, eventsMessageType, publishSucceeded)
+	initialFailedBytes := counterValue(publishedBytesCounter, eventsMessageType, publishFailed)
+	initialSucceededMessages := counterValue(publishedMessagesCounter, eventsMessageType, publishSucceeded)
+	initialMarkers := counterValue(publishedMessagesCounter, markerMessageType, publishSucceeded)
+
+	err = publisher.PublishMessages(
+		context.Background(),
+		[]*armadaevents.EventSequence{
+			{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}, {}}},
+			{JobSetName: "jobset2", Events: []*armadaevents.EventSequence_Event{{}}},
+			{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}},
+		},
+		func() bool { return true },
+	)
+	assert.Error(t, err)
+	_, err = publisher.PublishMarkers(context.Background(), uuid.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, 3.0, counterValue(publishedEventsCounter, eventsMessageType, publishSucceeded)-initialSucceededEvents)
+	assert.Equal(t, 1.0, counterValue(publishedEventsCounter, eventsMessageType, publishFailed)-initialFailedEvents)
+	assert.Equal(t, float64(numBytesByResult[publishSucceeded]), counterValue(publishedBytesCounter, eventsMessageType, publishSucceeded)-initialSucceededBytes)
+	assert.Equal(t, float64(numBytesByResult[publishFailed]), counterValue(publishedBytesCounter, eventsMessageType, publishFailed)-initialFailedBytes)
+	assert.Equal(t, 1.0, counterValue(publishedMessagesCounter, eventsMessageType, publishSucceeded)-initialSucceededMessages)
+	assert.Equal(t, float64(numPartitions), counterValue(publishedMessagesCounter, markerMessageType, publishSucceeded)-initialMarkers)
+	assert.Greater(t, testutil.CollectAndCount(publishLatencyHistogram), 0)
+}
+
+func TestPulsarPublisher_TestPublishToMultipleTopics(t *testing.T) {
+	const controlTopic = "controlTopic"
+	tests := map[string]struct {
+		createProducerErr error
+		expectedError     bool
+	}{
+		"Publish to selected topics": {},
+		"Return error if producer can't be created": {
+			createProducerErr: errors.New("error from mock pulsar client"),
+			expectedError:     true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPulsarClient := mocks.NewMockClient(ctrl)
+			primaryProducer := mocks.NewMockProducer(ctrl)
+			controlProducer := mocks.NewMockProducer(ctrl)
+			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+			mockPulsarClient.
+				EXPECT().
+				CreateProducer(gomock.Any()).
+				DoAndReturn(func(options pulsar.ProducerOptions) (pulsar.Producer, error) {
+					switch options.Topic {
+					case topic:
+						return primaryProducer, nil
+					case controlTopic:
+						assert.NotNil(t, options.MessageRouter)
+						if tc.createProducerErr != nil {
+							return nil, tc.createProducerErr
+						}
+						return controlProducer, nil
+					}
+					return nil, errors.Errorf("unexpected topic %s", options.Topic)
+				}).
+				Times(2)
+
+			// Record the jobsets of messages sent to each topic.
+			var mu sync.Mutex
+			jobSetsByTopic := make(map[string][]string)
+			sendAsync := func(topic string) func(context.Context, *pulsar.ProducerMessage, func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+				return func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+					mu.Lock()
+					jobSetsByTopic[topic] = append(jobSetsByTopic[topic], msg.Key)
+					mu.Unlock()
+					callback(pulsarutils.NewMessageId(1), msg, nil)
+				}
+			}
+			primaryProducer.EXPECT().SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(sendAsync(topic)).AnyTimes()
+			controlProducer.EXPECT().SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(sendAsync(controlTopic)).AnyTimes()
+
+			publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+			require.NoError(t, err)
+			publisher.SetTopicSelector(func(sequence *armadaevents.EventSequence) string {
+				for _, event := range sequence.Events {
+					if event.GetCancelJob() != nil {
+						return controlTopic
+					}
+				}
+				return ""
+			})
+
+			cancelEvent := &armadaevents.EventSequence_Event{
+				Event: &armadaevents.EventSequence_Event_CancelJob{CancelJob: &armadaevents.CancelJob{}},
+			}
+			sequences := []*armadaevents.EventSequence{
+				{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}},
+				{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{cancelEvent}},
+				{JobSetName: "jobset2", Events: []*armadaevents.EventSequence_Event{cancelEvent}},
+			}
+			// Publish twice to check that the producer for the control topic is only created once.
+			for i := 0; i < 2; i++ {
+				err = publisher.PublishMessages(context.Background(), sequences, func() bool { return true })
+				if tc.expectedError {
+					assert.Error(t, err)
+					assert.Empty(t, jobSetsByTopic)
+					return
+				}
+				require.NoError(t, err)
+			}
+			slices.Sort(jobSetsByTopic[topic])
+			slices.Sort(jobSetsByTopic[controlTopic])
+			assert.Equal(
+				t,
+				map[string][]string{
+					topic:        {"jobset1", "jobset1"},
+					controlTopic: {"jobset1", "jobset1", "jobset2", "jobset2"},
+				},
+				jobSetsByTopic,
+			)
+		})
+	}
+}
+
+func TestPulsarPublisher_TestPublishMessagesWithSequenceId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+
+	// Mimic Pulsar deduplication by discarding messages with a sequence id no greater than the highest seen so far.
+	var sentSequenceIds []int64
+	var persistedSequenceIds []int64
+	highestSequenceId := int64(-1)
+	mockPulsarProducer.
+		EXPECT().
+		SendAsync(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage, callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)) {
+			require.NotNil(t, msg.SequenceID)
+			sentSequenceIds = append(sentSequenceIds, *msg.SequenceID)
+			if *msg.SequenceID > highestSequenceId {
+				highestSequenceId = *msg.SequenceID
+				persistedSequenceIds = append(persistedSequenceIds, *msg.SequenceID)
+			}
+			callback(pulsarutils.NewMessageId(int(*msg.SequenceID)), msg, nil)
+		}).AnyTimes()
+
+	publisher, err := NewPulsarPublisher(mockPulsarClient, pulsar.ProducerOptions{Topic: topic}, 5*time.Second)
+	require.NoError(t, err)
+	sequences := []*armadaevents.EventSequence{
+		{JobSetName: "jobset1", Events: []*armadaevents.EventSequence_Event{{}}},
+		{JobSetName: "jobset2", Events: []*armadaevents.EventSequence_Event{{}}},
+	}
+	amLeader := func() bool { return true }
+
+	// Nothing is published if not leader, so the same sequence ids may be used again.
+	_, next, err := publisher.PublishMessagesWithSequenceId(context.Background(), sequences, func() bool { return false }, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), next)
+	assert.Empty(t, sentSequenceIds)
+
+	_, next, err = publisher.PublishMessagesWithSequenceId(context.Background(), sequences, amLeader, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), next)
+
+	// Republishing the same events with the same sequence id results in the same messages, which are discarded.
+	_, _, err = publisher.PublishMessagesWithSequenceId(context.Background(), sequences, amLeader, 10)
+	require.NoError(t, err)
+
+	// Events published with a later sequence id are persisted.
+	_, next, err = publisher.PublishMessagesWithSequenceId(context.Background(), sequences[:1], amLeader, next)
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), next)
+
+	slices.Sort(sentSequenceIds)
+	assert.Equal(t, []int64{10, 10, 11, 11, 12}, sentSequenceIds)
+	assert.Equal(t, []int64{10, 11, 12}, persistedSequenceIds)
+
+	_, _, err = publisher.PublishMessagesWithSequenceId(context.Background(), sequences, amLeader, -1)
+	assert.Error(t, err)
+}
+
 func TestPulsarPublisher_TestPublishMarkers(t *testing.T) {
 	allPartitions := make(map[string]bool, 0)
 	for i := 0; i < numPartitions; i++ {
 		allPartitions[fmt.Sprintf("%d", i)] = true
 	}
+	groupId := uuid.New()
 	tests := map[string]struct {
+		partitionSelector      MarkerPartitionSelector
+		keyFunc                MarkerKeyFunc
 		numSuccessfulPublishes int
 		expectedError          bool
+		expectedTargeted       []uint32
 		expectedPartitions     map[string]bool
+		expectedKeys           map[string]string
 	}{
 		"Publish successful": {
 			numSuccessfulPublishes: math.MaxInt,
 			expectedError:          false,
+			expectedTargeted:       AllPartitions(numPartitions),
 			expectedPartitions:     allPartitions,
 		},
+		"Publish to selected partitions": {
+			partitionSelector: func(numPartitions int) []uint32 {
+				return []uint32{0, uint32(numPartitions - 1)}
+			},
+			numSuccessfulPublishes: math.MaxInt,
+			expectedError:          false,
+			expectedTargeted:       []uint32{0, numPartitions - 1},
+			expectedPartitions:     map[string]bool{"0": true, fmt.Sprintf("%d", numPartitions-1): true},
+		},
+		"Publish with keys": {
+			partitionSelector: func(_ int) []uint32 {
+				return []uint32{1, 2}
+			},
+			keyFunc: func(id uuid.UUID, partition uint32) string {
+				return fmt.Sprintf("%s-%d", id, partition)
+			},
+			numSuccessfulPublishes: math.MaxInt,
+			expectedError:          false,
+			expectedTargeted:       []uint32{1, 2},
+			expectedPartitions:     map[string]bool{"1": true, "2": true},
+			expectedKeys: map[string]string{
+				"1": fmt.Sprintf("%s-1", groupId),
+				"2": fmt.Sprintf("%s-2", groupId),
+			},
+		},
 		"All Publishes fail": {
 			numSuccessfulPublishes: 0,
 			expectedError:          true,
@@ -173,6 +1016,7 @@ func TestPulsarPublisher_TestPublishMarkers(t *testing.T) {
 			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
 			numPublished := 0
 			capturedPartitions := make(map[string]bool)
+			capturedKeys := make(map[string]string)
 
 			mockPulsarProducer.
 				EXPECT().
@@ -182,6 +1026,9 @@ func TestPulsarPublisher_TestPublishMarkers(t *testing.T) {
 					key, ok := msg.Properties[explicitPartitionKey]
 					if ok {
 						capturedPartitions[key] = true
+						if msg.Key != "" {
+							capturedKeys[key] = msg.Key
+						}
 					}
 					if numPublished > tc.numSuccessfulPublishes {
 						return pulsarutils.NewMessageId(numPublished), errors.New("error from mock pulsar producer")
@@ -193,8 +1040,10 @@ func TestPulsarPublisher_TestPublishMarkers(t *testing.T) {
 			ctx := context.TODO()
 			publisher, err := NewPulsarPublisher(mockPulsarClient, options, 5*time.Second)
 			require.NoError(t, err)
+			publisher.SetMarkerPartitionSelector(tc.partitionSelector)
+			publisher.SetMarkerKeyFunc(tc.keyFunc)
 
-			published, err := publisher.PublishMarkers(ctx, uuid.New())
+			result, err := publisher.PublishMarkers(ctx, groupId)
 
 			// Check that we get an error if one is expected
 			if tc.expectedError {
@@ -204,9 +1053,229 @@ func TestPulsarPublisher_TestPublishMarkers(t *testing.T) {
 			}
 
 			if !tc.expectedError {
-				assert.Equal(t, uint32(numPartitions), published)
+				assert.Equal(t, tc.expectedTargeted, result.Targeted)
+				assert.Equal(t, tc.expectedTargeted, result.Published)
+				assert.Empty(t, result.Failed)
 				assert.Equal(t, tc.expectedPartitions, capturedPartitions)
+				if tc.expectedKeys != nil {
+					assert.Equal(t, tc.expectedKeys, capturedKeys)
+				} else {
+					assert.Empty(t, capturedKeys)
+				}
+			} else {
+				assert.Equal(t, len(result.Targeted), len(result.Published)+len(result.Failed))
+			}
+		})
+	}
+}
+
+func TestPulsarPublisher_TestPublishMarkersToPartitions(t *testing.T) {
+	tests := map[string]struct {
+		partitions        []uint32
+		failedPartitions  map[string]bool
+		expectedPublished []uint32
+		expectedFailed    []uint32
+		expectedError     bool
+	}{
+		"Publish successful": {
+			partitions:        []uint32{0, 1, 2},
+			expectedPublished: []uint32{0, 1, 2},
+		},
+		"Some publishes fail": {
+			partitions:        []uint32{0, 1, 2, 3},
+			failedPartitions:  map[string]bool{"1": true, "3": true},
+			expectedPublished: []uint32{0, 2},
+			expectedFailed:    []uint32{1, 3},
+			expectedError:     true,
+		},
+		"Subset of partitions": {
+			partitions:        []uint32{1, 3},
+			expectedPublished: []uint32{1, 3},
+		},
+		"Partition out of range": {
+			partitions:        []uint32{0, numPartitions},
+			expectedPublished: []uint32{0},
+			expectedFailed:    []uint32{numPartitions},
+			expectedError:     true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPulsarClient := mocks.NewMockClient(ctrl)
+			mockPulsarProducer := mocks.NewMockProducer(ctrl)
+			mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+			capturedPartitions := make(map[string]bool)
+
+			mockPulsarProducer.
+				EXPECT().
+				Send(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage) (pulsar.MessageID, error) {
+					key := msg.Properties[explicitPartitionKey]
+					if tc.failedPartitions[key] {
+						return nil, errors.New("error from mock pulsar producer")
+					}
+					capturedPartitions[key] = true
+					return pulsarutils.NewMessageId(1), nil
+				}).AnyTimes()
+
+			options := pulsar.ProducerOptions{Topic: topic}
+			publisher, err := NewPulsarPublisher(mockPulsarClient, options, 5*time.Second)
+			require.NoError(t, err)
+
+			result, err := publisher.PublishMarkersToPartitions(context.TODO(), uuid.New(), tc.partitions)
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
 			}
+			assert.Equal(t, tc.expectedPublished, result.Published)
+			assert.Equal(t, tc.expectedFailed, result.Failed)
+			for _, partition := range tc.expectedPublished {
+				assert.True(t, capturedPartitions[fmt.Sprintf("%d", partition)])
+			}
+			assert.Len(t, capturedPartitions, len(tc.expectedPublished))
+		})
+	}
+}
+
+func TestPulsarPublisher_TestPublishMarkersRetries(t *testing.T) {
+	tests := map[string]struct {
+		maxRetries uint
+		// Number of times sending to each partition fails before succeeding.
+		numFailuresByPartition map[string]int
+		expectedPublished      []uint32
+		expectedFailed         []uint32
+		expectedError          bool
+		// Number of sends expected for each partition.
+		expectedSendsByPartition map[string]int
+	}{
+		"No retries necessary": {
+			maxRetries:               3,
+			expectedPublished:        []uint32{0, 1, 2},
+			expectedSendsByPartition: map[string]int{"0": 1, "1": 1, "2": 1},
+		},
+		"Failed partitions succeed on retry": {
+			maxRetries:               3,
+			numFailuresByPartition:   map[string]int{"0": 2, "2": 1},
+			expectedPublished:        []uint32{1, 2, 0},
+			expectedSendsByPartition: map[string]int{"0": 3, "1": 1, "2": 2},
+		},
+		"Partitions still failing after all retries": {
+			maxRetries:               2,
+			numFailuresByPartition:   map[string]int{"0": 3, "1": 1},
+			expectedPublished:        []uint32{2, 1},
+			expectedFailed:           []uint32{0},
+			expectedError:            true,
+			expectedSendsByPartition: map[string]int{"0": 3, "1": 2, "2": 1},
+		},
+		"Retries disabled": {
+			numFailuresByPartition:   map[string]int{"1": 1},
+			expectedPublished:        []uint32{0, 2},
+			expectedFailed:           []uint32{1},
+			expectedError:            true,
+			expectedSendsByPartition: map[string]int{"0": 1, "1": 1, "2": 1},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPulsarClient := mocks.NewMockClient(ctrl)
+			mockPulsarProducer := mocks.NewMockProducer(ctrl)
+			mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+			sendsByPartition := make(map[string]int)
+			mockPulsarProducer.
+				EXPECT().
+				Send(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, msg *pulsar.ProducerMessage) (pulsar.MessageID, error) {
+					key := msg.Properties[explicitPartitionKey]
+					sendsByPartition[key]++
+					if sendsByPartition[key] <= tc.numFailuresByPartition[key] {
+						return nil, errors.New("error from mock pulsar producer")
+					}
+					return pulsarutils.NewMessageId(1), nil
+				}).AnyTimes()
+
+			options := pulsar.ProducerOptions{Topic: topic}
+			publisher, err := NewPulsarPublisher(mockPulsarClient, options, 5*time.Second)
+			require.NoError(t, err)
+			publisher.SetMarkerRetries(tc.maxRetries, time.Millisecond)
+
+			result, err := publisher.PublishMarkersToPartitions(context.TODO(), uuid.New(), []uint32{0, 1, 2})
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, []uint32{0, 1, 2}, result.Targeted)
+			assert.Equal(t, tc.expectedPublished, result.Published)
+			assert.Equal(t, tc.expectedFailed, result.Failed)
+			assert.Equal(t, tc.expectedSendsByPartition, sendsByPartition)
+		})
+	}
+}
+
+func TestPulsarPublisher_TestPublishMarkersRetriesStopOnCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPulsarClient := mocks.NewMockClient(ctrl)
+	mockPulsarProducer := mocks.NewMockProducer(ctrl)
+	mockPulsarClient.EXPECT().CreateProducer(gomock.Any()).Return(mockPulsarProducer, nil).Times(1)
+	mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	mockPulsarProducer.
+		EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *pulsar.ProducerMessage) (pulsar.MessageID, error) {
+			cancel()
+			return nil, errors.New("error from mock pulsar producer")
+		}).Times(1)
+
+	options := pulsar.ProducerOptions{Topic: topic}
+	publisher, err := NewPulsarPublisher(mockPulsarClient, options, 5*time.Second)
+	require.NoError(t, err)
+	publisher.SetMarkerRetries(3, time.Hour)
+
+	result, err := publisher.PublishMarkersToPartitions(ctx, uuid.New(), []uint32{0})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, []uint32{0}, result.Failed)
+	assert.Empty(t, result.Published)
+}
+
+func TestPulsarPublisher_TestCompression(t *testing.T) {
+	tests := map[string]pulsar.CompressionType{
+		"None": pulsar.NoCompression,
+		"LZ4":  pulsar.LZ4,
+		"Zlib": pulsar.ZLib,
+		"Zstd": pulsar.ZSTD,
+	}
+	for name, compressionType := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPulsarClient := mocks.NewMockClient(ctrl)
+			mockPulsarProducer := mocks.NewMockProducer(ctrl)
+			mockPulsarClient.EXPECT().TopicPartitions(topic).Return(make([]string, numPartitions), nil)
+			var capturedOptions pulsar.ProducerOptions
+			mockPulsarClient.
+				EXPECT().
+				CreateProducer(gomock.Any()).
+				DoAndReturn(func(options pulsar.ProducerOptions) (pulsar.Producer, error) {
+					capturedOptions = options
+					return mockPulsarProducer, nil
+				}).Times(1)
+
+			options := pulsar.ProducerOptions{
+				Topic:            topic,
+				CompressionType:  compressionType,
+				CompressionLevel: pulsar.Faster,
+			}
+			_, err := NewPulsarPublisher(mockPulsarClient, options, 5*time.Second)
+			require.NoError(t, err)
+			assert.Equal(t, compressionType, capturedOptions.CompressionType)
+			assert.Equal(t, pulsar.Faster, capturedOptions.CompressionLevel)
+			// Compression must not affect partitioning.
+			assert.NotNil(t, capturedOptions.MessageRouter)
 		})
 	}
 }
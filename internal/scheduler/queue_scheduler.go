@@ -5,11 +5,14 @@ import (
 	"context"
 	"math"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus/ctxlogrus"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/armadaproject/armada/internal/common/logging"
 	schedulerconstraints "github.com/armadaproject/armada/internal/scheduler/constraints"
@@ -61,6 +64,30 @@ func (sch *QueueScheduler) SkipUnsuccessfulSchedulingKeyCheck() {
 	sch.gangScheduler.SkipUnsuccessfulSchedulingKeyCheck()
 }
 
+func (sch *QueueScheduler) SetDrainingExecutors(drainingExecutors *DrainingExecutors) {
+	sch.gangScheduler.SetDrainingExecutors(drainingExecutors)
+}
+
+func (sch *QueueScheduler) SetPausedQueues(pausedQueues *PausedQueues) {
+	sch.gangScheduler.SetPausedQueues(pausedQueues)
+}
+
+// EnablePreemption allows jobs to be preempted to make room for new gangs; see GangScheduler.EnablePreemption.
+// Preempted jobs are included in the PreemptedJobs of the result returned by Schedule.
+func (sch *QueueScheduler) EnablePreemption(jobRepo JobRepository, priorityThreshold int32) {
+	sch.gangScheduler.EnablePreemption(jobRepo, priorityThreshold)
+}
+
+func (sch *QueueScheduler) SetPreemptionMinimumRuntime(minimumRuntime time.Duration) {
+	sch.gangScheduler.SetPreemptionMinimumRuntime(minimumRuntime)
+}
+
+// PreviewQueueOrder returns the order in which queues would next be considered for scheduling,
+// together with the fairness metric values determining it, without scheduling anything; see CandidateGangIterator.QueueOrder.
+func (sch *QueueScheduler) PreviewQueueOrder() []schedulercontext.QueueOrderEntry {
+	return sch.candidateGangIterator.QueueOrder()
+}
+
 func (sch *QueueScheduler) Schedule(ctx context.Context) (*SchedulerResult, error) {
 	log := ctxlogrus.Extract(ctx)
 	if ResourceListAsWeightedMillis(sch.schedulingContext.ResourceScarcity, sch.schedulingContext.TotalResources) == 0 {
@@ -70,6 +97,7 @@ func (sch *QueueScheduler) Schedule(ctx context.Context) (*SchedulerResult, erro
 			"no resources with non-zero weight available for scheduling on any cluster: resource scarcity %v, total resources %v",
 			sch.schedulingContext.ResourceScarcity, sch.schedulingContext.TotalResources,
 		)
+		sch.schedulingContext.RoundOutcome = schedulercontext.RoundOutcomeNoSchedulableResources
 		return &SchedulerResult{}, nil
 	}
 	if ResourceListAsWeightedMillis(sch.schedulingContext.ResourceScarcity, sch.gangScheduler.nodeDb.TotalResources()) == 0 {
@@ -78,10 +106,15 @@ func (sch *QueueScheduler) Schedule(ctx context.Context) (*SchedulerResult, erro
 			"no resources with non-zero weight available for scheduling in NodeDb: resource scarcity %v, total resources %v",
 			sch.schedulingContext.ResourceScarcity, sch.gangScheduler.nodeDb.TotalResources(),
 		)
+		sch.schedulingContext.RoundOutcome = schedulercontext.RoundOutcomeNoSchedulableResources
 		return &SchedulerResult{}, nil
 	}
+	sch.schedulingContext.QueueOrder = sch.PreviewQueueOrder()
 	nodeIdByJobId := make(map[string]string)
+	preemptedJobs := make([]interfaces.LegacySchedulerJob, 0)
 	scheduledJobs := make([]interfaces.LegacySchedulerJob, 0)
+	// Number of gangs that couldn't be scheduled, indexed by the outcome their rejection corresponds to.
+	numRejectedGangsByOutcome := make(map[schedulercontext.RoundOutcome]int)
 	for {
 		// Peek() returns the next gang to try to schedule. Call Clear() before calling Peek() again.
 		// Calling Clear() after (failing to) schedule ensures we get the next gang in order of smallest fair share.
@@ -107,19 +140,25 @@ func (sch *QueueScheduler) Schedule(ctx context.Context) (*SchedulerResult, erro
 			return nil, err
 		default:
 		}
-		if ok, unschedulableReason, err := sch.gangScheduler.Schedule(ctx, gctx); err != nil {
+		if ok, gangNodeIdByJobId, gangPreemptedJobs, unschedulableReason, err := sch.gangScheduler.Schedule(ctx, gctx); err != nil {
 			return nil, err
 		} else if ok {
 			for _, jctx := range gctx.JobSchedulingContexts {
-				scheduledJobs = append(scheduledJobs, jctx.Job)
-				if jctx.PodSchedulingContext != nil && jctx.PodSchedulingContext.Node != nil {
-					nodeIdByJobId[jctx.JobId] = jctx.PodSchedulingContext.Node.Id
+				if !jctx.IsSuccessful() {
+					// Jobs in partially scheduled gangs that couldn't be scheduled.
+					continue
 				}
+				scheduledJobs = append(scheduledJobs, jctx.Job)
+			}
+			preemptedJobs = append(preemptedJobs, gangPreemptedJobs...)
+			maps.Copy(nodeIdByJobId, gangNodeIdByJobId)
+		} else {
+			numRejectedGangsByOutcome[roundOutcomeFromRejectedGang(gctx)]++
+			if schedulerconstraints.IsTerminalUnschedulableReason(unschedulableReason) {
+				// If unschedulableReason indicates no more new jobs can be scheduled,
+				// instruct the underlying iterator to only yield evicted jobs from now on.
+				sch.candidateGangIterator.OnlyYieldEvicted()
 			}
-		} else if schedulerconstraints.IsTerminalUnschedulableReason(unschedulableReason) {
-			// If unschedulableReason indicates no more new jobs can be scheduled,
-			// instruct the underlying iterator to only yield evicted jobs from now on.
-			sch.candidateGangIterator.OnlyYieldEvicted()
 		}
 		// Clear() to get the next gang in order of smallest fair share.
 		// Calling clear here ensures the gang scheduled in this iteration is accounted for.
@@ -130,16 +169,80 @@ func (sch *QueueScheduler) Schedule(ctx context.Context) (*SchedulerResult, erro
 	if sch.schedulingContext.TerminationReason == "" {
 		sch.schedulingContext.TerminationReason = "no remaining candidate jobs"
 	}
-	if len(scheduledJobs) != len(nodeIdByJobId) {
-		return nil, errors.Errorf("only %d out of %d jobs mapped to a node", len(nodeIdByJobId), len(scheduledJobs))
+	if sch.schedulingContext.NumScheduledJobs == 0 {
+		sch.schedulingContext.RoundOutcome = dominantRoundOutcome(numRejectedGangsByOutcome)
+	}
+	if len(preemptedJobs)+len(scheduledJobs) != len(nodeIdByJobId) {
+		return nil, errors.Errorf(
+			"only %d out of %d jobs mapped to a node",
+			len(nodeIdByJobId), len(preemptedJobs)+len(scheduledJobs),
+		)
 	}
 	return &SchedulerResult{
-		PreemptedJobs: nil,
+		PreemptedJobs: preemptedJobs,
 		ScheduledJobs: scheduledJobs,
 		NodeIdByJobId: nodeIdByJobId,
 	}, nil
 }
 
+// roundOutcomesByPrecedence determines which outcome dominantRoundOutcome returns if several are equally common.
+var roundOutcomesByPrecedence = []schedulercontext.RoundOutcome{
+	schedulercontext.RoundOutcomeExecutorDraining,
+	schedulercontext.RoundOutcomeQueuesPaused,
+	schedulercontext.RoundOutcomeRoundLimitReached,
+	schedulercontext.RoundOutcomeNodeCapacityExhausted,
+	schedulercontext.RoundOutcomeQueueLimitReached,
+	schedulercontext.RoundOutcomeDeadlineExceeded,
+	schedulercontext.RoundOutcomeJobsUnschedulable,
+}
+
+// dominantRoundOutcome returns the outcome the largest number of gangs were rejected with,
+// or RoundOutcomeNoEligibleJobs if no gangs were rejected.
+func dominantRoundOutcome(numRejectedGangsByOutcome map[schedulercontext.RoundOutcome]int) schedulercontext.RoundOutcome {
+	rv := schedulercontext.RoundOutcomeNoEligibleJobs
+	maxCount := 0
+	for _, outcome := range roundOutcomesByPrecedence {
+		if count := numRejectedGangsByOutcome[outcome]; count > maxCount {
+			rv = outcome
+			maxCount = count
+		}
+	}
+	return rv
+}
+
+// roundOutcomeFromRejectedGang returns the round outcome corresponding to the rejection code of a gang
+// that couldn't be scheduled.
+func roundOutcomeFromRejectedGang(gctx *schedulercontext.GangSchedulingContext) schedulercontext.RoundOutcome {
+	code := ""
+	for _, jctx := range gctx.JobSchedulingContexts {
+		if jctx.UnschedulableReasonCode != "" {
+			code = jctx.UnschedulableReasonCode
+			break
+		}
+	}
+	switch schedulerconstraints.RejectionCode(code) {
+	case schedulerconstraints.RejectionCodeInsufficientNodeCapacity,
+		schedulerconstraints.RejectionCodeInsufficientPreemptibleCapacity,
+		schedulerconstraints.RejectionCodeGangMaxNodeSpanExceeded:
+		return schedulercontext.RoundOutcomeNodeCapacityExhausted
+	case schedulerconstraints.RejectionCodeMaximumJobsToSchedule,
+		schedulerconstraints.RejectionCodeMaximumGangsToSchedule,
+		schedulerconstraints.RejectionCodeMaximumResourceFractionToSchedule:
+		return schedulercontext.RoundOutcomeRoundLimitReached
+	case schedulerconstraints.RejectionCodeMaximumResourceFractionPerQueue,
+		schedulerconstraints.RejectionCodeMaximumResourceFractionToScheduleForPriorityClass:
+		return schedulercontext.RoundOutcomeQueueLimitReached
+	case schedulerconstraints.RejectionCodeDeadlineExceeded:
+		return schedulercontext.RoundOutcomeDeadlineExceeded
+	case schedulerconstraints.RejectionCodeExecutorDraining:
+		return schedulercontext.RoundOutcomeExecutorDraining
+	case schedulerconstraints.RejectionCodeQueuePaused:
+		return schedulercontext.RoundOutcomeQueuesPaused
+	default:
+		return schedulercontext.RoundOutcomeJobsUnschedulable
+	}
+}
+
 // QueuedGangIterator is an iterator over queued gangs.
 // Each gang is yielded once its final member is received from the underlying iterator.
 // Jobs without gangIdAnnotation are considered gangs of cardinality 1.
@@ -215,12 +318,14 @@ func (it *QueuedGangIterator) Peek() (*schedulercontext.GangSchedulingContext, e
 			schedulingKey := it.schedulingContext.SchedulingKeyFromLegacySchedulerJob(job)
 			if unsuccessfulJctx, ok := it.schedulingContext.UnfeasibleSchedulingKeys[schedulingKey]; ok {
 				jctx := &schedulercontext.JobSchedulingContext{
-					Created:              time.Now(),
-					ExecutorId:           it.schedulingContext.ExecutorId,
-					JobId:                job.GetId(),
-					Job:                  job,
-					UnschedulableReason:  unsuccessfulJctx.UnschedulableReason,
-					PodSchedulingContext: unsuccessfulJctx.PodSchedulingContext,
+					Created:                 time.Now(),
+					ExecutorId:              it.schedulingContext.ExecutorId,
+					JobId:                   job.GetId(),
+					Job:                     job,
+					UnschedulableReason:     unsuccessfulJctx.UnschedulableReason,
+					UnschedulableReasonCode: unsuccessfulJctx.UnschedulableReasonCode,
+					UnschedulableResource:   unsuccessfulJctx.UnschedulableResource,
+					PodSchedulingContext:    unsuccessfulJctx.PodSchedulingContext,
 				}
 				if _, err := it.schedulingContext.AddJobSchedulingContext(jctx); err != nil {
 					return nil, err
@@ -281,8 +386,6 @@ type CandidateGangIterator struct {
 	weightByQueue map[string]float64
 	// Sum of all weights.
 	weightSum float64
-	// Total weighted resources.
-	totalResourcesAsWeightedMillis int64
 	// Reusable buffer to avoid allocations.
 	buffer schedulerobjects.ResourceList
 	// Priority queue containing per-queue iterators.
@@ -305,17 +408,12 @@ func NewCandidateGangIterator(
 		weightByQueue[queue] = weight
 		weightSum += weight
 	}
-	totalResourcesAsWeightedMillis := ResourceListAsWeightedMillis(sctx.ResourceScarcity, sctx.TotalResources)
-	if totalResourcesAsWeightedMillis < 1 {
-		totalResourcesAsWeightedMillis = 1
-	}
 	it := &CandidateGangIterator{
-		SchedulingContext:              sctx,
-		weightByQueue:                  weightByQueue,
-		weightSum:                      weightSum,
-		totalResourcesAsWeightedMillis: totalResourcesAsWeightedMillis,
-		buffer:                         schedulerobjects.NewResourceListWithDefaultSize(),
-		pq:                             make(QueueCandidateGangIteratorPQ, 0, len(iteratorsByQueue)),
+		SchedulingContext: sctx,
+		weightByQueue:     weightByQueue,
+		weightSum:         weightSum,
+		buffer:            schedulerobjects.NewResourceListWithDefaultSize(),
+		pq:                make(QueueCandidateGangIteratorPQ, 0, len(iteratorsByQueue)),
 	}
 	for queue, queueIt := range iteratorsByQueue {
 		if _, err := it.updateAndPushPQItem(it.newPQItem(queue, queueIt)); err != nil {
@@ -371,6 +469,7 @@ func (it *CandidateGangIterator) updatePQItem(item *QueueCandidateGangIteratorIt
 }
 
 // fractionOfFairShareWithGctx returns the fraction of its fair share this queue would have if the jobs in gctx were scheduled.
+// Resource usage is computed according to the fairness model of the scheduling context.
 func (it *CandidateGangIterator) fractionOfFairShareWithGctx(gctx *schedulercontext.GangSchedulingContext) float64 {
 	it.buffer.Zero()
 	it.buffer.Add(it.SchedulingContext.QueueSchedulingContexts[gctx.Queue].Allocated)
@@ -380,9 +479,28 @@ func (it *CandidateGangIterator) fractionOfFairShareWithGctx(gctx *schedulercont
 		return 1
 	} else {
 		fairShare := queueWeight / it.weightSum
-		used := ResourceListAsWeightedMillis(it.SchedulingContext.ResourceScarcity, it.buffer)
-		return (float64(used) / float64(it.totalResourcesAsWeightedMillis)) / fairShare
+		return it.SchedulingContext.UsageFraction(it.buffer) / fairShare
+	}
+}
+
+// QueueOrder returns the queues with gangs left to consider, in the order in which the iterator would yield their next gang
+// if the fraction of fair share of each queue remained as it is now, i.e., if no gangs were scheduled.
+// Since scheduling a gang increases the fraction of fair share of its queue, this is the order in which queues are
+// first considered, rather than the order of all gangs yielded. The iterator is left unchanged.
+func (it *CandidateGangIterator) QueueOrder() []schedulercontext.QueueOrderEntry {
+	// Sorting a copy with sort.Slice leaves the heap indices of items, which belong to it.pq, unchanged.
+	pq := slices.Clone(it.pq)
+	sort.Slice(pq, func(i, j int) bool { return pq.Less(i, j) })
+	rv := make([]schedulercontext.QueueOrderEntry, len(pq))
+	for i, item := range pq {
+		rv[i] = schedulercontext.QueueOrderEntry{
+			Queue:               item.queue,
+			FairShare:           it.weightByQueue[item.queue] / it.weightSum,
+			FractionOfFairShare: item.fractionOfFairShare,
+			NextGangCardinality: len(item.gctx.JobSchedulingContexts),
+		}
 	}
+	return rv
 }
 
 // Clear removes the first item in the iterator.
@@ -58,6 +58,13 @@ func TestQueueScheduler(t *testing.T) {
 			Jobs:                     testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 33),
 			ExpectedScheduledIndices: testfixtures.IntRange(0, 31),
 		},
+		"nil resource scarcity": {
+			SchedulingConfig:         testfixtures.WithResourceScarcityConfig(nil, testfixtures.TestSchedulingConfig()),
+			PriorityFactorByQueue:    map[string]float64{"A": 1.0},
+			Nodes:                    testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Jobs:                     testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 33),
+			ExpectedScheduledIndices: testfixtures.IntRange(0, 31),
+		},
 		"multiple nodes": {
 			SchedulingConfig:         testfixtures.TestSchedulingConfig(),
 			PriorityFactorByQueue:    map[string]float64{"A": 1.0},
@@ -161,6 +168,63 @@ func TestQueueScheduler(t *testing.T) {
 				testfixtures.IntRange(18, 34),
 			),
 		},
+		"PerPriorityLimits absolute": {
+			SchedulingConfig: testfixtures.WithPerPriorityAbsoluteLimitsConfig(
+				map[int32]map[string]resource.Quantity{
+					0: {"cpu": resource.MustParse("32")},
+					1: {"cpu": resource.MustParse("15")},
+					2: {"cpu": resource.MustParse("10")},
+					3: {"cpu": resource.MustParse("3")},
+				},
+				testfixtures.TestSchedulingConfig(),
+			),
+			PriorityFactorByQueue: map[string]float64{"A": 1.0},
+			Nodes:                 testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Jobs: armadaslices.Concatenate(
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass3, 4),
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass2, 8),
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass1, 6),
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 18),
+			),
+			ExpectedScheduledIndices: armadaslices.Concatenate(
+				testfixtures.IntRange(0, 2),
+				testfixtures.IntRange(4, 10),
+				testfixtures.IntRange(12, 16),
+				testfixtures.IntRange(18, 34),
+			),
+		},
+		"PerPriorityLimits fractional and absolute": {
+			// The more restrictive of the two limits applies, i.e., 32, 15, 10, and 3 cpu for priorities 0 to 3.
+			SchedulingConfig: testfixtures.WithPerPriorityAbsoluteLimitsConfig(
+				map[int32]map[string]resource.Quantity{
+					2: {"cpu": resource.MustParse("10")},
+					3: {"cpu": resource.MustParse("16")},
+				},
+				testfixtures.WithPerPriorityLimitsConfig(
+					map[int32]map[string]float64{
+						0: {"cpu": 1.0},
+						1: {"cpu": 15.0 / 32.0},
+						2: {"cpu": 0.5},
+						3: {"cpu": 3.0 / 32.0},
+					},
+					testfixtures.TestSchedulingConfig(),
+				),
+			),
+			PriorityFactorByQueue: map[string]float64{"A": 1.0},
+			Nodes:                 testfixtures.N32CpuNodes(1, testfixtures.TestPriorities),
+			Jobs: armadaslices.Concatenate(
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass3, 4),
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass2, 8),
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass1, 6),
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 18),
+			),
+			ExpectedScheduledIndices: armadaslices.Concatenate(
+				testfixtures.IntRange(0, 2),
+				testfixtures.IntRange(4, 10),
+				testfixtures.IntRange(12, 16),
+				testfixtures.IntRange(18, 34),
+			),
+		},
 		"PerPriorityLimits equal MaximumResourceFractionToSchedule": {
 			SchedulingConfig: testfixtures.WithPerPriorityLimitsConfig(
 				map[int32]map[string]float64{
@@ -294,6 +358,50 @@ func TestQueueScheduler(t *testing.T) {
 			},
 			ExpectedScheduledIndices: testfixtures.IntRange(32, 63),
 		},
+		"asset fairness schedules from the queue with the least weighted usage": {
+			SchedulingConfig: testfixtures.WithFairnessModelConfig(
+				schedulercontext.FairnessModelAsset,
+				testfixtures.WithMaxJobsToScheduleConfig(
+					1,
+					testfixtures.WithResourceScarcityConfig(map[string]float64{"cpu": 1, "gpu": 1}, testfixtures.TestSchedulingConfig()),
+				),
+			),
+			Nodes: testfixtures.N8GpuNodes(1, testfixtures.TestPriorities),
+			Jobs: armadaslices.Concatenate(
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+				testfixtures.N1CpuJobs("B", testfixtures.PriorityClass0, 1),
+			),
+			PriorityFactorByQueue: map[string]float64{"A": 1, "B": 1},
+			// A uses 4 of 72 weighted units and B 16 of 72.
+			InitialAllocatedByQueueAndPriority: map[string]schedulerobjects.QuantityByPriorityAndResourceType{
+				"A": {0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"gpu": resource.MustParse("4")}}},
+				"B": {0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("16")}}},
+			},
+			ExpectedScheduledIndices:      []int{0},
+			ExpectedNeverAttemptedIndices: []int{1},
+		},
+		"dominant resource fairness schedules from the queue with the smallest dominant share": {
+			SchedulingConfig: testfixtures.WithFairnessModelConfig(
+				schedulercontext.FairnessModelDominantResource,
+				testfixtures.WithMaxJobsToScheduleConfig(
+					1,
+					testfixtures.WithResourceScarcityConfig(map[string]float64{"cpu": 1, "gpu": 1}, testfixtures.TestSchedulingConfig()),
+				),
+			),
+			Nodes: testfixtures.N8GpuNodes(1, testfixtures.TestPriorities),
+			Jobs: armadaslices.Concatenate(
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+				testfixtures.N1CpuJobs("B", testfixtures.PriorityClass0, 1),
+			),
+			PriorityFactorByQueue: map[string]float64{"A": 1, "B": 1},
+			// A uses half of all gpus and B a quarter of all cpu.
+			InitialAllocatedByQueueAndPriority: map[string]schedulerobjects.QuantityByPriorityAndResourceType{
+				"A": {0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"gpu": resource.MustParse("4")}}},
+				"B": {0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("16")}}},
+			},
+			ExpectedScheduledIndices:      []int{1},
+			ExpectedNeverAttemptedIndices: []int{0},
+		},
 		"node with no available capacity": {
 			SchedulingConfig: testfixtures.TestSchedulingConfig(),
 			Nodes: testfixtures.WithUsedResourcesNodes(
@@ -497,6 +605,7 @@ func TestQueueScheduler(t *testing.T) {
 				tc.SchedulingConfig.ResourceScarcity,
 				tc.TotalResources,
 			)
+			require.NoError(t, sctx.SetFairnessModel(tc.SchedulingConfig.FairnessModel))
 			for queue, priorityFactor := range tc.PriorityFactorByQueue {
 				err := sctx.AddQueueSchedulingContext(queue, priorityFactor, tc.InitialAllocatedByQueueAndPriority[queue])
 				require.NoError(t, err)
@@ -640,6 +749,176 @@ func TestQueueScheduler(t *testing.T) {
 	}
 }
 
+func TestQueueScheduler_PreviewQueueOrder(t *testing.T) {
+	config := testfixtures.TestSchedulingConfig()
+	nodeDb, err := CreateNodeDb(testfixtures.N32CpuNodes(1, testfixtures.TestPriorities))
+	require.NoError(t, err)
+	jobs := armadaslices.Concatenate(
+		testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+		testfixtures.N1CpuJobs("B", testfixtures.PriorityClass0, 1),
+		testfixtures.WithGangAnnotationsJobs(testfixtures.N1CpuJobs("C", testfixtures.PriorityClass0, 2)),
+	)
+	// D has no queued jobs, but still contributes to the sum of weights determining fair shares.
+	priorityFactorByQueue := map[string]float64{"A": 1, "B": 1, "C": 2, "D": 1}
+	legacySchedulerJobs := make([]interfaces.LegacySchedulerJob, len(jobs))
+	for i, job := range jobs {
+		legacySchedulerJobs[i] = job
+	}
+	jobRepo := NewInMemoryJobRepository(config.Preemption.PriorityClasses)
+	jobRepo.EnqueueMany(legacySchedulerJobs)
+
+	sctx := schedulercontext.NewSchedulingContext(
+		"executor",
+		"pool",
+		config.Preemption.PriorityClasses,
+		config.Preemption.DefaultPriorityClass,
+		config.ResourceScarcity,
+		nodeDb.TotalResources(),
+	)
+	for queue, priorityFactor := range priorityFactorByQueue {
+		var initialAllocated schedulerobjects.QuantityByPriorityAndResourceType
+		if queue == "A" {
+			initialAllocated = schedulerobjects.QuantityByPriorityAndResourceType{
+				0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("16")}},
+			}
+		}
+		require.NoError(t, sctx.AddQueueSchedulingContext(queue, priorityFactor, initialAllocated))
+	}
+	constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+		"pool",
+		nodeDb.TotalResources(),
+		schedulerobjects.ResourceList{},
+		config,
+	)
+	jobIteratorByQueue := make(map[string]JobIterator)
+	for queue := range priorityFactorByQueue {
+		it, err := jobRepo.GetJobIterator(context.Background(), queue)
+		require.NoError(t, err)
+		jobIteratorByQueue[queue] = it
+	}
+	sch, err := NewQueueScheduler(sctx, constraints, nodeDb, jobIteratorByQueue)
+	require.NoError(t, err)
+
+	order := sch.PreviewQueueOrder()
+
+	// B and C are unallocated, but C has half the fair share of B and its next gang requests twice as much.
+	// A already has half of the cluster allocated to it.
+	require.Equal(t, []string{"B", "C", "A"}, util.Map(order, func(e schedulercontext.QueueOrderEntry) string { return e.Queue }))
+	assert.InDelta(t, 1/3.5, order[0].FairShare, 1e-9)
+	assert.InDelta(t, 0.5/3.5, order[1].FairShare, 1e-9)
+	assert.InDelta(t, 1/3.5, order[2].FairShare, 1e-9)
+	assert.InDelta(t, 4*order[0].FractionOfFairShare, order[1].FractionOfFairShare, 1e-9)
+	assert.Less(t, order[1].FractionOfFairShare, order[2].FractionOfFairShare)
+	assert.Equal(t, []int{1, 2, 1}, util.Map(order, func(e schedulercontext.QueueOrderEntry) int { return e.NextGangCardinality }))
+
+	// Previewing doesn't schedule anything or change the order.
+	assert.Equal(t, order, sch.PreviewQueueOrder())
+	for _, qctx := range sctx.QueueSchedulingContexts {
+		assert.Empty(t, qctx.SuccessfulJobSchedulingContexts)
+		assert.Empty(t, qctx.UnsuccessfulJobSchedulingContexts)
+	}
+
+	// The order at the start of scheduling is recorded in the scheduling context and included in verbose reports.
+	result, err := sch.Schedule(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, result.ScheduledJobs, 4)
+	assert.Equal(t, order, sctx.QueueOrder)
+	assert.Empty(t, sch.PreviewQueueOrder())
+	assert.NotContains(t, sctx.ReportString(1), "Queue order:")
+	assert.Contains(t, sctx.ReportString(2), "Queue order:")
+}
+
+func TestQueueScheduler_RoundOutcome(t *testing.T) {
+	tests := map[string]struct {
+		SchedulingConfig configuration.SchedulingConfig
+		Jobs             []*jobdb.Job
+		Expected         schedulercontext.RoundOutcome
+	}{
+		"jobs scheduled": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Jobs: armadaslices.Concatenate(
+				testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1),
+				testfixtures.N1GpuJobs("A", testfixtures.PriorityClass0, 1),
+			),
+			Expected: "",
+		},
+		"no jobs": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Expected:         schedulercontext.RoundOutcomeNoEligibleJobs,
+		},
+		"node capacity exhausted": {
+			SchedulingConfig: testfixtures.TestSchedulingConfig(),
+			Jobs:             testfixtures.N1GpuJobs("A", testfixtures.PriorityClass0, 3),
+			Expected:         schedulercontext.RoundOutcomeNodeCapacityExhausted,
+		},
+		"queue limit reached": {
+			SchedulingConfig: testfixtures.WithPerPriorityLimitsConfig(
+				map[int32]map[string]float64{0: {"cpu": 0}},
+				testfixtures.TestSchedulingConfig(),
+			),
+			Jobs:     testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 3),
+			Expected: schedulercontext.RoundOutcomeQueueLimitReached,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			nodeDb, err := CreateNodeDb(testfixtures.N32CpuNodes(1, testfixtures.TestPriorities))
+			require.NoError(t, err)
+			legacySchedulerJobs := make([]interfaces.LegacySchedulerJob, len(tc.Jobs))
+			for i, job := range tc.Jobs {
+				legacySchedulerJobs[i] = job
+			}
+			jobRepo := NewInMemoryJobRepository(tc.SchedulingConfig.Preemption.PriorityClasses)
+			jobRepo.EnqueueMany(legacySchedulerJobs)
+
+			sctx := schedulercontext.NewSchedulingContext(
+				"executor",
+				"pool",
+				tc.SchedulingConfig.Preemption.PriorityClasses,
+				tc.SchedulingConfig.Preemption.DefaultPriorityClass,
+				tc.SchedulingConfig.ResourceScarcity,
+				nodeDb.TotalResources(),
+			)
+			require.NoError(t, sctx.AddQueueSchedulingContext("A", 1, nil))
+			constraints := schedulerconstraints.SchedulingConstraintsFromSchedulingConfig(
+				"pool",
+				nodeDb.TotalResources(),
+				schedulerobjects.ResourceList{},
+				tc.SchedulingConfig,
+			)
+			it, err := jobRepo.GetJobIterator(context.Background(), "A")
+			require.NoError(t, err)
+			sch, err := NewQueueScheduler(sctx, constraints, nodeDb, map[string]JobIterator{"A": it})
+			require.NoError(t, err)
+
+			_, err = sch.Schedule(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, sctx.RoundOutcome)
+		})
+	}
+}
+
+func TestDominantRoundOutcome(t *testing.T) {
+	assert.Equal(t, schedulercontext.RoundOutcomeNoEligibleJobs, dominantRoundOutcome(nil))
+	assert.Equal(
+		t,
+		schedulercontext.RoundOutcomeQueueLimitReached,
+		dominantRoundOutcome(map[schedulercontext.RoundOutcome]int{
+			schedulercontext.RoundOutcomeNodeCapacityExhausted: 1,
+			schedulercontext.RoundOutcomeQueueLimitReached:     2,
+		}),
+	)
+	// Ties are broken by precedence.
+	assert.Equal(
+		t,
+		schedulercontext.RoundOutcomeNodeCapacityExhausted,
+		dominantRoundOutcome(map[schedulercontext.RoundOutcome]int{
+			schedulercontext.RoundOutcomeJobsUnschedulable:     2,
+			schedulercontext.RoundOutcomeNodeCapacityExhausted: 2,
+		}),
+	)
+}
+
 func CreateNodeDb(nodes []*schedulerobjects.Node) (*nodedb.NodeDb, error) {
 	db, err := nodedb.NewNodeDb(
 		testfixtures.TestPriorityClasses,
@@ -1,22 +1,38 @@
 package scheduler
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"path"
+	"regexp"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"text/tabwriter"
+	"time"
 
+	"github.com/google/uuid"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/oklog/ulid"
 	"github.com/openconfig/goyang/pkg/indent"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
 	"github.com/armadaproject/armada/internal/common/armadaerrors"
 	armadamaps "github.com/armadaproject/armada/internal/common/maps"
+	commonmetrics "github.com/armadaproject/armada/internal/common/metrics"
+	armadaslices "github.com/armadaproject/armada/internal/common/slices"
+	"github.com/armadaproject/armada/internal/common/util"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
@@ -33,6 +49,13 @@ type SchedulingContextRepository struct {
 	mostRecentSuccessfulSchedulingContextByExecutorP atomic.Pointer[SchedulingContextByExecutor]
 	// The most recent attempt that preempted at least one job.
 	mostRecentPreemptingSchedulingContextByExecutorP atomic.Pointer[SchedulingContextByExecutor]
+	// The most recent attempt where no resources were scheduled.
+	mostRecentUnsuccessfulSchedulingContextByExecutorP atomic.Pointer[SchedulingContextByExecutor]
+	// Maps executor id to the up to historyLength most recent attempts, in the order they were added.
+	// Slices stored here are never mutated; a new slice is created on each add.
+	schedulingContextHistoryByExecutorP atomic.Pointer[map[string][]*schedulercontext.SchedulingContext]
+	// Number of recent attempts to store per executor; zero disables storing history.
+	historyLength uint
 
 	// Maps queue name to QueueSchedulingContextByExecutor.
 	// The most recent attempt.
@@ -41,10 +64,22 @@ type SchedulingContextRepository struct {
 	mostRecentSuccessfulQueueSchedulingContextByExecutorByQueueP atomic.Pointer[map[string]QueueSchedulingContextByExecutor]
 	// The most recent attempt that preempted at least one job belonging to this queue.
 	mostRecentPreemptingQueueSchedulingContextByExecutorByQueueP atomic.Pointer[map[string]QueueSchedulingContextByExecutor]
-
-	// Maps job id to JobSchedulingContextByExecutor.
-	// We limit the number of job contexts to store to control memory usage.
-	mostRecentJobSchedulingContextByExecutorByJobId *lru.Cache
+	// Maps queue name to the up to queueFairShareHistoryLength most recent samples, in the order they were added.
+	// Slices stored here are never mutated; a new slice is created on each add.
+	queueFairShareHistoryByQueueP atomic.Pointer[map[string][]QueueFairShareSample]
+	// Number of samples to store per queue; zero disables storing fair share history.
+	queueFairShareHistoryLength uint
+
+	// Maps executor id to a cache mapping job id to the most recent JobSchedulingContext for that executor.
+	// We limit the number of job contexts to store per executor to control memory usage.
+	// Each executor has its own cache, such that jobs of busy executors don't evict those of quiet ones.
+	jobSchedulingContextCacheByExecutorP atomic.Pointer[map[string]*lru.Cache]
+	// Capacity of each of the above caches, unless overridden for the executor in maxJobSchedulingContextsByExecutor.
+	maxJobSchedulingContexts int
+	// Maps executor id to the capacity of the cache of that executor; see SetMaxJobSchedulingContextsByExecutor.
+	maxJobSchedulingContextsByExecutor map[string]int
+	// Number of job contexts evicted from the above caches so far.
+	numJobSchedulingContextEvictions atomic.Uint64
 
 	// Store all executor ids seen so far in a set.
 	// Used to ensure all executors are included in reports.
@@ -52,51 +87,406 @@ type SchedulingContextRepository struct {
 	// All executors in sorted order.
 	sortedExecutorIdsP atomic.Pointer[[]string]
 
+	// Used to validate job ids provided to GetJobReport.
+	validateJobId JobIdValidator
+	// Executors for which the most recent scheduling context was started more than this long ago
+	// are removed from the repository. If zero, executors are never removed.
+	executorTtl time.Duration
+	clock       clock.Clock
+
+	// Number of additional job ids printed per list in queue reports for each verbosity level above 1.
+	// If zero, all job ids are printed for verbosity levels above 1.
+	maxPrintedJobIdsPerVerbosityLevel uint
+
+	// Used to resolve priorities to priority class names in reports.
+	// If empty, the priority classes of each scheduling context are used instead.
+	// Stored atomically, since it may be set while reports are generated.
+	priorityClassesP atomic.Pointer[map[string]configuration.PriorityClass]
+
+	// Executors onto which no new jobs are scheduled. Draining executors are marked as such in reports,
+	// but are otherwise treated like any other executor, such that their recent contexts remain available.
+	// Not affected by Clear.
+	drainingExecutors *DrainingExecutors
+	// Queues from which no new jobs are scheduled. Paused queues are marked as such in queue reports.
+	// Not affected by Clear.
+	pausedQueues *PausedQueues
+
+	// If non-nil, AddSchedulingContext only buffers contexts here and Run adds them to the repository.
+	// See SetIngestionBufferSize.
+	ingestionQueue chan *schedulercontext.SchedulingContext
+	// Number of contexts discarded by AddSchedulingContext because ingestionQueue was full.
+	numDroppedSchedulingContexts atomic.Uint64
+
+	// Running totals of the outcomes of the scheduling attempts added, by executor id and by queue; see GetCounters.
+	// Maps are replaced rather than mutated when counters are added, whereas the counters themselves are updated
+	// atomically in place. Not affected by Clear.
+	countersByExecutorP atomic.Pointer[map[string]*schedulingOutcomeCounters]
+	countersByQueueP    atomic.Pointer[map[string]*schedulingOutcomeCounters]
+
+	// Channels onto which each scheduling context is sent once added; see SubscribeToSchedulingContexts.
+	// Not affected by Clear.
+	subscribers map[chan *schedulercontext.SchedulingContext]bool
+	// Number of subscribers dropped because they fell behind.
+	numDroppedSubscribers atomic.Uint64
+	// Protects subscribers. Separate from mu, such that subscribing doesn't wait for writes in progress.
+	subscribersMu sync.Mutex
+
 	// Protects the fields in this struct from concurrent and dirty writes.
 	mu sync.Mutex
 }
 
+// JobIdValidator returns an error if the provided job id is not valid.
+type JobIdValidator func(jobId string) error
+
+// ValidateUlidJobId accepts job ids that are valid ULIDs, as generated by Armada.
+func ValidateUlidJobId(jobId string) error {
+	_, err := ulid.Parse(jobId)
+	return err
+}
+
+// ValidateUuidJobId accepts job ids that are valid UUIDs.
+func ValidateUuidJobId(jobId string) error {
+	_, err := uuid.Parse(jobId)
+	return err
+}
+
+// ValidateNonEmptyJobId accepts any non-empty job id.
+func ValidateNonEmptyJobId(jobId string) error {
+	if jobId == "" {
+		return errors.New("job id is empty")
+	}
+	return nil
+}
+
+// QueueFairShareSample records the resources scheduled to a queue in a single scheduling attempt
+// and the fair share of that queue at the time.
+type QueueFairShareSample struct {
+	// Time at which the scheduling attempt started.
+	Time       time.Time
+	ExecutorId string
+	// Resources scheduled to the queue in this attempt.
+	ScheduledResources schedulerobjects.ResourceList
+	// Fraction of total resources the queue was entitled to in this attempt.
+	FairShare float64
+}
+
 type (
 	SchedulingContextByExecutor      map[string]*schedulercontext.SchedulingContext
 	QueueSchedulingContextByExecutor map[string]*schedulercontext.QueueSchedulingContext
 	JobSchedulingContextByExecutor   map[string]*schedulercontext.JobSchedulingContext
 )
 
-func NewSchedulingContextRepository(maxJobSchedulingContextsPerExecutor uint) (*SchedulingContextRepository, error) {
-	jobSchedulingContextByExecutorByJobId, err := lru.New(int(maxJobSchedulingContextsPerExecutor))
+// NewSchedulingContextRepository returns a new repository.
+// For each executor, up to historyLength of the most recent scheduling contexts are stored in addition to
+// the most recent, most recent successful, most recent preempting, and most recent unsuccessful contexts.
+func NewSchedulingContextRepository(maxJobSchedulingContextsPerExecutor uint, historyLength uint) (*SchedulingContextRepository, error) {
+	rv := &SchedulingContextRepository{
+		maxJobSchedulingContexts: int(maxJobSchedulingContextsPerExecutor),
+		executorIds:              make(map[string]bool),
+		historyLength:            historyLength,
+		validateJobId:            ValidateUlidJobId,
+		clock:                    clock.RealClock{},
+		drainingExecutors:        NewDrainingExecutors(),
+		pausedQueues:             NewPausedQueues(),
+		subscribers:              make(map[chan *schedulercontext.SchedulingContext]bool),
+	}
+	// Fail early if the capacity is invalid, rather than when the first job context is added.
+	if _, err := rv.newJobSchedulingContextCache(""); err != nil {
+		return nil, err
+	}
+	jobSchedulingContextCacheByExecutor := make(map[string]*lru.Cache)
+	rv.jobSchedulingContextCacheByExecutorP.Store(&jobSchedulingContextCacheByExecutor)
+	rv.storeEmptySchedulingContexts()
+	rv.storeEmptyQueueSchedulingContexts()
+	sortedExecutorIds := make([]string, 0)
+	rv.sortedExecutorIdsP.Store(&sortedExecutorIds)
+	countersByExecutor := make(map[string]*schedulingOutcomeCounters)
+	rv.countersByExecutorP.Store(&countersByExecutor)
+	countersByQueue := make(map[string]*schedulingOutcomeCounters)
+	rv.countersByQueueP.Store(&countersByQueue)
+
+	return rv, nil
+}
+
+// NewSchedulingContextRepositoryFromConfig returns a new repository with the limits and report settings of config.
+// Ingesting contexts from a buffer and loading or writing snapshots require background tasks and are left to the caller.
+func NewSchedulingContextRepositoryFromConfig(config configuration.SchedulingConfig) (*SchedulingContextRepository, error) {
+	repo, err := NewSchedulingContextRepository(config.MaxJobSchedulingContextsPerExecutor, config.SchedulingContextHistoryLength)
 	if err != nil {
 		return nil, err
 	}
-	rv := &SchedulingContextRepository{
-		mostRecentJobSchedulingContextByExecutorByJobId: jobSchedulingContextByExecutorByJobId,
-		executorIds: make(map[string]bool),
+	if err := repo.SetMaxJobSchedulingContextsByExecutor(config.MaxJobSchedulingContextsByExecutor); err != nil {
+		return nil, err
+	}
+	repo.SetExecutorTtl(config.SchedulingContextExecutorTtl)
+	repo.SetMaxPrintedJobIdsPerVerbosityLevel(config.MaxPrintedJobIdsPerVerbosityLevel)
+	repo.SetQueueFairShareHistoryLength(config.QueueFairShareHistoryLength)
+	repo.SetPriorityClasses(config.Preemption.PriorityClasses)
+	return repo, nil
+}
+
+// Clear removes all contexts stored in the repository.
+//
+// It's safe to call this method concurrently with methods adding or getting contexts.
+// Each map is swapped for an empty map atomically. Hence, concurrent readers observe either the old or the new
+// version of each map, but never a partially cleared one. Since the maps are swapped one at a time,
+// a reader accessing several maps may observe some maps before and some after clearing.
+// Scheduling contexts are cleared before queue contexts, which are cleared before job contexts;
+// this avoids having a stored scheduling (queue) context referring to a queue (job) context that's been removed.
+func (repo *SchedulingContextRepository) Clear() {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.storeEmptySchedulingContexts()
+	repo.storeEmptyQueueSchedulingContexts()
+
+	// Swapping the caches rather than purging them avoids counting removed entries as evictions.
+	jobSchedulingContextCacheByExecutor := make(map[string]*lru.Cache)
+	repo.jobSchedulingContextCacheByExecutorP.Store(&jobSchedulingContextCacheByExecutor)
+
+	repo.executorIds = make(map[string]bool)
+	sortedExecutorIds := make([]string, 0)
+	repo.sortedExecutorIdsP.Store(&sortedExecutorIds)
+}
+
+// ForgetJob removes the job scheduling contexts of the given job from the caches of all executors,
+// e.g., to purge the scheduling traces of a job on request, rather than waiting for them to be evicted.
+// Returns true if any context was removed.
+//
+// Only the per-executor job caches are affected. Hence, the id of the job may still appear in
+// scheduling and queue reports until the contexts referring to it are replaced by more recent ones.
+func (repo *SchedulingContextRepository) ForgetJob(jobId string) bool {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	removed := false
+	for _, cache := range *repo.jobSchedulingContextCacheByExecutorP.Load() {
+		if cache.Remove(jobId) {
+			// Remove calls the eviction callback; removed entries shouldn't be counted as evictions.
+			// Contexts are only added while holding repo.mu. Hence, no eviction can happen concurrently.
+			repo.numJobSchedulingContextEvictions.Add(^uint64(0))
+			removed = true
+		}
 	}
+	return removed
+}
+
+// ValidateJobId returns an error if jobId isn't a valid job id according to the validator of the repository;
+// see SetJobIdValidator.
+func (repo *SchedulingContextRepository) ValidateJobId(jobId string) error {
+	return repo.validateJobId(jobId)
+}
+
+// newJobSchedulingContextCache returns a new cache for storing the job contexts of the given executor.
+func (repo *SchedulingContextRepository) newJobSchedulingContextCache(executorId string) (*lru.Cache, error) {
+	return lru.NewWithEvict(
+		repo.maxJobSchedulingContextsForExecutor(executorId),
+		func(_, _ interface{}) {
+			repo.numJobSchedulingContextEvictions.Add(1)
+		},
+	)
+}
 
+func (repo *SchedulingContextRepository) storeEmptySchedulingContexts() {
 	mostRecentSchedulingContextByExecutor := make(SchedulingContextByExecutor)
 	mostRecentSuccessfulSchedulingContextByExecutor := make(SchedulingContextByExecutor)
-	mostRecentPreemptingSchedulingContextByExecutorP := make(SchedulingContextByExecutor)
+	mostRecentPreemptingSchedulingContextByExecutor := make(SchedulingContextByExecutor)
+	mostRecentUnsuccessfulSchedulingContextByExecutor := make(SchedulingContextByExecutor)
+	schedulingContextHistoryByExecutor := make(map[string][]*schedulercontext.SchedulingContext)
+	repo.mostRecentSchedulingContextByExecutorP.Store(&mostRecentSchedulingContextByExecutor)
+	repo.mostRecentSuccessfulSchedulingContextByExecutorP.Store(&mostRecentSuccessfulSchedulingContextByExecutor)
+	repo.mostRecentPreemptingSchedulingContextByExecutorP.Store(&mostRecentPreemptingSchedulingContextByExecutor)
+	repo.mostRecentUnsuccessfulSchedulingContextByExecutorP.Store(&mostRecentUnsuccessfulSchedulingContextByExecutor)
+	repo.schedulingContextHistoryByExecutorP.Store(&schedulingContextHistoryByExecutor)
+}
 
+func (repo *SchedulingContextRepository) storeEmptyQueueSchedulingContexts() {
 	mostRecentQueueSchedulingContextByExecutorByQueue := make(map[string]QueueSchedulingContextByExecutor)
 	mostRecentSuccessfulQueueSchedulingContextByExecutorByQueue := make(map[string]QueueSchedulingContextByExecutor)
 	mostRecentPreemptingQueueSchedulingContextByExecutorByQueue := make(map[string]QueueSchedulingContextByExecutor)
+	repo.mostRecentQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentQueueSchedulingContextByExecutorByQueue)
+	repo.mostRecentSuccessfulQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentSuccessfulQueueSchedulingContextByExecutorByQueue)
+	repo.mostRecentPreemptingQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentPreemptingQueueSchedulingContextByExecutorByQueue)
+	queueFairShareHistoryByQueue := make(map[string][]QueueFairShareSample)
+	repo.queueFairShareHistoryByQueueP.Store(&queueFairShareHistoryByQueue)
+}
 
-	sortedExecutorIds := make([]string, 0)
+// SetJobIdValidator replaces the function used to validate job ids provided to GetJobReport and ValidateJobId.
+// By default, only ULIDs are accepted. Should be called before the repository is used.
+func (repo *SchedulingContextRepository) SetJobIdValidator(validator JobIdValidator) {
+	repo.validateJobId = validator
+}
 
-	rv.mostRecentSchedulingContextByExecutorP.Store(&mostRecentSchedulingContextByExecutor)
-	rv.mostRecentSuccessfulSchedulingContextByExecutorP.Store(&mostRecentSuccessfulSchedulingContextByExecutor)
-	rv.mostRecentPreemptingSchedulingContextByExecutorP.Store(&mostRecentPreemptingSchedulingContextByExecutorP)
+// SetMaxJobSchedulingContextsByExecutor overrides, for each executor in the provided map, the number of
+// job scheduling contexts to store for that executor, e.g., such that executors managing large clusters retain
+// more contexts than those managing small ones. Executors not in the map use the capacity provided to
+// NewSchedulingContextRepository. Returns an error if any of the provided capacities is zero.
+// Should be called before the repository is used.
+func (repo *SchedulingContextRepository) SetMaxJobSchedulingContextsByExecutor(maxJobSchedulingContextsByExecutor map[string]uint) error {
+	rv := make(map[string]int, len(maxJobSchedulingContextsByExecutor))
+	for executorId, n := range maxJobSchedulingContextsByExecutor {
+		if n == 0 {
+			return errors.WithStack(&armadaerrors.ErrInvalidArgument{
+				Name:    "maxJobSchedulingContextsByExecutor",
+				Value:   executorId,
+				Message: "capacity must be positive",
+			})
+		}
+		rv[executorId] = int(n)
+	}
+	repo.maxJobSchedulingContextsByExecutor = rv
+	return nil
+}
 
-	rv.mostRecentQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentQueueSchedulingContextByExecutorByQueue)
-	rv.mostRecentSuccessfulQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentSuccessfulQueueSchedulingContextByExecutorByQueue)
-	rv.mostRecentPreemptingQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentPreemptingQueueSchedulingContextByExecutorByQueue)
+// maxJobSchedulingContextsForExecutor returns the capacity of the job context cache of the given executor.
+func (repo *SchedulingContextRepository) maxJobSchedulingContextsForExecutor(executorId string) int {
+	if n, ok := repo.maxJobSchedulingContextsByExecutor[executorId]; ok {
+		return n
+	}
+	return repo.maxJobSchedulingContexts
+}
 
-	rv.sortedExecutorIdsP.Store(&sortedExecutorIds)
+// SetExecutorTtl causes executors for which the most recent scheduling context was started more than ttl ago
+// to be removed from the repository the next time a context is added. If ttl is zero, executors are never removed.
+// Should be called before the repository is used.
+func (repo *SchedulingContextRepository) SetExecutorTtl(ttl time.Duration) {
+	repo.executorTtl = ttl
+}
 
-	return rv, nil
+// SetMaxPrintedJobIdsPerVerbosityLevel limits the number of job ids printed in queue reports.
+// At verbosity JOBS, up to n job ids are printed for each list of jobs; at verbosity FULL, all job ids are printed.
+// If n is zero, no limit is applied. Should be called before the repository is used.
+func (repo *SchedulingContextRepository) SetMaxPrintedJobIdsPerVerbosityLevel(n uint) {
+	repo.maxPrintedJobIdsPerVerbosityLevel = n
+}
+
+// SetQueueFairShareHistoryLength enables storing, for each queue, up to n samples of the resources scheduled
+// to that queue and its fair share, one for each queue scheduling context added to the repository.
+// Samples are retrieved using GetQueueFairShareHistory. If n is zero, the default, no samples are stored.
+// Should be called before the repository is used.
+func (repo *SchedulingContextRepository) SetQueueFairShareHistoryLength(n uint) {
+	repo.queueFairShareHistoryLength = n
+}
+
+// SetIngestionBufferSize causes AddSchedulingContext to buffer up to n contexts and return immediately,
+// rather than adding each context to the repository before returning, such that scheduling isn't slowed down by
+// contention on the repository when many contexts are added in quick succession. Buffered contexts are added by Run,
+// which must be running for any contexts to be added. Contexts provided while the buffer is full are discarded
+// and counted; see SchedulingContextRepositoryStats.NumDroppedSchedulingContexts.
+// Reads are unaffected, except that contexts become visible only once Run has added them.
+// If n is zero, the default, contexts are added synchronously. Should be called before the repository is used.
+func (repo *SchedulingContextRepository) SetIngestionBufferSize(n uint) {
+	if n == 0 {
+		repo.ingestionQueue = nil
+		return
+	}
+	repo.ingestionQueue = make(chan *schedulercontext.SchedulingContext, n)
+}
+
+// Run adds contexts buffered by AddSchedulingContext to the repository until ctx is cancelled.
+// All contexts buffered at the time are added together, such that the lock is acquired once per batch.
+// Contexts still buffered once ctx is cancelled are discarded.
+// Returns immediately if no ingestion buffer has been configured; see SetIngestionBufferSize.
+func (repo *SchedulingContextRepository) Run(ctx context.Context) error {
+	if repo.ingestionQueue == nil {
+		return nil
+	}
+	batch := make([]*schedulercontext.SchedulingContext, 0, cap(repo.ingestionQueue))
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case sctx := <-repo.ingestionQueue:
+			batch = append(batch[:0], sctx)
+			batch = repo.appendBufferedSchedulingContexts(batch)
+			repo.addSchedulingContextBatch(batch)
+		}
+	}
+}
+
+// appendBufferedSchedulingContexts appends to batch any contexts in ingestionQueue, without blocking.
+func (repo *SchedulingContextRepository) appendBufferedSchedulingContexts(batch []*schedulercontext.SchedulingContext) []*schedulercontext.SchedulingContext {
+	for len(batch) < cap(batch) {
+		select {
+		case sctx := <-repo.ingestionQueue:
+			batch = append(batch, sctx)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// addSchedulingContextBatch adds each of the provided contexts to the repository, in order.
+// Since Run has no caller to return errors to, errors are logged and the remaining contexts are still added.
+func (repo *SchedulingContextRepository) addSchedulingContextBatch(sctxs []*schedulercontext.SchedulingContext) {
+	queueSchedulingContextsByQueue := make([]map[string]*schedulercontext.QueueSchedulingContext, len(sctxs))
+	jobSchedulingContextsByJobId := make([]map[string]*schedulercontext.JobSchedulingContext, len(sctxs))
+	for i, sctx := range sctxs {
+		queueSchedulingContextsByQueue[i], jobSchedulingContextsByJobId[i] = extractQueueAndJobContexts(sctx)
+	}
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	for i, sctx := range sctxs {
+		if err := repo.addSchedulingContextLocked(sctx, queueSchedulingContextsByQueue[i], jobSchedulingContextsByJobId[i]); err != nil {
+			log.WithError(err).Errorf("failed to add scheduling context for executor %s", sctx.ExecutorId)
+		}
+	}
+}
+
+// DrainingExecutors returns the set of draining executors, which schedulers should consult
+// via PreemptingQueueScheduler.SetDrainingExecutors.
+func (repo *SchedulingContextRepository) DrainingExecutors() *DrainingExecutors {
+	return repo.drainingExecutors
+}
+
+// PausedQueues returns the set of paused queues, which schedulers should consult
+// via PreemptingQueueScheduler.SetPausedQueues.
+func (repo *SchedulingContextRepository) PausedQueues() *PausedQueues {
+	return repo.pausedQueues
+}
+
+// SetPriorityClasses sets the priority classes used to resolve priorities to priority class names in reports,
+// e.g., to summarise the resources scheduled at each priority class.
+func (repo *SchedulingContextRepository) SetPriorityClasses(priorityClasses map[string]configuration.PriorityClass) {
+	repo.priorityClassesP.Store(&priorityClasses)
+}
+
+// priorityClasses returns the priority classes set via SetPriorityClasses, or nil if none have been set.
+func (repo *SchedulingContextRepository) priorityClasses() map[string]configuration.PriorityClass {
+	if p := repo.priorityClassesP.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// clampReportVerbosity returns the verbosity level used to generate reports for the given requested verbosity.
+// Out-of-range values are clamped to the nearest valid level rather than rejected,
+// such that clients may request, e.g., "as verbose as possible" without knowing how many levels there are.
+func clampReportVerbosity(verbosity schedulerobjects.ReportVerbosity) int32 {
+	if verbosity < schedulerobjects.ReportVerbosity_SUMMARY {
+		return int32(schedulerobjects.ReportVerbosity_SUMMARY)
+	}
+	if verbosity > schedulerobjects.ReportVerbosity_FULL {
+		return int32(schedulerobjects.ReportVerbosity_FULL)
+	}
+	return int32(verbosity)
+}
+
+// maxPrintedJobIds returns the maximum number of job ids to print per list of jobs at the given verbosity,
+// or -1 if there's no limit.
+func (repo *SchedulingContextRepository) maxPrintedJobIds(verbosity int32) int {
+	if verbosity <= int32(schedulerobjects.ReportVerbosity_QUEUES) {
+		return 1
+	}
+	if verbosity >= int32(schedulerobjects.ReportVerbosity_FULL) || repo.maxPrintedJobIdsPerVerbosityLevel == 0 {
+		return -1
+	}
+	return int(repo.maxPrintedJobIdsPerVerbosityLevel)
 }
 
 // AddSchedulingContext adds a scheduling context to the repo.
 // It also extracts the queue and job scheduling contexts it contains and stores those separately.
+// If an ingestion buffer is configured, the context is instead buffered and added later by Run;
+// see SetIngestionBufferSize.
 //
 // It's safe to call this method concurrently with itself and with methods getting contexts from the repo.
 // It's not safe to mutate contexts once they've been provided to this method.
@@ -104,9 +494,36 @@ func NewSchedulingContextRepository(maxJobSchedulingContextsPerExecutor uint) (*
 // Job contexts are stored first, then queue contexts, and finally the scheduling context itself.
 // This avoids having a stored scheduling (queue) context referring to a queue (job) context that isn't stored yet.
 func (repo *SchedulingContextRepository) AddSchedulingContext(sctx *schedulercontext.SchedulingContext) error {
+	if repo.ingestionQueue == nil {
+		return repo.addSchedulingContextNow(sctx)
+	}
+	select {
+	case repo.ingestionQueue <- sctx:
+	default:
+		repo.numDroppedSchedulingContexts.Add(1)
+	}
+	return nil
+}
+
+// addSchedulingContextNow adds a scheduling context to the repo before returning, regardless of whether
+// an ingestion buffer is configured.
+func (repo *SchedulingContextRepository) addSchedulingContextNow(sctx *schedulercontext.SchedulingContext) error {
 	queueSchedulingContextByQueue, jobSchedulingContextByJobId := extractQueueAndJobContexts(sctx)
 	repo.mu.Lock()
 	defer repo.mu.Unlock()
+	return repo.addSchedulingContextLocked(sctx, queueSchedulingContextByQueue, jobSchedulingContextByJobId)
+}
+
+// addSchedulingContextLocked stores sctx and the queue and job contexts extracted from it.
+// Should only be called with repo.mu held.
+func (repo *SchedulingContextRepository) addSchedulingContextLocked(
+	sctx *schedulercontext.SchedulingContext,
+	queueSchedulingContextByQueue map[string]*schedulercontext.QueueSchedulingContext,
+	jobSchedulingContextByJobId map[string]*schedulercontext.JobSchedulingContext,
+) error {
+	if err := repo.removeExpiredExecutors(sctx.ExecutorId); err != nil {
+		return err
+	}
 	for _, jctx := range jobSchedulingContextByJobId {
 		if err := repo.addJobSchedulingContext(jctx); err != nil {
 			return err
@@ -121,6 +538,7 @@ func (repo *SchedulingContextRepository) AddSchedulingContext(sctx *schedulercon
 	if err := repo.addExecutorId(sctx.ExecutorId); err != nil {
 		return err
 	}
+	repo.notifySubscribers(sctx)
 	return nil
 }
 
@@ -136,6 +554,68 @@ func (repo *SchedulingContextRepository) addExecutorId(executorId string) error
 	return nil
 }
 
+// removeExpiredExecutors removes all contexts associated with executors other than currentExecutorId
+// for which the most recent scheduling context was started more than executorTtl ago.
+//
+// Should only be called from AddSchedulingContext to avoid concurrent and/or dirty writes.
+func (repo *SchedulingContextRepository) removeExpiredExecutors(currentExecutorId string) error {
+	if repo.executorTtl == 0 {
+		return nil
+	}
+	cutoff := repo.clock.Now().Add(-repo.executorTtl)
+	expired := make(map[string]bool)
+	for executorId, sctx := range *repo.mostRecentSchedulingContextByExecutorP.Load() {
+		if executorId != currentExecutorId && sctx.Started.Before(cutoff) {
+			expired[executorId] = true
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	isNotExpired := func(executorId string) bool {
+		return !expired[executorId]
+	}
+
+	// Remove scheduling contexts first and job contexts last, i.e., in the opposite order to which they're added.
+	// Job contexts are removed by dropping the cache of the executor, which doesn't count as evictions.
+	mostRecentSchedulingContextByExecutor := armadamaps.FilterKeys(*repo.mostRecentSchedulingContextByExecutorP.Load(), isNotExpired)
+	mostRecentSuccessfulSchedulingContextByExecutor := armadamaps.FilterKeys(*repo.mostRecentSuccessfulSchedulingContextByExecutorP.Load(), isNotExpired)
+	mostRecentPreemptingSchedulingContextByExecutor := armadamaps.FilterKeys(*repo.mostRecentPreemptingSchedulingContextByExecutorP.Load(), isNotExpired)
+	mostRecentUnsuccessfulSchedulingContextByExecutor := armadamaps.FilterKeys(*repo.mostRecentUnsuccessfulSchedulingContextByExecutorP.Load(), isNotExpired)
+	schedulingContextHistoryByExecutor := armadamaps.FilterKeys(*repo.schedulingContextHistoryByExecutorP.Load(), isNotExpired)
+	repo.mostRecentSchedulingContextByExecutorP.Store(&mostRecentSchedulingContextByExecutor)
+	repo.mostRecentSuccessfulSchedulingContextByExecutorP.Store(&mostRecentSuccessfulSchedulingContextByExecutor)
+	repo.mostRecentPreemptingSchedulingContextByExecutorP.Store(&mostRecentPreemptingSchedulingContextByExecutor)
+	repo.mostRecentUnsuccessfulSchedulingContextByExecutorP.Store(&mostRecentUnsuccessfulSchedulingContextByExecutor)
+	repo.schedulingContextHistoryByExecutorP.Store(&schedulingContextHistoryByExecutor)
+
+	for _, p := range []*atomic.Pointer[map[string]QueueSchedulingContextByExecutor]{
+		&repo.mostRecentQueueSchedulingContextByExecutorByQueueP,
+		&repo.mostRecentSuccessfulQueueSchedulingContextByExecutorByQueueP,
+		&repo.mostRecentPreemptingQueueSchedulingContextByExecutorByQueueP,
+	} {
+		queueSchedulingContextByExecutorByQueue := make(map[string]QueueSchedulingContextByExecutor)
+		for queue, queueSchedulingContextByExecutor := range *p.Load() {
+			queueSchedulingContextByExecutor = armadamaps.FilterKeys(queueSchedulingContextByExecutor, isNotExpired)
+			if len(queueSchedulingContextByExecutor) > 0 {
+				queueSchedulingContextByExecutorByQueue[queue] = queueSchedulingContextByExecutor
+			}
+		}
+		p.Store(&queueSchedulingContextByExecutorByQueue)
+	}
+
+	jobSchedulingContextCacheByExecutor := armadamaps.FilterKeys(*repo.jobSchedulingContextCacheByExecutorP.Load(), isNotExpired)
+	repo.jobSchedulingContextCacheByExecutorP.Store(&jobSchedulingContextCacheByExecutor)
+
+	for executorId := range expired {
+		delete(repo.executorIds, executorId)
+	}
+	sortedExecutorIds := maps.Keys(repo.executorIds)
+	slices.Sort(sortedExecutorIds)
+	repo.sortedExecutorIdsP.Store(&sortedExecutorIds)
+	return nil
+}
+
 // Should only be called from AddSchedulingContext to avoid dirty writes.
 func (repo *SchedulingContextRepository) addSchedulingContext(sctx *schedulercontext.SchedulingContext) error {
 	mostRecentSchedulingContextByExecutor := *repo.mostRecentSchedulingContextByExecutorP.Load()
@@ -148,6 +628,12 @@ func (repo *SchedulingContextRepository) addSchedulingContext(sctx *schedulercon
 		mostRecentSuccessfulSchedulingContextByExecutor[sctx.ExecutorId] = sctx
 	}
 
+	mostRecentUnsuccessfulSchedulingContextByExecutor := *repo.mostRecentUnsuccessfulSchedulingContextByExecutorP.Load()
+	mostRecentUnsuccessfulSchedulingContextByExecutor = maps.Clone(mostRecentUnsuccessfulSchedulingContextByExecutor)
+	if sctx.ScheduledResourcesByPriority.IsZero() {
+		mostRecentUnsuccessfulSchedulingContextByExecutor[sctx.ExecutorId] = sctx
+	}
+
 	mostRecentPreemptingContextByExecutor := *repo.mostRecentPreemptingSchedulingContextByExecutorP.Load()
 	mostRecentPreemptingContextByExecutor = maps.Clone(mostRecentPreemptingContextByExecutor)
 	if !sctx.EvictedResourcesByPriority.IsZero() {
@@ -157,6 +643,21 @@ func (repo *SchedulingContextRepository) addSchedulingContext(sctx *schedulercon
 	repo.mostRecentSchedulingContextByExecutorP.Store(&mostRecentSchedulingContextByExecutor)
 	repo.mostRecentSuccessfulSchedulingContextByExecutorP.Store(&mostRecentSuccessfulSchedulingContextByExecutor)
 	repo.mostRecentPreemptingSchedulingContextByExecutorP.Store(&mostRecentPreemptingContextByExecutor)
+	repo.mostRecentUnsuccessfulSchedulingContextByExecutorP.Store(&mostRecentUnsuccessfulSchedulingContextByExecutor)
+	repo.countSchedulingContext(sctx)
+
+	if repo.historyLength > 0 {
+		schedulingContextHistoryByExecutor := maps.Clone(*repo.schedulingContextHistoryByExecutorP.Load())
+		previous := schedulingContextHistoryByExecutor[sctx.ExecutorId]
+		if uint(len(previous)) >= repo.historyLength {
+			previous = previous[uint(len(previous))-repo.historyLength+1:]
+		}
+		// Copy into a new slice, since readers may hold a reference to the previous one.
+		history := make([]*schedulercontext.SchedulingContext, len(previous), len(previous)+1)
+		copy(history, previous)
+		schedulingContextHistoryByExecutor[sctx.ExecutorId] = append(history, sctx)
+		repo.schedulingContextHistoryByExecutorP.Store(&schedulingContextHistoryByExecutor)
+	}
 
 	return nil
 }
@@ -169,6 +670,11 @@ func (repo *SchedulingContextRepository) addQueueSchedulingContexts(qctxs []*sch
 
 	mostRecentPreemptingQueueSchedulingContextByExecutorByQueue := maps.Clone(*repo.mostRecentPreemptingQueueSchedulingContextByExecutorByQueueP.Load())
 
+	var queueFairShareHistoryByQueue map[string][]QueueFairShareSample
+	if repo.queueFairShareHistoryLength > 0 {
+		queueFairShareHistoryByQueue = maps.Clone(*repo.queueFairShareHistoryByQueueP.Load())
+	}
+
 	for _, qctx := range qctxs {
 		if qctx.ExecutorId == "" {
 			return errors.WithStack(&armadaerrors.ErrInvalidArgument{
@@ -218,15 +724,55 @@ func (repo *SchedulingContextRepository) addQueueSchedulingContexts(qctxs []*sch
 				}
 			}
 		}
+
+		if queueFairShareHistoryByQueue != nil {
+			queueFairShareHistoryByQueue[qctx.Queue] = repo.appendQueueFairShareSample(
+				queueFairShareHistoryByQueue[qctx.Queue],
+				repo.queueFairShareSample(qctx),
+			)
+		}
 	}
 
 	repo.mostRecentQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentQueueSchedulingContextByExecutorByQueue)
 	repo.mostRecentSuccessfulQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentSuccessfulQueueSchedulingContextByExecutorByQueue)
 	repo.mostRecentPreemptingQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentPreemptingQueueSchedulingContextByExecutorByQueue)
+	if queueFairShareHistoryByQueue != nil {
+		repo.queueFairShareHistoryByQueueP.Store(&queueFairShareHistoryByQueue)
+	}
+	for _, qctx := range qctxs {
+		repo.countQueueSchedulingContext(qctx)
+	}
 
 	return nil
 }
 
+// queueFairShareSample returns a sample of the resources scheduled to the queue of qctx and its fair share.
+// The sample is timestamped with the time the scheduling attempt started, if known, and the current time otherwise.
+func (repo *SchedulingContextRepository) queueFairShareSample(qctx *schedulercontext.QueueSchedulingContext) QueueFairShareSample {
+	t := repo.clock.Now()
+	if sctx := qctx.SchedulingContext; sctx != nil && !sctx.Started.IsZero() {
+		t = sctx.Started
+	}
+	return QueueFairShareSample{
+		Time:               t,
+		ExecutorId:         qctx.ExecutorId,
+		ScheduledResources: qctx.ScheduledResourcesByPriority.AggregateByResource(),
+		FairShare:          qctx.FairShare(),
+	}
+}
+
+// appendQueueFairShareSample returns a new slice consisting of the most recent samples in history followed by sample,
+// such that the returned slice contains at most queueFairShareHistoryLength samples. history isn't mutated.
+func (repo *SchedulingContextRepository) appendQueueFairShareSample(history []QueueFairShareSample, sample QueueFairShareSample) []QueueFairShareSample {
+	n := int(repo.queueFairShareHistoryLength)
+	if len(history) >= n {
+		history = history[len(history)-n+1:]
+	}
+	rv := make([]QueueFairShareSample, len(history), len(history)+1)
+	copy(rv, history)
+	return append(rv, sample)
+}
+
 // Should only be called from AddSchedulingContext to avoid dirty writes.
 func (repo *SchedulingContextRepository) addJobSchedulingContext(jctx *schedulercontext.JobSchedulingContext) error {
 	if jctx.ExecutorId == "" {
@@ -243,18 +789,32 @@ func (repo *SchedulingContextRepository) addJobSchedulingContext(jctx *scheduler
 			Message: "received empty jobId",
 		})
 	}
-	previous, ok, _ := repo.mostRecentJobSchedulingContextByExecutorByJobId.PeekOrAdd(
-		jctx.JobId,
-		JobSchedulingContextByExecutor{jctx.ExecutorId: jctx},
-	)
-	if ok {
-		jobSchedulingContextByExecutor := previous.(JobSchedulingContextByExecutor)
-		jobSchedulingContextByExecutor[jctx.ExecutorId] = jctx
-		repo.mostRecentJobSchedulingContextByExecutorByJobId.Add(jctx.JobId, jobSchedulingContextByExecutor)
+	cache, err := repo.getOrCreateJobSchedulingContextCache(jctx.ExecutorId)
+	if err != nil {
+		return err
 	}
+	cache.Add(jctx.JobId, jctx)
 	return nil
 }
 
+// getOrCreateJobSchedulingContextCache returns the job context cache of the given executor,
+// creating it if it doesn't exist yet.
+//
+// Should only be called from AddSchedulingContext to avoid dirty writes.
+func (repo *SchedulingContextRepository) getOrCreateJobSchedulingContextCache(executorId string) (*lru.Cache, error) {
+	if cache, ok := (*repo.jobSchedulingContextCacheByExecutorP.Load())[executorId]; ok {
+		return cache, nil
+	}
+	cache, err := repo.newJobSchedulingContextCache(executorId)
+	if err != nil {
+		return nil, err
+	}
+	jobSchedulingContextCacheByExecutor := maps.Clone(*repo.jobSchedulingContextCacheByExecutorP.Load())
+	jobSchedulingContextCacheByExecutor[executorId] = cache
+	repo.jobSchedulingContextCacheByExecutorP.Store(&jobSchedulingContextCacheByExecutor)
+	return cache, nil
+}
+
 // extractQueueAndJobContexts extracts the job and queue scheduling contexts from the scheduling context,
 // and returns those separately.
 func extractQueueAndJobContexts(sctx *schedulercontext.SchedulingContext) (map[string]*schedulercontext.QueueSchedulingContext, map[string]*schedulercontext.JobSchedulingContext) {
@@ -283,10 +843,15 @@ func (repo *SchedulingContextRepository) getSchedulingReportForQueue(queueName s
 		mostRecentPreemptingSchedulingContextByExecutor: armadamaps.MapValues(mostRecentPreempting, schedulercontext.GetSchedulingContextFromQueueSchedulingContext),
 
 		sortedExecutorIds: repo.GetSortedExecutorIds(),
+		priorityClasses:   repo.priorityClasses(),
+		drainingExecutors: repo.drainingExecutors,
 	}
 }
 
-func (repo *SchedulingContextRepository) getSchedulingReportForJob(jobId string) schedulingReport {
+// getSchedulingReportForJob returns a report including, for each executor, the most recent attempts in which the job
+// was considered. If excludeEvicted is true, attempts in which the job was only evicted, e.g., as part of an unrelated
+// preemption round, are ignored, such that the report only reflects attempts to schedule the job.
+func (repo *SchedulingContextRepository) getSchedulingReportForJob(jobId string, excludeEvicted bool) schedulingReport {
 	mostRecent := make(map[string]*schedulercontext.QueueSchedulingContext)
 	for _, byExecutor := range *repo.mostRecentQueueSchedulingContextByExecutorByQueueP.Load() {
 		for executorId, qctx := range byExecutor {
@@ -296,7 +861,7 @@ func (repo *SchedulingContextRepository) getSchedulingReportForJob(jobId string)
 			_, successful := qctx.SuccessfulJobSchedulingContexts[jobId]
 			_, unsuccessful := qctx.UnsuccessfulJobSchedulingContexts[jobId]
 			_, preempted := qctx.EvictedJobsById[jobId]
-			if successful || unsuccessful || preempted {
+			if successful || unsuccessful || (preempted && !excludeEvicted) {
 				mostRecent[executorId] = qctx
 			}
 		}
@@ -315,13 +880,15 @@ func (repo *SchedulingContextRepository) getSchedulingReportForJob(jobId string)
 	}
 
 	mostRecentPreempting := make(map[string]*schedulercontext.QueueSchedulingContext)
-	for _, byExecutor := range *repo.mostRecentPreemptingQueueSchedulingContextByExecutorByQueueP.Load() {
-		for executorId, qctx := range byExecutor {
-			if existing, existed := mostRecentPreempting[executorId]; existed && qctx.Created.Before(existing.Created) {
-				continue
-			}
-			if _, preempted := qctx.EvictedJobsById[jobId]; preempted {
-				mostRecentPreempting[executorId] = qctx
+	if !excludeEvicted {
+		for _, byExecutor := range *repo.mostRecentPreemptingQueueSchedulingContextByExecutorByQueueP.Load() {
+			for executorId, qctx := range byExecutor {
+				if existing, existed := mostRecentPreempting[executorId]; existed && qctx.Created.Before(existing.Created) {
+					continue
+				}
+				if _, preempted := qctx.EvictedJobsById[jobId]; preempted {
+					mostRecentPreempting[executorId] = qctx
+				}
 			}
 		}
 	}
@@ -332,22 +899,62 @@ func (repo *SchedulingContextRepository) getSchedulingReportForJob(jobId string)
 		mostRecentPreemptingSchedulingContextByExecutor: armadamaps.MapValues(mostRecentPreempting, schedulercontext.GetSchedulingContextFromQueueSchedulingContext),
 
 		sortedExecutorIds: repo.GetSortedExecutorIds(),
+		priorityClasses:   repo.priorityClasses(),
+		drainingExecutors: repo.drainingExecutors,
+	}
+}
+
+// getSchedulingReportForPool returns a report including only executors for which the most recent context belongs to pool.
+// Executors not in the pool are omitted entirely.
+func (repo *SchedulingContextRepository) getSchedulingReportForPool(pool string) schedulingReport {
+	mostRecent := armadamaps.Filter(
+		repo.GetMostRecentSchedulingContextByExecutor(),
+		func(_ string, sctx *schedulercontext.SchedulingContext) bool {
+			return sctx.Pool == pool
+		},
+	)
+	isInPool := func(executorId string) bool {
+		_, ok := mostRecent[executorId]
+		return ok
+	}
+	sortedExecutorIds := make([]string, 0, len(mostRecent))
+	for _, executorId := range repo.GetSortedExecutorIds() {
+		if isInPool(executorId) {
+			sortedExecutorIds = append(sortedExecutorIds, executorId)
+		}
+	}
+	return schedulingReport{
+		mostRecentSchedulingContextByExecutor:             mostRecent,
+		mostRecentSuccessfulSchedulingContextByExecutor:   armadamaps.FilterKeys(repo.GetMostRecentSuccessfulSchedulingContextByExecutor(), isInPool),
+		mostRecentPreemptingSchedulingContextByExecutor:   armadamaps.FilterKeys(repo.GetMostRecentPreemptingSchedulingContextByExecutor(), isInPool),
+		mostRecentUnsuccessfulSchedulingContextByExecutor: armadamaps.FilterKeys(repo.GetMostRecentUnsuccessfulSchedulingContextByExecutor(), isInPool),
+
+		sortedExecutorIds: sortedExecutorIds,
+		priorityClasses:   repo.priorityClasses(),
+		drainingExecutors: repo.drainingExecutors,
 	}
 }
 
 func (repo *SchedulingContextRepository) getSchedulingReport() schedulingReport {
 	return schedulingReport{
-		mostRecentSchedulingContextByExecutor:           repo.GetMostRecentSchedulingContextByExecutor(),
-		mostRecentSuccessfulSchedulingContextByExecutor: repo.GetMostRecentSuccessfulSchedulingContextByExecutor(),
-		mostRecentPreemptingSchedulingContextByExecutor: repo.GetMostRecentPreemptingSchedulingContextByExecutor(),
+		mostRecentSchedulingContextByExecutor:             repo.GetMostRecentSchedulingContextByExecutor(),
+		mostRecentSuccessfulSchedulingContextByExecutor:   repo.GetMostRecentSuccessfulSchedulingContextByExecutor(),
+		mostRecentPreemptingSchedulingContextByExecutor:   repo.GetMostRecentPreemptingSchedulingContextByExecutor(),
+		mostRecentUnsuccessfulSchedulingContextByExecutor: repo.GetMostRecentUnsuccessfulSchedulingContextByExecutor(),
 
 		sortedExecutorIds: repo.GetSortedExecutorIds(),
+		priorityClasses:   repo.priorityClasses(),
+		drainingExecutors: repo.drainingExecutors,
 	}
 }
 
 // GetSchedulingReport is a gRPC endpoint for querying scheduler reports.
 // TODO: Further separate this from internal contexts.
-func (repo *SchedulingContextRepository) GetSchedulingReport(_ context.Context, request *schedulerobjects.SchedulingReportRequest) (*schedulerobjects.SchedulingReport, error) {
+func (repo *SchedulingContextRepository) GetSchedulingReport(ctx context.Context, request *schedulerobjects.SchedulingReportRequest) (*schedulerobjects.SchedulingReport, error) {
+	matchesExecutorPattern, err := executorIdMatcher(request.GetExecutorPattern(), request.GetExecutorPatternIsRegex())
+	if err != nil {
+		return nil, err
+	}
 	var sr schedulingReport
 
 	switch filter := request.GetFilter().(type) {
@@ -356,132 +963,1004 @@ func (repo *SchedulingContextRepository) GetSchedulingReport(_ context.Context,
 		sr = repo.getSchedulingReportForQueue(queueName)
 	case *schedulerobjects.SchedulingReportRequest_MostRecentForJob:
 		jobId := strings.TrimSpace(filter.MostRecentForJob.GetJobId())
-		sr = repo.getSchedulingReportForJob(jobId)
+		sr = repo.getSchedulingReportForJob(jobId, filter.MostRecentForJob.GetExcludeEvicted())
+	case *schedulerobjects.SchedulingReportRequest_MostRecentForPool:
+		pool := strings.TrimSpace(filter.MostRecentForPool.GetPoolName())
+		sr = repo.getSchedulingReportForPool(pool)
 	default:
 		sr = repo.getSchedulingReport()
 	}
+	if matchesExecutorPattern != nil {
+		// Filter before rendering, such that we don't format contexts that would be discarded.
+		sr.sortedExecutorIds = armadaslices.Filter(sr.sortedExecutorIds, matchesExecutorPattern)
+	}
+	if minResources := request.GetMinResources(); len(minResources.Resources) > 0 {
+		sr.minResources = minResources
+		numExecutors := len(sr.sortedExecutorIds)
+		sr.sortedExecutorIds = armadaslices.Filter(sr.sortedExecutorIds, sr.includeExecutor)
+		sr.numExecutorsBelowMinResources = numExecutors - len(sr.sortedExecutorIds)
+	}
+	if history := int(request.GetHistory()); history > 0 {
+		switch request.GetFilter().(type) {
+		case nil, *schedulerobjects.SchedulingReportRequest_MostRecentForPool:
+			sr.recentSchedulingContextsByExecutor = make(map[string][]*schedulercontext.SchedulingContext, len(sr.sortedExecutorIds))
+			for _, executorId := range sr.sortedExecutorIds {
+				sr.recentSchedulingContextsByExecutor[executorId] = repo.GetRecentSchedulingContextsByExecutor(executorId, history)
+			}
+		}
+	}
+
+	var report string
+	if request.GetFormat() == schedulerobjects.ReportFormat_JSON {
+		report, err = sr.ReportJson(ctx, clampReportVerbosity(request.GetVerbosity()))
+	} else {
+		sr.format = request.GetFormat()
+		report, err = sr.ReportString(ctx, clampReportVerbosity(request.GetVerbosity()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	report, compressedReport, encoding, err := encodeReport(report, request.GetCompress())
+	if err != nil {
+		return nil, err
+	}
+	return &schedulerobjects.SchedulingReport{Report: report, CompressedReport: compressedReport, Encoding: encoding}, nil
+}
+
+// encodeReport returns report unchanged if compress is false. Otherwise, it returns an empty report
+// and the gzip-compressed report instead, such that reports can be compressed without relying on
+// compression being enabled for the connection; see schedulerobjects.ReportEncoding.
+func encodeReport(report string, compress bool) (string, []byte, schedulerobjects.ReportEncoding, error) {
+	if !compress {
+		return report, nil, schedulerobjects.ReportEncoding_IDENTITY, nil
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(w, report); err != nil {
+		return "", nil, schedulerobjects.ReportEncoding_IDENTITY, errors.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		return "", nil, schedulerobjects.ReportEncoding_IDENTITY, errors.WithStack(err)
+	}
+	return "", buf.Bytes(), schedulerobjects.ReportEncoding_GZIP, nil
+}
 
-	return &schedulerobjects.SchedulingReport{Report: sr.ReportString(request.GetVerbosity())}, nil
+// executorIdMatcher returns a function indicating whether an executor id matches the given pattern,
+// which is interpreted as an RE2 regular expression if isRegex is true and as a glob otherwise.
+// Returns nil if the pattern is empty, i.e., if all executors should be included.
+func executorIdMatcher(pattern string, isRegex bool) (func(string) bool, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	if isRegex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, &armadaerrors.ErrInvalidArgument{
+				Name:    "executorPattern",
+				Value:   pattern,
+				Message: fmt.Sprintf("%s is not a valid regular expression: %s", pattern, err),
+			}
+		}
+		return re.MatchString, nil
+	}
+	// Match only returns an error if the pattern is malformed.
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, &armadaerrors.ErrInvalidArgument{
+			Name:    "executorPattern",
+			Value:   pattern,
+			Message: fmt.Sprintf("%s is not a valid glob: %s", pattern, err),
+		}
+	}
+	return func(executorId string) bool {
+		matches, _ := path.Match(pattern, executorId)
+		return matches
+	}, nil
 }
 
 type schedulingReport struct {
 	mostRecentSchedulingContextByExecutor           SchedulingContextByExecutor
 	mostRecentSuccessfulSchedulingContextByExecutor SchedulingContextByExecutor
 	mostRecentPreemptingSchedulingContextByExecutor SchedulingContextByExecutor
+	// Only populated for reports built from per-executor contexts, i.e., not for queue and job reports.
+	mostRecentUnsuccessfulSchedulingContextByExecutor SchedulingContextByExecutor
+	// Recent attempts for each executor, most recent first.
+	// Only populated if history was requested.
+	recentSchedulingContextsByExecutor map[string][]*schedulercontext.SchedulingContext
 
 	sortedExecutorIds []string
+	// Used to resolve priorities to priority class names; see SchedulingContextRepository.SetPriorityClasses.
+	priorityClasses map[string]configuration.PriorityClass
+	// Draining executors are marked as such in the report.
+	drainingExecutors *DrainingExecutors
+	// If non-empty, queues that scheduled no more than this amount of each resource are omitted from the report.
+	minResources schedulerobjects.ResourceList
+	// Number of executors omitted from sortedExecutorIds because of minResources.
+	numExecutorsBelowMinResources int
+	// Format of human-readable reports; see newReportWriter.
+	format schedulerobjects.ReportFormat
 }
 
-func (sr schedulingReport) ReportString(verbosity int32) string {
-	var sb strings.Builder
-	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
-	for _, executorId := range sr.sortedExecutorIds {
-		fmt.Fprintf(w, "%s:\n", executorId)
-		sctx := sr.mostRecentSchedulingContextByExecutor[executorId]
-		if sctx != nil {
-			fmt.Fprint(w, indent.String("\t", "Most recent attempt:\n"))
-			fmt.Fprint(w, indent.String("\t\t", sctx.ReportString(verbosity)))
-		} else {
-			fmt.Fprint(w, indent.String("\t", "Most recent attempt: none\n"))
-		}
-		sctx = sr.mostRecentSuccessfulSchedulingContextByExecutor[executorId]
-		if sctx != nil {
-			fmt.Fprint(w, indent.String("\t", "Most recent successful attempt:\n"))
-			fmt.Fprint(w, indent.String("\t\t", sctx.ReportString(verbosity)))
-		} else {
-			fmt.Fprint(w, indent.String("\t", "Most recent successful attempt: none\n"))
-		}
-		sctx = sr.mostRecentPreemptingSchedulingContextByExecutor[executorId]
-		if sctx != nil {
-			fmt.Fprint(w, indent.String("\t", "Most recent preempting attempt:\n"))
-			fmt.Fprint(w, indent.String("\t\t", sctx.ReportString(verbosity)))
-		} else {
-			fmt.Fprint(w, indent.String("\t", "Most recent preempting attempt: none\n"))
+// exceedsMinResources returns true if rl contains strictly more of at least one resource in sr.minResources.
+func (sr schedulingReport) exceedsMinResources(rl schedulerobjects.ResourceList) bool {
+	for t, q := range sr.minResources.Resources {
+		if q.Cmp(rl.Get(t)) == -1 {
+			return true
 		}
 	}
-	w.Flush()
-	return sb.String()
+	return false
 }
 
-// GetQueueReport is a gRPC endpoint for querying queue reports.
-// TODO: Further separate this from internal contexts.
-func (repo *SchedulingContextRepository) GetQueueReport(_ context.Context, request *schedulerobjects.QueueReportRequest) (*schedulerobjects.QueueReport, error) {
-	queueName := strings.TrimSpace(request.GetQueueName())
-	verbosity := request.GetVerbosity()
-	return &schedulerobjects.QueueReport{
-		Report: repo.getQueueReportString(queueName, verbosity),
-	}, nil
+// includeExecutor returns true if the most recent successful attempt of the given executor
+// scheduled more than the minimum resources of the report.
+func (sr schedulingReport) includeExecutor(executorId string) bool {
+	sctx := sr.mostRecentSuccessfulSchedulingContextByExecutor[executorId]
+	return sctx != nil && sr.exceedsMinResources(sctx.ScheduledResourcesByPriority.AggregateByResource())
+}
+
+// queueFilter returns a function indicating whether a queue should be included in the report,
+// or nil if all queues should be included.
+func (sr schedulingReport) queueFilter() func(*schedulercontext.QueueSchedulingContext) bool {
+	if len(sr.minResources.Resources) == 0 {
+		return nil
+	}
+	return func(qctx *schedulercontext.QueueSchedulingContext) bool {
+		return sr.exceedsMinResources(qctx.ScheduledResourcesByPriority.AggregateByResource())
+	}
+}
+
+// schedulingContextJson is like schedulingContextJsonFromSchedulingContext,
+// but omits queues excluded by the queue filter of the report.
+func (sr schedulingReport) schedulingContextJson(sctx *schedulercontext.SchedulingContext, verbosity int32) *schedulingContextJson {
+	rv := schedulingContextJsonFromSchedulingContext(sctx, verbosity)
+	includeQueue := sr.queueFilter()
+	if rv == nil || includeQueue == nil {
+		return rv
+	}
+	for queue, qctx := range sctx.QueueSchedulingContexts {
+		if !includeQueue(qctx) {
+			delete(rv.Queues, queue)
+			rv.NumQueuesBelowMinResources++
+		}
+	}
+	return rv
 }
 
-func (repo *SchedulingContextRepository) getQueueReportString(queue string, verbosity int32) string {
+// ReportString returns a human-readable representation of the report.
+// Returns ctx.Err() if ctx is cancelled before the report is complete.
+//
+// The whole report is written to a single tabwriter, which is flushed once. Hence, values are aligned
+// across all executors, and reports generated at different times can be compared line-by-line.
+func (sr schedulingReport) ReportString(ctx context.Context, verbosity int32) (string, error) {
 	var sb strings.Builder
-	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
-	sortedExecutorIds := repo.GetSortedExecutorIds()
-	mostRecentQueueSchedulingContextByExecutor, _ := repo.GetMostRecentQueueSchedulingContextByExecutor(queue)
-	mostRecentSuccessfulQueueSchedulingContextByExecutor, _ := repo.GetMostRecentSuccessfulQueueSchedulingContextByExecutor(queue)
-	mostRecentPreemptingQueueSchedulingContextByExecutor, _ := repo.GetMostRecentPreemptingQueueSchedulingContextByExecutor(queue)
-	for _, executorId := range sortedExecutorIds {
-		fmt.Fprintf(w, "%s:\n", executorId)
-		qctx := mostRecentQueueSchedulingContextByExecutor[executorId]
-		if qctx != nil {
-			fmt.Fprint(w, indent.String("\t", "Most recent attempt:\n"))
-			fmt.Fprint(w, indent.String("\t\t", qctx.ReportString(verbosity)))
+	w := newReportWriter(&sb, sr.format)
+	includeQueue := sr.queueFilter()
+	writeAttempt := func(name string, sctx *schedulercontext.SchedulingContext) {
+		if sctx != nil && sctx.RoundOutcome != "" {
+			fmt.Fprintf(w, "%s%s:\tnothing scheduled: %s\n", reportIndent, name, sctx.RoundOutcome)
+			sctx.WriteReportWithQueueFilter(w, reportIndent+reportIndent, verbosity, includeQueue)
+		} else if sctx != nil {
+			fmt.Fprintf(w, "%s%s:\t\n", reportIndent, name)
+			sctx.WriteReportWithQueueFilter(w, reportIndent+reportIndent, verbosity, includeQueue)
 		} else {
-			fmt.Fprint(w, indent.String("\t", "Most recent attempt: none\n"))
+			fmt.Fprintf(w, "%s%s:\tnone\n", reportIndent, name)
 		}
-		qctx = mostRecentSuccessfulQueueSchedulingContextByExecutor[executorId]
-		if qctx != nil {
-			fmt.Fprint(w, indent.String("\t", "Most recent successful attempt:\n"))
-			fmt.Fprint(w, indent.String("\t\t", qctx.ReportString(verbosity)))
-		} else {
-			fmt.Fprint(w, indent.String("\t", "Most recent successful attempt: none\n"))
+	}
+	for _, executorId := range sr.sortedExecutorIds {
+		if err := ctx.Err(); err != nil {
+			return "", err
 		}
-		qctx = mostRecentPreemptingQueueSchedulingContextByExecutor[executorId]
-		if qctx != nil {
-			fmt.Fprint(w, indent.String("\t", "Most recent preempting attempt:\n"))
-			fmt.Fprint(w, indent.String("\t\t", qctx.ReportString(verbosity)))
+		if sr.drainingExecutors.IsDraining(executorId) {
+			fmt.Fprintf(w, "%s (draining):\t\n", executorId)
 		} else {
-			fmt.Fprint(w, indent.String("\t", "Most recent preempting attempt: none\n"))
+			fmt.Fprintf(w, "%s:\t\n", executorId)
+		}
+		writeAttempt("Most recent attempt", sr.mostRecentSchedulingContextByExecutor[executorId])
+		writeAttempt("Most recent successful attempt", sr.mostRecentSuccessfulSchedulingContextByExecutor[executorId])
+		writeAttempt("Most recent preempting attempt", sr.mostRecentPreemptingSchedulingContextByExecutor[executorId])
+		if sr.mostRecentUnsuccessfulSchedulingContextByExecutor != nil {
+			writeAttempt("Most recent unsuccessful attempt", sr.mostRecentUnsuccessfulSchedulingContextByExecutor[executorId])
+		}
+		if sctx := sr.mostRecentSchedulingContextByExecutor[executorId]; sctx != nil && verbosity >= priorityClassSummaryMinVerbosity {
+			if summary := sr.priorityClassSummary(sctx); len(summary) > 0 {
+				fmt.Fprintf(w, "%sResources by priority class:\t\n", reportIndent)
+				for _, pcs := range summary {
+					fmt.Fprintf(
+						w, "%s%s:\tscheduled %s, preempted %s\n",
+						reportIndent+reportIndent, pcs.priorityClassName,
+						sortedResourceListString(pcs.scheduled), sortedResourceListString(pcs.evicted),
+					)
+				}
+			}
+		}
+		if recent := sr.recentSchedulingContextsByExecutor[executorId]; len(recent) > 0 {
+			fmt.Fprintf(w, "%s%d most recent attempts:\t\n", reportIndent, len(recent))
+			for _, sctx := range recent {
+				sctx.WriteReportWithQueueFilter(w, reportIndent+reportIndent, verbosity, includeQueue)
+			}
+		}
+	}
+	if sr.numExecutorsBelowMinResources > 0 {
+		fmt.Fprintf(w, "Executors below minimum resources:\t%d\n", sr.numExecutorsBelowMinResources)
+	}
+	if verbosity >= fairnessSummaryMinVerbosity {
+		if summary := sr.fairnessSummary(); len(summary) > 0 {
+			fmt.Fprint(w, "Fairness summary (most recent successful attempts):\t\n")
+			for _, qf := range summary {
+				fmt.Fprintf(w, "%s%s:\tactual share %f, target share %f\n", reportIndent, qf.Queue, qf.ActualShare, qf.TargetShare)
+			}
 		}
 	}
 	w.Flush()
-	return sb.String()
+	return util.TrimTrailingWhitespace(sb.String()), nil
 }
 
-// GetJobReport is a gRPC endpoint for querying job reports.
+// Added to the indentation of each nested level of human-readable reports.
+const reportIndent = "  "
+
+// reportWriter is the writer human-readable reports are written to; see newReportWriter.
+type reportWriter interface {
+	io.Writer
+	Flush() error
+}
+
+// newReportWriter returns a writer for human-readable reports written to out in the given format.
+// Reports separate labels from values, and columns from each other, with tabs. For ReportFormat_TEXT_UNALIGNED,
+// each tab is written as a single space; otherwise, values are aligned into columns by a tabwriter.
+// Either way, the writer must be flushed once the report is complete.
+func newReportWriter(out io.Writer, format schedulerobjects.ReportFormat) reportWriter {
+	if format == schedulerobjects.ReportFormat_TEXT_UNALIGNED {
+		return unalignedReportWriter{out: out}
+	}
+	return tabwriter.NewWriter(out, 1, 1, 1, ' ', 0)
+}
+
+// unalignedReportWriter writes to out, replacing each tab with a single space.
+type unalignedReportWriter struct {
+	out io.Writer
+}
+
+func (w unalignedReportWriter) Write(p []byte) (int, error) {
+	if _, err := w.out.Write(bytes.ReplaceAll(p, []byte{'\t'}, []byte{' '})); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w unalignedReportWriter) Flush() error {
+	return nil
+}
+
+// writeIndentedJobSchedulingContext writes a report of jctx to w, indented by one level.
+// Unless format is ReportFormat_TEXT_UNALIGNED, the report of jctx is aligned independently of the rest of w.
+func writeIndentedJobSchedulingContext(w io.Writer, jctx *schedulercontext.JobSchedulingContext, format schedulerobjects.ReportFormat) {
+	if format == schedulerobjects.ReportFormat_TEXT_UNALIGNED {
+		jctx.WriteReport(w, "\t")
+		return
+	}
+	fmt.Fprint(w, indent.String("\t", jctx.String()))
+}
+
+// writeUnschedulableReasonCounts writes a single line summarising why the jobs of qctx could not be scheduled,
+// e.g., "Unschedulable reasons: 40 jobs: insufficient cpu, 3 jobs: node selector unmatched".
+// Nothing is written if all jobs were scheduled.
+func writeUnschedulableReasonCounts(w io.Writer, indent string, qctx *schedulercontext.QueueSchedulingContext) {
+	reasonCounts := qctx.UnschedulableReasonCounts()
+	if len(reasonCounts) == 0 {
+		return
+	}
+	parts := make([]string, len(reasonCounts))
+	for i, reasonCount := range reasonCounts {
+		parts[i] = fmt.Sprintf("%d jobs: %s", reasonCount.NumJobs, reasonCount.Reason)
+	}
+	fmt.Fprintf(w, "%sUnschedulable reasons:\t%s\n", indent, strings.Join(parts, ", "))
+}
+
+// Fairness summaries are only included in scheduling reports at this verbosity or higher.
+const fairnessSummaryMinVerbosity = int32(schedulerobjects.ReportVerbosity_JOBS)
+
+// Per-executor summaries of resources by priority class are only included in scheduling reports
+// at this verbosity or higher.
+const priorityClassSummaryMinVerbosity = int32(schedulerobjects.ReportVerbosity_QUEUES)
+
+// priorityClassResources is the resources scheduled and evicted at a priority class in a scheduling attempt.
+type priorityClassResources struct {
+	priorityClassName string
+	priority          int32
+	scheduled         schedulerobjects.ResourceList
+	evicted           schedulerobjects.ResourceList
+}
+
+// priorityClassSummary rolls up the resources scheduled and evicted in sctx by priority class,
+// resolving each priority to the name of the priority class with that priority.
+// Priority classes are taken from the report if set, and from sctx otherwise; see priorityClassNameFromPriority.
+// Entries are sorted by decreasing priority.
+func (sr schedulingReport) priorityClassSummary(sctx *schedulercontext.SchedulingContext) []priorityClassResources {
+	priorityClasses := sr.priorityClasses
+	if len(priorityClasses) == 0 {
+		priorityClasses = sctx.PriorityClasses
+	}
+	byPriority := make(map[int32]*priorityClassResources)
+	get := func(priority int32) *priorityClassResources {
+		pcs, ok := byPriority[priority]
+		if !ok {
+			pcs = &priorityClassResources{
+				priorityClassName: priorityClassNameFromPriority(priorityClasses, priority),
+				priority:          priority,
+			}
+			byPriority[priority] = pcs
+		}
+		return pcs
+	}
+	for priority, rl := range sctx.ScheduledResourcesByPriority {
+		get(priority).scheduled.Add(rl)
+	}
+	for priority, rl := range sctx.EvictedResourcesByPriority {
+		get(priority).evicted.Add(rl)
+	}
+	priorities := maps.Keys(byPriority)
+	slices.SortFunc(priorities, func(a, b int32) bool { return a > b })
+	rv := make([]priorityClassResources, len(priorities))
+	for i, priority := range priorities {
+		rv[i] = *byPriority[priority]
+	}
+	return rv
+}
+
+// sortedResourceListString is like ResourceList.CompactString, but lists resources in sorted order,
+// such that the output is deterministic.
+func sortedResourceListString(rl schedulerobjects.ResourceList) string {
+	resourceTypes := maps.Keys(rl.Resources)
+	slices.Sort(resourceTypes)
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, t := range resourceTypes {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		q := rl.Resources[t]
+		fmt.Fprintf(&sb, "%s: %s", t, q.String())
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// fairnessSummary compares, for each queue, the share of resources scheduled to that queue
+// with its priority factor-derived fair share, aggregated across the executors in the report.
+//
+// Only the most recent successful attempt of each executor is considered. Resources are aggregated using
+// the resource scarcity of each attempt. The target share of a queue is the average of its fair share
+// across attempts, weighted by the total amount of resources scheduled in each attempt.
+// Queues are sorted by name. Returns nil if no resources were scheduled.
+func (sr schedulingReport) fairnessSummary() []queueFairnessJson {
+	scheduledByQueue := make(map[string]float64)
+	targetByQueue := make(map[string]float64)
+	totalScheduled := 0.0
+	for _, executorId := range sr.sortedExecutorIds {
+		sctx := sr.mostRecentSuccessfulSchedulingContextByExecutor[executorId]
+		if sctx == nil {
+			continue
+		}
+		scheduledByQueueForExecutor := make(map[string]float64, len(sctx.QueueSchedulingContexts))
+		totalScheduledForExecutor := 0.0
+		for queue, qctx := range sctx.QueueSchedulingContexts {
+			scheduled := float64(ResourceListAsWeightedMillis(sctx.ResourceScarcity, qctx.ScheduledResourcesByPriority.AggregateByResource()))
+			scheduledByQueueForExecutor[queue] = scheduled
+			totalScheduledForExecutor += scheduled
+		}
+		if totalScheduledForExecutor <= 0 {
+			continue
+		}
+		for queue, qctx := range sctx.QueueSchedulingContexts {
+			scheduledByQueue[queue] += scheduledByQueueForExecutor[queue]
+			targetByQueue[queue] += qctx.FairShare() * totalScheduledForExecutor
+		}
+		totalScheduled += totalScheduledForExecutor
+	}
+	if totalScheduled <= 0 {
+		return nil
+	}
+	queues := maps.Keys(targetByQueue)
+	slices.Sort(queues)
+	rv := make([]queueFairnessJson, len(queues))
+	for i, queue := range queues {
+		rv[i] = queueFairnessJson{
+			Queue:       queue,
+			ActualShare: scheduledByQueue[queue] / totalScheduled,
+			TargetShare: targetByQueue[queue] / totalScheduled,
+		}
+	}
+	return rv
+}
+
+// ReportJson returns a JSON representation of the report.
+// Executors are listed in sorted order; for each, the most recent, most recent successful,
+// most recent preempting, and most recent unsuccessful attempts are included if they exist.
+// Returns ctx.Err() if ctx is cancelled before the report is complete.
+func (sr schedulingReport) ReportJson(ctx context.Context, verbosity int32) (string, error) {
+	executors := make([]executorSchedulingReportJson, len(sr.sortedExecutorIds))
+	for i, executorId := range sr.sortedExecutorIds {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		executors[i] = executorSchedulingReportJson{
+			ExecutorId:             executorId,
+			Draining:               sr.drainingExecutors.IsDraining(executorId),
+			MostRecent:             sr.schedulingContextJson(sr.mostRecentSchedulingContextByExecutor[executorId], verbosity),
+			MostRecentSuccessful:   sr.schedulingContextJson(sr.mostRecentSuccessfulSchedulingContextByExecutor[executorId], verbosity),
+			MostRecentPreempting:   sr.schedulingContextJson(sr.mostRecentPreemptingSchedulingContextByExecutor[executorId], verbosity),
+			MostRecentUnsuccessful: sr.schedulingContextJson(sr.mostRecentUnsuccessfulSchedulingContextByExecutor[executorId], verbosity),
+		}
+		for _, sctx := range sr.recentSchedulingContextsByExecutor[executorId] {
+			executors[i].Recent = append(executors[i].Recent, sr.schedulingContextJson(sctx, verbosity))
+		}
+	}
+	rv := schedulingReportJson{
+		Executors:                     executors,
+		NumExecutorsBelowMinResources: sr.numExecutorsBelowMinResources,
+	}
+	if verbosity >= fairnessSummaryMinVerbosity {
+		rv.FairnessSummary = sr.fairnessSummary()
+	}
+	return marshalReportJson(rv)
+}
+
+// GetQueueReport is a gRPC endpoint for querying queue reports.
 // TODO: Further separate this from internal contexts.
-func (repo *SchedulingContextRepository) GetJobReport(_ context.Context, request *schedulerobjects.JobReportRequest) (*schedulerobjects.JobReport, error) {
+func (repo *SchedulingContextRepository) GetQueueReport(ctx context.Context, request *schedulerobjects.QueueReportRequest) (*schedulerobjects.QueueReport, error) {
+	queueName := strings.TrimSpace(request.GetQueueName())
+	verbosity := clampReportVerbosity(request.GetVerbosity())
+	executors, err := repo.getExecutorQueueReports(ctx, queueName)
+	if err != nil {
+		return nil, err
+	}
+	var report string
+	if request.GetFormat() == schedulerobjects.ReportFormat_JSON {
+		report, err = repo.getQueueReportJson(ctx, queueName, verbosity)
+	} else {
+		report, err = repo.getQueueReportString(ctx, queueName, verbosity, request.GetFormat())
+	}
+	if err != nil {
+		return nil, err
+	}
+	report, compressedReport, encoding, err := encodeReport(report, request.GetCompress())
+	if err != nil {
+		return nil, err
+	}
+	return &schedulerobjects.QueueReport{
+		Report:           report,
+		Executors:        executors,
+		CompressedReport: compressedReport,
+		Encoding:         encoding,
+		Paused:           repo.pausedQueues.IsPaused(queueName),
+	}, nil
+}
+
+// getExecutorQueueReports returns a numeric summary of the most recent attempts for the given queue for each executor.
+func (repo *SchedulingContextRepository) getExecutorQueueReports(ctx context.Context, queue string) ([]*schedulerobjects.ExecutorQueueReport, error) {
+	sortedExecutorIds := repo.GetSortedExecutorIds()
+	mostRecentQueueSchedulingContextByExecutor, _ := repo.GetMostRecentQueueSchedulingContextByExecutor(queue)
+	mostRecentSuccessfulQueueSchedulingContextByExecutor, _ := repo.GetMostRecentSuccessfulQueueSchedulingContextByExecutor(queue)
+	mostRecentPreemptingQueueSchedulingContextByExecutor, _ := repo.GetMostRecentPreemptingQueueSchedulingContextByExecutor(queue)
+	rv := make([]*schedulerobjects.ExecutorQueueReport, len(sortedExecutorIds))
+	for i, executorId := range sortedExecutorIds {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rv[i] = &schedulerobjects.ExecutorQueueReport{
+			ExecutorId:           executorId,
+			MostRecent:           queueSchedulingSummaryFromQueueSchedulingContext(mostRecentQueueSchedulingContextByExecutor[executorId]),
+			MostRecentSuccessful: queueSchedulingSummaryFromQueueSchedulingContext(mostRecentSuccessfulQueueSchedulingContextByExecutor[executorId]),
+			MostRecentPreempting: queueSchedulingSummaryFromQueueSchedulingContext(mostRecentPreemptingQueueSchedulingContextByExecutor[executorId]),
+		}
+	}
+	return rv, nil
+}
+
+func queueSchedulingSummaryFromQueueSchedulingContext(qctx *schedulercontext.QueueSchedulingContext) *schedulerobjects.QueueSchedulingSummary {
+	if qctx == nil {
+		return nil
+	}
+	return &schedulerobjects.QueueSchedulingSummary{
+		Created:                              qctx.Created,
+		ScheduledResourcesByPriority:         qctx.ScheduledResourcesByPriority.DeepCopy(),
+		EvictedResourcesByPriority:           qctx.EvictedResourcesByPriority.DeepCopy(),
+		NumSuccessfulJobSchedulingContexts:   int32(len(qctx.SuccessfulJobSchedulingContexts)),
+		NumUnsuccessfulJobSchedulingContexts: int32(len(qctx.UnsuccessfulJobSchedulingContexts)),
+		FairShare:                            qctx.FairShare(),
+	}
+}
+
+func (repo *SchedulingContextRepository) getQueueReportString(ctx context.Context, queue string, verbosity int32, format schedulerobjects.ReportFormat) (string, error) {
+	var sb strings.Builder
+	w := newReportWriter(&sb, format)
+	sortedExecutorIds := repo.GetSortedExecutorIds()
+	mostRecentQueueSchedulingContextByExecutor, _ := repo.GetMostRecentQueueSchedulingContextByExecutor(queue)
+	mostRecentSuccessfulQueueSchedulingContextByExecutor, _ := repo.GetMostRecentSuccessfulQueueSchedulingContextByExecutor(queue)
+	mostRecentPreemptingQueueSchedulingContextByExecutor, _ := repo.GetMostRecentPreemptingQueueSchedulingContextByExecutor(queue)
+	maxPrintedJobIds := repo.maxPrintedJobIds(verbosity)
+	if repo.pausedQueues.IsPaused(queue) {
+		fmt.Fprintf(w, "Scheduling is paused for queue %s.\n", queue)
+	}
+	for _, executorId := range sortedExecutorIds {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		// Write all executors through w, with nesting expressed by indenting the first cell of each row,
+		// such that columns are aligned across the whole report.
+		fmt.Fprintf(w, "%s:\t\n", executorId)
+		for _, attempt := range []struct {
+			name string
+			qctx *schedulercontext.QueueSchedulingContext
+		}{
+			{"Most recent attempt", mostRecentQueueSchedulingContextByExecutor[executorId]},
+			{"Most recent successful attempt", mostRecentSuccessfulQueueSchedulingContextByExecutor[executorId]},
+			{"Most recent preempting attempt", mostRecentPreemptingQueueSchedulingContextByExecutor[executorId]},
+		} {
+			if attempt.qctx == nil {
+				fmt.Fprintf(w, "%s%s:\tnone\n", reportIndent, attempt.name)
+				continue
+			}
+			fmt.Fprintf(w, "%s%s:\t\n", reportIndent, attempt.name)
+			writeUnschedulableReasonCounts(w, reportIndent+reportIndent, attempt.qctx)
+			attempt.qctx.WriteReportWithMaxPrintedJobIds(w, reportIndent+reportIndent, verbosity, maxPrintedJobIds)
+		}
+	}
+	w.Flush()
+	return util.TrimTrailingWhitespace(sb.String()), nil
+}
+
+func (repo *SchedulingContextRepository) getQueueReportJson(ctx context.Context, queue string, verbosity int32) (string, error) {
+	sortedExecutorIds := repo.GetSortedExecutorIds()
+	mostRecentQueueSchedulingContextByExecutor, _ := repo.GetMostRecentQueueSchedulingContextByExecutor(queue)
+	mostRecentSuccessfulQueueSchedulingContextByExecutor, _ := repo.GetMostRecentSuccessfulQueueSchedulingContextByExecutor(queue)
+	mostRecentPreemptingQueueSchedulingContextByExecutor, _ := repo.GetMostRecentPreemptingQueueSchedulingContextByExecutor(queue)
+	executors := make([]executorQueueReportJson, len(sortedExecutorIds))
+	for i, executorId := range sortedExecutorIds {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		executors[i] = executorQueueReportJson{
+			ExecutorId:           executorId,
+			MostRecent:           queueSchedulingContextJsonFromQueueSchedulingContext(mostRecentQueueSchedulingContextByExecutor[executorId], verbosity),
+			MostRecentSuccessful: queueSchedulingContextJsonFromQueueSchedulingContext(mostRecentSuccessfulQueueSchedulingContextByExecutor[executorId], verbosity),
+			MostRecentPreempting: queueSchedulingContextJsonFromQueueSchedulingContext(mostRecentPreemptingQueueSchedulingContextByExecutor[executorId], verbosity),
+		}
+	}
+	return marshalReportJson(queueReportJson{Queue: queue, Paused: repo.pausedQueues.IsPaused(queue), Executors: executors})
+}
+
+// GetJobReport is a gRPC endpoint for querying job reports.
+// TODO: Further separate this from internal contexts.
+func (repo *SchedulingContextRepository) GetJobReport(ctx context.Context, request *schedulerobjects.JobReportRequest) (*schedulerobjects.JobReport, error) {
 	jobId := strings.TrimSpace(request.GetJobId())
-	if _, err := ulid.Parse(jobId); err != nil {
+	if err := repo.validateJobId(jobId); err != nil {
 		return nil, &armadaerrors.ErrInvalidArgument{
 			Name:    "jobId",
 			Value:   request.GetJobId(),
-			Message: fmt.Sprintf("%s is not a valid jobId", request.GetJobId()),
+			Message: fmt.Sprintf("%s is not a valid jobId: %s", request.GetJobId(), err),
 		}
 	}
-	return &schedulerobjects.JobReport{
-		Report: repo.getJobReportString(jobId),
-	}, nil
+	var report string
+	var err error
+	if request.GetSince() != nil || request.GetUntil() != nil {
+		var since, until time.Time
+		if request.GetSince() != nil {
+			since = *request.GetSince()
+		}
+		if request.GetUntil() != nil {
+			until = *request.GetUntil()
+		}
+		if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+			return nil, &armadaerrors.ErrInvalidArgument{
+				Name:    "until",
+				Value:   until,
+				Message: fmt.Sprintf("until must be after since %s", since),
+			}
+		}
+		jobSchedulingContextsByExecutor := repo.GetJobSchedulingContextsByExecutorInWindow(jobId, since, until)
+		if request.GetFormat() == schedulerobjects.ReportFormat_JSON {
+			report, err = repo.getJobReportJsonInWindow(ctx, jobId, jobSchedulingContextsByExecutor)
+		} else {
+			report, err = repo.getJobReportStringInWindow(ctx, jobSchedulingContextsByExecutor, request.GetFormat())
+		}
+	} else if request.GetFormat() == schedulerobjects.ReportFormat_JSON {
+		report, err = repo.getJobReportJson(ctx, jobId)
+	} else {
+		report, err = repo.getJobReportString(ctx, jobId, request.GetFormat())
+	}
+	if err != nil {
+		return nil, err
+	}
+	report, compressedReport, encoding, err := encodeReport(report, request.GetCompress())
+	if err != nil {
+		return nil, err
+	}
+	return &schedulerobjects.JobReport{Report: report, CompressedReport: compressedReport, Encoding: encoding}, nil
 }
 
-func (repo *SchedulingContextRepository) getJobReportString(jobId string) string {
+func (repo *SchedulingContextRepository) getJobReportString(ctx context.Context, jobId string, format schedulerobjects.ReportFormat) (string, error) {
 	sortedExecutorIds := repo.GetSortedExecutorIds()
 	jobSchedulingContextByExecutor, _ := repo.GetMostRecentJobSchedulingContextByExecutor(jobId)
 	var sb strings.Builder
-	w := tabwriter.NewWriter(&sb, 1, 1, 1, ' ', 0)
+	w := newReportWriter(&sb, format)
 	for _, executorId := range sortedExecutorIds {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
 		jctx := jobSchedulingContextByExecutor[executorId]
 		if jctx != nil {
 			fmt.Fprintf(w, "%s:\n", executorId)
-			fmt.Fprint(w, indent.String("\t", jctx.String()))
+			writeIndentedJobSchedulingContext(w, jctx, format)
 		} else {
 			fmt.Fprintf(w, "%s: no recent attempt\n", executorId)
 		}
 	}
 	w.Flush()
+	return sb.String(), nil
+}
+
+func (repo *SchedulingContextRepository) getJobReportStringInWindow(
+	ctx context.Context,
+	jobSchedulingContextsByExecutor map[string][]*schedulercontext.JobSchedulingContext,
+	format schedulerobjects.ReportFormat,
+) (string, error) {
+	var sb strings.Builder
+	w := newReportWriter(&sb, format)
+	for _, executorId := range repo.GetSortedExecutorIds() {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		jctxs := jobSchedulingContextsByExecutor[executorId]
+		if len(jctxs) == 0 {
+			fmt.Fprintf(w, "%s: no attempts in window\n", executorId)
+			continue
+		}
+		fmt.Fprintf(w, "%s:\n", executorId)
+		for _, jctx := range jctxs {
+			writeIndentedJobSchedulingContext(w, jctx, format)
+		}
+	}
+	w.Flush()
+	return sb.String(), nil
+}
+
+func (repo *SchedulingContextRepository) getJobReportJsonInWindow(ctx context.Context, jobId string, jobSchedulingContextsByExecutor map[string][]*schedulercontext.JobSchedulingContext) (string, error) {
+	sortedExecutorIds := repo.GetSortedExecutorIds()
+	executors := make([]executorJobReportJson, len(sortedExecutorIds))
+	for i, executorId := range sortedExecutorIds {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		executors[i] = executorJobReportJson{ExecutorId: executorId}
+		for _, jctx := range jobSchedulingContextsByExecutor[executorId] {
+			executors[i].Attempts = append(executors[i].Attempts, jobSchedulingContextJsonFromJobSchedulingContext(jctx))
+		}
+	}
+	return marshalReportJson(jobReportJson{JobId: jobId, Executors: executors})
+}
+
+func (repo *SchedulingContextRepository) getJobReportJson(ctx context.Context, jobId string) (string, error) {
+	sortedExecutorIds := repo.GetSortedExecutorIds()
+	jobSchedulingContextByExecutor, _ := repo.GetMostRecentJobSchedulingContextByExecutor(jobId)
+	executors := make([]executorJobReportJson, len(sortedExecutorIds))
+	for i, executorId := range sortedExecutorIds {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		executors[i] = executorJobReportJson{
+			ExecutorId: executorId,
+			MostRecent: jobSchedulingContextJsonFromJobSchedulingContext(jobSchedulingContextByExecutor[executorId]),
+		}
+	}
+	return marshalReportJson(jobReportJson{JobId: jobId, Executors: executors})
+}
+
+// GetJobSchedulingSummaries returns a structured summary of the most recent attempt to schedule the given job
+// for each executor, e.g., for tooling that would otherwise need to parse job reports.
+func (repo *SchedulingContextRepository) GetJobSchedulingSummaries(_ context.Context, request *schedulerobjects.JobSchedulingSummariesRequest) (*schedulerobjects.JobSchedulingSummaries, error) {
+	jobId := strings.TrimSpace(request.GetJobId())
+	if err := repo.validateJobId(jobId); err != nil {
+		return nil, &armadaerrors.ErrInvalidArgument{
+			Name:    "jobId",
+			Value:   request.GetJobId(),
+			Message: fmt.Sprintf("%s is not a valid jobId: %s", request.GetJobId(), err),
+		}
+	}
+	jobSchedulingContextByExecutor, _ := repo.GetMostRecentJobSchedulingContextByExecutor(jobId)
+	schedulingContextByExecutor := repo.GetMostRecentSchedulingContextByExecutor()
+	executorIds := maps.Keys(jobSchedulingContextByExecutor)
+	slices.Sort(executorIds)
+	rv := &schedulerobjects.JobSchedulingSummaries{
+		JobSchedulingSummaries: make([]*schedulerobjects.JobSchedulingSummary, len(executorIds)),
+	}
+	for i, executorId := range executorIds {
+		rv.JobSchedulingSummaries[i] = repo.jobSchedulingSummaryFromJobSchedulingContext(
+			jobSchedulingContextByExecutor[executorId],
+			schedulingContextByExecutor[executorId],
+		)
+	}
+	return rv, nil
+}
+
+// jobSchedulingSummaryFromJobSchedulingContext converts jctx into its proto representation.
+// sctx is the most recent scheduling context of the same executor, if any, and is used to determine the pool
+// and to resolve the priority class of jobs for which the job spec has been cleared.
+func (repo *SchedulingContextRepository) jobSchedulingSummaryFromJobSchedulingContext(
+	jctx *schedulercontext.JobSchedulingContext,
+	sctx *schedulercontext.SchedulingContext,
+) *schedulerobjects.JobSchedulingSummary {
+	rv := &schedulerobjects.JobSchedulingSummary{
+		ExecutorId:              jctx.ExecutorId,
+		JobId:                   jctx.JobId,
+		GangId:                  jctx.GangId,
+		Created:                 jctx.Created,
+		Scheduled:               jctx.IsSuccessful(),
+		UnschedulableReason:     jctx.UnschedulableReason,
+		UnschedulableReasonCode: jctx.UnschedulableReasonCode,
+	}
+	if sctx != nil {
+		rv.Pool = sctx.Pool
+	}
+	if jctx.Job != nil {
+		rv.PriorityClassName = jctx.Job.GetPriorityClassName()
+	} else if jctx.Req != nil {
+		priorityClasses := repo.priorityClasses()
+		if len(priorityClasses) == 0 && sctx != nil {
+			priorityClasses = sctx.PriorityClasses
+		}
+		rv.PriorityClassName = priorityClassNameFromPriority(priorityClasses, jctx.Req.Priority)
+	}
+	if rv.Scheduled {
+		rv.NodeId = jctx.NodeId
+	}
+	return rv
+}
+
+// GetExecutorSchedulingContext is a gRPC endpoint for querying the most recent scheduling context of an executor.
+func (repo *SchedulingContextRepository) GetExecutorSchedulingContext(_ context.Context, request *schedulerobjects.ExecutorSchedulingContextRequest) (*schedulerobjects.ExecutorSchedulingContext, error) {
+	executorId := strings.TrimSpace(request.GetExecutorId())
+	sctx, ok := repo.GetSchedulingContextForExecutor(executorId)
+	if !ok {
+		return nil, &armadaerrors.ErrNotFound{
+			Type:    "executor",
+			Value:   executorId,
+			Message: "no scheduling context stored for this executor",
+		}
+	}
+	return executorSchedulingContextFromSchedulingContext(sctx), nil
+}
+
+// executorSchedulingContextFromSchedulingContext converts sctx into its proto representation.
+func executorSchedulingContextFromSchedulingContext(sctx *schedulercontext.SchedulingContext) *schedulerobjects.ExecutorSchedulingContext {
+	queueSchedulingSummaries := make(map[string]*schedulerobjects.QueueSchedulingSummary, len(sctx.QueueSchedulingContexts))
+	for queue, qctx := range sctx.QueueSchedulingContexts {
+		queueSchedulingSummaries[queue] = queueSchedulingSummaryFromQueueSchedulingContext(qctx)
+	}
+	return &schedulerobjects.ExecutorSchedulingContext{
+		ExecutorId:                   sctx.ExecutorId,
+		Pool:                         sctx.Pool,
+		Started:                      sctx.Started,
+		Finished:                     sctx.Finished,
+		TotalResources:               sctx.TotalResources.DeepCopy(),
+		ScheduledResourcesByPriority: sctx.ScheduledResourcesByPriority.DeepCopy(),
+		EvictedResourcesByPriority:   sctx.EvictedResourcesByPriority.DeepCopy(),
+		NumScheduledJobs:             int32(sctx.NumScheduledJobs),
+		NumScheduledGangs:            int32(sctx.NumScheduledGangs),
+		NumEvictedJobs:               int32(sctx.NumEvictedJobs),
+		TerminationReason:            sctx.TerminationReason,
+		QueueSchedulingSummaries:     queueSchedulingSummaries,
+	}
+}
+
+// CompareExecutors is a gRPC endpoint for comparing the most recent scheduling contexts of two executors.
+// The report includes the resources scheduled and evicted by each executor and the fair share of each queue,
+// along with the difference between the two, computed as the value for executor b minus the value for executor a.
+func (repo *SchedulingContextRepository) CompareExecutors(_ context.Context, request *schedulerobjects.CompareExecutorsRequest) (*schedulerobjects.CompareExecutorsReport, error) {
+	// Load the map once, such that both contexts are taken from the same snapshot.
+	mostRecentSchedulingContextByExecutor := repo.GetMostRecentSchedulingContextByExecutor()
+	var sctxs [2]*schedulercontext.SchedulingContext
+	for i, executorId := range []string{
+		strings.TrimSpace(request.GetExecutorIdA()),
+		strings.TrimSpace(request.GetExecutorIdB()),
+	} {
+		sctx, ok := mostRecentSchedulingContextByExecutor[executorId]
+		if !ok {
+			return nil, &armadaerrors.ErrNotFound{
+				Type:    "executor",
+				Value:   executorId,
+				Message: "no scheduling context stored for this executor",
+			}
+		}
+		sctxs[i] = sctx
+	}
+	comparison := executorComparison{a: sctxs[0], b: sctxs[1], format: request.GetFormat()}
+	if request.GetFormat() == schedulerobjects.ReportFormat_JSON {
+		report, err := comparison.ReportJson()
+		if err != nil {
+			return nil, err
+		}
+		return &schedulerobjects.CompareExecutorsReport{Report: report}, nil
+	}
+	return &schedulerobjects.CompareExecutorsReport{Report: comparison.ReportString()}, nil
+}
+
+// executorComparison compares two scheduling contexts, typically the most recent contexts of two different executors.
+type executorComparison struct {
+	a *schedulercontext.SchedulingContext
+	b *schedulercontext.SchedulingContext
+	// Format of the human-readable comparison; see newReportWriter.
+	format schedulerobjects.ReportFormat
+}
+
+// sortedQueues returns the sorted names of all queues considered by either context.
+func (c executorComparison) sortedQueues() []string {
+	queues := maps.Keys(c.a.QueueSchedulingContexts)
+	for queue := range c.b.QueueSchedulingContexts {
+		if _, ok := c.a.QueueSchedulingContexts[queue]; !ok {
+			queues = append(queues, queue)
+		}
+	}
+	slices.Sort(queues)
+	return queues
+}
+
+// fairShares returns the fair share of the queue in each context.
+// The fair share of a queue not considered by a context is zero.
+func (c executorComparison) fairShares(queue string) (a, b float64) {
+	if qctx := c.a.QueueSchedulingContexts[queue]; qctx != nil {
+		a = qctx.FairShare()
+	}
+	if qctx := c.b.QueueSchedulingContexts[queue]; qctx != nil {
+		b = qctx.FairShare()
+	}
+	return
+}
+
+func (c executorComparison) ReportString() string {
+	var sb strings.Builder
+	w := newReportWriter(&sb, c.format)
+	fmt.Fprintf(w, "\t%s\t%s\tdelta\n", c.a.ExecutorId, c.b.ExecutorId)
+	fmt.Fprintf(w, "Pool:\t%s\t%s\t\n", c.a.Pool, c.b.Pool)
+	fmt.Fprintf(w, "Time:\t%s\t%s\t%s\n", c.a.Started, c.b.Started, c.b.Started.Sub(c.a.Started))
+	fmt.Fprintf(w, "Termination reason:\t%s\t%s\t\n", c.a.TerminationReason, c.b.TerminationReason)
+	fmt.Fprintf(w, "Scheduled jobs:\t%d\t%d\t%+d\n", c.a.NumScheduledJobs, c.b.NumScheduledJobs, c.b.NumScheduledJobs-c.a.NumScheduledJobs)
+	fmt.Fprintf(w, "Evicted jobs:\t%d\t%d\t%+d\n", c.a.NumEvictedJobs, c.b.NumEvictedJobs, c.b.NumEvictedJobs-c.a.NumEvictedJobs)
+	for _, resources := range []struct {
+		name string
+		a    schedulerobjects.ResourceList
+		b    schedulerobjects.ResourceList
+	}{
+		{"Scheduled resources", c.a.ScheduledResourcesByPriority.AggregateByResource(), c.b.ScheduledResourcesByPriority.AggregateByResource()},
+		{"Evicted resources", c.a.EvictedResourcesByPriority.AggregateByResource(), c.b.EvictedResourcesByPriority.AggregateByResource()},
+	} {
+		resourceComparisons := compareResourceLists(resources.a, resources.b)
+		if len(resourceComparisons) == 0 {
+			fmt.Fprintf(w, "%s:\tnone\tnone\t\n", resources.name)
+			continue
+		}
+		fmt.Fprintf(w, "%s:\t\t\t\n", resources.name)
+		for _, rc := range resourceComparisons {
+			fmt.Fprintf(w, "  %s:\t%s\t%s\t%s\n", rc.Resource, rc.A.String(), rc.B.String(), signedQuantityString(rc.Delta))
+		}
+	}
+	if queues := c.sortedQueues(); len(queues) > 0 {
+		fmt.Fprint(w, "Fair share:\t\t\t\n")
+		for _, queue := range queues {
+			a, b := c.fairShares(queue)
+			fmt.Fprintf(w, "  %s:\t%f\t%f\t%+f\n", queue, a, b, b-a)
+		}
+	} else {
+		fmt.Fprint(w, "Fair share:\tnone\tnone\t\n")
+	}
+	w.Flush()
 	return sb.String()
 }
 
+// ReportJson returns a JSON representation of the comparison.
+func (c executorComparison) ReportJson() (string, error) {
+	rv := executorComparisonJson{
+		ExecutorIdA:           c.a.ExecutorId,
+		ExecutorIdB:           c.b.ExecutorId,
+		NumScheduledJobsA:     c.a.NumScheduledJobs,
+		NumScheduledJobsB:     c.b.NumScheduledJobs,
+		NumScheduledJobsDelta: c.b.NumScheduledJobs - c.a.NumScheduledJobs,
+		NumEvictedJobsA:       c.a.NumEvictedJobs,
+		NumEvictedJobsB:       c.b.NumEvictedJobs,
+		NumEvictedJobsDelta:   c.b.NumEvictedJobs - c.a.NumEvictedJobs,
+		ScheduledResources:    compareResourceLists(c.a.ScheduledResourcesByPriority.AggregateByResource(), c.b.ScheduledResourcesByPriority.AggregateByResource()),
+		EvictedResources:      compareResourceLists(c.a.EvictedResourcesByPriority.AggregateByResource(), c.b.EvictedResourcesByPriority.AggregateByResource()),
+		FairShareByQueue:      make([]fairShareComparisonJson, 0),
+	}
+	for _, queue := range c.sortedQueues() {
+		a, b := c.fairShares(queue)
+		rv.FairShareByQueue = append(rv.FairShareByQueue, fairShareComparisonJson{
+			Queue: queue,
+			A:     a,
+			B:     b,
+			Delta: b - a,
+		})
+	}
+	return marshalReportJson(rv)
+}
+
+// compareResourceLists returns, for each resource in either a or b, the quantity in each list and their difference,
+// sorted by resource name.
+func compareResourceLists(a, b schedulerobjects.ResourceList) []resourceComparisonJson {
+	resourceTypes := maps.Keys(a.Resources)
+	for t := range b.Resources {
+		if _, ok := a.Resources[t]; !ok {
+			resourceTypes = append(resourceTypes, t)
+		}
+	}
+	slices.Sort(resourceTypes)
+	rv := make([]resourceComparisonJson, len(resourceTypes))
+	for i, t := range resourceTypes {
+		delta := b.Get(t).DeepCopy()
+		delta.Sub(a.Get(t))
+		rv[i] = resourceComparisonJson{
+			Resource: t,
+			A:        a.Get(t),
+			B:        b.Get(t),
+			Delta:    delta,
+		}
+	}
+	return rv
+}
+
+// signedQuantityString returns the string representation of q, prefixed with + if q is positive.
+func signedQuantityString(q resource.Quantity) string {
+	if q.Sign() > 0 {
+		return "+" + q.String()
+	}
+	return q.String()
+}
+
+// GetClusterScheduledResources is a gRPC endpoint returning the resources scheduled and evicted,
+// and the number of jobs successfully and unsuccessfully scheduled, summed over the most recent scheduling context
+// of each executor.
+func (repo *SchedulingContextRepository) GetClusterScheduledResources(_ context.Context, _ *schedulerobjects.ClusterScheduledResourcesRequest) (*schedulerobjects.ClusterScheduledResources, error) {
+	return repo.ClusterScheduledResources(), nil
+}
+
+// ClusterScheduledResources sums the resources scheduled and evicted, and the number of jobs successfully and
+// unsuccessfully scheduled, over the most recent scheduling context of each executor.
+// Reads a single snapshot of the stored contexts and doesn't require locking.
+func (repo *SchedulingContextRepository) ClusterScheduledResources() *schedulerobjects.ClusterScheduledResources {
+	mostRecentSchedulingContextByExecutor := repo.GetMostRecentSchedulingContextByExecutor()
+	rv := &schedulerobjects.ClusterScheduledResources{
+		ScheduledResources: schedulerobjects.NewResourceListWithDefaultSize(),
+		EvictedResources:   schedulerobjects.NewResourceListWithDefaultSize(),
+		NumExecutors:       int32(len(mostRecentSchedulingContextByExecutor)),
+	}
+	for _, sctx := range mostRecentSchedulingContextByExecutor {
+		rv.ScheduledResources.Add(sctx.ScheduledResourcesByPriority.AggregateByResource())
+		rv.EvictedResources.Add(sctx.EvictedResourcesByPriority.AggregateByResource())
+		for _, qctx := range sctx.QueueSchedulingContexts {
+			rv.NumSuccessfulJobs += int32(len(qctx.SuccessfulJobSchedulingContexts))
+			rv.NumUnsuccessfulJobs += int32(len(qctx.UnsuccessfulJobSchedulingContexts))
+		}
+	}
+	return rv
+}
+
+// GetQueues is a gRPC endpoint for listing the queues for which scheduling reports are available.
+func (repo *SchedulingContextRepository) GetQueues(_ context.Context, _ *schedulerobjects.QueuesRequest) (*schedulerobjects.Queues, error) {
+	return &schedulerobjects.Queues{QueueNames: repo.ListTrackedQueues()}, nil
+}
+
+// ListTrackedQueues returns the sorted names of all queues for which queue scheduling contexts are stored.
+func (repo *SchedulingContextRepository) ListTrackedQueues() []string {
+	queues := maps.Keys(*repo.mostRecentQueueSchedulingContextByExecutorByQueueP.Load())
+	slices.Sort(queues)
+	return queues
+}
+
+// GetSchedulingContextForExecutor returns the most recent scheduling context for the given executor, if any.
+func (repo *SchedulingContextRepository) GetSchedulingContextForExecutor(executorId string) (*schedulercontext.SchedulingContext, bool) {
+	sctx, ok := (*repo.mostRecentSchedulingContextByExecutorP.Load())[executorId]
+	return sctx, ok
+}
+
 func (repo *SchedulingContextRepository) GetMostRecentSchedulingContextByExecutor() SchedulingContextByExecutor {
 	return *repo.mostRecentSchedulingContextByExecutorP.Load()
 }
@@ -494,6 +1973,249 @@ func (repo *SchedulingContextRepository) GetMostRecentPreemptingSchedulingContex
 	return *repo.mostRecentPreemptingSchedulingContextByExecutorP.Load()
 }
 
+func (repo *SchedulingContextRepository) GetMostRecentUnsuccessfulSchedulingContextByExecutor() SchedulingContextByExecutor {
+	return *repo.mostRecentUnsuccessfulSchedulingContextByExecutorP.Load()
+}
+
+// SchedulingContextRepositorySnapshot is a consistent view of the contents of a SchedulingContextRepository
+// at a single point in time, as returned by SchedulingContextRepository.Snapshot.
+// The maps of a snapshot are shared with the repository, which never mutates them, and must not be mutated;
+// the same applies to the contexts they contain.
+type SchedulingContextRepositorySnapshot struct {
+	// Time at which the snapshot was taken.
+	Time time.Time
+	// Ids of all executors for which contexts are stored, in sorted order.
+	ExecutorIds []string
+	// Map executor id to the most recent, most recent successful, most recent preempting,
+	// and most recent unsuccessful scheduling context.
+	MostRecentSchedulingContextByExecutor             SchedulingContextByExecutor
+	MostRecentSuccessfulSchedulingContextByExecutor   SchedulingContextByExecutor
+	MostRecentPreemptingSchedulingContextByExecutor   SchedulingContextByExecutor
+	MostRecentUnsuccessfulSchedulingContextByExecutor SchedulingContextByExecutor
+	// Map queue name to the most recent, most recent successful, and most recent preempting queue scheduling context
+	// for each executor.
+	MostRecentQueueSchedulingContextByExecutorByQueue           map[string]QueueSchedulingContextByExecutor
+	MostRecentSuccessfulQueueSchedulingContextByExecutorByQueue map[string]QueueSchedulingContextByExecutor
+	MostRecentPreemptingQueueSchedulingContextByExecutorByQueue map[string]QueueSchedulingContextByExecutor
+	// Maps executor id to job id to the most recent job scheduling context stored for that executor.
+	// Unlike the other maps, this is a copy of the contents of the per-executor caches, which are mutated in place.
+	JobSchedulingContextByJobIdByExecutor map[string]map[string]*schedulercontext.JobSchedulingContext
+}
+
+// Snapshot returns the contents of the repository as of a single point in time.
+// Unlike calling the individual getters in succession, all parts of the snapshot are guaranteed to be consistent,
+// i.e., no scheduling context is added to the repository between reading one part and another.
+// Taking a snapshot blocks concurrent writes while the job scheduling context caches are copied.
+func (repo *SchedulingContextRepository) Snapshot() *SchedulingContextRepositorySnapshot {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	jobSchedulingContextCacheByExecutor := *repo.jobSchedulingContextCacheByExecutorP.Load()
+	jobSchedulingContextByJobIdByExecutor := make(map[string]map[string]*schedulercontext.JobSchedulingContext, len(jobSchedulingContextCacheByExecutor))
+	for executorId, cache := range jobSchedulingContextCacheByExecutor {
+		jctxByJobId := make(map[string]*schedulercontext.JobSchedulingContext, cache.Len())
+		for _, key := range cache.Keys() {
+			// Use Peek such that taking a snapshot doesn't affect which contexts are evicted next.
+			if v, ok := cache.Peek(key); ok {
+				jctxByJobId[key.(string)] = v.(*schedulercontext.JobSchedulingContext)
+			}
+		}
+		jobSchedulingContextByJobIdByExecutor[executorId] = jctxByJobId
+	}
+	return &SchedulingContextRepositorySnapshot{
+		Time:                                  repo.clock.Now(),
+		ExecutorIds:                           *repo.sortedExecutorIdsP.Load(),
+		MostRecentSchedulingContextByExecutor: *repo.mostRecentSchedulingContextByExecutorP.Load(),
+		MostRecentSuccessfulSchedulingContextByExecutor:             *repo.mostRecentSuccessfulSchedulingContextByExecutorP.Load(),
+		MostRecentPreemptingSchedulingContextByExecutor:             *repo.mostRecentPreemptingSchedulingContextByExecutorP.Load(),
+		MostRecentUnsuccessfulSchedulingContextByExecutor:           *repo.mostRecentUnsuccessfulSchedulingContextByExecutorP.Load(),
+		MostRecentQueueSchedulingContextByExecutorByQueue:           *repo.mostRecentQueueSchedulingContextByExecutorByQueueP.Load(),
+		MostRecentSuccessfulQueueSchedulingContextByExecutorByQueue: *repo.mostRecentSuccessfulQueueSchedulingContextByExecutorByQueueP.Load(),
+		MostRecentPreemptingQueueSchedulingContextByExecutorByQueue: *repo.mostRecentPreemptingQueueSchedulingContextByExecutorByQueueP.Load(),
+		JobSchedulingContextByJobIdByExecutor:                       jobSchedulingContextByJobIdByExecutor,
+	}
+}
+
+// ReportJson returns a JSON representation of the snapshot, e.g., for export to external systems.
+// Scheduling contexts are represented as in scheduling reports of the given verbosity, executors and queues are listed
+// in sorted order, and the job scheduling contexts of each executor are listed in order of job id.
+func (s *SchedulingContextRepositorySnapshot) ReportJson(verbosity int32) (string, error) {
+	rv := snapshotJson{
+		Time:      s.Time,
+		Executors: make([]executorSchedulingReportJson, len(s.ExecutorIds)),
+	}
+	for i, executorId := range s.ExecutorIds {
+		rv.Executors[i] = executorSchedulingReportJson{
+			ExecutorId:             executorId,
+			MostRecent:             schedulingContextJsonFromSchedulingContext(s.MostRecentSchedulingContextByExecutor[executorId], verbosity),
+			MostRecentSuccessful:   schedulingContextJsonFromSchedulingContext(s.MostRecentSuccessfulSchedulingContextByExecutor[executorId], verbosity),
+			MostRecentPreempting:   schedulingContextJsonFromSchedulingContext(s.MostRecentPreemptingSchedulingContextByExecutor[executorId], verbosity),
+			MostRecentUnsuccessful: schedulingContextJsonFromSchedulingContext(s.MostRecentUnsuccessfulSchedulingContextByExecutor[executorId], verbosity),
+		}
+	}
+	queues := maps.Keys(s.MostRecentQueueSchedulingContextByExecutorByQueue)
+	slices.Sort(queues)
+	for _, queue := range queues {
+		queueReport := queueReportJson{Queue: queue}
+		for _, executorId := range s.ExecutorIds {
+			mostRecent := s.MostRecentQueueSchedulingContextByExecutorByQueue[queue][executorId]
+			mostRecentSuccessful := s.MostRecentSuccessfulQueueSchedulingContextByExecutorByQueue[queue][executorId]
+			mostRecentPreempting := s.MostRecentPreemptingQueueSchedulingContextByExecutorByQueue[queue][executorId]
+			if mostRecent == nil && mostRecentSuccessful == nil && mostRecentPreempting == nil {
+				continue
+			}
+			queueReport.Executors = append(queueReport.Executors, executorQueueReportJson{
+				ExecutorId:           executorId,
+				MostRecent:           queueSchedulingContextJsonFromQueueSchedulingContext(mostRecent, verbosity),
+				MostRecentSuccessful: queueSchedulingContextJsonFromQueueSchedulingContext(mostRecentSuccessful, verbosity),
+				MostRecentPreempting: queueSchedulingContextJsonFromQueueSchedulingContext(mostRecentPreempting, verbosity),
+			})
+		}
+		rv.Queues = append(rv.Queues, queueReport)
+	}
+	executorIds := maps.Keys(s.JobSchedulingContextByJobIdByExecutor)
+	slices.Sort(executorIds)
+	for _, executorId := range executorIds {
+		jctxByJobId := s.JobSchedulingContextByJobIdByExecutor[executorId]
+		jobIds := maps.Keys(jctxByJobId)
+		slices.Sort(jobIds)
+		jobs := executorJobsJson{ExecutorId: executorId}
+		for _, jobId := range jobIds {
+			jobs.Jobs = append(jobs.Jobs, jobSchedulingContextJsonFromJobSchedulingContext(jctxByJobId[jobId]))
+		}
+		rv.Jobs = append(rv.Jobs, jobs)
+	}
+	return marshalReportJson(rv)
+}
+
+// SchedulingContextRepositoryStats summarises the contents of a SchedulingContextRepository.
+type SchedulingContextRepositoryStats struct {
+	// Number of job scheduling contexts currently stored, summed over all executors.
+	NumJobSchedulingContexts int
+	// Maximum number of job scheduling contexts that can be stored per executor,
+	// unless overridden for that executor; see SetMaxJobSchedulingContextsByExecutor.
+	MaxJobSchedulingContexts int
+	// Maps the id of each executor for which contexts are stored to the maximum number of
+	// job scheduling contexts that can be stored for that executor, accounting for overrides.
+	MaxJobSchedulingContextsByExecutor map[string]int
+	// Number of job scheduling contexts evicted from the per-executor caches so far.
+	NumJobSchedulingContextEvictions uint64
+	// Number of distinct queues for which contexts are stored.
+	NumQueues int
+	// Number of distinct executors for which contexts are stored.
+	NumExecutors int
+	// Number of scheduling contexts discarded so far because the ingestion buffer was full.
+	NumDroppedSchedulingContexts uint64
+	// Number of subscribers dropped so far because they fell behind; see SubscribeToSchedulingContexts.
+	NumDroppedSubscribers uint64
+}
+
+// Stats returns a summary of the current contents of the repository.
+func (repo *SchedulingContextRepository) Stats() SchedulingContextRepositoryStats {
+	numJobSchedulingContexts := 0
+	maxJobSchedulingContextsByExecutor := make(map[string]int)
+	for executorId, cache := range *repo.jobSchedulingContextCacheByExecutorP.Load() {
+		numJobSchedulingContexts += cache.Len()
+		maxJobSchedulingContextsByExecutor[executorId] = repo.maxJobSchedulingContextsForExecutor(executorId)
+	}
+	return SchedulingContextRepositoryStats{
+		NumJobSchedulingContexts:           numJobSchedulingContexts,
+		MaxJobSchedulingContexts:           repo.maxJobSchedulingContexts,
+		MaxJobSchedulingContextsByExecutor: maxJobSchedulingContextsByExecutor,
+		NumJobSchedulingContextEvictions:   repo.numJobSchedulingContextEvictions.Load(),
+		NumQueues:                          len(*repo.mostRecentQueueSchedulingContextByExecutorByQueueP.Load()),
+		NumExecutors:                       len(*repo.sortedExecutorIdsP.Load()),
+		NumDroppedSchedulingContexts:       repo.numDroppedSchedulingContexts.Load(),
+		NumDroppedSubscribers:              repo.numDroppedSubscribers.Load(),
+	}
+}
+
+var (
+	schedulingContextRepositoryJobContextsDesc = prometheus.NewDesc(
+		commonmetrics.MetricPrefix+"scheduling_context_repository_job_contexts",
+		"Number of job scheduling contexts stored, summed over all executors",
+		nil,
+		nil,
+	)
+	schedulingContextRepositoryJobContextsCapacityDesc = prometheus.NewDesc(
+		commonmetrics.MetricPrefix+"scheduling_context_repository_job_contexts_capacity",
+		"Maximum number of job scheduling contexts that can be stored per executor",
+		nil,
+		nil,
+	)
+	schedulingContextRepositoryJobContextEvictionsDesc = prometheus.NewDesc(
+		commonmetrics.MetricPrefix+"scheduling_context_repository_job_context_evictions_total",
+		"Number of job scheduling contexts evicted from the per-executor caches",
+		nil,
+		nil,
+	)
+	schedulingContextRepositoryQueuesDesc = prometheus.NewDesc(
+		commonmetrics.MetricPrefix+"scheduling_context_repository_queues",
+		"Number of queues for which scheduling contexts are stored",
+		nil,
+		nil,
+	)
+	schedulingContextRepositoryExecutorsDesc = prometheus.NewDesc(
+		commonmetrics.MetricPrefix+"scheduling_context_repository_executors",
+		"Number of executors for which scheduling contexts are stored",
+		nil,
+		nil,
+	)
+	schedulingContextRepositoryDroppedContextsDesc = prometheus.NewDesc(
+		commonmetrics.MetricPrefix+"scheduling_context_repository_dropped_contexts_total",
+		"Number of scheduling contexts discarded because the ingestion buffer was full",
+		nil,
+		nil,
+	)
+)
+
+// Describe returns all descriptions of the metrics exported by the repository.
+func (repo *SchedulingContextRepository) Describe(out chan<- *prometheus.Desc) {
+	out <- schedulingContextRepositoryJobContextsDesc
+	out <- schedulingContextRepositoryJobContextsCapacityDesc
+	out <- schedulingContextRepositoryJobContextEvictionsDesc
+	out <- schedulingContextRepositoryQueuesDesc
+	out <- schedulingContextRepositoryExecutorsDesc
+	out <- schedulingContextRepositoryDroppedContextsDesc
+	schedulingOutcomeCountDescsByExecutor.describe(out)
+	schedulingOutcomeCountDescsByQueue.describe(out)
+}
+
+// Collect returns metrics computed from the current contents and running totals of the repository.
+func (repo *SchedulingContextRepository) Collect(metrics chan<- prometheus.Metric) {
+	stats := repo.Stats()
+	metrics <- prometheus.MustNewConstMetric(schedulingContextRepositoryJobContextsDesc, prometheus.GaugeValue, float64(stats.NumJobSchedulingContexts))
+	metrics <- prometheus.MustNewConstMetric(schedulingContextRepositoryJobContextsCapacityDesc, prometheus.GaugeValue, float64(stats.MaxJobSchedulingContexts))
+	metrics <- prometheus.MustNewConstMetric(schedulingContextRepositoryJobContextEvictionsDesc, prometheus.CounterValue, float64(stats.NumJobSchedulingContextEvictions))
+	metrics <- prometheus.MustNewConstMetric(schedulingContextRepositoryQueuesDesc, prometheus.GaugeValue, float64(stats.NumQueues))
+	metrics <- prometheus.MustNewConstMetric(schedulingContextRepositoryExecutorsDesc, prometheus.GaugeValue, float64(stats.NumExecutors))
+	metrics <- prometheus.MustNewConstMetric(schedulingContextRepositoryDroppedContextsDesc, prometheus.CounterValue, float64(stats.NumDroppedSchedulingContexts))
+	counters := repo.GetCounters()
+	schedulingOutcomeCountDescsByExecutor.collect(metrics, counters.ByExecutor)
+	schedulingOutcomeCountDescsByQueue.collect(metrics, counters.ByQueue)
+}
+
+// GetRecentSchedulingContextsByExecutor returns up to limit of the most recent scheduling contexts
+// stored for the given executor, most recent first. If limit is non-positive, all stored contexts are returned.
+func (repo *SchedulingContextRepository) GetRecentSchedulingContextsByExecutor(executorId string, limit int) []*schedulercontext.SchedulingContext {
+	history := (*repo.schedulingContextHistoryByExecutorP.Load())[executorId]
+	if limit <= 0 || limit > len(history) {
+		limit = len(history)
+	}
+	rv := make([]*schedulercontext.SchedulingContext, limit)
+	for i := range rv {
+		rv[i] = history[len(history)-1-i]
+	}
+	return rv
+}
+
+// GetQueueFairShareHistory returns the samples stored for the provided queue, oldest first, and true,
+// or nil and false if there are none, e.g., because fair share history isn't enabled.
+// See SetQueueFairShareHistoryLength. The returned slice must not be mutated.
+func (repo *SchedulingContextRepository) GetQueueFairShareHistory(queue string) ([]QueueFairShareSample, bool) {
+	history, ok := (*repo.queueFairShareHistoryByQueueP.Load())[queue]
+	return history, ok
+}
+
 func (repo *SchedulingContextRepository) GetMostRecentQueueSchedulingContextByExecutor(queue string) (QueueSchedulingContextByExecutor, bool) {
 	mostRecentQueueSchedulingContextByExecutorByQueue := *repo.mostRecentQueueSchedulingContextByExecutorByQueueP.Load()
 	mostRecentQueueSchedulingContextByExecutor, ok := mostRecentQueueSchedulingContextByExecutorByQueue[queue]
@@ -512,13 +2234,79 @@ func (repo *SchedulingContextRepository) GetMostRecentPreemptingQueueSchedulingC
 	return mostRecentPreemptingQueueSchedulingContextByExecutor, ok
 }
 
+// ForEachQueueContext calls fn for the most recent queue scheduling context of each queue and executor,
+// in no particular order, stopping early if fn returns false.
+// The map of contexts is loaded once before iterating, such that fn observes a consistent view of the repository
+// even if contexts are added concurrently. The contexts passed to fn are shared with the repository and must not be mutated.
+func (repo *SchedulingContextRepository) ForEachQueueContext(fn func(queue, executor string, qctx *schedulercontext.QueueSchedulingContext) bool) {
+	for queue, queueSchedulingContextByExecutor := range *repo.mostRecentQueueSchedulingContextByExecutorByQueueP.Load() {
+		for executorId, qctx := range queueSchedulingContextByExecutor {
+			if !fn(queue, executorId, qctx) {
+				return
+			}
+		}
+	}
+}
+
+// GetMostRecentJobSchedulingContextByExecutor returns the most recent context of the job with the given id
+// for each executor the job context of which is stored, merged across the per-executor caches.
 func (repo *SchedulingContextRepository) GetMostRecentJobSchedulingContextByExecutor(jobId string) (JobSchedulingContextByExecutor, bool) {
-	if v, ok := repo.mostRecentJobSchedulingContextByExecutorByJobId.Get(jobId); ok {
-		jobSchedulingContextByExecutor := v.(JobSchedulingContextByExecutor)
-		return jobSchedulingContextByExecutor, true
-	} else {
-		return nil, false
+	var rv JobSchedulingContextByExecutor
+	for executorId, cache := range *repo.jobSchedulingContextCacheByExecutorP.Load() {
+		if v, ok := cache.Get(jobId); ok {
+			if rv == nil {
+				rv = make(JobSchedulingContextByExecutor)
+			}
+			rv[executorId] = v.(*schedulercontext.JobSchedulingContext)
+		}
+	}
+	return rv, rv != nil
+}
+
+// GetJobSchedulingContextsByExecutorInWindow returns all stored attempts to schedule the job with the given id
+// created within [since, until), grouped by executor and sorted by creation time.
+// A zero since or until leaves that end of the window unbounded.
+//
+// Attempts are found by searching the scheduling context history of each executor,
+// as well as the most recent job scheduling context; hence, older attempts are only available if historyLength is non-zero.
+func (repo *SchedulingContextRepository) GetJobSchedulingContextsByExecutorInWindow(jobId string, since, until time.Time) map[string][]*schedulercontext.JobSchedulingContext {
+	inWindow := func(jctx *schedulercontext.JobSchedulingContext) bool {
+		if !since.IsZero() && jctx.Created.Before(since) {
+			return false
+		}
+		if !until.IsZero() && !jctx.Created.Before(until) {
+			return false
+		}
+		return true
 	}
+	rv := make(map[string][]*schedulercontext.JobSchedulingContext)
+	seen := make(map[*schedulercontext.JobSchedulingContext]bool)
+	add := func(jctx *schedulercontext.JobSchedulingContext) {
+		if jctx == nil || seen[jctx] || !inWindow(jctx) {
+			return
+		}
+		seen[jctx] = true
+		rv[jctx.ExecutorId] = append(rv[jctx.ExecutorId], jctx)
+	}
+	for _, sctxs := range *repo.schedulingContextHistoryByExecutorP.Load() {
+		for _, sctx := range sctxs {
+			for _, qctx := range sctx.QueueSchedulingContexts {
+				add(qctx.SuccessfulJobSchedulingContexts[jobId])
+				add(qctx.UnsuccessfulJobSchedulingContexts[jobId])
+			}
+		}
+	}
+	if jobSchedulingContextByExecutor, ok := repo.GetMostRecentJobSchedulingContextByExecutor(jobId); ok {
+		for _, jctx := range jobSchedulingContextByExecutor {
+			add(jctx)
+		}
+	}
+	for _, jctxs := range rv {
+		slices.SortFunc(jctxs, func(a, b *schedulercontext.JobSchedulingContext) bool {
+			return a.Created.Before(b.Created)
+		})
+	}
+	return rv
 }
 
 func (repo *SchedulingContextRepository) GetSortedExecutorIds() []string {
@@ -566,3 +2354,234 @@ func (m JobSchedulingContextByExecutor) String() string {
 	w.Flush()
 	return sb.String()
 }
+
+// The types below make up the JSON representation of reports returned when ReportFormat_JSON is requested.
+// Resource quantities are represented as maps from resource name to quantity,
+// where quantities are marshalled to their canonical string representation, e.g., "100m" or "1Gi".
+type (
+	schedulingReportJson struct {
+		Executors []executorSchedulingReportJson `json:"executors"`
+		// Number of executors omitted because they scheduled less than the requested minimum resources.
+		NumExecutorsBelowMinResources int `json:"numExecutorsBelowMinResources,omitempty"`
+		// Only included for verbosity >= fairnessSummaryMinVerbosity.
+		FairnessSummary []queueFairnessJson `json:"fairnessSummary,omitempty"`
+	}
+	queueFairnessJson struct {
+		Queue       string  `json:"queue"`
+		ActualShare float64 `json:"actualShare"`
+		TargetShare float64 `json:"targetShare"`
+	}
+	executorSchedulingReportJson struct {
+		ExecutorId             string                   `json:"executorId"`
+		Draining               bool                     `json:"draining,omitempty"`
+		MostRecent             *schedulingContextJson   `json:"mostRecent,omitempty"`
+		MostRecentSuccessful   *schedulingContextJson   `json:"mostRecentSuccessful,omitempty"`
+		MostRecentPreempting   *schedulingContextJson   `json:"mostRecentPreempting,omitempty"`
+		MostRecentUnsuccessful *schedulingContextJson   `json:"mostRecentUnsuccessful,omitempty"`
+		Recent                 []*schedulingContextJson `json:"recent,omitempty"`
+	}
+	queueReportJson struct {
+		Queue     string                    `json:"queue"`
+		Paused    bool                      `json:"paused,omitempty"`
+		Executors []executorQueueReportJson `json:"executors"`
+	}
+	executorQueueReportJson struct {
+		ExecutorId           string                      `json:"executorId"`
+		MostRecent           *queueSchedulingContextJson `json:"mostRecent,omitempty"`
+		MostRecentSuccessful *queueSchedulingContextJson `json:"mostRecentSuccessful,omitempty"`
+		MostRecentPreempting *queueSchedulingContextJson `json:"mostRecentPreempting,omitempty"`
+	}
+	jobReportJson struct {
+		JobId     string                  `json:"jobId"`
+		Executors []executorJobReportJson `json:"executors"`
+	}
+	executorJobReportJson struct {
+		ExecutorId string                      `json:"executorId"`
+		MostRecent *jobSchedulingContextJson   `json:"mostRecent,omitempty"`
+		Attempts   []*jobSchedulingContextJson `json:"attempts,omitempty"`
+	}
+	schedulingContextJson struct {
+		ExecutorId                   string                                 `json:"executorId"`
+		Pool                         string                                 `json:"pool"`
+		Started                      time.Time                              `json:"started"`
+		Finished                     time.Time                              `json:"finished"`
+		TerminationReason            string                                 `json:"terminationReason"`
+		RoundOutcome                 string                                 `json:"roundOutcome,omitempty"`
+		TotalResources               map[string]resource.Quantity           `json:"totalResources"`
+		ScheduledResourcesByPriority map[int32]map[string]resource.Quantity `json:"scheduledResourcesByPriority"`
+		EvictedResourcesByPriority   map[int32]map[string]resource.Quantity `json:"evictedResourcesByPriority"`
+		NumScheduledJobs             int                                    `json:"numScheduledJobs"`
+		NumScheduledGangs            int                                    `json:"numScheduledGangs"`
+		NumEvictedJobs               int                                    `json:"numEvictedJobs"`
+		Queues                       map[string]*queueSchedulingContextJson `json:"queues"`
+		// Number of queues omitted because they scheduled less than the requested minimum resources.
+		NumQueuesBelowMinResources int `json:"numQueuesBelowMinResources,omitempty"`
+	}
+	queueSchedulingContextJson struct {
+		Queue                        string                                 `json:"queue"`
+		Created                      time.Time                              `json:"created"`
+		PriorityFactor               float64                                `json:"priorityFactor"`
+		AllocatedByPriority          map[int32]map[string]resource.Quantity `json:"allocatedByPriority"`
+		ScheduledResourcesByPriority map[int32]map[string]resource.Quantity `json:"scheduledResourcesByPriority"`
+		EvictedResourcesByPriority   map[int32]map[string]resource.Quantity `json:"evictedResourcesByPriority"`
+		NumSuccessfulJobs            int                                    `json:"numSuccessfulJobs"`
+		NumUnsuccessfulJobs          int                                    `json:"numUnsuccessfulJobs"`
+		NumEvictedJobs               int                                    `json:"numEvictedJobs"`
+		// Number of unsuccessful jobs by the reason they could not be scheduled, most common reason first.
+		UnschedulableReasonCounts []unschedulableReasonCountJson `json:"unschedulableReasonCounts,omitempty"`
+		// Only included for verbosity > 0.
+		SuccessfulJobIds []string `json:"successfulJobIds,omitempty"`
+		// Maps job id to the reason the job could not be scheduled.
+		// Only included for verbosity > 0.
+		UnschedulableReasonByJobId map[string]string `json:"unschedulableReasonByJobId,omitempty"`
+		// Only included for verbosity > 0.
+		EvictedJobIds []string `json:"evictedJobIds,omitempty"`
+	}
+	unschedulableReasonCountJson struct {
+		Reason  string `json:"reason"`
+		NumJobs int    `json:"numJobs"`
+	}
+	executorComparisonJson struct {
+		ExecutorIdA           string                    `json:"executorIdA"`
+		ExecutorIdB           string                    `json:"executorIdB"`
+		NumScheduledJobsA     int                       `json:"numScheduledJobsA"`
+		NumScheduledJobsB     int                       `json:"numScheduledJobsB"`
+		NumScheduledJobsDelta int                       `json:"numScheduledJobsDelta"`
+		NumEvictedJobsA       int                       `json:"numEvictedJobsA"`
+		NumEvictedJobsB       int                       `json:"numEvictedJobsB"`
+		NumEvictedJobsDelta   int                       `json:"numEvictedJobsDelta"`
+		ScheduledResources    []resourceComparisonJson  `json:"scheduledResources"`
+		EvictedResources      []resourceComparisonJson  `json:"evictedResources"`
+		FairShareByQueue      []fairShareComparisonJson `json:"fairShareByQueue"`
+	}
+	resourceComparisonJson struct {
+		Resource string            `json:"resource"`
+		A        resource.Quantity `json:"a"`
+		B        resource.Quantity `json:"b"`
+		Delta    resource.Quantity `json:"delta"`
+	}
+	fairShareComparisonJson struct {
+		Queue string  `json:"queue"`
+		A     float64 `json:"a"`
+		B     float64 `json:"b"`
+		Delta float64 `json:"delta"`
+	}
+	snapshotJson struct {
+		Time      time.Time                      `json:"time"`
+		Executors []executorSchedulingReportJson `json:"executors"`
+		Queues    []queueReportJson              `json:"queues"`
+		Jobs      []executorJobsJson             `json:"jobs"`
+	}
+	executorJobsJson struct {
+		ExecutorId string                      `json:"executorId"`
+		Jobs       []*jobSchedulingContextJson `json:"jobs"`
+	}
+	jobSchedulingContextJson struct {
+		JobId               string    `json:"jobId"`
+		GangId              string    `json:"gangId,omitempty"`
+		Created             time.Time `json:"created"`
+		NumNodes            int       `json:"numNodes"`
+		UnschedulableReason string    `json:"unschedulableReason,omitempty"`
+		Rejection           string    `json:"rejection,omitempty"`
+		NodeId              string    `json:"nodeId,omitempty"`
+	}
+)
+
+func marshalReportJson(v any) (string, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(bytes), nil
+}
+
+func schedulingContextJsonFromSchedulingContext(sctx *schedulercontext.SchedulingContext, verbosity int32) *schedulingContextJson {
+	if sctx == nil {
+		return nil
+	}
+	queues := make(map[string]*queueSchedulingContextJson, len(sctx.QueueSchedulingContexts))
+	for queue, qctx := range sctx.QueueSchedulingContexts {
+		queues[queue] = queueSchedulingContextJsonFromQueueSchedulingContext(qctx, verbosity)
+	}
+	return &schedulingContextJson{
+		ExecutorId:                   sctx.ExecutorId,
+		Pool:                         sctx.Pool,
+		Started:                      sctx.Started,
+		Finished:                     sctx.Finished,
+		TerminationReason:            sctx.TerminationReason,
+		RoundOutcome:                 string(sctx.RoundOutcome),
+		TotalResources:               resourceListJson(sctx.TotalResources),
+		ScheduledResourcesByPriority: quantityByPriorityAndResourceTypeJson(sctx.ScheduledResourcesByPriority),
+		EvictedResourcesByPriority:   quantityByPriorityAndResourceTypeJson(sctx.EvictedResourcesByPriority),
+		NumScheduledJobs:             sctx.NumScheduledJobs,
+		NumScheduledGangs:            sctx.NumScheduledGangs,
+		NumEvictedJobs:               sctx.NumEvictedJobs,
+		Queues:                       queues,
+	}
+}
+
+func queueSchedulingContextJsonFromQueueSchedulingContext(qctx *schedulercontext.QueueSchedulingContext, verbosity int32) *queueSchedulingContextJson {
+	if qctx == nil {
+		return nil
+	}
+	rv := &queueSchedulingContextJson{
+		Queue:                        qctx.Queue,
+		Created:                      qctx.Created,
+		PriorityFactor:               qctx.PriorityFactor,
+		AllocatedByPriority:          quantityByPriorityAndResourceTypeJson(qctx.AllocatedByPriority),
+		ScheduledResourcesByPriority: quantityByPriorityAndResourceTypeJson(qctx.ScheduledResourcesByPriority),
+		EvictedResourcesByPriority:   quantityByPriorityAndResourceTypeJson(qctx.EvictedResourcesByPriority),
+		NumSuccessfulJobs:            len(qctx.SuccessfulJobSchedulingContexts),
+		NumUnsuccessfulJobs:          len(qctx.UnsuccessfulJobSchedulingContexts),
+		NumEvictedJobs:               len(qctx.EvictedJobsById),
+	}
+	for _, reasonCount := range qctx.UnschedulableReasonCounts() {
+		rv.UnschedulableReasonCounts = append(rv.UnschedulableReasonCounts, unschedulableReasonCountJson{
+			Reason:  reasonCount.Reason,
+			NumJobs: reasonCount.NumJobs,
+		})
+	}
+	if verbosity > 0 {
+		rv.SuccessfulJobIds = maps.Keys(qctx.SuccessfulJobSchedulingContexts)
+		slices.Sort(rv.SuccessfulJobIds)
+		rv.UnschedulableReasonByJobId = armadamaps.MapValues(
+			qctx.UnsuccessfulJobSchedulingContexts,
+			func(jctx *schedulercontext.JobSchedulingContext) string { return jctx.UnschedulableReason },
+		)
+		rv.EvictedJobIds = maps.Keys(qctx.EvictedJobsById)
+		slices.Sort(rv.EvictedJobIds)
+	}
+	return rv
+}
+
+func jobSchedulingContextJsonFromJobSchedulingContext(jctx *schedulercontext.JobSchedulingContext) *jobSchedulingContextJson {
+	if jctx == nil {
+		return nil
+	}
+	rv := &jobSchedulingContextJson{
+		JobId:               jctx.JobId,
+		GangId:              jctx.GangId,
+		Created:             jctx.Created,
+		NumNodes:            jctx.NumNodes,
+		UnschedulableReason: jctx.UnschedulableReason,
+		Rejection:           jctx.Rejection(),
+		NodeId:              jctx.NodeId,
+	}
+	return rv
+}
+
+func resourceListJson(rl schedulerobjects.ResourceList) map[string]resource.Quantity {
+	rv := make(map[string]resource.Quantity, len(rl.Resources))
+	for t, q := range rl.Resources {
+		rv[t] = q.DeepCopy()
+	}
+	return rv
+}
+
+func quantityByPriorityAndResourceTypeJson(m schedulerobjects.QuantityByPriorityAndResourceType) map[int32]map[string]resource.Quantity {
+	rv := make(map[int32]map[string]resource.Quantity, len(m))
+	for p, rl := range m {
+		rv[p] = resourceListJson(rl)
+	}
+	return rv
+}
@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/maps"
+
+	commonmetrics "github.com/armadaproject/armada/internal/common/metrics"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+)
+
+// SchedulingOutcomeCounts are running totals of the outcomes of the scheduling attempts added to a repository
+// since it was created, for a single executor or queue. Unlike the stored contexts, counts aren't affected by Clear
+// or by executors expiring.
+type SchedulingOutcomeCounts struct {
+	// Number of scheduling attempts; for queues, the number of attempts in which the queue was considered.
+	NumRounds uint64
+	// Number of attempts in which a non-zero amount of resources were scheduled.
+	NumSuccessfulRounds uint64
+	// Number of attempts in which at least one job was preempted.
+	NumPreemptingRounds uint64
+	// Number of jobs scheduled, could not be scheduled, and preempted, summed over all attempts.
+	NumScheduledJobs     uint64
+	NumUnschedulableJobs uint64
+	NumPreemptedJobs     uint64
+}
+
+// SchedulingContextRepositoryCounters are the running totals of a repository; see SchedulingContextRepository.GetCounters.
+type SchedulingContextRepositoryCounters struct {
+	ByExecutor map[string]SchedulingOutcomeCounts
+	ByQueue    map[string]SchedulingOutcomeCounts
+}
+
+// schedulingOutcomeCounters is the concurrency-safe counterpart of SchedulingOutcomeCounts stored in the repository.
+type schedulingOutcomeCounters struct {
+	numRounds            atomic.Uint64
+	numSuccessfulRounds  atomic.Uint64
+	numPreemptingRounds  atomic.Uint64
+	numScheduledJobs     atomic.Uint64
+	numUnschedulableJobs atomic.Uint64
+	numPreemptedJobs     atomic.Uint64
+}
+
+func (c *schedulingOutcomeCounters) add(qctxs []*schedulercontext.QueueSchedulingContext, successful, preempting bool) {
+	c.numRounds.Add(1)
+	if successful {
+		c.numSuccessfulRounds.Add(1)
+	}
+	if preempting {
+		c.numPreemptingRounds.Add(1)
+	}
+	for _, qctx := range qctxs {
+		c.numScheduledJobs.Add(uint64(len(qctx.SuccessfulJobSchedulingContexts)))
+		c.numUnschedulableJobs.Add(uint64(len(qctx.UnsuccessfulJobSchedulingContexts)))
+		c.numPreemptedJobs.Add(uint64(len(qctx.EvictedJobsById)))
+	}
+}
+
+func (c *schedulingOutcomeCounters) load() SchedulingOutcomeCounts {
+	return SchedulingOutcomeCounts{
+		NumRounds:            c.numRounds.Load(),
+		NumSuccessfulRounds:  c.numSuccessfulRounds.Load(),
+		NumPreemptingRounds:  c.numPreemptingRounds.Load(),
+		NumScheduledJobs:     c.numScheduledJobs.Load(),
+		NumUnschedulableJobs: c.numUnschedulableJobs.Load(),
+		NumPreemptedJobs:     c.numPreemptedJobs.Load(),
+	}
+}
+
+// getOrCreateCounters returns the counters stored in p for key, adding new counters if there are none.
+// Should only be called with repo.mu held.
+func getOrCreateCounters(p *atomic.Pointer[map[string]*schedulingOutcomeCounters], key string) *schedulingOutcomeCounters {
+	if c, ok := (*p.Load())[key]; ok {
+		return c
+	}
+	c := &schedulingOutcomeCounters{}
+	countersByKey := maps.Clone(*p.Load())
+	countersByKey[key] = c
+	p.Store(&countersByKey)
+	return c
+}
+
+// countSchedulingContext adds the outcome of sctx to the counters of its executor.
+// Should only be called with repo.mu held.
+func (repo *SchedulingContextRepository) countSchedulingContext(sctx *schedulercontext.SchedulingContext) {
+	getOrCreateCounters(&repo.countersByExecutorP, sctx.ExecutorId).add(
+		maps.Values(sctx.QueueSchedulingContexts),
+		!sctx.ScheduledResourcesByPriority.IsZero(),
+		!sctx.EvictedResourcesByPriority.IsZero(),
+	)
+}
+
+// countQueueSchedulingContext adds the outcome of qctx to the counters of its queue.
+// Should only be called with repo.mu held.
+func (repo *SchedulingContextRepository) countQueueSchedulingContext(qctx *schedulercontext.QueueSchedulingContext) {
+	getOrCreateCounters(&repo.countersByQueueP, qctx.Queue).add(
+		[]*schedulercontext.QueueSchedulingContext{qctx},
+		!qctx.ScheduledResourcesByPriority.IsZero(),
+		!qctx.EvictedResourcesByPriority.IsZero(),
+	)
+}
+
+// GetCounters returns the running totals of the outcomes of the scheduling attempts added to the repository,
+// per executor and per queue. Counters are read without locking; hence, the counts of an attempt being added
+// concurrently may be partially included.
+func (repo *SchedulingContextRepository) GetCounters() SchedulingContextRepositoryCounters {
+	rv := SchedulingContextRepositoryCounters{
+		ByExecutor: make(map[string]SchedulingOutcomeCounts),
+		ByQueue:    make(map[string]SchedulingOutcomeCounts),
+	}
+	for executorId, c := range *repo.countersByExecutorP.Load() {
+		rv.ByExecutor[executorId] = c.load()
+	}
+	for queue, c := range *repo.countersByQueueP.Load() {
+		rv.ByQueue[queue] = c.load()
+	}
+	return rv
+}
+
+// Descriptions of the metrics exporting SchedulingOutcomeCounts, one per field,
+// for executors and queues respectively.
+var (
+	schedulingOutcomeCountDescsByExecutor = newSchedulingOutcomeCountDescs("executor")
+	schedulingOutcomeCountDescsByQueue    = newSchedulingOutcomeCountDescs("queue")
+)
+
+type schedulingOutcomeCountDescs struct {
+	rounds            *prometheus.Desc
+	successfulRounds  *prometheus.Desc
+	preemptingRounds  *prometheus.Desc
+	scheduledJobs     *prometheus.Desc
+	unschedulableJobs *prometheus.Desc
+	preemptedJobs     *prometheus.Desc
+}
+
+func newSchedulingOutcomeCountDescs(label string) schedulingOutcomeCountDescs {
+	newDesc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			commonmetrics.MetricPrefix+"scheduling_context_repository_"+label+"_"+name+"_total",
+			help+" for each "+label+" since the repository was created",
+			[]string{label},
+			nil,
+		)
+	}
+	return schedulingOutcomeCountDescs{
+		rounds:            newDesc("rounds", "Number of scheduling attempts"),
+		successfulRounds:  newDesc("successful_rounds", "Number of scheduling attempts in which resources were scheduled"),
+		preemptingRounds:  newDesc("preempting_rounds", "Number of scheduling attempts in which jobs were preempted"),
+		scheduledJobs:     newDesc("scheduled_jobs", "Number of jobs scheduled"),
+		unschedulableJobs: newDesc("unschedulable_jobs", "Number of jobs that could not be scheduled"),
+		preemptedJobs:     newDesc("preempted_jobs", "Number of jobs preempted"),
+	}
+}
+
+func (descs schedulingOutcomeCountDescs) describe(out chan<- *prometheus.Desc) {
+	out <- descs.rounds
+	out <- descs.successfulRounds
+	out <- descs.preemptingRounds
+	out <- descs.scheduledJobs
+	out <- descs.unschedulableJobs
+	out <- descs.preemptedJobs
+}
+
+func (descs schedulingOutcomeCountDescs) collect(metrics chan<- prometheus.Metric, countsByLabel map[string]SchedulingOutcomeCounts) {
+	for label, counts := range countsByLabel {
+		metrics <- prometheus.MustNewConstMetric(descs.rounds, prometheus.CounterValue, float64(counts.NumRounds), label)
+		metrics <- prometheus.MustNewConstMetric(descs.successfulRounds, prometheus.CounterValue, float64(counts.NumSuccessfulRounds), label)
+		metrics <- prometheus.MustNewConstMetric(descs.preemptingRounds, prometheus.CounterValue, float64(counts.NumPreemptingRounds), label)
+		metrics <- prometheus.MustNewConstMetric(descs.scheduledJobs, prometheus.CounterValue, float64(counts.NumScheduledJobs), label)
+		metrics <- prometheus.MustNewConstMetric(descs.unschedulableJobs, prometheus.CounterValue, float64(counts.NumUnschedulableJobs), label)
+		metrics <- prometheus.MustNewConstMetric(descs.preemptedJobs, prometheus.CounterValue, float64(counts.NumPreemptedJobs), label)
+	}
+}
@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulingContextRepositoryCounters(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		SchedulingContextRepositoryCounters{
+			ByExecutor: map[string]SchedulingOutcomeCounts{},
+			ByQueue:    map[string]SchedulingOutcomeCounts{},
+		},
+		repo.GetCounters(),
+	)
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA1")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA2")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "failureFooA")
+	sctx = withPreemptingJobSchedulingContext(sctx, "B", "preemptedFooB")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	sctx = testSchedulingContext("foo")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "failureFooA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	sctx = testSchedulingContext("bar")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	// Counters are running totals and hence aren't affected by clearing the repository.
+	repo.Clear()
+
+	assert.Equal(
+		t,
+		SchedulingContextRepositoryCounters{
+			ByExecutor: map[string]SchedulingOutcomeCounts{
+				"foo": {
+					NumRounds:            2,
+					NumSuccessfulRounds:  1,
+					NumPreemptingRounds:  1,
+					NumScheduledJobs:     2,
+					NumUnschedulableJobs: 2,
+					NumPreemptedJobs:     1,
+				},
+				"bar": {
+					NumRounds:           1,
+					NumSuccessfulRounds: 1,
+					NumScheduledJobs:    1,
+				},
+			},
+			ByQueue: map[string]SchedulingOutcomeCounts{
+				"A": {
+					NumRounds:            3,
+					NumSuccessfulRounds:  2,
+					NumScheduledJobs:     3,
+					NumUnschedulableJobs: 2,
+				},
+				"B": {
+					NumRounds:           1,
+					NumPreemptingRounds: 1,
+					NumPreemptedJobs:    1,
+				},
+			},
+		},
+		repo.GetCounters(),
+	)
+
+	expected := `
+# HELP armada_scheduling_context_repository_queue_preempted_jobs_total Number of jobs preempted for each queue since the repository was created
+# TYPE armada_scheduling_context_repository_queue_preempted_jobs_total counter
+armada_scheduling_context_repository_queue_preempted_jobs_total{queue="A"} 0
+armada_scheduling_context_repository_queue_preempted_jobs_total{queue="B"} 1
+# HELP armada_scheduling_context_repository_executor_rounds_total Number of scheduling attempts for each executor since the repository was created
+# TYPE armada_scheduling_context_repository_executor_rounds_total counter
+armada_scheduling_context_repository_executor_rounds_total{executor="bar"} 1
+armada_scheduling_context_repository_executor_rounds_total{executor="foo"} 2
+`
+	assert.NoError(
+		t,
+		testutil.CollectAndCompare(
+			repo,
+			strings.NewReader(expected),
+			"armada_scheduling_context_repository_queue_preempted_jobs_total",
+			"armada_scheduling_context_repository_executor_rounds_total",
+		),
+	)
+}
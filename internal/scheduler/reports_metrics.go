@@ -0,0 +1,155 @@
+package scheduler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	commonmetrics "github.com/armadaproject/armada/internal/common/metrics"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+var (
+	schedulingReportScheduledResourcesDesc = prometheus.NewDesc(
+		commonmetrics.MetricPrefix+"scheduling_report_scheduled_resources",
+		"Resources scheduled to a queue in the most recent scheduling attempt of an executor",
+		[]string{"executor", "queue", "priority_class", "resource"},
+		nil,
+	)
+	schedulingReportEvictedResourcesDesc = prometheus.NewDesc(
+		commonmetrics.MetricPrefix+"scheduling_report_evicted_resources",
+		"Resources evicted from a queue in the most recent scheduling attempt of an executor",
+		[]string{"executor", "queue", "priority_class", "resource"},
+		nil,
+	)
+	schedulingReportSuccessfulJobsDesc = prometheus.NewDesc(
+		commonmetrics.MetricPrefix+"scheduling_report_successful_jobs",
+		"Number of jobs of a queue scheduled in the most recent scheduling attempt of an executor",
+		[]string{"executor", "queue", "priority_class"},
+		nil,
+	)
+	schedulingReportUnsuccessfulJobsDesc = prometheus.NewDesc(
+		commonmetrics.MetricPrefix+"scheduling_report_unsuccessful_jobs",
+		"Number of jobs of a queue that could not be scheduled in the most recent scheduling attempt of an executor",
+		[]string{"executor", "queue", "priority_class"},
+		nil,
+	)
+)
+
+// SchedulingReportCollector exports the contents of the most recent scheduling report of each queue and executor
+// as Prometheus metrics, such that reports can be scraped without using the gRPC reports api.
+// The number of series exported grows with the product of the number of executors, queues, and priority classes.
+// Hence, this is separate from the metrics exported by SchedulingContextRepository itself and must be registered
+// explicitly.
+type SchedulingReportCollector struct {
+	repo *SchedulingContextRepository
+}
+
+// NewSchedulingReportCollector returns a SchedulingReportCollector exporting metrics derived from repo.
+func NewSchedulingReportCollector(repo *SchedulingContextRepository) *SchedulingReportCollector {
+	return &SchedulingReportCollector{repo: repo}
+}
+
+// MetricsHandler returns an HTTP handler serving the metrics of the collector, and only those,
+// in the Prometheus exposition format.
+func (c *SchedulingReportCollector) MetricsHandler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(c)
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Describe returns all descriptions of the metrics exported by the collector.
+func (c *SchedulingReportCollector) Describe(out chan<- *prometheus.Desc) {
+	out <- schedulingReportScheduledResourcesDesc
+	out <- schedulingReportEvictedResourcesDesc
+	out <- schedulingReportSuccessfulJobsDesc
+	out <- schedulingReportUnsuccessfulJobsDesc
+}
+
+// Collect returns metrics computed from the most recent queue scheduling context of each queue and executor.
+func (c *SchedulingReportCollector) Collect(metrics chan<- prometheus.Metric) {
+	for queue, qctxByExecutor := range *c.repo.mostRecentQueueSchedulingContextByExecutorByQueueP.Load() {
+		for executorId, qctx := range qctxByExecutor {
+			if qctx == nil {
+				continue
+			}
+			collectResourcesByPriority(metrics, schedulingReportScheduledResourcesDesc, executorId, queue, qctx, qctx.ScheduledResourcesByPriority)
+			collectResourcesByPriority(metrics, schedulingReportEvictedResourcesDesc, executorId, queue, qctx, qctx.EvictedResourcesByPriority)
+			collectJobCountsByPriorityClass(metrics, schedulingReportSuccessfulJobsDesc, executorId, queue, qctx, qctx.SuccessfulJobSchedulingContexts)
+			collectJobCountsByPriorityClass(metrics, schedulingReportUnsuccessfulJobsDesc, executorId, queue, qctx, qctx.UnsuccessfulJobSchedulingContexts)
+		}
+	}
+}
+
+func collectResourcesByPriority(
+	metrics chan<- prometheus.Metric,
+	desc *prometheus.Desc,
+	executorId, queue string,
+	qctx *schedulercontext.QueueSchedulingContext,
+	m schedulerobjects.QuantityByPriorityAndResourceType,
+) {
+	var priorityClasses map[string]configuration.PriorityClass
+	if qctx.SchedulingContext != nil {
+		priorityClasses = qctx.SchedulingContext.PriorityClasses
+	}
+	for priority, rl := range m {
+		priorityClassName := priorityClassNameFromPriority(priorityClasses, priority)
+		for t, q := range rl.Resources {
+			metrics <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, q.AsApproximateFloat64(), executorId, queue, priorityClassName, t)
+		}
+	}
+}
+
+func collectJobCountsByPriorityClass(
+	metrics chan<- prometheus.Metric,
+	desc *prometheus.Desc,
+	executorId, queue string,
+	qctx *schedulercontext.QueueSchedulingContext,
+	jctxByJobId map[string]*schedulercontext.JobSchedulingContext,
+) {
+	defaultPriorityClassName := ""
+	var priorityClasses map[string]configuration.PriorityClass
+	if qctx.SchedulingContext != nil {
+		defaultPriorityClassName = qctx.SchedulingContext.DefaultPriorityClass
+		priorityClasses = qctx.SchedulingContext.PriorityClasses
+	}
+	countByPriorityClass := make(map[string]int)
+	for _, jctx := range jctxByJobId {
+		priorityClassName := defaultPriorityClassName
+		if jctx.Job != nil {
+			if name := jctx.Job.GetPriorityClassName(); name != "" {
+				priorityClassName = name
+			}
+		} else if jctx.Req != nil {
+			// Job specs are cleared before contexts are stored; see SchedulingContext.ClearJobSpecs.
+			priorityClassName = priorityClassNameFromPriority(priorityClasses, jctx.Req.Priority)
+		}
+		countByPriorityClass[priorityClassName]++
+	}
+	for priorityClassName, count := range countByPriorityClass {
+		metrics <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(count), executorId, queue, priorityClassName)
+	}
+}
+
+// priorityClassNameFromPriority returns the name of the priority class with the given priority.
+// If several priority classes have the same priority, their names are joined by "|" in sorted order.
+// If there is no such priority class, the priority itself is returned, such that the label is never empty.
+func priorityClassNameFromPriority(priorityClasses map[string]configuration.PriorityClass, priority int32) string {
+	var names []string
+	for name, priorityClass := range priorityClasses {
+		if priorityClass.Priority == priority {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return strconv.Itoa(int(priority))
+	}
+	slices.Sort(names)
+	return strings.Join(names, "|")
+}
@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+	"github.com/armadaproject/armada/internal/scheduler/testfixtures"
+)
+
+func TestSchedulingReportCollector(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+	collector := NewSchedulingReportCollector(repo)
+
+	sctx := testSchedulingContext("foo")
+	sctx.PriorityClasses = map[string]configuration.PriorityClass{
+		"armada-default":     {Priority: 0},
+		"armada-preemptible": {Priority: 0},
+	}
+	sctx.DefaultPriorityClass = "armada-default"
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successA1")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successA2")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "failureA")
+	sctx = withPreemptingJobSchedulingContext(sctx, "B", "preemptedB")
+	for _, qctx := range sctx.QueueSchedulingContexts {
+		qctx.SchedulingContext = sctx
+	}
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	// Without priority classes, the priority is used as the priority class label.
+	sctx = testSchedulingContext("bar")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	expected := `
+# HELP armada_scheduling_report_evicted_resources Resources evicted from a queue in the most recent scheduling attempt of an executor
+# TYPE armada_scheduling_report_evicted_resources gauge
+armada_scheduling_report_evicted_resources{executor="foo",priority_class="armada-default|armada-preemptible",queue="B",resource="cpu"} 1
+# HELP armada_scheduling_report_scheduled_resources Resources scheduled to a queue in the most recent scheduling attempt of an executor
+# TYPE armada_scheduling_report_scheduled_resources gauge
+armada_scheduling_report_scheduled_resources{executor="bar",priority_class="0",queue="A",resource="cpu"} 1
+armada_scheduling_report_scheduled_resources{executor="foo",priority_class="armada-default|armada-preemptible",queue="A",resource="cpu"} 2
+# HELP armada_scheduling_report_successful_jobs Number of jobs of a queue scheduled in the most recent scheduling attempt of an executor
+# TYPE armada_scheduling_report_successful_jobs gauge
+armada_scheduling_report_successful_jobs{executor="bar",priority_class="",queue="A"} 1
+armada_scheduling_report_successful_jobs{executor="foo",priority_class="armada-default",queue="A"} 2
+# HELP armada_scheduling_report_unsuccessful_jobs Number of jobs of a queue that could not be scheduled in the most recent scheduling attempt of an executor
+# TYPE armada_scheduling_report_unsuccessful_jobs gauge
+armada_scheduling_report_unsuccessful_jobs{executor="foo",priority_class="armada-default",queue="A"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+
+	server := httptest.NewServer(collector.MetricsHandler())
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), `armada_scheduling_report_successful_jobs{executor="foo",priority_class="armada-default",queue="A"} 2`)
+	assert.NotContains(t, string(body), "scheduling_context_repository")
+}
+
+func TestSchedulingReportCollectorWithClearedJobSpecs(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	collector := NewSchedulingReportCollector(repo)
+
+	// Job specs are cleared before contexts are stored. Hence, priority classes are derived from job requirements.
+	sctx := schedulercontext.NewSchedulingContext(
+		"foo",
+		"pool",
+		testfixtures.TestPriorityClasses,
+		testfixtures.TestDefaultPriorityClass,
+		nil,
+		schedulerobjects.ResourceList{},
+	)
+	require.NoError(t, sctx.AddQueueSchedulingContext("A", 1, nil))
+	qctx := sctx.QueueSchedulingContexts["A"]
+	for _, jctx := range jobSchedulingContextsFromJobs(testfixtures.N1CpuJobs("A", testfixtures.PriorityClass1, 2), "foo", testfixtures.TestPriorityClasses) {
+		qctx.SuccessfulJobSchedulingContexts[jctx.JobId] = jctx
+	}
+	for _, jctx := range jobSchedulingContextsFromJobs(testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 1), "foo", testfixtures.TestPriorityClasses) {
+		jctx.UnschedulableReason = "unknown"
+		qctx.UnsuccessfulJobSchedulingContexts[jctx.JobId] = jctx
+	}
+	sctx.ClearJobSpecs()
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	expected := `
+# HELP armada_scheduling_report_successful_jobs Number of jobs of a queue scheduled in the most recent scheduling attempt of an executor
+# TYPE armada_scheduling_report_successful_jobs gauge
+armada_scheduling_report_successful_jobs{executor="foo",priority_class="priority-1",queue="A"} 2
+# HELP armada_scheduling_report_unsuccessful_jobs Number of jobs of a queue that could not be scheduled in the most recent scheduling attempt of an executor
+# TYPE armada_scheduling_report_unsuccessful_jobs gauge
+armada_scheduling_report_unsuccessful_jobs{executor="foo",priority_class="priority-0",queue="A"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(
+		collector,
+		strings.NewReader(expected),
+		"armada_scheduling_report_successful_jobs",
+		"armada_scheduling_report_unsuccessful_jobs",
+	))
+}
@@ -0,0 +1,318 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	armadaslices "github.com/armadaproject/armada/internal/common/slices"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+)
+
+// persistedState is the on-disk representation of a SchedulingContextRepository,
+// as written by WriteSnapshot and read by LoadSnapshot.
+type persistedState struct {
+	// Time at which the state was written.
+	Created time.Time `json:"created"`
+	// All scheduling contexts stored in the repository, i.e., the most recent, most recent successful,
+	// and most recent preempting contexts as well as the history, sorted by the time they were started.
+	SchedulingContexts []*schedulercontext.SchedulingContext `json:"schedulingContexts"`
+	// Job contexts stored in the LRU cache of each executor, ordered from least to most recently used.
+	JobSchedulingContextsByExecutor map[string][]*schedulercontext.JobSchedulingContext `json:"jobSchedulingContextsByExecutor"`
+}
+
+// WriteSnapshot serialises the contexts stored in the repository to the file at path.
+// The snapshot is first written to a temporary file in the same directory, which is then renamed,
+// such that a crash during writing never leaves a partially written snapshot behind.
+//
+// Job specs and other data not included in reports aren't written to disk.
+func (repo *SchedulingContextRepository) WriteSnapshot(path string) error {
+	bytes, err := json.Marshal(repo.toPersistedState())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(bytes); err != nil {
+		f.Close()
+		return errors.WithStack(err)
+	}
+	if err := f.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// LoadSnapshot replaces the contexts stored in the repository with those of a snapshot previously written by WriteSnapshot.
+// A missing file is not considered an error; the repository is left unchanged.
+// The snapshot is decoded and validated in full before any contexts are stored. Hence, if the file can't be loaded,
+// an error is returned and the repository is left unchanged.
+//
+// Loaded contexts aren't counted, aren't sent to subscribers, and don't cause executors to expire,
+// since they were already handled when first added to the repository that wrote the snapshot.
+// Should be called before the repository is used.
+func (repo *SchedulingContextRepository) LoadSnapshot(path string) error {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+	var state persistedState
+	if err := json.Unmarshal(bytes, &state); err != nil {
+		return errors.Wrapf(err, "failed to decode scheduling context snapshot %s", path)
+	}
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return repo.restorePersistedState(&state)
+}
+
+// restorePersistedState builds the maps of the repository from state off to the side and then swaps them in.
+// As with Clear, each map is swapped atomically; if state is invalid, an error is returned before any map is swapped.
+// Job contexts are stored before queue contexts, which are stored before scheduling contexts,
+// such that no stored context refers to one that hasn't been stored yet.
+//
+// Should only be called with repo.mu held.
+func (repo *SchedulingContextRepository) restorePersistedState(state *persistedState) error {
+	// Adding contexts in the order they were started recreates the most recent maps and the history.
+	sctxs := armadaslices.Filter(state.SchedulingContexts, func(sctx *schedulercontext.SchedulingContext) bool {
+		return sctx != nil
+	})
+	slices.SortStableFunc(sctxs, func(a, b *schedulercontext.SchedulingContext) bool {
+		return a.Started.Before(b.Started)
+	})
+
+	executorIds := make(map[string]bool)
+	mostRecentSchedulingContextByExecutor := make(SchedulingContextByExecutor)
+	mostRecentSuccessfulSchedulingContextByExecutor := make(SchedulingContextByExecutor)
+	mostRecentPreemptingSchedulingContextByExecutor := make(SchedulingContextByExecutor)
+	mostRecentUnsuccessfulSchedulingContextByExecutor := make(SchedulingContextByExecutor)
+	schedulingContextHistoryByExecutor := make(map[string][]*schedulercontext.SchedulingContext)
+	mostRecentQueueSchedulingContextByExecutorByQueue := make(map[string]QueueSchedulingContextByExecutor)
+	mostRecentSuccessfulQueueSchedulingContextByExecutorByQueue := make(map[string]QueueSchedulingContextByExecutor)
+	mostRecentPreemptingQueueSchedulingContextByExecutorByQueue := make(map[string]QueueSchedulingContextByExecutor)
+	queueFairShareHistoryByQueue := make(map[string][]QueueFairShareSample)
+	for _, sctx := range sctxs {
+		if sctx.ExecutorId == "" {
+			return errors.WithStack(&armadaerrors.ErrInvalidArgument{
+				Name:    "ExecutorId",
+				Value:   "",
+				Message: "received empty executorId",
+			})
+		}
+		executorIds[sctx.ExecutorId] = true
+		mostRecentSchedulingContextByExecutor[sctx.ExecutorId] = sctx
+		if !sctx.ScheduledResourcesByPriority.IsZero() {
+			mostRecentSuccessfulSchedulingContextByExecutor[sctx.ExecutorId] = sctx
+		} else {
+			mostRecentUnsuccessfulSchedulingContextByExecutor[sctx.ExecutorId] = sctx
+		}
+		if !sctx.EvictedResourcesByPriority.IsZero() {
+			mostRecentPreemptingSchedulingContextByExecutor[sctx.ExecutorId] = sctx
+		}
+		if repo.historyLength > 0 {
+			history := append(schedulingContextHistoryByExecutor[sctx.ExecutorId], sctx)
+			if uint(len(history)) > repo.historyLength {
+				history = history[uint(len(history))-repo.historyLength:]
+			}
+			schedulingContextHistoryByExecutor[sctx.ExecutorId] = history
+		}
+
+		for _, qctx := range sctx.QueueSchedulingContexts {
+			if qctx.ExecutorId == "" {
+				return errors.WithStack(&armadaerrors.ErrInvalidArgument{
+					Name:    "ExecutorId",
+					Value:   "",
+					Message: "received empty executorId",
+				})
+			}
+			if qctx.Queue == "" {
+				return errors.WithStack(&armadaerrors.ErrInvalidArgument{
+					Name:    "Queue",
+					Value:   "",
+					Message: "received empty queue name",
+				})
+			}
+			qctx.SchedulingContext = sctx
+			setQueueSchedulingContext(mostRecentQueueSchedulingContextByExecutorByQueue, qctx)
+			if !qctx.ScheduledResourcesByPriority.IsZero() {
+				setQueueSchedulingContext(mostRecentSuccessfulQueueSchedulingContextByExecutorByQueue, qctx)
+			}
+			if !qctx.EvictedResourcesByPriority.IsZero() {
+				setQueueSchedulingContext(mostRecentPreemptingQueueSchedulingContextByExecutorByQueue, qctx)
+			}
+			if repo.queueFairShareHistoryLength > 0 {
+				queueFairShareHistoryByQueue[qctx.Queue] = repo.appendQueueFairShareSample(
+					queueFairShareHistoryByQueue[qctx.Queue],
+					repo.queueFairShareSample(qctx),
+				)
+			}
+		}
+	}
+
+	// Adding job contexts in order of use last restores the order of the LRU caches.
+	jobSchedulingContextCacheByExecutor := make(map[string]*lru.Cache, len(state.JobSchedulingContextsByExecutor))
+	for executorId, jctxs := range state.JobSchedulingContextsByExecutor {
+		if executorId == "" {
+			return errors.WithStack(&armadaerrors.ErrInvalidArgument{
+				Name:    "ExecutorId",
+				Value:   "",
+				Message: "received empty executorId",
+			})
+		}
+		jctxs = armadaslices.Filter(jctxs, func(jctx *schedulercontext.JobSchedulingContext) bool {
+			return jctx != nil && jctx.JobId != ""
+		})
+		// Only the most recently used contexts that fit are added, such that loading doesn't count as evictions.
+		if n := repo.maxJobSchedulingContextsForExecutor(executorId); len(jctxs) > n {
+			jctxs = jctxs[len(jctxs)-n:]
+		}
+		cache, err := repo.newJobSchedulingContextCache(executorId)
+		if err != nil {
+			return err
+		}
+		for _, jctx := range jctxs {
+			jctx.ExecutorId = executorId
+			cache.Add(jctx.JobId, jctx)
+		}
+		jobSchedulingContextCacheByExecutor[executorId] = cache
+	}
+
+	repo.jobSchedulingContextCacheByExecutorP.Store(&jobSchedulingContextCacheByExecutor)
+	repo.mostRecentQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentQueueSchedulingContextByExecutorByQueue)
+	repo.mostRecentSuccessfulQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentSuccessfulQueueSchedulingContextByExecutorByQueue)
+	repo.mostRecentPreemptingQueueSchedulingContextByExecutorByQueueP.Store(&mostRecentPreemptingQueueSchedulingContextByExecutorByQueue)
+	repo.queueFairShareHistoryByQueueP.Store(&queueFairShareHistoryByQueue)
+	repo.mostRecentSchedulingContextByExecutorP.Store(&mostRecentSchedulingContextByExecutor)
+	repo.mostRecentSuccessfulSchedulingContextByExecutorP.Store(&mostRecentSuccessfulSchedulingContextByExecutor)
+	repo.mostRecentPreemptingSchedulingContextByExecutorP.Store(&mostRecentPreemptingSchedulingContextByExecutor)
+	repo.mostRecentUnsuccessfulSchedulingContextByExecutorP.Store(&mostRecentUnsuccessfulSchedulingContextByExecutor)
+	repo.schedulingContextHistoryByExecutorP.Store(&schedulingContextHistoryByExecutor)
+	repo.executorIds = executorIds
+	sortedExecutorIds := maps.Keys(executorIds)
+	slices.Sort(sortedExecutorIds)
+	repo.sortedExecutorIdsP.Store(&sortedExecutorIds)
+	return nil
+}
+
+// setQueueSchedulingContext stores qctx in m under its queue and executor.
+// m is mutated in place. Hence, it must not yet be visible to readers.
+func setQueueSchedulingContext(m map[string]QueueSchedulingContextByExecutor, qctx *schedulercontext.QueueSchedulingContext) {
+	queueSchedulingContextByExecutor, ok := m[qctx.Queue]
+	if !ok {
+		queueSchedulingContextByExecutor = make(QueueSchedulingContextByExecutor)
+		m[qctx.Queue] = queueSchedulingContextByExecutor
+	}
+	queueSchedulingContextByExecutor[qctx.ExecutorId] = qctx
+}
+
+// WriteSnapshotBestEffort writes a snapshot to path and logs, rather than returns, any error.
+// Intended to be run periodically in the background.
+func (repo *SchedulingContextRepository) WriteSnapshotBestEffort(path string) {
+	if err := repo.WriteSnapshot(path); err != nil {
+		log.WithError(err).Warnf("failed to write scheduling context snapshot to %s", path)
+	}
+}
+
+// toPersistedState returns copies of all contexts stored in the repository, stripped of any fields that can't be serialised.
+func (repo *SchedulingContextRepository) toPersistedState() *persistedState {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	// Contexts may be stored in several maps; each is only included once.
+	sctxs := make(map[*schedulercontext.SchedulingContext]bool)
+	for _, p := range []*SchedulingContextByExecutor{
+		repo.mostRecentSchedulingContextByExecutorP.Load(),
+		repo.mostRecentSuccessfulSchedulingContextByExecutorP.Load(),
+		repo.mostRecentPreemptingSchedulingContextByExecutorP.Load(),
+		repo.mostRecentUnsuccessfulSchedulingContextByExecutorP.Load(),
+	} {
+		for _, sctx := range *p {
+			sctxs[sctx] = true
+		}
+	}
+	for _, history := range *repo.schedulingContextHistoryByExecutorP.Load() {
+		for _, sctx := range history {
+			sctxs[sctx] = true
+		}
+	}
+	rv := &persistedState{
+		Created:            repo.clock.Now(),
+		SchedulingContexts: make([]*schedulercontext.SchedulingContext, 0, len(sctxs)),
+	}
+	for _, sctx := range maps.Keys(sctxs) {
+		rv.SchedulingContexts = append(rv.SchedulingContexts, persistableSchedulingContext(sctx))
+	}
+	slices.SortStableFunc(rv.SchedulingContexts, func(a, b *schedulercontext.SchedulingContext) bool {
+		return a.Started.Before(b.Started)
+	})
+
+	rv.JobSchedulingContextsByExecutor = make(map[string][]*schedulercontext.JobSchedulingContext)
+	for executorId, cache := range *repo.jobSchedulingContextCacheByExecutorP.Load() {
+		// Keys are returned from oldest to newest.
+		for _, key := range cache.Keys() {
+			value, ok := cache.Peek(key)
+			if !ok {
+				continue
+			}
+			jctx, ok := value.(*schedulercontext.JobSchedulingContext)
+			if !ok || jctx == nil {
+				continue
+			}
+			rv.JobSchedulingContextsByExecutor[executorId] = append(
+				rv.JobSchedulingContextsByExecutor[executorId],
+				persistableJobSchedulingContext(jctx),
+			)
+		}
+	}
+	return rv
+}
+
+// persistableSchedulingContext returns a shallow copy of sctx with the back-pointers from queue contexts
+// and the scheduling key bookkeeping removed, since these can't be serialised.
+func persistableSchedulingContext(sctx *schedulercontext.SchedulingContext) *schedulercontext.SchedulingContext {
+	rv := *sctx
+	rv.SchedulingKeyGenerator = nil
+	rv.UnfeasibleSchedulingKeys = nil
+	rv.QueueSchedulingContexts = make(map[string]*schedulercontext.QueueSchedulingContext, len(sctx.QueueSchedulingContexts))
+	for queue, qctx := range sctx.QueueSchedulingContexts {
+		qctxCopy := *qctx
+		qctxCopy.SchedulingContext = nil
+		qctxCopy.SuccessfulJobSchedulingContexts = persistableJobSchedulingContexts(qctx.SuccessfulJobSchedulingContexts)
+		qctxCopy.UnsuccessfulJobSchedulingContexts = persistableJobSchedulingContexts(qctx.UnsuccessfulJobSchedulingContexts)
+		rv.QueueSchedulingContexts[queue] = &qctxCopy
+	}
+	return &rv
+}
+
+func persistableJobSchedulingContexts(m map[string]*schedulercontext.JobSchedulingContext) map[string]*schedulercontext.JobSchedulingContext {
+	rv := make(map[string]*schedulercontext.JobSchedulingContext, len(m))
+	for k, jctx := range m {
+		if jctx == nil {
+			continue
+		}
+		rv[k] = persistableJobSchedulingContext(jctx)
+	}
+	return rv
+}
+
+// persistableJobSchedulingContext returns a shallow copy of jctx with the job spec removed.
+func persistableJobSchedulingContext(jctx *schedulercontext.JobSchedulingContext) *schedulercontext.JobSchedulingContext {
+	rv := *jctx
+	rv.Job = nil
+	return &rv
+}
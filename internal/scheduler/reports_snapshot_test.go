@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+func TestSchedulingContextRepositorySnapshotRoundTrip(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 2)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+	started := time.Now().Truncate(time.Second)
+
+	sctx := testSchedulingContext("foo")
+	sctx.Started = started
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	sctx = testSchedulingContext("foo")
+	sctx.Started = started.Add(time.Second)
+	sctx = withPreemptingJobSchedulingContext(sctx, "A", "preemptedFooA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	sctx = testSchedulingContext("foo")
+	sctx.Started = started.Add(2 * time.Second)
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "B", "failureFooB")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	sctx = testSchedulingContext("bar")
+	sctx.Started = started.Add(3 * time.Second)
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, repo.WriteSnapshot(path))
+
+	loaded, err := NewSchedulingContextRepository(10, 2)
+	require.NoError(t, err)
+	loaded.SetJobIdValidator(ValidateNonEmptyJobId)
+	require.NoError(t, loaded.LoadSnapshot(path))
+
+	assert.Equal(t, repo.GetSortedExecutorIds(), loaded.GetSortedExecutorIds())
+	assert.Equal(t, sortedKeys(repo.ListTrackedQueues()), sortedKeys(loaded.ListTrackedQueues()))
+	for _, getter := range []func(*SchedulingContextRepository) SchedulingContextByExecutor{
+		(*SchedulingContextRepository).GetMostRecentSchedulingContextByExecutor,
+		(*SchedulingContextRepository).GetMostRecentSuccessfulSchedulingContextByExecutor,
+		(*SchedulingContextRepository).GetMostRecentPreemptingSchedulingContextByExecutor,
+		(*SchedulingContextRepository).GetMostRecentUnsuccessfulSchedulingContextByExecutor,
+	} {
+		expected := getter(repo)
+		actual := getter(loaded)
+		if assert.Equal(t, sortedKeys(maps.Keys(expected)), sortedKeys(maps.Keys(actual))) {
+			for executorId, sctx := range expected {
+				assert.True(t, sctx.Started.Equal(actual[executorId].Started))
+			}
+		}
+	}
+	assert.Len(t, loaded.GetRecentSchedulingContextsByExecutor("foo", 0), 2)
+	for _, jobId := range []string{"successFooA", "failureFooB", "successBarA"} {
+		_, ok := loaded.GetMostRecentJobSchedulingContextByExecutor(jobId)
+		assert.True(t, ok, jobId)
+	}
+	ctx := context.Background()
+	expected, err := repo.getSchedulingReport().ReportString(ctx, 1)
+	require.NoError(t, err)
+	actualReport, err := loaded.getSchedulingReport().ReportString(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actualReport)
+	expected, err = repo.getJobReportString(ctx, "successFooA", schedulerobjects.ReportFormat_TEXT)
+	require.NoError(t, err)
+	actualReport, err = loaded.getJobReportString(ctx, "successFooA", schedulerobjects.ReportFormat_TEXT)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actualReport)
+}
+
+func TestSchedulingContextRepositoryLoadSnapshotMissingFile(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	require.NoError(t, repo.LoadSnapshot(filepath.Join(t.TempDir(), "snapshot.json")))
+	assert.Empty(t, repo.GetMostRecentSchedulingContextByExecutor())
+	assert.Empty(t, repo.GetSortedExecutorIds())
+}
+
+func TestSchedulingContextRepositoryLoadSnapshotCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"schedulingContexts": [{"ExecutorId": "foo"`), 0o600))
+
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	assert.Error(t, repo.LoadSnapshot(path))
+	assert.Empty(t, repo.GetMostRecentSchedulingContextByExecutor())
+	assert.Empty(t, repo.GetSortedExecutorIds())
+
+	// The repository should be usable after failing to load the snapshot.
+	require.NoError(t, repo.AddSchedulingContext(testSchedulingContext("bar")))
+	assert.Equal(t, []string{"bar"}, repo.GetSortedExecutorIds())
+}
+
+func TestSchedulingContextRepositoryLoadSnapshotInvalidContext(t *testing.T) {
+	// The first context is valid, but the second isn't; neither should be loaded.
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"schedulingContexts": [{"ExecutorId": "foo"}, {"ExecutorId": ""}]}`), 0o600))
+
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	require.NoError(t, repo.AddSchedulingContext(testSchedulingContext("bar")))
+	assert.Error(t, repo.LoadSnapshot(path))
+	assert.Equal(t, []string{"bar"}, repo.GetSortedExecutorIds())
+	assert.Equal(t, []string{"bar"}, maps.Keys(repo.GetMostRecentSchedulingContextByExecutor()))
+}
+
+func TestSchedulingContextRepositoryLoadSnapshotDoesNotCountNotifyOrExpire(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+	for _, executorId := range []string{"foo", "bar"} {
+		sctx := testSchedulingContext(executorId)
+		sctx.Started = time.Now().Add(-time.Hour)
+		sctx = withSuccessfulJobSchedulingContext(sctx, "A", executorId+"A")
+		require.NoError(t, repo.AddSchedulingContext(sctx))
+	}
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, repo.WriteSnapshot(path))
+
+	loaded, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	loaded.SetJobIdValidator(ValidateNonEmptyJobId)
+	// Both executors are older than the ttl; neither should be removed on loading.
+	loaded.SetExecutorTtl(time.Minute)
+	c, unsubscribe := loaded.SubscribeToSchedulingContexts(10)
+	defer unsubscribe()
+	require.NoError(t, loaded.LoadSnapshot(path))
+
+	assert.Equal(t, []string{"bar", "foo"}, loaded.GetSortedExecutorIds())
+	for _, jobId := range []string{"fooA", "barA"} {
+		_, ok := loaded.GetMostRecentJobSchedulingContextByExecutor(jobId)
+		assert.True(t, ok, jobId)
+	}
+	counters := loaded.GetCounters()
+	assert.Empty(t, counters.ByExecutor)
+	assert.Empty(t, counters.ByQueue)
+	assert.Len(t, c, 0)
+}
+
+func TestSchedulingContextRepositoryWriteSnapshotOverwrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	require.NoError(t, repo.AddSchedulingContext(testSchedulingContext("foo")))
+	require.NoError(t, repo.WriteSnapshot(path))
+	require.NoError(t, repo.AddSchedulingContext(testSchedulingContext("bar")))
+	require.NoError(t, repo.WriteSnapshot(path))
+
+	loaded, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	require.NoError(t, loaded.LoadSnapshot(path))
+	assert.Equal(t, []string{"bar", "foo"}, loaded.GetSortedExecutorIds())
+
+	// No temporary files should be left behind.
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func sortedKeys(s []string) []string {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
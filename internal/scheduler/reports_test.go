@@ -1,15 +1,30 @@
 package scheduler
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
 
+	"github.com/armadaproject/armada/internal/armada/configuration"
+	"github.com/armadaproject/armada/internal/common/armadaerrors"
+	"github.com/armadaproject/armada/internal/common/util"
 	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
 	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 )
@@ -45,7 +60,7 @@ func TestExtractQueueAndJobContexts(t *testing.T) {
 }
 
 func TestAddGetSchedulingContext(t *testing.T) {
-	repo, err := NewSchedulingContextRepository(10)
+	repo, err := NewSchedulingContextRepository(10, 0)
 	require.NoError(t, err)
 
 	sctx := testSchedulingContext("foo")
@@ -183,11 +198,1487 @@ func TestAddGetSchedulingContext(t *testing.T) {
 		},
 		actualSchedulingContextByExecutor,
 	)
+
+	actualSchedulingContextByExecutor = repo.GetMostRecentUnsuccessfulSchedulingContextByExecutor()
+	assert.Equal(
+		t,
+		SchedulingContextByExecutor{
+			"foo": withUnsuccessfulJobSchedulingContext(testSchedulingContext("foo"), "A", "failureA"),
+			"bar": withUnsuccessfulJobSchedulingContext(testSchedulingContext("bar"), "B", "failureB"),
+			"baz": withPreemptingJobSchedulingContext(testSchedulingContext("baz"), "C", "preempted"),
+		},
+		actualSchedulingContextByExecutor,
+	)
+}
+
+func TestReportsJson(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "failureA")
+	sctx = withPreemptingJobSchedulingContext(sctx, "B", "preempted")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	schedulingReport, err := repo.GetSchedulingReport(ctx, &schedulerobjects.SchedulingReportRequest{Verbosity: 1, Format: schedulerobjects.ReportFormat_JSON})
+	require.NoError(t, err)
+	var actualSchedulingReport schedulingReportJson
+	err = json.Unmarshal([]byte(schedulingReport.Report), &actualSchedulingReport)
+	require.NoError(t, err)
+	require.Len(t, actualSchedulingReport.Executors, 1)
+	executorReport := actualSchedulingReport.Executors[0]
+	assert.Equal(t, "foo", executorReport.ExecutorId)
+	require.NotNil(t, executorReport.MostRecent)
+	require.NotNil(t, executorReport.MostRecentSuccessful)
+	require.NotNil(t, executorReport.MostRecentPreempting)
+	cpu := executorReport.MostRecent.ScheduledResourcesByPriority[0]["cpu"]
+	assert.True(t, cpu.Equal(resource.MustParse("1")))
+	cpu = executorReport.MostRecent.EvictedResourcesByPriority[0]["cpu"]
+	assert.True(t, cpu.Equal(resource.MustParse("1")))
+	require.Contains(t, executorReport.MostRecent.Queues, "A")
+	assert.Equal(t, []string{"successFooA"}, executorReport.MostRecent.Queues["A"].SuccessfulJobIds)
+	assert.Equal(t, map[string]string{"failureA": "unknown"}, executorReport.MostRecent.Queues["A"].UnschedulableReasonByJobId)
+	require.Contains(t, executorReport.MostRecent.Queues, "B")
+	assert.Equal(t, []string{"preempted"}, executorReport.MostRecent.Queues["B"].EvictedJobIds)
+
+	queueReport, err := repo.GetQueueReport(ctx, &schedulerobjects.QueueReportRequest{QueueName: "A", Format: schedulerobjects.ReportFormat_JSON})
+	require.NoError(t, err)
+	var actualQueueReport queueReportJson
+	err = json.Unmarshal([]byte(queueReport.Report), &actualQueueReport)
+	require.NoError(t, err)
+	assert.Equal(t, "A", actualQueueReport.Queue)
+	require.Len(t, actualQueueReport.Executors, 1)
+	require.NotNil(t, actualQueueReport.Executors[0].MostRecent)
+	assert.Equal(t, 1, actualQueueReport.Executors[0].MostRecent.NumSuccessfulJobs)
+	assert.Equal(t, 1, actualQueueReport.Executors[0].MostRecent.NumUnsuccessfulJobs)
+	assert.Nil(t, actualQueueReport.Executors[0].MostRecentPreempting)
+
+	jobId := util.NewULID()
+	sctx = testSchedulingContext("foo")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", jobId)
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+	jobReport, err := repo.GetJobReport(ctx, &schedulerobjects.JobReportRequest{JobId: jobId, Format: schedulerobjects.ReportFormat_JSON})
+	require.NoError(t, err)
+	var actualJobReport jobReportJson
+	err = json.Unmarshal([]byte(jobReport.Report), &actualJobReport)
+	require.NoError(t, err)
+	assert.Equal(t, jobId, actualJobReport.JobId)
+	require.Len(t, actualJobReport.Executors, 1)
+	require.NotNil(t, actualJobReport.Executors[0].MostRecent)
+	assert.Equal(t, "unknown", actualJobReport.Executors[0].MostRecent.UnschedulableReason)
+}
+
+func TestReportsCompressed(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	jobId := util.NewULID()
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", jobId)
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	// Round-trip compressed responses through the wire format.
+	roundTrip := func(t *testing.T, src, dst proto.Message) {
+		t.Helper()
+		b, err := proto.Marshal(src)
+		require.NoError(t, err)
+		require.NoError(t, proto.Unmarshal(b, dst))
+	}
+	decompress := func(t *testing.T, compressedReport []byte) string {
+		t.Helper()
+		r, err := gzip.NewReader(bytes.NewReader(compressedReport))
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(b)
+	}
+	ctx := context.Background()
+	for _, format := range []schedulerobjects.ReportFormat{schedulerobjects.ReportFormat_TEXT, schedulerobjects.ReportFormat_JSON} {
+		t.Run(format.String(), func(t *testing.T) {
+			schedulingReport, err := repo.GetSchedulingReport(ctx, &schedulerobjects.SchedulingReportRequest{Format: format})
+			require.NoError(t, err)
+			require.NotEmpty(t, schedulingReport.Report)
+			assert.Equal(t, schedulerobjects.ReportEncoding_IDENTITY, schedulingReport.Encoding)
+			assert.Empty(t, schedulingReport.CompressedReport)
+			compressed, err := repo.GetSchedulingReport(ctx, &schedulerobjects.SchedulingReportRequest{Format: format, Compress: true})
+			require.NoError(t, err)
+			actualSchedulingReport := &schedulerobjects.SchedulingReport{}
+			roundTrip(t, compressed, actualSchedulingReport)
+			assert.Equal(t, schedulerobjects.ReportEncoding_GZIP, actualSchedulingReport.Encoding)
+			assert.Empty(t, actualSchedulingReport.Report)
+			assert.Equal(t, schedulingReport.Report, decompress(t, actualSchedulingReport.CompressedReport))
+
+			queueReport, err := repo.GetQueueReport(ctx, &schedulerobjects.QueueReportRequest{QueueName: "A", Format: format})
+			require.NoError(t, err)
+			compressedQueueReport, err := repo.GetQueueReport(ctx, &schedulerobjects.QueueReportRequest{QueueName: "A", Format: format, Compress: true})
+			require.NoError(t, err)
+			actualQueueReport := &schedulerobjects.QueueReport{}
+			roundTrip(t, compressedQueueReport, actualQueueReport)
+			assert.Equal(t, schedulerobjects.ReportEncoding_GZIP, actualQueueReport.Encoding)
+			assert.Empty(t, actualQueueReport.Report)
+			assert.Equal(t, queueReport.Report, decompress(t, actualQueueReport.CompressedReport))
+			// The structured per-executor breakdown is unaffected.
+			assert.Len(t, actualQueueReport.Executors, 1)
+
+			jobReport, err := repo.GetJobReport(ctx, &schedulerobjects.JobReportRequest{JobId: jobId, Format: format})
+			require.NoError(t, err)
+			compressedJobReport, err := repo.GetJobReport(ctx, &schedulerobjects.JobReportRequest{JobId: jobId, Format: format, Compress: true})
+			require.NoError(t, err)
+			actualJobReport := &schedulerobjects.JobReport{}
+			roundTrip(t, compressedJobReport, actualJobReport)
+			assert.Equal(t, schedulerobjects.ReportEncoding_GZIP, actualJobReport.Encoding)
+			assert.Empty(t, actualJobReport.Report)
+			assert.Equal(t, jobReport.Report, decompress(t, actualJobReport.CompressedReport))
+		})
+	}
+}
+
+func TestGetSchedulingReportForPool(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	sctx := testSchedulingContext("foo")
+	sctx.Pool = "cpu"
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	sctx = testSchedulingContext("bar")
+	sctx.Pool = "gpu"
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	sr := repo.getSchedulingReportForPool("cpu")
+	assert.Equal(t, []string{"foo"}, sr.sortedExecutorIds)
+	assert.Equal(t, []string{"foo"}, maps.Keys(sr.mostRecentSchedulingContextByExecutor))
+	assert.Equal(t, []string{"foo"}, maps.Keys(sr.mostRecentSuccessfulSchedulingContextByExecutor))
+	assert.Empty(t, sr.mostRecentPreemptingSchedulingContextByExecutor)
+
+	report, err := repo.GetSchedulingReport(
+		context.Background(),
+		&schedulerobjects.SchedulingReportRequest{
+			Filter: &schedulerobjects.SchedulingReportRequest_MostRecentForPool{
+				MostRecentForPool: &schedulerobjects.MostRecentForPool{PoolName: "gpu"},
+			},
+		},
+	)
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "bar:")
+	assert.NotContains(t, report.Report, "foo:")
+
+	sr = repo.getSchedulingReportForPool("doesNotExist")
+	assert.Empty(t, sr.sortedExecutorIds)
+	reportString, err := sr.ReportString(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, reportString)
+}
+
+func TestGetRecentSchedulingContextsByExecutor(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 3)
+	require.NoError(t, err)
+	assert.Empty(t, repo.GetRecentSchedulingContextsByExecutor("foo", 0))
+
+	sctxs := make([]*schedulercontext.SchedulingContext, 5)
+	for i := range sctxs {
+		sctxs[i] = withSuccessfulJobSchedulingContext(testSchedulingContext("foo"), "A", util.NewULID())
+		err := repo.AddSchedulingContext(sctxs[i])
+		require.NoError(t, err)
+	}
+	err = repo.AddSchedulingContext(testSchedulingContext("bar"))
+	require.NoError(t, err)
+
+	// Only the historyLength most recent contexts are kept, most recent first.
+	assert.Equal(t, []*schedulercontext.SchedulingContext{sctxs[4], sctxs[3], sctxs[2]}, repo.GetRecentSchedulingContextsByExecutor("foo", 0))
+	assert.Equal(t, []*schedulercontext.SchedulingContext{sctxs[4], sctxs[3]}, repo.GetRecentSchedulingContextsByExecutor("foo", 2))
+	assert.Equal(t, []*schedulercontext.SchedulingContext{sctxs[4], sctxs[3], sctxs[2]}, repo.GetRecentSchedulingContextsByExecutor("foo", 10))
+	assert.Len(t, repo.GetRecentSchedulingContextsByExecutor("bar", 0), 1)
+	assert.Empty(t, repo.GetRecentSchedulingContextsByExecutor("baz", 0))
+
+	report, err := repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{History: 2})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "2 most recent attempts:")
+	assert.Contains(t, report.Report, "1 most recent attempts:")
+
+	report, err = repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{})
+	require.NoError(t, err)
+	assert.NotContains(t, report.Report, "most recent attempts:")
+
+	// Storing history is disabled if historyLength is zero.
+	repo, err = NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	err = repo.AddSchedulingContext(testSchedulingContext("foo"))
+	require.NoError(t, err)
+	assert.Empty(t, repo.GetRecentSchedulingContextsByExecutor("foo", 0))
+}
+
+func TestSchedulingContextRepositoryStats(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, SchedulingContextRepositoryStats{MaxJobSchedulingContexts: 2, MaxJobSchedulingContextsByExecutor: map[string]int{}}, repo.Stats())
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "B", "failureFooB")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	sctx = testSchedulingContext("bar")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		SchedulingContextRepositoryStats{
+			NumJobSchedulingContexts:           3,
+			MaxJobSchedulingContexts:           2,
+			MaxJobSchedulingContextsByExecutor: map[string]int{"foo": 2, "bar": 2},
+			NumJobSchedulingContextEvictions:   0,
+			NumQueues:                          2,
+			NumExecutors:                       2,
+		},
+		repo.Stats(),
+	)
+	// 6 metrics for the repository as a whole, plus 6 counters for each of the 2 executors and 2 queues.
+	assert.Equal(t, 6+6*2+6*2, testutil.CollectAndCount(repo))
+
+	sctx = testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA2")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, repo.Stats().NumJobSchedulingContexts)
+	assert.Equal(t, uint64(1), repo.Stats().NumJobSchedulingContextEvictions)
+}
+
+func TestSchedulingContextRepositoryMaxJobSchedulingContextsByExecutor(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(1, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+	assert.Error(t, repo.SetMaxJobSchedulingContextsByExecutor(map[string]uint{"foo": 0}))
+	require.NoError(t, repo.SetMaxJobSchedulingContextsByExecutor(map[string]uint{"foo": 2}))
+
+	// Add one job per attempt, such that the order in which jobs are added is deterministic.
+	for _, executorId := range []string{"foo", "bar"} {
+		for _, jobId := range []string{executorId + "1", executorId + "2"} {
+			require.NoError(t, repo.AddSchedulingContext(withSuccessfulJobSchedulingContext(testSchedulingContext(executorId), "A", jobId)))
+		}
+	}
+
+	// The override applies to foo only; bar uses the default capacity.
+	for jobId, expected := range map[string]bool{"foo1": true, "foo2": true, "bar1": false, "bar2": true} {
+		_, ok := repo.GetMostRecentJobSchedulingContextByExecutor(jobId)
+		assert.Equal(t, expected, ok, jobId)
+	}
+	stats := repo.Stats()
+	assert.Equal(t, 3, stats.NumJobSchedulingContexts)
+	assert.Equal(t, 1, stats.MaxJobSchedulingContexts)
+	assert.Equal(t, map[string]int{"foo": 2, "bar": 1}, stats.MaxJobSchedulingContextsByExecutor)
+}
+
+func TestSchedulingContextRepositoryIngestionBuffer(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+	repo.SetIngestionBufferSize(2)
+
+	// Adding doesn't wait for the lock, since contexts are only buffered.
+	// Contexts provided once the buffer is full are discarded.
+	repo.mu.Lock()
+	for i, executorId := range []string{"foo", "bar", "baz"} {
+		sctx := withSuccessfulJobSchedulingContext(testSchedulingContext(executorId), "A", fmt.Sprintf("job%d", i))
+		require.NoError(t, repo.AddSchedulingContext(sctx))
+	}
+	repo.mu.Unlock()
+	assert.Equal(t, uint64(1), repo.Stats().NumDroppedSchedulingContexts)
+	_, ok := repo.GetSchedulingContextForExecutor("foo")
+	assert.False(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- repo.Run(ctx) }()
+	require.Eventually(
+		t,
+		func() bool { return repo.Stats().NumExecutors == 2 },
+		5*time.Second,
+		time.Millisecond,
+	)
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.Equal(t, []string{"bar", "foo"}, repo.GetSortedExecutorIds())
+	_, ok = repo.GetMostRecentJobSchedulingContextByExecutor("job0")
+	assert.True(t, ok)
+	_, ok = repo.GetMostRecentJobSchedulingContextByExecutor("job2")
+	assert.False(t, ok)
+	assert.Equal(t, uint64(1), repo.Stats().NumDroppedSchedulingContexts)
+}
+
+func TestSchedulingContextRepositoryRunWithoutIngestionBuffer(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	// Returns immediately, since contexts are added synchronously.
+	require.NoError(t, repo.Run(context.Background()))
+	require.NoError(t, repo.AddSchedulingContext(testSchedulingContext("foo")))
+	_, ok := repo.GetSchedulingContextForExecutor("foo")
+	assert.True(t, ok)
+}
+
+func TestGetSchedulingReportForJobExcludeEvicted(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	sctx := testSchedulingContext("foo")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "job")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	sctx = testSchedulingContext("bar")
+	sctx = withPreemptingJobSchedulingContext(sctx, "A", "job")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	sr := repo.getSchedulingReportForJob("job", false)
+	assert.ElementsMatch(t, []string{"bar", "foo"}, maps.Keys(sr.mostRecentSchedulingContextByExecutor))
+	assert.ElementsMatch(t, []string{"bar"}, maps.Keys(sr.mostRecentPreemptingSchedulingContextByExecutor))
+
+	sr = repo.getSchedulingReportForJob("job", true)
+	assert.ElementsMatch(t, []string{"foo"}, maps.Keys(sr.mostRecentSchedulingContextByExecutor))
+	assert.Empty(t, sr.mostRecentPreemptingSchedulingContextByExecutor)
+
+	_, err = repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{
+		Filter: &schedulerobjects.SchedulingReportRequest_MostRecentForJob{
+			MostRecentForJob: &schedulerobjects.MostRecentForJob{JobId: "job", ExcludeEvicted: true},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestSchedulingContextRepositorySnapshot(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "B", "failureFooB")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	sctx = testSchedulingContext("bar")
+	sctx = withPreemptingJobSchedulingContext(sctx, "A", "preemptedBarA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "failureBarA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	snapshot := repo.Snapshot()
+
+	// Contexts added after taking the snapshot aren't included in it.
+	sctx = testSchedulingContext("baz")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "C", "successBazC")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	sctx = testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA2")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	assert.Equal(t, []string{"bar", "foo"}, snapshot.ExecutorIds)
+	assert.ElementsMatch(t, []string{"bar", "foo"}, maps.Keys(snapshot.MostRecentSchedulingContextByExecutor))
+	assert.ElementsMatch(t, []string{"foo"}, maps.Keys(snapshot.MostRecentSuccessfulSchedulingContextByExecutor))
+	assert.ElementsMatch(t, []string{"bar"}, maps.Keys(snapshot.MostRecentPreemptingSchedulingContextByExecutor))
+	assert.ElementsMatch(t, []string{"A", "B"}, maps.Keys(snapshot.MostRecentQueueSchedulingContextByExecutorByQueue))
+	assert.ElementsMatch(t, []string{"A"}, maps.Keys(snapshot.MostRecentPreemptingQueueSchedulingContextByExecutorByQueue))
+	assert.ElementsMatch(t, []string{"bar", "foo"}, maps.Keys(snapshot.JobSchedulingContextByJobIdByExecutor))
+	assert.ElementsMatch(t, []string{"successFooA", "failureFooB"}, maps.Keys(snapshot.JobSchedulingContextByJobIdByExecutor["foo"]))
+	assert.ElementsMatch(t, []string{"failureBarA"}, maps.Keys(snapshot.JobSchedulingContextByJobIdByExecutor["bar"]))
+
+	// The repository itself does reflect the later additions.
+	assert.Equal(t, []string{"bar", "baz", "foo"}, repo.Snapshot().ExecutorIds)
+
+	s, err := snapshot.ReportJson(0)
+	require.NoError(t, err)
+	var report snapshotJson
+	require.NoError(t, json.Unmarshal([]byte(s), &report))
+	if assert.Len(t, report.Executors, 2) {
+		assert.Equal(t, "bar", report.Executors[0].ExecutorId)
+		assert.Equal(t, "foo", report.Executors[1].ExecutorId)
+		assert.NotNil(t, report.Executors[1].MostRecentSuccessful)
+	}
+	if assert.Len(t, report.Queues, 2) {
+		assert.Equal(t, "A", report.Queues[0].Queue)
+		assert.Len(t, report.Queues[0].Executors, 2)
+		assert.Equal(t, "B", report.Queues[1].Queue)
+		assert.Len(t, report.Queues[1].Executors, 1)
+	}
+	if assert.Len(t, report.Jobs, 2) {
+		assert.Equal(t, "foo", report.Jobs[1].ExecutorId)
+		if assert.Len(t, report.Jobs[1].Jobs, 2) {
+			assert.Equal(t, "failureFooB", report.Jobs[1].Jobs[0].JobId)
+			assert.Equal(t, "successFooA", report.Jobs[1].Jobs[1].JobId)
+		}
+	}
+}
+
+func TestJobSchedulingContextCachePerExecutor(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(2, 0)
+	require.NoError(t, err)
+
+	sctx := testSchedulingContext("quiet")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "shared")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "quietA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	// Jobs of a busy executor shouldn't evict those of the quiet executor.
+	for i := 0; i < 5; i++ {
+		sctx = testSchedulingContext("busy")
+		sctx = withSuccessfulJobSchedulingContext(sctx, "A", fmt.Sprintf("busy%d", i))
+		err = repo.AddSchedulingContext(sctx)
+		require.NoError(t, err)
+	}
+	jobSchedulingContextByExecutor, ok := repo.GetMostRecentJobSchedulingContextByExecutor("quietA")
+	require.True(t, ok)
+	assert.Equal(t, []string{"quiet"}, maps.Keys(jobSchedulingContextByExecutor))
+	_, ok = repo.GetMostRecentJobSchedulingContextByExecutor("busy0")
+	assert.False(t, ok)
+	_, ok = repo.GetMostRecentJobSchedulingContextByExecutor("busy4")
+	assert.True(t, ok)
+
+	// Contexts of the same job stored by different executors are merged.
+	sctx = testSchedulingContext("busy")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "shared")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+	jobSchedulingContextByExecutor, ok = repo.GetMostRecentJobSchedulingContextByExecutor("shared")
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"busy", "quiet"}, maps.Keys(jobSchedulingContextByExecutor))
+	assert.Equal(t, "busy", jobSchedulingContextByExecutor["busy"].ExecutorId)
+	assert.Equal(t, "unknown", jobSchedulingContextByExecutor["busy"].UnschedulableReason)
+	assert.Empty(t, jobSchedulingContextByExecutor["quiet"].UnschedulableReason)
+}
+
+func TestSchedulingContextRepositoryForgetJob(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+	require.NoError(t, repo.AddSchedulingContext(withSuccessfulJobSchedulingContext(testSchedulingContext("foo"), "A", "job")))
+	require.NoError(t, repo.AddSchedulingContext(withUnsuccessfulJobSchedulingContext(testSchedulingContext("bar"), "A", "job")))
+	require.NoError(t, repo.AddSchedulingContext(withSuccessfulJobSchedulingContext(testSchedulingContext("bar"), "A", "otherJob")))
+
+	assert.False(t, repo.ForgetJob("missingJob"))
+	assert.True(t, repo.ForgetJob("job"))
+	_, ok := repo.GetMostRecentJobSchedulingContextByExecutor("job")
+	assert.False(t, ok)
+	assert.False(t, repo.ForgetJob("job"))
+
+	// Other jobs are unaffected and removals aren't counted as evictions.
+	_, ok = repo.GetMostRecentJobSchedulingContextByExecutor("otherJob")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), repo.Stats().NumJobSchedulingContextEvictions)
+}
+
+func TestListTrackedQueues(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, repo.ListTrackedQueues())
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "B", "successFooB")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "failureFooA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	sctx = testSchedulingContext("bar")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "C", "successBarC")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"A", "B", "C"}, repo.ListTrackedQueues())
+	queues, err := repo.GetQueues(context.Background(), &schedulerobjects.QueuesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", "C"}, queues.QueueNames)
+}
+
+func TestForEachQueueContext(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.ForEachQueueContext(func(_, _ string, _ *schedulercontext.QueueSchedulingContext) bool {
+		t.Fatal("unexpected call on empty repository")
+		return true
+	})
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "B", "successFooB")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	sctx = testSchedulingContext("bar")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	visited := make(map[string]bool)
+	repo.ForEachQueueContext(func(queue, executor string, qctx *schedulercontext.QueueSchedulingContext) bool {
+		assert.Equal(t, queue, qctx.Queue)
+		assert.Equal(t, executor, qctx.ExecutorId)
+		visited[queue+"/"+executor] = true
+		return true
+	})
+	assert.Equal(t, map[string]bool{"A/foo": true, "B/foo": true, "A/bar": true}, visited)
+
+	n := 0
+	repo.ForEachQueueContext(func(_, _ string, _ *schedulercontext.QueueSchedulingContext) bool {
+		n++
+		return false
+	})
+	assert.Equal(t, 1, n)
+}
+
+func TestGetJobSchedulingContextsByExecutorInWindow(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 10)
+	require.NoError(t, err)
+	jobId := util.NewULID()
+	t0 := time.Now()
+	for i, executorId := range []string{"foo", "bar", "foo"} {
+		sctx := withUnsuccessfulJobSchedulingContext(testSchedulingContext(executorId), "A", jobId)
+		sctx.QueueSchedulingContexts["A"].UnsuccessfulJobSchedulingContexts[jobId].Created = t0.Add(time.Duration(i) * time.Second)
+		err := repo.AddSchedulingContext(sctx)
+		require.NoError(t, err)
+	}
+
+	jctxsByExecutor := repo.GetJobSchedulingContextsByExecutorInWindow(jobId, time.Time{}, time.Time{})
+	assert.Len(t, jctxsByExecutor["bar"], 1)
+	if assert.Len(t, jctxsByExecutor["foo"], 2) {
+		assert.Equal(t, t0, jctxsByExecutor["foo"][0].Created)
+		assert.Equal(t, t0.Add(2*time.Second), jctxsByExecutor["foo"][1].Created)
+	}
+
+	jctxsByExecutor = repo.GetJobSchedulingContextsByExecutorInWindow(jobId, t0.Add(time.Second), t0.Add(2*time.Second))
+	assert.Len(t, jctxsByExecutor["bar"], 1)
+	assert.Empty(t, jctxsByExecutor["foo"])
+
+	since := t0.Add(time.Second)
+	report, err := repo.GetJobReport(context.Background(), &schedulerobjects.JobReportRequest{JobId: jobId, Since: &since})
+	require.NoError(t, err)
+	assert.NotContains(t, report.Report, "no attempts in window")
+
+	until := t0
+	report, err = repo.GetJobReport(context.Background(), &schedulerobjects.JobReportRequest{JobId: jobId, Until: &until})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "foo: no attempts in window")
+
+	_, err = repo.GetJobReport(context.Background(), &schedulerobjects.JobReportRequest{JobId: jobId, Since: &since, Until: &until})
+	assert.Error(t, err)
+}
+
+func TestGetQueueReportSummaries(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "failureFooA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	sctx = testSchedulingContext("bar")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "failureBarA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	report, err := repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, report.Report)
+	require.Len(t, report.Executors, 2)
+
+	bar := report.Executors[0]
+	assert.Equal(t, "bar", bar.ExecutorId)
+	if assert.NotNil(t, bar.MostRecent) {
+		assert.Equal(t, int32(0), bar.MostRecent.NumSuccessfulJobSchedulingContexts)
+		assert.Equal(t, int32(1), bar.MostRecent.NumUnsuccessfulJobSchedulingContexts)
+	}
+	assert.Nil(t, bar.MostRecentSuccessful)
+	assert.Nil(t, bar.MostRecentPreempting)
+
+	foo := report.Executors[1]
+	assert.Equal(t, "foo", foo.ExecutorId)
+	if assert.NotNil(t, foo.MostRecentSuccessful) {
+		assert.Equal(t, int32(1), foo.MostRecentSuccessful.NumSuccessfulJobSchedulingContexts)
+		assert.Equal(t, int32(1), foo.MostRecentSuccessful.NumUnsuccessfulJobSchedulingContexts)
+		assert.True(
+			t,
+			schedulerobjects.QuantityByPriorityAndResourceType(foo.MostRecentSuccessful.ScheduledResourcesByPriority).Equal(
+				schedulerobjects.QuantityByPriorityAndResourceType{
+					0: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+				},
+			),
+		)
+	}
+}
+
+func TestGetJobReportJobIdValidation(t *testing.T) {
+	tests := map[string]struct {
+		validator JobIdValidator
+		jobId     string
+		expectErr bool
+	}{
+		"default accepts ulid": {
+			jobId: util.NewULID(),
+		},
+		"default rejects uuid": {
+			jobId:     uuid.NewString(),
+			expectErr: true,
+		},
+		"uuid validator accepts uuid": {
+			validator: ValidateUuidJobId,
+			jobId:     uuid.NewString(),
+		},
+		"uuid validator rejects ulid": {
+			validator: ValidateUuidJobId,
+			jobId:     util.NewULID(),
+			expectErr: true,
+		},
+		"non-empty validator accepts free-form id": {
+			validator: ValidateNonEmptyJobId,
+			jobId:     "my-job",
+		},
+		"non-empty validator rejects empty id": {
+			validator: ValidateNonEmptyJobId,
+			jobId:     " ",
+			expectErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo, err := NewSchedulingContextRepository(10, 0)
+			require.NoError(t, err)
+			if tc.validator != nil {
+				repo.SetJobIdValidator(tc.validator)
+			}
+			if !tc.expectErr {
+				err = repo.AddSchedulingContext(withSuccessfulJobSchedulingContext(testSchedulingContext("foo"), "A", tc.jobId))
+				require.NoError(t, err)
+			}
+
+			report, err := repo.GetJobReport(context.Background(), &schedulerobjects.JobReportRequest{JobId: tc.jobId})
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotContains(t, report.Report, "no recent attempt")
+		})
+	}
+}
+
+func TestGetExecutorSchedulingContext(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	_, ok := repo.GetSchedulingContextForExecutor("foo")
+	assert.False(t, ok)
+	_, err = repo.GetExecutorSchedulingContext(context.Background(), &schedulerobjects.ExecutorSchedulingContextRequest{ExecutorId: "foo"})
+	assert.Error(t, err)
+
+	sctx := testSchedulingContext("foo")
+	sctx.Pool = "cpu"
+	sctx.TerminationReason = "no remaining candidate jobs"
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "B", "failureFooB")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	actual, ok := repo.GetSchedulingContextForExecutor("foo")
+	require.True(t, ok)
+	assert.Same(t, sctx, actual)
+
+	executorSchedulingContext, err := repo.GetExecutorSchedulingContext(context.Background(), &schedulerobjects.ExecutorSchedulingContextRequest{ExecutorId: "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, "foo", executorSchedulingContext.ExecutorId)
+	assert.Equal(t, "cpu", executorSchedulingContext.Pool)
+	assert.Equal(t, "no remaining candidate jobs", executorSchedulingContext.TerminationReason)
+	assert.True(t, sctx.ScheduledResourcesByPriority.Equal(executorSchedulingContext.ScheduledResourcesByPriority))
+	assert.ElementsMatch(t, []string{"A", "B"}, maps.Keys(executorSchedulingContext.QueueSchedulingSummaries))
+	assert.Equal(t, int32(1), executorSchedulingContext.QueueSchedulingSummaries["A"].NumSuccessfulJobSchedulingContexts)
+	assert.Equal(t, int32(1), executorSchedulingContext.QueueSchedulingSummaries["B"].NumUnsuccessfulJobSchedulingContexts)
+}
+
+func TestGetJobSchedulingSummaries(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+
+	_, err = repo.GetJobSchedulingSummaries(context.Background(), &schedulerobjects.JobSchedulingSummariesRequest{JobId: " "})
+	assert.Error(t, err)
+
+	summaries, err := repo.GetJobSchedulingSummaries(context.Background(), &schedulerobjects.JobSchedulingSummariesRequest{JobId: "job"})
+	require.NoError(t, err)
+	assert.Empty(t, summaries.JobSchedulingSummaries)
+
+	sctx := testSchedulingContext("foo")
+	sctx.Pool = "cpu"
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "job")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	sctx = testSchedulingContext("bar")
+	sctx.Pool = "gpu"
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "job")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	summaries, err = repo.GetJobSchedulingSummaries(context.Background(), &schedulerobjects.JobSchedulingSummariesRequest{JobId: " job "})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]*schedulerobjects.JobSchedulingSummary{
+			{
+				ExecutorId:          "bar",
+				Pool:                "gpu",
+				JobId:               "job",
+				Scheduled:           false,
+				UnschedulableReason: "unknown",
+			},
+			{
+				ExecutorId: "foo",
+				Pool:       "cpu",
+				JobId:      "job",
+				Scheduled:  true,
+			},
+		},
+		summaries.JobSchedulingSummaries,
+	)
+}
+
+func TestSchedulingReportExecutorPattern(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 1)
+	require.NoError(t, err)
+	for _, executorId := range []string{"cluster-1", "cluster-2", "other"} {
+		err = repo.AddSchedulingContext(testSchedulingContext(executorId))
+		require.NoError(t, err)
+	}
+
+	tests := map[string]struct {
+		pattern         string
+		isRegex         bool
+		expectedMatches []string
+	}{
+		"empty pattern": {
+			expectedMatches: []string{"cluster-1", "cluster-2", "other"},
+		},
+		"glob": {
+			pattern:         "cluster-*",
+			expectedMatches: []string{"cluster-1", "cluster-2"},
+		},
+		"glob matches entire id": {
+			pattern:         "cluster",
+			expectedMatches: []string{},
+		},
+		"regex": {
+			pattern:         "^(cluster-2|oth)",
+			isRegex:         true,
+			expectedMatches: []string{"cluster-2", "other"},
+		},
+		"regex matches any part of id": {
+			pattern:         "[0-9]",
+			isRegex:         true,
+			expectedMatches: []string{"cluster-1", "cluster-2"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, history := range []uint32{0, 1} {
+				report, err := repo.GetSchedulingReport(
+					context.Background(),
+					&schedulerobjects.SchedulingReportRequest{
+						ExecutorPattern:        tc.pattern,
+						ExecutorPatternIsRegex: tc.isRegex,
+						History:                history,
+						Format:                 schedulerobjects.ReportFormat_JSON,
+					},
+				)
+				require.NoError(t, err)
+				var actual schedulingReportJson
+				require.NoError(t, json.Unmarshal([]byte(report.Report), &actual))
+				actualMatches := make([]string, len(actual.Executors))
+				for i, executor := range actual.Executors {
+					actualMatches[i] = executor.ExecutorId
+				}
+				assert.Equal(t, tc.expectedMatches, actualMatches)
+			}
+		})
+	}
+
+	_, err = repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{ExecutorPattern: "cluster-["})
+	assert.ErrorAs(t, err, new(*armadaerrors.ErrInvalidArgument))
+	_, err = repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{ExecutorPattern: "cluster-(", ExecutorPatternIsRegex: true})
+	assert.ErrorAs(t, err, new(*armadaerrors.ErrInvalidArgument))
+}
+
+func TestSchedulingReportMinResources(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+	sctx := testSchedulingContext("big")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "bigA")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "B", "bigB1")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "B", "bigB2")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	sctx = testSchedulingContext("small")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "smallA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	minResources := schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}}
+
+	report, err := repo.GetSchedulingReport(
+		context.Background(),
+		&schedulerobjects.SchedulingReportRequest{
+			MinResources: minResources,
+			Format:       schedulerobjects.ReportFormat_JSON,
+		},
+	)
+	require.NoError(t, err)
+	var actual schedulingReportJson
+	require.NoError(t, json.Unmarshal([]byte(report.Report), &actual))
+	require.Len(t, actual.Executors, 1)
+	assert.Equal(t, "big", actual.Executors[0].ExecutorId)
+	assert.Equal(t, 1, actual.NumExecutorsBelowMinResources)
+	assert.ElementsMatch(t, []string{"B"}, maps.Keys(actual.Executors[0].MostRecent.Queues))
+	assert.Equal(t, 1, actual.Executors[0].MostRecent.NumQueuesBelowMinResources)
+
+	report, err = repo.GetSchedulingReport(
+		context.Background(),
+		&schedulerobjects.SchedulingReportRequest{
+			MinResources: minResources,
+			Verbosity:    1,
+		},
+	)
+	require.NoError(t, err)
+	assert.NotContains(t, report.Report, "small")
+	assert.Contains(t, report.Report, "B:")
+	assert.NotContains(t, report.Report, "A:")
+	assert.Regexp(t, `Queues below minimum resources: +1`, report.Report)
+	assert.Regexp(t, `Executors below minimum resources: +1`, report.Report)
+
+	// Without a minimum, all executors and queues are included.
+	report, err = repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{Verbosity: 1})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "small")
+	assert.Contains(t, report.Report, "A:")
+	assert.NotContains(t, report.Report, "below minimum resources")
+}
+
+func TestSchedulingReportFairnessSummary(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	sctx := testSchedulingContext("foo")
+	sctx.ResourceScarcity = map[string]float64{"cpu": 1}
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "B", "failureFooB")
+	for _, qctx := range sctx.QueueSchedulingContexts {
+		qctx.SchedulingContext = sctx
+	}
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	sctx = testSchedulingContext("bar")
+	sctx.ResourceScarcity = map[string]float64{"cpu": 1}
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA1")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA2")
+	for _, qctx := range sctx.QueueSchedulingContexts {
+		qctx.SchedulingContext = sctx
+	}
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	// foo scheduled 1 cpu to A, with A and B each entitled to half; bar scheduled 2 cpu to A, which is entitled to all of it.
+	summary := repo.getSchedulingReport().fairnessSummary()
+	if assert.Len(t, summary, 2) {
+		assert.Equal(t, "A", summary[0].Queue)
+		assert.InDelta(t, 1.0, summary[0].ActualShare, 1e-9)
+		assert.InDelta(t, 5.0/6.0, summary[0].TargetShare, 1e-9)
+		assert.Equal(t, "B", summary[1].Queue)
+		assert.InDelta(t, 0.0, summary[1].ActualShare, 1e-9)
+		assert.InDelta(t, 1.0/6.0, summary[1].TargetShare, 1e-9)
+	}
+
+	// The summary is only included at higher verbosity levels.
+	report, err := repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{Verbosity: schedulerobjects.ReportVerbosity(fairnessSummaryMinVerbosity - 1)})
+	require.NoError(t, err)
+	assert.NotContains(t, report.Report, "Fairness summary")
+	report, err = repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{Verbosity: schedulerobjects.ReportVerbosity(fairnessSummaryMinVerbosity)})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "Fairness summary")
+
+	report, err = repo.GetSchedulingReport(
+		context.Background(),
+		&schedulerobjects.SchedulingReportRequest{Verbosity: schedulerobjects.ReportVerbosity(fairnessSummaryMinVerbosity), Format: schedulerobjects.ReportFormat_JSON},
+	)
+	require.NoError(t, err)
+	var actual schedulingReportJson
+	require.NoError(t, json.Unmarshal([]byte(report.Report), &actual))
+	assert.Len(t, actual.FairnessSummary, 2)
+}
+
+func TestSchedulingReportPriorityClassSummary(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetPriorityClasses(map[string]configuration.PriorityClass{
+		"armada-default":     {Priority: 1},
+		"armada-preemptible": {Priority: 1},
+		"armada-urgent":      {Priority: 10},
+	})
+
+	sctx := testSchedulingContext("foo")
+	sctx.ScheduledResourcesByPriority = schedulerobjects.QuantityByPriorityAndResourceType{
+		1:  schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}},
+		10: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("2"), "memory": resource.MustParse("1Gi")}},
+	}
+	sctx.EvictedResourcesByPriority = schedulerobjects.QuantityByPriorityAndResourceType{
+		1: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("3")}},
+		5: schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("4")}},
+	}
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	summary := repo.getSchedulingReport().priorityClassSummary(sctx)
+	if assert.Len(t, summary, 3) {
+		assert.Equal(t, "armada-urgent", summary[0].priorityClassName)
+		assert.Equal(t, "{cpu: 2, memory: 1Gi}", sortedResourceListString(summary[0].scheduled))
+		assert.Equal(t, "{}", sortedResourceListString(summary[0].evicted))
+		// Priorities not matching any priority class are reported as-is.
+		assert.Equal(t, "5", summary[1].priorityClassName)
+		assert.Equal(t, "{}", sortedResourceListString(summary[1].scheduled))
+		assert.Equal(t, "{cpu: 4}", sortedResourceListString(summary[1].evicted))
+		assert.Equal(t, "armada-default|armada-preemptible", summary[2].priorityClassName)
+		assert.Equal(t, "{cpu: 1}", sortedResourceListString(summary[2].scheduled))
+		assert.Equal(t, "{cpu: 3}", sortedResourceListString(summary[2].evicted))
+	}
+
+	// The summary is only included at higher verbosity levels.
+	report, err := repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{Verbosity: schedulerobjects.ReportVerbosity(priorityClassSummaryMinVerbosity - 1)})
+	require.NoError(t, err)
+	assert.NotContains(t, report.Report, "Resources by priority class")
+	report, err = repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{Verbosity: schedulerobjects.ReportVerbosity(priorityClassSummaryMinVerbosity)})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "Resources by priority class")
+	assert.Contains(t, report.Report, "scheduled {cpu: 2, memory: 1Gi}, preempted {}")
+}
+
+func TestSchedulingReportDrainingExecutors(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	for _, executorId := range []string{"foo", "bar"} {
+		sctx := testSchedulingContext(executorId)
+		sctx = withSuccessfulJobSchedulingContext(sctx, "A", "success"+executorId)
+		require.NoError(t, repo.AddSchedulingContext(sctx))
+	}
+	repo.DrainingExecutors().SetDraining("foo", true)
+
+	// Draining executors remain in reports, marked as draining.
+	report, err := repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "foo (draining):")
+	assert.Contains(t, report.Report, "bar:")
+	assert.NotContains(t, report.Report, "bar (draining)")
+
+	report, err = repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{Format: schedulerobjects.ReportFormat_JSON})
+	require.NoError(t, err)
+	var actual schedulingReportJson
+	require.NoError(t, json.Unmarshal([]byte(report.Report), &actual))
+	drainingByExecutorId := make(map[string]bool)
+	for _, executorReport := range actual.Executors {
+		drainingByExecutorId[executorReport.ExecutorId] = executorReport.Draining
+		assert.NotNil(t, executorReport.MostRecent)
+	}
+	assert.Equal(t, map[string]bool{"foo": true, "bar": false}, drainingByExecutorId)
+
+	// The drain state is administrative and hence not cleared alongside stored contexts.
+	repo.Clear()
+	assert.True(t, repo.DrainingExecutors().IsDraining("foo"))
+}
+
+func TestQueueReportPausedQueues(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successA")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "B", "successB")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	repo.PausedQueues().SetPaused("A", true)
+
+	report, err := repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A"})
+	require.NoError(t, err)
+	assert.True(t, report.Paused)
+	assert.Contains(t, report.Report, "Scheduling is paused for queue A.")
+	report, err = repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "B"})
+	require.NoError(t, err)
+	assert.False(t, report.Paused)
+	assert.NotContains(t, report.Report, "paused")
+
+	report, err = repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A", Format: schedulerobjects.ReportFormat_JSON})
+	require.NoError(t, err)
+	var actual queueReportJson
+	require.NoError(t, json.Unmarshal([]byte(report.Report), &actual))
+	assert.True(t, actual.Paused)
+
+	// The paused state is administrative and hence not cleared alongside stored contexts.
+	repo.Clear()
+	assert.True(t, repo.PausedQueues().IsPaused("A"))
+}
+
+func TestReportsHonourContextCancellation(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, format := range []schedulerobjects.ReportFormat{schedulerobjects.ReportFormat_TEXT, schedulerobjects.ReportFormat_JSON} {
+		_, err = repo.GetSchedulingReport(ctx, &schedulerobjects.SchedulingReportRequest{Format: format})
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = repo.GetQueueReport(ctx, &schedulerobjects.QueueReportRequest{QueueName: "A", Format: format})
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = repo.GetJobReport(ctx, &schedulerobjects.JobReportRequest{JobId: "successFooA", Format: format})
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = repo.GetJobReport(ctx, &schedulerobjects.JobReportRequest{JobId: "successFooA", Format: format, Since: &time.Time{}})
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+
+	// Reports are built as usual if the context isn't cancelled.
+	report, err := repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "foo:")
+}
+
+func TestGetClusterScheduledResources(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	actual, err := repo.GetClusterScheduledResources(context.Background(), &schedulerobjects.ClusterScheduledResourcesRequest{})
+	require.NoError(t, err)
+	assert.True(t, actual.ScheduledResources.IsZero())
+	assert.True(t, actual.EvictedResources.IsZero())
+	assert.Equal(t, int32(0), actual.NumExecutors)
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "B", "failureFooB")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	sctx = testSchedulingContext("bar")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "B", "successBarB")
+	sctx = withPreemptingJobSchedulingContext(sctx, "C", "preemptedBarC")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	// Only the most recent context of each executor is included.
+	err = repo.AddSchedulingContext(withUnsuccessfulJobSchedulingContext(testSchedulingContext("baz"), "A", "failureBazA"))
+	require.NoError(t, err)
+	err = repo.AddSchedulingContext(withUnsuccessfulJobSchedulingContext(testSchedulingContext("baz"), "A", "failureBazA"))
+	require.NoError(t, err)
+
+	actual, err = repo.GetClusterScheduledResources(context.Background(), &schedulerobjects.ClusterScheduledResourcesRequest{})
+	require.NoError(t, err)
+	assert.True(t, schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("3")}}.Equal(actual.ScheduledResources))
+	assert.True(t, schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{"cpu": resource.MustParse("1")}}.Equal(actual.EvictedResources))
+	assert.Equal(t, int32(3), actual.NumSuccessfulJobs)
+	assert.Equal(t, int32(2), actual.NumUnsuccessfulJobs)
+	assert.Equal(t, int32(3), actual.NumExecutors)
+}
+
+func TestReportsTextUnaligned(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+	sctx := testSchedulingContext("foo")
+	sctx.TerminationReason = "a very long termination reason that would otherwise widen the value column"
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "job")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	require.NoError(t, repo.AddSchedulingContext(withUnsuccessfulJobSchedulingContext(testSchedulingContext("bar"), "A", "job")))
+	ctx := context.Background()
+	format := schedulerobjects.ReportFormat_TEXT_UNALIGNED
+
+	schedulingReport, err := repo.GetSchedulingReport(ctx, &schedulerobjects.SchedulingReportRequest{Verbosity: 1, Format: format})
+	require.NoError(t, err)
+	queueReport, err := repo.GetQueueReport(ctx, &schedulerobjects.QueueReportRequest{QueueName: "A", Verbosity: 1, Format: format})
+	require.NoError(t, err)
+	jobReport, err := repo.GetJobReport(ctx, &schedulerobjects.JobReportRequest{JobId: "job", Format: format})
+	require.NoError(t, err)
+	comparison, err := repo.CompareExecutors(ctx, &schedulerobjects.CompareExecutorsRequest{ExecutorIdA: "foo", ExecutorIdB: "bar", Format: format})
+	require.NoError(t, err)
+
+	for name, report := range map[string]string{
+		"scheduling": schedulingReport.Report,
+		"queue":      queueReport.Report,
+		"job":        jobReport.Report,
+		"comparison": comparison.Report,
+	} {
+		assert.NotContains(t, report, "\t", name)
+		// Values follow their label after a single space, rather than being padded into columns.
+		assert.NotRegexp(t, `: {2,}\S`, report, name)
+	}
+	assert.Contains(t, schedulingReport.Report, "Termination reason: a very long termination reason")
+	assert.Contains(t, jobReport.Report, "UnschedulableReason: unknown")
+	assert.Contains(t, queueReport.Report, "Number of jobs scheduled: 1")
+
+	// The default format aligns values into columns.
+	alignedReport, err := repo.GetSchedulingReport(ctx, &schedulerobjects.SchedulingReportRequest{Verbosity: 1})
+	require.NoError(t, err)
+	assert.Regexp(t, `Started: {2,}\S`, alignedReport.Report)
+}
+
+func TestCompareExecutors(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "B", "failureFooB")
+	for _, qctx := range sctx.QueueSchedulingContexts {
+		qctx.SchedulingContext = sctx
+	}
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	_, err = repo.CompareExecutors(context.Background(), &schedulerobjects.CompareExecutorsRequest{ExecutorIdA: "foo", ExecutorIdB: "bar"})
+	assert.Error(t, err)
+
+	sctx = testSchedulingContext("bar")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA1")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successBarA2")
+	sctx = withPreemptingJobSchedulingContext(sctx, "C", "preemptedBarC")
+	for _, qctx := range sctx.QueueSchedulingContexts {
+		qctx.SchedulingContext = sctx
+	}
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	report, err := repo.CompareExecutors(context.Background(), &schedulerobjects.CompareExecutorsRequest{ExecutorIdA: "foo", ExecutorIdB: "bar"})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "Scheduled resources:")
+	assert.Contains(t, report.Report, "+1")
+	assert.Contains(t, report.Report, "Fair share:")
+	for _, queue := range []string{"A", "B", "C"} {
+		assert.Contains(t, report.Report, queue+":")
+	}
+
+	report, err = repo.CompareExecutors(
+		context.Background(),
+		&schedulerobjects.CompareExecutorsRequest{ExecutorIdA: "foo", ExecutorIdB: "bar", Format: schedulerobjects.ReportFormat_JSON},
+	)
+	require.NoError(t, err)
+	var actual executorComparisonJson
+	require.NoError(t, json.Unmarshal([]byte(report.Report), &actual))
+	assert.Equal(t, "foo", actual.ExecutorIdA)
+	assert.Equal(t, "bar", actual.ExecutorIdB)
+	if assert.Len(t, actual.ScheduledResources, 1) {
+		assert.Equal(t, "cpu", actual.ScheduledResources[0].Resource)
+		assert.True(t, resource.MustParse("1").Equal(actual.ScheduledResources[0].A))
+		assert.True(t, resource.MustParse("2").Equal(actual.ScheduledResources[0].B))
+		assert.True(t, resource.MustParse("1").Equal(actual.ScheduledResources[0].Delta))
+	}
+	if assert.Len(t, actual.EvictedResources, 1) {
+		assert.True(t, resource.MustParse("1").Equal(actual.EvictedResources[0].Delta))
+	}
+	if assert.Len(t, actual.FairShareByQueue, 3) {
+		// foo considered queues A and B and bar considered A and C, all with equal priority factors.
+		assert.Equal(t, fairShareComparisonJson{Queue: "A", A: 0.5, B: 0.5, Delta: 0}, actual.FairShareByQueue[0])
+		assert.Equal(t, fairShareComparisonJson{Queue: "B", A: 0.5, B: 0, Delta: -0.5}, actual.FairShareByQueue[1])
+		assert.Equal(t, fairShareComparisonJson{Queue: "C", A: 0, B: 0.5, Delta: 0.5}, actual.FairShareByQueue[2])
+	}
+}
+
+var updateGoldenFiles = flag.Bool("update", false, "update golden files in testdata")
+
+func TestSchedulingReportStringGolden(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 2)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "B", "failureFooB")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	sctx = testSchedulingContext("a-much-longer-executor-name")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "a-much-longer-queue-name", "successLongA")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "a-much-longer-queue-name", "successLongB")
+	sctx = withPreemptingJobSchedulingContext(sctx, "B", "preemptedLongB")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	sctx = testSchedulingContext("bar")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "failureBarA")
+	sctx.RoundOutcome = schedulercontext.RoundOutcomeNodeCapacityExhausted
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	for _, verbosity := range []int32{0, 1} {
+		t.Run(fmt.Sprintf("verbosity %d", verbosity), func(t *testing.T) {
+			actual, err := repo.getSchedulingReport().ReportString(context.Background(), verbosity)
+			require.NoError(t, err)
+			path := filepath.Join("testdata", fmt.Sprintf("scheduling_report_verbosity_%d.golden", verbosity))
+			if *updateGoldenFiles {
+				require.NoError(t, os.WriteFile(path, []byte(actual), 0o644))
+			}
+			expected, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), actual)
+		})
+	}
+}
+
+func TestQueueReportStringGolden(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", "failureFooA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	sctx = testSchedulingContext("a-much-longer-executor-name")
+	sctx = withPreemptingJobSchedulingContext(sctx, "A", "preemptedLongA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	actual, err := repo.getQueueReportString(context.Background(), "A", 2, schedulerobjects.ReportFormat_TEXT)
+	require.NoError(t, err)
+	path := filepath.Join("testdata", "queue_report.golden")
+	if *updateGoldenFiles {
+		require.NoError(t, os.WriteFile(path, []byte(actual), 0o644))
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), actual)
+}
+
+func TestQueueFairShareHistory(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+
+	// Disabled by default.
+	require.NoError(t, repo.AddSchedulingContext(withSuccessfulJobSchedulingContext(testSchedulingContext("foo"), "A", "job")))
+	_, ok := repo.GetQueueFairShareHistory("A")
+	assert.False(t, ok)
+
+	repo.SetQueueFairShareHistoryLength(2)
+	started := time.Now().Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		sctx := testSchedulingContext("foo")
+		sctx.Started = started.Add(time.Duration(i) * time.Second)
+		for j := 0; j <= i; j++ {
+			sctx = withSuccessfulJobSchedulingContext(sctx, "A", fmt.Sprintf("job%d", j))
+		}
+		sctx = withUnsuccessfulJobSchedulingContext(sctx, "B", "failure")
+		for _, qctx := range sctx.QueueSchedulingContexts {
+			qctx.SchedulingContext = sctx
+		}
+		require.NoError(t, repo.AddSchedulingContext(sctx))
+	}
+
+	history, ok := repo.GetQueueFairShareHistory("A")
+	require.True(t, ok)
+	if assert.Len(t, history, 2) {
+		for i, sample := range history {
+			assert.True(t, started.Add(time.Duration(i+1)*time.Second).Equal(sample.Time))
+			assert.Equal(t, "foo", sample.ExecutorId)
+			assert.Equal(t, 0.5, sample.FairShare)
+			cpu := sample.ScheduledResources.Get("cpu")
+			assert.Equal(t, int64(i+2), cpu.Value())
+		}
+	}
+	history, ok = repo.GetQueueFairShareHistory("B")
+	require.True(t, ok)
+	if assert.Len(t, history, 2) {
+		assert.True(t, history[0].ScheduledResources.Equal(schedulerobjects.ResourceList{}))
+	}
+
+	repo.Clear()
+	_, ok = repo.GetQueueFairShareHistory("A")
+	assert.False(t, ok)
+}
+
+func TestReportsAreDeterministic(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	sctx := testSchedulingContext("foo")
+	sctx.TotalResources = schedulerobjects.ResourceList{Resources: map[string]resource.Quantity{
+		"cpu":    resource.MustParse("64"),
+		"memory": resource.MustParse("512Gi"),
+		"gpu":    resource.MustParse("8"),
+	}}
+	for i := 0; i < 20; i++ {
+		sctx = withSuccessfulJobSchedulingContext(sctx, "A", fmt.Sprintf("success%d", i))
+		sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", fmt.Sprintf("failure%d", i))
+		sctx = withPreemptingJobSchedulingContext(sctx, "B", fmt.Sprintf("preempted%d", i))
+	}
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	expectedSchedulingReport, err := repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{Verbosity: schedulerobjects.ReportVerbosity_FULL})
+	require.NoError(t, err)
+	expectedQueueReport, err := repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A", Verbosity: schedulerobjects.ReportVerbosity_FULL})
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		schedulingReport, err := repo.GetSchedulingReport(context.Background(), &schedulerobjects.SchedulingReportRequest{Verbosity: schedulerobjects.ReportVerbosity_FULL})
+		require.NoError(t, err)
+		assert.Equal(t, expectedSchedulingReport.Report, schedulingReport.Report)
+		queueReport, err := repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A", Verbosity: schedulerobjects.ReportVerbosity_FULL})
+		require.NoError(t, err)
+		assert.Equal(t, expectedQueueReport.Report, queueReport.Report)
+	}
+}
+
+func TestQueueReportMaxPrintedJobIds(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetMaxPrintedJobIdsPerVerbosityLevel(2)
+	assert.Equal(t, 1, repo.maxPrintedJobIds(0))
+	assert.Equal(t, 1, repo.maxPrintedJobIds(1))
+	assert.Equal(t, 2, repo.maxPrintedJobIds(2))
+	assert.Equal(t, -1, repo.maxPrintedJobIds(3))
+
+	sctx := testSchedulingContext("foo")
+	for i := 0; i < 5; i++ {
+		sctx = withSuccessfulJobSchedulingContext(sctx, "A", fmt.Sprintf("success%d", i))
+	}
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	report, err := repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A", Verbosity: 2})
+	require.NoError(t, err)
+	assert.Contains(t, report.Report, "(and 3 others not shown)")
+
+	report, err = repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A", Verbosity: schedulerobjects.ReportVerbosity_FULL})
+	require.NoError(t, err)
+	assert.NotContains(t, report.Report, "not shown")
+
+	// Verbosity levels above FULL are clamped to FULL.
+	report, err = repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A", Verbosity: 10})
+	require.NoError(t, err)
+	assert.NotContains(t, report.Report, "not shown")
+
+	repo.SetMaxPrintedJobIdsPerVerbosityLevel(0)
+	report, err = repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A", Verbosity: 2})
+	require.NoError(t, err)
+	assert.NotContains(t, report.Report, "not shown")
+}
+
+func TestQueueReportUnschedulableReasonCounts(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "success")
+	for i := 0; i < 3; i++ {
+		sctx = withUnsuccessfulJobSchedulingContext(sctx, "A", fmt.Sprintf("unsuccessful%d", i))
+	}
+	sctx.QueueSchedulingContexts["A"].UnsuccessfulJobSchedulingContexts["unsuccessful0"].UnschedulableReason = "insufficient cpu"
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+
+	for _, format := range []schedulerobjects.ReportFormat{schedulerobjects.ReportFormat_TEXT, schedulerobjects.ReportFormat_TEXT_UNALIGNED} {
+		report, err := repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A", Format: format})
+		require.NoError(t, err)
+		assert.Regexp(t, `Unschedulable reasons: +2 jobs: unknown, 1 jobs: insufficient cpu\n`, report.Report)
+	}
+
+	report, err := repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "A", Format: schedulerobjects.ReportFormat_JSON})
+	require.NoError(t, err)
+	var actual queueReportJson
+	require.NoError(t, json.Unmarshal([]byte(report.Report), &actual))
+	require.Len(t, actual.Executors, 1)
+	assert.Equal(
+		t,
+		[]unschedulableReasonCountJson{{Reason: "unknown", NumJobs: 2}, {Reason: "insufficient cpu", NumJobs: 1}},
+		actual.Executors[0].MostRecent.UnschedulableReasonCounts,
+	)
+
+	// Queues for which all jobs were scheduled have no reasons to report.
+	sctx = testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "B", "successB")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	report, err = repo.GetQueueReport(context.Background(), &schedulerobjects.QueueReportRequest{QueueName: "B"})
+	require.NoError(t, err)
+	assert.NotContains(t, report.Report, "Unschedulable reasons")
+}
+
+func TestClampReportVerbosity(t *testing.T) {
+	assert.Equal(t, int32(0), clampReportVerbosity(-1))
+	assert.Equal(t, int32(0), clampReportVerbosity(schedulerobjects.ReportVerbosity_SUMMARY))
+	assert.Equal(t, int32(2), clampReportVerbosity(schedulerobjects.ReportVerbosity_JOBS))
+	assert.Equal(t, int32(3), clampReportVerbosity(schedulerobjects.ReportVerbosity_FULL))
+	assert.Equal(t, int32(3), clampReportVerbosity(100))
+}
+
+func TestSchedulingContextRepositoryClear(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 10)
+	require.NoError(t, err)
+
+	sctx := testSchedulingContext("foo")
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	sctx = withPreemptingJobSchedulingContext(sctx, "A", "preemptedFooA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	repo.Clear()
+	assert.Empty(t, repo.GetMostRecentSchedulingContextByExecutor())
+	assert.Empty(t, repo.GetMostRecentSuccessfulSchedulingContextByExecutor())
+	assert.Empty(t, repo.GetMostRecentPreemptingSchedulingContextByExecutor())
+	assert.Empty(t, repo.GetRecentSchedulingContextsByExecutor("foo", 0))
+	assert.Empty(t, repo.ListTrackedQueues())
+	assert.Empty(t, repo.GetSortedExecutorIds())
+	_, ok := repo.GetMostRecentJobSchedulingContextByExecutor("successFooA")
+	assert.False(t, ok)
+	assert.Equal(t, SchedulingContextRepositoryStats{MaxJobSchedulingContexts: 10, MaxJobSchedulingContextsByExecutor: map[string]int{}}, repo.Stats())
+
+	// The repository should be usable after clearing.
+	err = repo.AddSchedulingContext(testSchedulingContext("bar"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bar"}, repo.GetSortedExecutorIds())
+}
+
+func TestSchedulingContextRepositoryExecutorTtl(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 10)
+	require.NoError(t, err)
+	testClock := clock.NewFakeClock(time.Now())
+	repo.clock = testClock
+	repo.SetExecutorTtl(time.Hour)
+
+	sctx := testSchedulingContext("foo")
+	sctx.Started = testClock.Now()
+	sctx = withSuccessfulJobSchedulingContext(sctx, "A", "successFooA")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	sctx = testSchedulingContext("bar")
+	sctx.Started = testClock.Now()
+	sctx = withSuccessfulJobSchedulingContext(sctx, "B", "successBarB")
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bar", "foo"}, repo.GetSortedExecutorIds())
+
+	// Only bar reports within the ttl; foo should be removed.
+	testClock.Step(2 * time.Hour)
+	sctx = testSchedulingContext("bar")
+	sctx.Started = testClock.Now()
+	err = repo.AddSchedulingContext(sctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"bar"}, repo.GetSortedExecutorIds())
+	assert.Equal(t, []string{"bar"}, maps.Keys(repo.GetMostRecentSchedulingContextByExecutor()))
+	assert.Equal(t, []string{"bar"}, maps.Keys(repo.GetMostRecentSuccessfulSchedulingContextByExecutor()))
+	assert.Empty(t, repo.GetRecentSchedulingContextsByExecutor("foo", 0))
+	assert.Equal(t, []string{"B"}, repo.ListTrackedQueues())
+	_, ok := repo.GetMostRecentSuccessfulQueueSchedulingContextByExecutor("A")
+	assert.False(t, ok)
+	_, ok = repo.GetMostRecentJobSchedulingContextByExecutor("successFooA")
+	assert.False(t, ok)
+	_, ok = repo.GetMostRecentJobSchedulingContextByExecutor("successBarB")
+	assert.True(t, ok)
 }
 
 // Concurrently write/read to/from the repo to test that there are no panics.
 func TestTestAddGetSchedulingContextConcurrency(t *testing.T) {
-	repo, err := NewSchedulingContextRepository(10)
+	repo, err := NewSchedulingContextRepository(10, 0)
 	require.NoError(t, err)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -205,23 +1696,33 @@ func TestTestAddGetSchedulingContextConcurrency(t *testing.T) {
 				sctx = withUnsuccessfulJobSchedulingContext(sctx, "C", "failureC")
 				sctx = withSuccessfulJobSchedulingContext(sctx, "B", fmt.Sprintf("success%sB", executorId))
 				sctx = withPreemptingJobSchedulingContext(sctx, "C", "preempted")
-				err = repo.AddSchedulingContext(sctx)
-				require.NoError(t, err)
-				err = repo.AddSchedulingContext(sctx)
-				require.NoError(t, err)
+				require.NoError(t, repo.AddSchedulingContext(sctx))
+				require.NoError(t, repo.AddSchedulingContext(sctx))
 			}
 		}(executorId)
 	}
-	for _, queue := range []string{"A", "B"} {
-		go func(queue string) {
+	go func() {
+		for {
 			select {
 			case <-ctx.Done():
 				return
 			default:
 			}
-			repo.getJobReportString(fmt.Sprintf("failure%s", queue))
-			repo.getQueueReportString(queue, 0)
-			repo.getSchedulingReport().ReportString(0)
+			repo.SetPriorityClasses(map[string]configuration.PriorityClass{"armada-default": {Priority: 1}})
+		}
+	}()
+	for _, queue := range []string{"A", "B"} {
+		go func(queue string) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+				_, _ = repo.getJobReportString(ctx, fmt.Sprintf("failure%s", queue), schedulerobjects.ReportFormat_TEXT)
+				_, _ = repo.getQueueReportString(ctx, queue, 0, schedulerobjects.ReportFormat_TEXT)
+				_, _ = repo.getSchedulingReport().ReportString(ctx, 0)
+			}
 		}(queue)
 	}
 	<-ctx.Done()
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// Number of scheduling contexts buffered for each WatchSchedulingReports stream.
+// Streams that fall further behind than this are dropped.
+const watchSchedulingReportsBufferSize = 100
+
+// SubscribeToSchedulingContexts returns a channel onto which each scheduling context subsequently added to the
+// repository is sent, and a function that cancels the subscription and closes the channel.
+// Calling the cancel function more than once is safe.
+//
+// Up to bufferSize contexts are buffered for the subscriber. If the buffer is full when a context is added,
+// the subscriber is dropped and the channel closed, rather than blocking the writer;
+// subscribers should treat the channel being closed before cancelling as having fallen behind.
+// Contexts sent on the channel are shared with the repository and must not be mutated.
+func (repo *SchedulingContextRepository) SubscribeToSchedulingContexts(bufferSize int) (<-chan *schedulercontext.SchedulingContext, func()) {
+	c := make(chan *schedulercontext.SchedulingContext, bufferSize)
+	repo.subscribersMu.Lock()
+	defer repo.subscribersMu.Unlock()
+	repo.subscribers[c] = true
+	return c, func() {
+		repo.subscribersMu.Lock()
+		defer repo.subscribersMu.Unlock()
+		repo.removeSubscriber(c)
+	}
+}
+
+// notifySubscribers sends sctx to all subscribers without blocking, dropping those whose buffer is full.
+// Should only be called once sctx has been added to the repository.
+func (repo *SchedulingContextRepository) notifySubscribers(sctx *schedulercontext.SchedulingContext) {
+	repo.subscribersMu.Lock()
+	defer repo.subscribersMu.Unlock()
+	for c := range repo.subscribers {
+		select {
+		case c <- sctx:
+		default:
+			repo.removeSubscriber(c)
+			repo.numDroppedSubscribers.Add(1)
+		}
+	}
+}
+
+// removeSubscriber removes c from the set of subscribers and closes it, unless it's been removed already.
+// Should only be called with repo.subscribersMu held.
+func (repo *SchedulingContextRepository) removeSubscriber(c chan *schedulercontext.SchedulingContext) {
+	if repo.subscribers[c] {
+		delete(repo.subscribers, c)
+		close(c)
+	}
+}
+
+// WatchSchedulingReports is a gRPC endpoint streaming the scheduling context of each executor as it's added.
+// Returns a ResourceExhausted error if the client doesn't keep up with the contexts being added.
+func (repo *SchedulingContextRepository) WatchSchedulingReports(request *schedulerobjects.WatchSchedulingReportsRequest, stream schedulerobjects.SchedulerReporting_WatchSchedulingReportsServer) error {
+	executorIds := make(map[string]bool, len(request.GetExecutorIds()))
+	for _, executorId := range request.GetExecutorIds() {
+		executorIds[strings.TrimSpace(executorId)] = true
+	}
+	c, cancel := repo.SubscribeToSchedulingContexts(watchSchedulingReportsBufferSize)
+	defer cancel()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case sctx, ok := <-c:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "stream dropped since it fell behind the scheduling contexts being added")
+			}
+			if len(executorIds) > 0 && !executorIds[sctx.ExecutorId] {
+				continue
+			}
+			if err := stream.Send(executorSchedulingContextFromSchedulingContext(sctx)); err != nil {
+				return err
+			}
+		}
+	}
+}
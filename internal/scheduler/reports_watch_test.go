@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+func TestSubscribeToSchedulingContexts(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	c, cancel := repo.SubscribeToSchedulingContexts(2)
+	sctx := withSuccessfulJobSchedulingContext(testSchedulingContext("foo"), "A", "successFooA")
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	select {
+	case actual := <-c:
+		assert.Same(t, sctx, actual)
+	default:
+		t.Fatal("expected a scheduling context")
+	}
+
+	// Cancelling closes the channel and is idempotent.
+	cancel()
+	cancel()
+	_, ok := <-c
+	assert.False(t, ok)
+	require.NoError(t, repo.AddSchedulingContext(testSchedulingContext("foo")))
+	assert.Equal(t, uint64(0), repo.Stats().NumDroppedSubscribers)
+}
+
+func TestSubscribeToSchedulingContextsDropsSlowSubscribers(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	slow, cancelSlow := repo.SubscribeToSchedulingContexts(1)
+	defer cancelSlow()
+	fast, cancelFast := repo.SubscribeToSchedulingContexts(1)
+	defer cancelFast()
+
+	require.NoError(t, repo.AddSchedulingContext(testSchedulingContext("foo")))
+	<-fast
+	require.NoError(t, repo.AddSchedulingContext(testSchedulingContext("bar")))
+	<-fast
+
+	// The slow subscriber is dropped once its buffer is full, but still receives the buffered context.
+	sctx, ok := <-slow
+	require.True(t, ok)
+	assert.Equal(t, "foo", sctx.ExecutorId)
+	_, ok = <-slow
+	assert.False(t, ok)
+	assert.Equal(t, uint64(1), repo.Stats().NumDroppedSubscribers)
+
+	// The writer was never blocked, and contexts are still stored.
+	_, ok = repo.GetSchedulingContextForExecutor("bar")
+	assert.True(t, ok)
+}
+
+func TestWatchSchedulingReports(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &watchSchedulingReportsStreamMock{ctx: ctx}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var streamErr error
+	go func() {
+		defer wg.Done()
+		streamErr = repo.WatchSchedulingReports(&schedulerobjects.WatchSchedulingReportsRequest{ExecutorIds: []string{"foo"}}, stream)
+	}()
+
+	// Wait for the stream to subscribe before adding contexts.
+	require.Eventually(t, func() bool {
+		repo.subscribersMu.Lock()
+		defer repo.subscribersMu.Unlock()
+		return len(repo.subscribers) == 1
+	}, time.Second, time.Millisecond)
+	require.NoError(t, repo.AddSchedulingContext(withSuccessfulJobSchedulingContext(testSchedulingContext("bar"), "A", "successBarA")))
+	require.NoError(t, repo.AddSchedulingContext(withSuccessfulJobSchedulingContext(testSchedulingContext("foo"), "A", "successFooA")))
+	require.Eventually(t, func() bool { return len(stream.messages()) == 1 }, time.Second, time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assert.NoError(t, streamErr)
+	report := stream.messages()[0]
+	assert.Equal(t, "foo", report.ExecutorId)
+	assert.Equal(t, int32(1), report.QueueSchedulingSummaries["A"].NumSuccessfulJobSchedulingContexts)
+}
+
+func TestWatchSchedulingReportsDropped(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+
+	// Block the first send, such that the stream falls behind.
+	unblock := make(chan struct{})
+	stream := &watchSchedulingReportsStreamMock{ctx: context.Background(), block: unblock}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- repo.WatchSchedulingReports(&schedulerobjects.WatchSchedulingReportsRequest{}, stream)
+	}()
+	require.Eventually(t, func() bool {
+		repo.subscribersMu.Lock()
+		defer repo.subscribersMu.Unlock()
+		return len(repo.subscribers) == 1
+	}, time.Second, time.Millisecond)
+	for i := 0; i < watchSchedulingReportsBufferSize+2; i++ {
+		require.NoError(t, repo.AddSchedulingContext(testSchedulingContext("foo")))
+	}
+	close(unblock)
+
+	err = <-errs
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, uint64(1), repo.Stats().NumDroppedSubscribers)
+}
+
+type watchSchedulingReportsStreamMock struct {
+	grpc.ServerStream
+	ctx context.Context
+	// If non-nil, Send blocks until this channel is closed.
+	block chan struct{}
+	sent  []*schedulerobjects.ExecutorSchedulingContext
+	mu    sync.Mutex
+}
+
+func (s *watchSchedulingReportsStreamMock) Send(m *schedulerobjects.ExecutorSchedulingContext) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func (s *watchSchedulingReportsStreamMock) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchSchedulingReportsStreamMock) messages() []*schedulerobjects.ExecutorSchedulingContext {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sent
+}
@@ -778,7 +778,6 @@ func (s *Scheduler) ensureDbUpToDate(ctx context.Context, pollInterval time.Dura
 
 	groupId := uuid.New()
 	var numSent uint32
-	var err error
 
 	// Send messages to Pulsar
 	messagesSent := false
@@ -787,11 +786,12 @@ func (s *Scheduler) ensureDbUpToDate(ctx context.Context, pollInterval time.Dura
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			numSent, err = s.publisher.PublishMarkers(ctx, groupId)
+			result, err := s.publisher.PublishMarkers(ctx, groupId)
 			if err != nil {
 				log.WithError(err).Error("Error sending marker messages to pulsar")
 				s.clock.Sleep(pollInterval)
 			} else {
+				numSent = uint32(len(result.Published))
 				messagesSent = true
 			}
 		}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/armadaproject/armada/internal/armada/permissions"
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
+	"github.com/armadaproject/armada/internal/common/auth/permission"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+// SchedulerAdminServer implements administrative operations on the scheduler,
+// e.g., draining executors ahead of decommissioning them or pausing scheduling for queues.
+// Changes only affect schedulers consulting the same SchedulingContextRepository, i.e., those in the same process.
+type SchedulerAdminServer struct {
+	permissions                 authorization.PermissionChecker
+	drainingExecutors           *DrainingExecutors
+	pausedQueues                *PausedQueues
+	schedulingContextRepository *SchedulingContextRepository
+}
+
+func NewSchedulerAdminServer(
+	permissions authorization.PermissionChecker,
+	schedulingContextRepository *SchedulingContextRepository,
+) *SchedulerAdminServer {
+	return &SchedulerAdminServer{
+		permissions:                 permissions,
+		drainingExecutors:           schedulingContextRepository.DrainingExecutors(),
+		pausedQueues:                schedulingContextRepository.PausedQueues(),
+		schedulingContextRepository: schedulingContextRepository,
+	}
+}
+
+func (s *SchedulerAdminServer) SetExecutorDraining(ctx context.Context, req *schedulerobjects.SetExecutorDrainingRequest) (*schedulerobjects.SetExecutorDrainingResponse, error) {
+	if err := checkAdminPermission(s.permissions, ctx, permissions.DrainExecutors); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "[SetExecutorDraining] error: %s", err)
+	}
+	if req.ExecutorId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "[SetExecutorDraining] error: executor id must not be empty")
+	}
+	s.drainingExecutors.SetDraining(req.ExecutorId, req.Draining)
+	log.WithFields(log.Fields{
+		"executor": req.ExecutorId,
+		"draining": req.Draining,
+		"user":     authorization.GetPrincipal(ctx).GetName(),
+	}).Info("set executor drain state")
+	return &schedulerobjects.SetExecutorDrainingResponse{
+		DrainingExecutorIds: s.drainingExecutors.ExecutorIds(),
+	}, nil
+}
+
+func (s *SchedulerAdminServer) ForgetJob(ctx context.Context, req *schedulerobjects.ForgetJobRequest) (*schedulerobjects.ForgetJobResponse, error) {
+	if err := checkAdminPermission(s.permissions, ctx, permissions.ForgetJobs); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "[ForgetJob] error: %s", err)
+	}
+	jobId := strings.TrimSpace(req.JobId)
+	if err := s.schedulingContextRepository.ValidateJobId(jobId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "[ForgetJob] error: %s is not a valid job id: %s", req.JobId, err)
+	}
+	removed := s.schedulingContextRepository.ForgetJob(jobId)
+	log.WithFields(log.Fields{
+		"job":     jobId,
+		"removed": removed,
+		"user":    authorization.GetPrincipal(ctx).GetName(),
+	}).Info("forgot job scheduling contexts")
+	return &schedulerobjects.ForgetJobResponse{Removed: removed}, nil
+}
+
+func (s *SchedulerAdminServer) SetQueuePaused(ctx context.Context, req *schedulerobjects.SetQueuePausedRequest) (*schedulerobjects.SetQueuePausedResponse, error) {
+	if err := checkAdminPermission(s.permissions, ctx, permissions.PauseQueues); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "[SetQueuePaused] error: %s", err)
+	}
+	queue := strings.TrimSpace(req.Queue)
+	if queue == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "[SetQueuePaused] error: queue must not be empty")
+	}
+	s.pausedQueues.SetPaused(queue, req.Paused)
+	log.WithFields(log.Fields{
+		"queue":  queue,
+		"paused": req.Paused,
+		"user":   authorization.GetPrincipal(ctx).GetName(),
+	}).Info("set queue paused state")
+	return &schedulerobjects.SetQueuePausedResponse{
+		PausedQueues: s.pausedQueues.Queues(),
+	}, nil
+}
+
+func checkAdminPermission(p authorization.PermissionChecker, ctx context.Context, perm permission.Permission) error {
+	if !p.UserHasPermission(ctx, perm) {
+		return fmt.Errorf("user %s does not have permission %s", authorization.GetPrincipal(ctx).GetName(), perm)
+	}
+	return nil
+}
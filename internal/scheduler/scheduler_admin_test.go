@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
+	"github.com/armadaproject/armada/internal/common/auth/permission"
+	schedulercontext "github.com/armadaproject/armada/internal/scheduler/context"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
+)
+
+func TestSchedulerAdminServer_SetExecutorDraining(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	drainingExecutors := repo.DrainingExecutors()
+	s := NewSchedulerAdminServer(allowAllPermissionChecker{}, repo)
+	ctx := context.Background()
+
+	resp, err := s.SetExecutorDraining(ctx, &schedulerobjects.SetExecutorDrainingRequest{ExecutorId: "foo", Draining: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo"}, resp.DrainingExecutorIds)
+	resp, err = s.SetExecutorDraining(ctx, &schedulerobjects.SetExecutorDrainingRequest{ExecutorId: "bar", Draining: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bar", "foo"}, resp.DrainingExecutorIds)
+	assert.True(t, drainingExecutors.IsDraining("foo"))
+
+	resp, err = s.SetExecutorDraining(ctx, &schedulerobjects.SetExecutorDrainingRequest{ExecutorId: "foo", Draining: false})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bar"}, resp.DrainingExecutorIds)
+	assert.False(t, drainingExecutors.IsDraining("foo"))
+
+	_, err = s.SetExecutorDraining(ctx, &schedulerobjects.SetExecutorDrainingRequest{Draining: true})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSchedulerAdminServer_SetExecutorDrainingRequiresPermission(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	drainingExecutors := repo.DrainingExecutors()
+	s := NewSchedulerAdminServer(denyAllPermissionChecker{}, repo)
+
+	_, err = s.SetExecutorDraining(context.Background(), &schedulerobjects.SetExecutorDrainingRequest{ExecutorId: "foo", Draining: true})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.False(t, drainingExecutors.IsDraining("foo"))
+}
+
+func TestSchedulerAdminServer_SetQueuePaused(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	pausedQueues := repo.PausedQueues()
+	s := NewSchedulerAdminServer(allowAllPermissionChecker{}, repo)
+	ctx := context.Background()
+
+	resp, err := s.SetQueuePaused(ctx, &schedulerobjects.SetQueuePausedRequest{Queue: "B", Paused: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B"}, resp.PausedQueues)
+	resp, err = s.SetQueuePaused(ctx, &schedulerobjects.SetQueuePausedRequest{Queue: " A ", Paused: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, resp.PausedQueues)
+	assert.True(t, pausedQueues.IsPaused("A"))
+
+	resp, err = s.SetQueuePaused(ctx, &schedulerobjects.SetQueuePausedRequest{Queue: "A", Paused: false})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B"}, resp.PausedQueues)
+	assert.False(t, pausedQueues.IsPaused("A"))
+
+	_, err = s.SetQueuePaused(ctx, &schedulerobjects.SetQueuePausedRequest{Queue: " ", Paused: true})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSchedulerAdminServer_SetQueuePausedRequiresPermission(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	s := NewSchedulerAdminServer(denyAllPermissionChecker{}, repo)
+
+	_, err = s.SetQueuePaused(context.Background(), &schedulerobjects.SetQueuePausedRequest{Queue: "A", Paused: true})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.False(t, repo.PausedQueues().IsPaused("A"))
+}
+
+func TestSchedulerAdminServer_ForgetJob(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	repo.SetJobIdValidator(ValidateNonEmptyJobId)
+	sctx := schedulercontext.NewSchedulingContext("executor", "pool", nil, "", nil, schedulerobjects.ResourceList{})
+	require.NoError(t, sctx.AddQueueSchedulingContext("queue", 1, nil))
+	sctx.QueueSchedulingContexts["queue"].UnsuccessfulJobSchedulingContexts["job"] = &schedulercontext.JobSchedulingContext{
+		ExecutorId:          "executor",
+		JobId:               "job",
+		UnschedulableReason: "unknown",
+	}
+	require.NoError(t, repo.AddSchedulingContext(sctx))
+	s := NewSchedulerAdminServer(allowAllPermissionChecker{}, repo)
+	ctx := context.Background()
+
+	resp, err := s.ForgetJob(ctx, &schedulerobjects.ForgetJobRequest{JobId: " job "})
+	require.NoError(t, err)
+	assert.True(t, resp.Removed)
+	_, ok := repo.GetMostRecentJobSchedulingContextByExecutor("job")
+	assert.False(t, ok)
+
+	resp, err = s.ForgetJob(ctx, &schedulerobjects.ForgetJobRequest{JobId: "job"})
+	require.NoError(t, err)
+	assert.False(t, resp.Removed)
+
+	_, err = s.ForgetJob(ctx, &schedulerobjects.ForgetJobRequest{JobId: " "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSchedulerAdminServer_ForgetJobRequiresPermission(t *testing.T) {
+	repo, err := NewSchedulingContextRepository(10, 0)
+	require.NoError(t, err)
+	s := NewSchedulerAdminServer(denyAllPermissionChecker{}, repo)
+
+	_, err = s.ForgetJob(context.Background(), &schedulerobjects.ForgetJobRequest{JobId: "job"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+type allowAllPermissionChecker struct{}
+
+func (allowAllPermissionChecker) UserOwns(ctx context.Context, obj authorization.Owned) (owned bool, ownershipGroups []string) {
+	return true, []string{}
+}
+
+func (allowAllPermissionChecker) UserHasPermission(ctx context.Context, perm permission.Permission) bool {
+	return true
+}
+
+type denyAllPermissionChecker struct{}
+
+func (denyAllPermissionChecker) UserOwns(ctx context.Context, obj authorization.Owned) (owned bool, ownershipGroups []string) {
+	return false, []string{}
+}
+
+func (denyAllPermissionChecker) UserHasPermission(ctx context.Context, perm permission.Permission) bool {
+	return false
+}
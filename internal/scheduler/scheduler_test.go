@@ -621,13 +621,13 @@ func TestRun(t *testing.T) {
 	assert.Equal(t, schedulingAlgo.numberOfScheduleCalls, 1)
 
 	// invalidate our leadership: we should not publish
-	leaderController.token = InvalidLeaderToken()
+	leaderController.Demote()
 	fireCycle()
 	assert.Equal(t, 0, len(publisher.events))
 	assert.Equal(t, schedulingAlgo.numberOfScheduleCalls, 1)
 
 	// become master again: we should publish
-	leaderController.token = NewLeaderToken()
+	leaderController.Promote()
 	fireCycle()
 	assert.Equal(t, 1, len(publisher.events))
 	assert.Equal(t, schedulingAlgo.numberOfScheduleCalls, 2)
@@ -954,8 +954,9 @@ func (t *testPublisher) Reset() {
 	t.events = nil
 }
 
-func (t *testPublisher) PublishMarkers(ctx context.Context, groupId uuid.UUID) (uint32, error) {
-	return 100, nil
+func (t *testPublisher) PublishMarkers(ctx context.Context, groupId uuid.UUID) (*MarkerPublishResult, error) {
+	partitions := AllPartitions(100)
+	return &MarkerPublishResult{Targeted: partitions, Published: partitions}, nil
 }
 
 func stringSet(src []string) map[string]bool {
@@ -21,12 +21,14 @@ import (
 	"github.com/armadaproject/armada/internal/common"
 	"github.com/armadaproject/armada/internal/common/app"
 	"github.com/armadaproject/armada/internal/common/auth"
+	"github.com/armadaproject/armada/internal/common/auth/authorization"
 	dbcommon "github.com/armadaproject/armada/internal/common/database"
 	grpcCommon "github.com/armadaproject/armada/internal/common/grpc"
 	"github.com/armadaproject/armada/internal/common/pulsarutils"
 	"github.com/armadaproject/armada/internal/common/stringinterner"
 	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 	"github.com/armadaproject/armada/internal/scheduler/database"
+	"github.com/armadaproject/armada/internal/scheduler/schedulerobjects"
 	"github.com/armadaproject/armada/pkg/executorapi"
 )
 
@@ -82,6 +84,9 @@ func Run(config schedulerconfig.Configuration) error {
 	if err != nil {
 		return errors.WithMessage(err, "error creating pulsar publisher")
 	}
+	pulsarPublisher.SetSendRetries(config.PulsarSendMaxRetries, config.PulsarSendRetryBackoff)
+	pulsarPublisher.SetPreserveJobSetOrder(config.PulsarPreserveJobSetOrder)
+	pulsarPublisher.SetMaxInFlightSends(config.PulsarMaxInFlight)
 
 	//////////////////////////////////////////////////////////////////////////
 	// Leader Election
@@ -90,6 +95,13 @@ func Run(config schedulerconfig.Configuration) error {
 	if err != nil {
 		return errors.WithMessage(err, "error creating leader controller")
 	}
+	pulsarPublisher.SetDemotionDrainTimeout(config.PulsarDemotionDrainTimeout)
+	pulsarPublisher.SetValidateEventSequences(config.PulsarValidateEventSequences)
+	pulsarPublisher.SetCircuitBreaker(config.PulsarCircuitBreakerFailureThreshold, config.PulsarCircuitBreakerCooldown)
+	pulsarPublisher.SetMarkerRetries(config.PulsarMaxMarkerRetries, config.PulsarMarkerRetryDelay)
+	if kubernetesLeaderController, ok := leaderController.(*KubernetesLeaderController); ok {
+		kubernetesLeaderController.RegisterListener(pulsarPublisher)
+	}
 	services = append(services, func() error { return leaderController.Run(ctx) })
 
 	//////////////////////////////////////////////////////////////////////////
@@ -132,6 +144,25 @@ func Run(config schedulerconfig.Configuration) error {
 		return errors.WithMessage(err, "error creating executorApi")
 	}
 	executorapi.RegisterExecutorApiServer(grpcServer, executorServer)
+
+	// Scheduling reports and admin operations, e.g., draining executors or pausing queues.
+	// These are served by this process, since they act on the state consulted by its scheduling rounds.
+	schedulingContextRepository, err := NewSchedulingContextRepositoryFromConfig(config.Scheduling)
+	if err != nil {
+		return errors.WithMessage(err, "error creating scheduling context repository")
+	}
+	if n := config.Scheduling.SchedulingContextIngestionBufferSize; n > 0 {
+		schedulingContextRepository.SetIngestionBufferSize(n)
+		services = append(services, func() error { return schedulingContextRepository.Run(ctx) })
+	}
+	prometheus.MustRegister(schedulingContextRepository)
+	permissions := authorization.NewPrincipalPermissionChecker(
+		config.Auth.PermissionGroupMapping,
+		config.Auth.PermissionScopeMapping,
+		config.Auth.PermissionClaimMapping,
+	)
+	schedulerobjects.RegisterSchedulerReportingServer(grpcServer, schedulingContextRepository)
+	schedulerobjects.RegisterSchedulerAdminServer(grpcServer, NewSchedulerAdminServer(permissions, schedulingContextRepository))
 	services = append(services, func() error {
 		log.Infof("Executor api listening on %s", lis.Addr())
 		return grpcServer.Serve(lis)
@@ -158,8 +189,13 @@ func Run(config schedulerconfig.Configuration) error {
 	if err != nil {
 		return errors.WithMessage(err, "error creating submit checker")
 	}
-	// TODO(reports): Pass in a non-nil SchedulingContextRepository.
-	schedulingAlgo, err := NewFairSchedulingAlgo(config.Scheduling, config.MaxSchedulingDuration, executorRepository, queueRepository, nil)
+	schedulingAlgo, err := NewFairSchedulingAlgo(
+		config.Scheduling,
+		config.MaxSchedulingDuration,
+		executorRepository,
+		queueRepository,
+		schedulingContextRepository,
+	)
 	if err != nil {
 		return errors.WithMessage(err, "error creating scheduling algo")
 	}
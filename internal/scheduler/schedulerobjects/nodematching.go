@@ -8,11 +8,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
 )
 
 const (
 	// When checking if a pod fits on a node, this score indicates how well the pods fits.
-	// However, all nodes are currently given the same score.
+	// Nodes are given this score plus the total weight of the preferred node affinity terms of the pod they match;
+	// see PreferredNodeAffinityScore.
 	SchedulableScore                                 = 0
 	SchedulableBestScore                             = SchedulableScore
 	PodRequirementsNotMetReasonUnmatchedNodeSelector = "node does not match pod NodeAffinity"
@@ -119,9 +121,8 @@ func (nodeType *NodeType) PodRequirementsMet(req *PodRequirements) (bool, PodReq
 }
 
 // PodRequirementsMet determines whether a pod can be scheduled onto this node.
-// If the pod can be scheduled, the returned score indicates how well the node fits:
-// - 0: Pod can be scheduled by preempting running pods.
-// - 1: Pod can be scheduled without preempting any running pods.
+// If the pod can be scheduled, the returned score indicates how well the node fits;
+// it's SchedulableScore plus the total weight of the preferred node affinity terms of the pod matched by the node.
 // If the requirements are not met, it returns the reason why.
 // If the requirements can't be parsed, an error is returned.
 func (node *Node) PodRequirementsMet(priority int32, req *PodRequirements) (bool, int, PodRequirementsNotMetReason, error) {
@@ -129,7 +130,41 @@ func (node *Node) PodRequirementsMet(priority int32, req *PodRequirements) (bool
 	if !matches || err != nil {
 		return matches, 0, reason, err
 	}
-	return node.DynamicPodRequirementsMet(priority, req)
+	matches, score, reason, err := node.DynamicPodRequirementsMet(priority, req)
+	if !matches || err != nil {
+		return matches, score, reason, err
+	}
+	affinityScore, err := PreferredNodeAffinityScore(node.GetLabels(), req)
+	if err != nil {
+		return false, 0, nil, err
+	}
+	return true, score + affinityScore, nil, nil
+}
+
+// PreferredNodeAffinityScore returns the total weight of the preferred node affinity terms of the pod
+// matched by a node with the given labels. Unlike required node affinity, these terms never exclude a node.
+func PreferredNodeAffinityScore(nodeLabels map[string]string, req *PodRequirements) (int, error) {
+	terms := req.GetPreferredNodeAffinityTerms()
+	if len(terms) == 0 {
+		return 0, nil
+	}
+	preferredSchedulingTerms, err := nodeaffinity.NewPreferredSchedulingTerms(terms)
+	if err != nil {
+		return 0, err
+	}
+	return int(preferredSchedulingTerms.Score(&v1.Node{ObjectMeta: metav1.ObjectMeta{Labels: nodeLabels}})), nil
+}
+
+// MaxPreferredNodeAffinityScore returns the highest score PreferredNodeAffinityScore may return for the pod,
+// i.e., the total weight of its preferred node affinity terms.
+func MaxPreferredNodeAffinityScore(req *PodRequirements) int {
+	rv := 0
+	for _, term := range req.GetPreferredNodeAffinityTerms() {
+		if term.Weight > 0 {
+			rv += int(term.Weight)
+		}
+	}
+	return rv
 }
 
 // StaticPodRequirementsMet checks if a pod can be scheduled onto this node,
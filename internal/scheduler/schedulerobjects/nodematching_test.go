@@ -388,6 +388,63 @@ func TestNodeSchedulingRequirementsMet(t *testing.T) {
 	}
 }
 
+func TestPreferredNodeAffinityScore(t *testing.T) {
+	term := func(weight int32, label, value string) v1.PreferredSchedulingTerm {
+		return v1.PreferredSchedulingTerm{
+			Weight: weight,
+			Preference: v1.NodeSelectorTerm{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: label, Operator: v1.NodeSelectorOpIn, Values: []string{value}},
+				},
+			},
+		}
+	}
+	req := &PodRequirements{
+		Affinity: &v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{
+					term(10, "zone", "near"),
+					term(1, "rack", "near"),
+				},
+			},
+		},
+	}
+	tests := map[string]struct {
+		nodeLabels    map[string]string
+		req           *PodRequirements
+		expectedScore int
+	}{
+		"no preferences": {
+			nodeLabels:    map[string]string{"zone": "near"},
+			req:           &PodRequirements{},
+			expectedScore: 0,
+		},
+		"no match": {
+			nodeLabels:    map[string]string{"zone": "far"},
+			req:           req,
+			expectedScore: 0,
+		},
+		"single match": {
+			nodeLabels:    map[string]string{"zone": "near", "rack": "far"},
+			req:           req,
+			expectedScore: 10,
+		},
+		"all match": {
+			nodeLabels:    map[string]string{"zone": "near", "rack": "near"},
+			req:           req,
+			expectedScore: 11,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			score, err := PreferredNodeAffinityScore(tc.nodeLabels, tc.req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedScore, score)
+			assert.LessOrEqual(t, score, MaxPreferredNodeAffinityScore(tc.req))
+		})
+	}
+}
+
 func TestNodeTypeSchedulingRequirementsMet(t *testing.T) {
 	tests := map[string]struct {
 		Taints        []v1.Taint
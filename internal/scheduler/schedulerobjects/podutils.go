@@ -23,6 +23,19 @@ func (req *PodRequirements) GetAffinityNodeSelector() *v1.NodeSelector {
 	return nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
 }
 
+// GetPreferredNodeAffinityTerms returns the preferred node affinity terms of the pod, if any.
+func (req *PodRequirements) GetPreferredNodeAffinityTerms() []v1.PreferredSchedulingTerm {
+	affinity := req.Affinity
+	if affinity == nil {
+		return nil
+	}
+	nodeAffinity := affinity.NodeAffinity
+	if nodeAffinity == nil {
+		return nil
+	}
+	return nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+}
+
 // SchedulingKeyGenerator is used to generate scheduling keys efficiently.
 // A scheduling key is the canonical hash of the scheduling requirements of a job.
 // All memory is allocated up-front and re-used. Not thread-safe.
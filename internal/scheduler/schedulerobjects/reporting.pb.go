@@ -5,13 +5,17 @@ package schedulerobjects
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 
+	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -21,6 +25,7 @@ import (
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -28,6 +33,105 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// Format in which reports are returned.
+// TEXT is the default and produces human-readable reports, with values aligned into columns;
+// JSON produces a structured representation intended for machine consumption.
+// TEXT_UNALIGNED is like TEXT, but values aren't aligned, such that each line is a plain "key: value" pair;
+// this keeps reports readable when some values are very wide, e.g., long node names or unschedulable reasons.
+type ReportFormat int32
+
+const (
+	ReportFormat_TEXT           ReportFormat = 0
+	ReportFormat_JSON           ReportFormat = 1
+	ReportFormat_TEXT_UNALIGNED ReportFormat = 2
+)
+
+var ReportFormat_name = map[int32]string{
+	0: "TEXT",
+	1: "JSON",
+	2: "TEXT_UNALIGNED",
+}
+
+var ReportFormat_value = map[string]int32{
+	"TEXT":           0,
+	"JSON":           1,
+	"TEXT_UNALIGNED": 2,
+}
+
+func (x ReportFormat) String() string {
+	return proto.EnumName(ReportFormat_name, int32(x))
+}
+
+func (ReportFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{0}
+}
+
+// Levels of detail of scheduling and queue reports; each level includes everything included at lower levels.
+// Values above FULL are treated as FULL.
+type ReportVerbosity int32
+
+const (
+	// Per-executor summaries of each attempt, listing the queues that scheduled or preempted jobs.
+	ReportVerbosity_SUMMARY ReportVerbosity = 0
+	// Per-queue details of each attempt, with one example job id per list of jobs,
+	// and per-executor summaries of resources by priority class.
+	ReportVerbosity_QUEUES ReportVerbosity = 1
+	// Job ids, limited to a configurable number per list of jobs, and per-queue fairness summaries.
+	ReportVerbosity_JOBS ReportVerbosity = 2
+	// All job ids, without limit.
+	ReportVerbosity_FULL ReportVerbosity = 3
+)
+
+var ReportVerbosity_name = map[int32]string{
+	0: "SUMMARY",
+	1: "QUEUES",
+	2: "JOBS",
+	3: "FULL",
+}
+
+var ReportVerbosity_value = map[string]int32{
+	"SUMMARY": 0,
+	"QUEUES":  1,
+	"JOBS":    2,
+	"FULL":    3,
+}
+
+func (x ReportVerbosity) String() string {
+	return proto.EnumName(ReportVerbosity_name, int32(x))
+}
+
+func (ReportVerbosity) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{1}
+}
+
+// Encoding of reports returned by report endpoints.
+// IDENTITY indicates the report is returned as is in the report field;
+// GZIP indicates the report is returned gzip-compressed in the compressed_report field.
+type ReportEncoding int32
+
+const (
+	ReportEncoding_IDENTITY ReportEncoding = 0
+	ReportEncoding_GZIP     ReportEncoding = 1
+)
+
+var ReportEncoding_name = map[int32]string{
+	0: "IDENTITY",
+	1: "GZIP",
+}
+
+var ReportEncoding_value = map[string]int32{
+	"IDENTITY": 0,
+	"GZIP":     1,
+}
+
+func (x ReportEncoding) String() string {
+	return proto.EnumName(ReportEncoding_name, int32(x))
+}
+
+func (ReportEncoding) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{2}
+}
+
 type MostRecentForQueue struct {
 	QueueName string `protobuf:"bytes,1,opt,name=queue_name,json=queueName,proto3" json:"queueName,omitempty"`
 }
@@ -73,7 +177,8 @@ func (m *MostRecentForQueue) GetQueueName() string {
 }
 
 type MostRecentForJob struct {
-	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+	JobId          string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+	ExcludeEvicted bool   `protobuf:"varint,2,opt,name=exclude_evicted,json=excludeEvicted,proto3" json:"excludeEvicted,omitempty"`
 }
 
 func (m *MostRecentForJob) Reset()         { *m = MostRecentForJob{} }
@@ -116,20 +221,88 @@ func (m *MostRecentForJob) GetJobId() string {
 	return ""
 }
 
+func (m *MostRecentForJob) GetExcludeEvicted() bool {
+	if m != nil {
+		return m.ExcludeEvicted
+	}
+	return false
+}
+
+type MostRecentForPool struct {
+	PoolName string `protobuf:"bytes,1,opt,name=pool_name,json=poolName,proto3" json:"poolName,omitempty"`
+}
+
+func (m *MostRecentForPool) Reset()         { *m = MostRecentForPool{} }
+func (m *MostRecentForPool) String() string { return proto.CompactTextString(m) }
+func (*MostRecentForPool) ProtoMessage()    {}
+func (*MostRecentForPool) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{2}
+}
+func (m *MostRecentForPool) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MostRecentForPool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MostRecentForPool.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MostRecentForPool) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MostRecentForPool.Merge(m, src)
+}
+func (m *MostRecentForPool) XXX_Size() int {
+	return m.Size()
+}
+func (m *MostRecentForPool) XXX_DiscardUnknown() {
+	xxx_messageInfo_MostRecentForPool.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MostRecentForPool proto.InternalMessageInfo
+
+func (m *MostRecentForPool) GetPoolName() string {
+	if m != nil {
+		return m.PoolName
+	}
+	return ""
+}
+
 type SchedulingReportRequest struct {
 	// Types that are valid to be assigned to Filter:
 	//
 	//	*SchedulingReportRequest_MostRecentForQueue
 	//	*SchedulingReportRequest_MostRecentForJob
+	//	*SchedulingReportRequest_MostRecentForPool
 	Filter    isSchedulingReportRequest_Filter `protobuf_oneof:"filter"`
-	Verbosity int32                            `protobuf:"varint,3,opt,name=verbosity,proto3" json:"verbosity,omitempty"`
+	Verbosity ReportVerbosity                  `protobuf:"varint,3,opt,name=verbosity,proto3,enum=schedulerobjects.ReportVerbosity" json:"verbosity,omitempty"`
+	Format    ReportFormat                     `protobuf:"varint,4,opt,name=format,proto3,enum=schedulerobjects.ReportFormat" json:"format,omitempty"`
+	// If non-zero, the report also includes up to this many of the most recent attempts for each executor.
+	// Only applies to reports not filtered by queue or job.
+	History uint32 `protobuf:"varint,6,opt,name=history,proto3" json:"history,omitempty"`
+	// If non-empty, only executors the id of which matches this pattern are included in the report.
+	// The pattern is a glob, e.g., "cluster-*", unless executor_pattern_is_regex is set,
+	// in which case it's an RE2 regular expression matched against any part of the executor id.
+	ExecutorPattern        string `protobuf:"bytes,7,opt,name=executor_pattern,json=executorPattern,proto3" json:"executorPattern,omitempty"`
+	ExecutorPatternIsRegex bool   `protobuf:"varint,8,opt,name=executor_pattern_is_regex,json=executorPatternIsRegex,proto3" json:"executorPatternIsRegex,omitempty"`
+	// If non-empty, only executors the most recent successful attempt of which scheduled more than this amount
+	// of at least one of the given resources are included in the report, and within each attempt only queues
+	// that scheduled more than this amount of at least one of the given resources are included.
+	// Omitted executors and queues are summarised by a count.
+	MinResources ResourceList `protobuf:"bytes,9,opt,name=min_resources,json=minResources,proto3" json:"minResources"`
+	// If true, the report is returned gzip-compressed; see ReportEncoding.
+	Compress bool `protobuf:"varint,10,opt,name=compress,proto3" json:"compress,omitempty"`
 }
 
 func (m *SchedulingReportRequest) Reset()         { *m = SchedulingReportRequest{} }
 func (m *SchedulingReportRequest) String() string { return proto.CompactTextString(m) }
 func (*SchedulingReportRequest) ProtoMessage()    {}
 func (*SchedulingReportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_131a439a3ff6540b, []int{2}
+	return fileDescriptor_131a439a3ff6540b, []int{3}
 }
 func (m *SchedulingReportRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -170,9 +343,13 @@ type SchedulingReportRequest_MostRecentForQueue struct {
 type SchedulingReportRequest_MostRecentForJob struct {
 	MostRecentForJob *MostRecentForJob `protobuf:"bytes,2,opt,name=most_recent_for_job,json=mostRecentForJob,proto3,oneof" json:"mostRecentForJob,omitempty"`
 }
+type SchedulingReportRequest_MostRecentForPool struct {
+	MostRecentForPool *MostRecentForPool `protobuf:"bytes,5,opt,name=most_recent_for_pool,json=mostRecentForPool,proto3,oneof" json:"mostRecentForPool,omitempty"`
+}
 
 func (*SchedulingReportRequest_MostRecentForQueue) isSchedulingReportRequest_Filter() {}
 func (*SchedulingReportRequest_MostRecentForJob) isSchedulingReportRequest_Filter()   {}
+func (*SchedulingReportRequest_MostRecentForPool) isSchedulingReportRequest_Filter()  {}
 
 func (m *SchedulingReportRequest) GetFilter() isSchedulingReportRequest_Filter {
 	if m != nil {
@@ -195,30 +372,83 @@ func (m *SchedulingReportRequest) GetMostRecentForJob() *MostRecentForJob {
 	return nil
 }
 
-func (m *SchedulingReportRequest) GetVerbosity() int32 {
+func (m *SchedulingReportRequest) GetMostRecentForPool() *MostRecentForPool {
+	if x, ok := m.GetFilter().(*SchedulingReportRequest_MostRecentForPool); ok {
+		return x.MostRecentForPool
+	}
+	return nil
+}
+
+func (m *SchedulingReportRequest) GetVerbosity() ReportVerbosity {
 	if m != nil {
 		return m.Verbosity
 	}
+	return ReportVerbosity_SUMMARY
+}
+
+func (m *SchedulingReportRequest) GetFormat() ReportFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ReportFormat_TEXT
+}
+
+func (m *SchedulingReportRequest) GetHistory() uint32 {
+	if m != nil {
+		return m.History
+	}
 	return 0
 }
 
+func (m *SchedulingReportRequest) GetExecutorPattern() string {
+	if m != nil {
+		return m.ExecutorPattern
+	}
+	return ""
+}
+
+func (m *SchedulingReportRequest) GetExecutorPatternIsRegex() bool {
+	if m != nil {
+		return m.ExecutorPatternIsRegex
+	}
+	return false
+}
+
+func (m *SchedulingReportRequest) GetMinResources() ResourceList {
+	if m != nil {
+		return m.MinResources
+	}
+	return ResourceList{}
+}
+
+func (m *SchedulingReportRequest) GetCompress() bool {
+	if m != nil {
+		return m.Compress
+	}
+	return false
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*SchedulingReportRequest) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*SchedulingReportRequest_MostRecentForQueue)(nil),
 		(*SchedulingReportRequest_MostRecentForJob)(nil),
+		(*SchedulingReportRequest_MostRecentForPool)(nil),
 	}
 }
 
 type SchedulingReport struct {
 	Report string `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	// Set instead of report if the request set compress.
+	CompressedReport []byte         `protobuf:"bytes,2,opt,name=compressed_report,json=compressedReport,proto3" json:"compressedReport,omitempty"`
+	Encoding         ReportEncoding `protobuf:"varint,3,opt,name=encoding,proto3,enum=schedulerobjects.ReportEncoding" json:"encoding,omitempty"`
 }
 
 func (m *SchedulingReport) Reset()         { *m = SchedulingReport{} }
 func (m *SchedulingReport) String() string { return proto.CompactTextString(m) }
 func (*SchedulingReport) ProtoMessage()    {}
 func (*SchedulingReport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_131a439a3ff6540b, []int{3}
+	return fileDescriptor_131a439a3ff6540b, []int{4}
 }
 func (m *SchedulingReport) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -254,16 +484,32 @@ func (m *SchedulingReport) GetReport() string {
 	return ""
 }
 
+func (m *SchedulingReport) GetCompressedReport() []byte {
+	if m != nil {
+		return m.CompressedReport
+	}
+	return nil
+}
+
+func (m *SchedulingReport) GetEncoding() ReportEncoding {
+	if m != nil {
+		return m.Encoding
+	}
+	return ReportEncoding_IDENTITY
+}
+
 type QueueReportRequest struct {
-	QueueName string `protobuf:"bytes,1,opt,name=queue_name,json=queueName,proto3" json:"queueName,omitempty"`
-	Verbosity int32  `protobuf:"varint,2,opt,name=verbosity,proto3" json:"verbosity,omitempty"`
+	QueueName string          `protobuf:"bytes,1,opt,name=queue_name,json=queueName,proto3" json:"queueName,omitempty"`
+	Verbosity ReportVerbosity `protobuf:"varint,2,opt,name=verbosity,proto3,enum=schedulerobjects.ReportVerbosity" json:"verbosity,omitempty"`
+	Format    ReportFormat    `protobuf:"varint,3,opt,name=format,proto3,enum=schedulerobjects.ReportFormat" json:"format,omitempty"`
+	Compress  bool            `protobuf:"varint,4,opt,name=compress,proto3" json:"compress,omitempty"`
 }
 
 func (m *QueueReportRequest) Reset()         { *m = QueueReportRequest{} }
 func (m *QueueReportRequest) String() string { return proto.CompactTextString(m) }
 func (*QueueReportRequest) ProtoMessage()    {}
 func (*QueueReportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_131a439a3ff6540b, []int{4}
+	return fileDescriptor_131a439a3ff6540b, []int{5}
 }
 func (m *QueueReportRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -299,22 +545,44 @@ func (m *QueueReportRequest) GetQueueName() string {
 	return ""
 }
 
-func (m *QueueReportRequest) GetVerbosity() int32 {
+func (m *QueueReportRequest) GetVerbosity() ReportVerbosity {
 	if m != nil {
 		return m.Verbosity
 	}
-	return 0
+	return ReportVerbosity_SUMMARY
+}
+
+func (m *QueueReportRequest) GetFormat() ReportFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ReportFormat_TEXT
+}
+
+func (m *QueueReportRequest) GetCompress() bool {
+	if m != nil {
+		return m.Compress
+	}
+	return false
 }
 
 type QueueReport struct {
+	// Human-readable report.
 	Report string `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	// Per-executor breakdown of recent scheduling attempts for this queue, sorted by executor id.
+	Executors []*ExecutorQueueReport `protobuf:"bytes,2,rep,name=executors,proto3" json:"executors,omitempty"`
+	// Set instead of report if the request set compress.
+	CompressedReport []byte         `protobuf:"bytes,3,opt,name=compressed_report,json=compressedReport,proto3" json:"compressedReport,omitempty"`
+	Encoding         ReportEncoding `protobuf:"varint,4,opt,name=encoding,proto3,enum=schedulerobjects.ReportEncoding" json:"encoding,omitempty"`
+	// True if scheduling is paused for this queue.
+	Paused bool `protobuf:"varint,5,opt,name=paused,proto3" json:"paused,omitempty"`
 }
 
 func (m *QueueReport) Reset()         { *m = QueueReport{} }
 func (m *QueueReport) String() string { return proto.CompactTextString(m) }
 func (*QueueReport) ProtoMessage()    {}
 func (*QueueReport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_131a439a3ff6540b, []int{5}
+	return fileDescriptor_131a439a3ff6540b, []int{6}
 }
 func (m *QueueReport) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -350,15 +618,206 @@ func (m *QueueReport) GetReport() string {
 	return ""
 }
 
+func (m *QueueReport) GetExecutors() []*ExecutorQueueReport {
+	if m != nil {
+		return m.Executors
+	}
+	return nil
+}
+
+func (m *QueueReport) GetCompressedReport() []byte {
+	if m != nil {
+		return m.CompressedReport
+	}
+	return nil
+}
+
+func (m *QueueReport) GetEncoding() ReportEncoding {
+	if m != nil {
+		return m.Encoding
+	}
+	return ReportEncoding_IDENTITY
+}
+
+func (m *QueueReport) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+type ExecutorQueueReport struct {
+	ExecutorId string `protobuf:"bytes,1,opt,name=executor_id,json=executorId,proto3" json:"executorId,omitempty"`
+	// Each of these is unset if there's no corresponding attempt stored for this executor.
+	MostRecent           *QueueSchedulingSummary `protobuf:"bytes,2,opt,name=most_recent,json=mostRecent,proto3" json:"mostRecent,omitempty"`
+	MostRecentSuccessful *QueueSchedulingSummary `protobuf:"bytes,3,opt,name=most_recent_successful,json=mostRecentSuccessful,proto3" json:"mostRecentSuccessful,omitempty"`
+	MostRecentPreempting *QueueSchedulingSummary `protobuf:"bytes,4,opt,name=most_recent_preempting,json=mostRecentPreempting,proto3" json:"mostRecentPreempting,omitempty"`
+}
+
+func (m *ExecutorQueueReport) Reset()         { *m = ExecutorQueueReport{} }
+func (m *ExecutorQueueReport) String() string { return proto.CompactTextString(m) }
+func (*ExecutorQueueReport) ProtoMessage()    {}
+func (*ExecutorQueueReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{7}
+}
+func (m *ExecutorQueueReport) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExecutorQueueReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExecutorQueueReport.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExecutorQueueReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutorQueueReport.Merge(m, src)
+}
+func (m *ExecutorQueueReport) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExecutorQueueReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutorQueueReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutorQueueReport proto.InternalMessageInfo
+
+func (m *ExecutorQueueReport) GetExecutorId() string {
+	if m != nil {
+		return m.ExecutorId
+	}
+	return ""
+}
+
+func (m *ExecutorQueueReport) GetMostRecent() *QueueSchedulingSummary {
+	if m != nil {
+		return m.MostRecent
+	}
+	return nil
+}
+
+func (m *ExecutorQueueReport) GetMostRecentSuccessful() *QueueSchedulingSummary {
+	if m != nil {
+		return m.MostRecentSuccessful
+	}
+	return nil
+}
+
+func (m *ExecutorQueueReport) GetMostRecentPreempting() *QueueSchedulingSummary {
+	if m != nil {
+		return m.MostRecentPreempting
+	}
+	return nil
+}
+
+// Numeric summary of a single attempt to schedule jobs from a queue.
+type QueueSchedulingSummary struct {
+	Created                              time.Time              `protobuf:"bytes,1,opt,name=created,proto3,stdtime" json:"created"`
+	ScheduledResourcesByPriority         map[int32]ResourceList `protobuf:"bytes,2,rep,name=scheduled_resources_by_priority,json=scheduledResourcesByPriority,proto3" json:"scheduledResourcesByPriority" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	EvictedResourcesByPriority           map[int32]ResourceList `protobuf:"bytes,3,rep,name=evicted_resources_by_priority,json=evictedResourcesByPriority,proto3" json:"evictedResourcesByPriority" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	NumSuccessfulJobSchedulingContexts   int32                  `protobuf:"varint,4,opt,name=num_successful_job_scheduling_contexts,json=numSuccessfulJobSchedulingContexts,proto3" json:"numSuccessfulJobSchedulingContexts,omitempty"`
+	NumUnsuccessfulJobSchedulingContexts int32                  `protobuf:"varint,5,opt,name=num_unsuccessful_job_scheduling_contexts,json=numUnsuccessfulJobSchedulingContexts,proto3" json:"numUnsuccessfulJobSchedulingContexts,omitempty"`
+	// Fraction of total resources this queue is entitled to, relative to other queues considered in the same attempt.
+	FairShare float64 `protobuf:"fixed64,6,opt,name=fair_share,json=fairShare,proto3" json:"fairShare,omitempty"`
+}
+
+func (m *QueueSchedulingSummary) Reset()         { *m = QueueSchedulingSummary{} }
+func (m *QueueSchedulingSummary) String() string { return proto.CompactTextString(m) }
+func (*QueueSchedulingSummary) ProtoMessage()    {}
+func (*QueueSchedulingSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{8}
+}
+func (m *QueueSchedulingSummary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueueSchedulingSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueueSchedulingSummary.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueueSchedulingSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueueSchedulingSummary.Merge(m, src)
+}
+func (m *QueueSchedulingSummary) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueueSchedulingSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueueSchedulingSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueueSchedulingSummary proto.InternalMessageInfo
+
+func (m *QueueSchedulingSummary) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
+	}
+	return time.Time{}
+}
+
+func (m *QueueSchedulingSummary) GetScheduledResourcesByPriority() map[int32]ResourceList {
+	if m != nil {
+		return m.ScheduledResourcesByPriority
+	}
+	return nil
+}
+
+func (m *QueueSchedulingSummary) GetEvictedResourcesByPriority() map[int32]ResourceList {
+	if m != nil {
+		return m.EvictedResourcesByPriority
+	}
+	return nil
+}
+
+func (m *QueueSchedulingSummary) GetNumSuccessfulJobSchedulingContexts() int32 {
+	if m != nil {
+		return m.NumSuccessfulJobSchedulingContexts
+	}
+	return 0
+}
+
+func (m *QueueSchedulingSummary) GetNumUnsuccessfulJobSchedulingContexts() int32 {
+	if m != nil {
+		return m.NumUnsuccessfulJobSchedulingContexts
+	}
+	return 0
+}
+
+func (m *QueueSchedulingSummary) GetFairShare() float64 {
+	if m != nil {
+		return m.FairShare
+	}
+	return 0
+}
+
 type JobReportRequest struct {
-	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+	JobId  string       `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+	Format ReportFormat `protobuf:"varint,2,opt,name=format,proto3,enum=schedulerobjects.ReportFormat" json:"format,omitempty"`
+	// If either of since or until is provided, the report includes all stored attempts to schedule the job
+	// created within [since, until) instead of only the most recent attempt for each executor.
+	// Attempts are only available for as long as they're included in the stored scheduling context history.
+	Since *time.Time `protobuf:"bytes,3,opt,name=since,proto3,stdtime" json:"since,omitempty"`
+	Until *time.Time `protobuf:"bytes,4,opt,name=until,proto3,stdtime" json:"until,omitempty"`
+	// If true, the report is returned gzip-compressed; see ReportEncoding.
+	Compress bool `protobuf:"varint,5,opt,name=compress,proto3" json:"compress,omitempty"`
 }
 
 func (m *JobReportRequest) Reset()         { *m = JobReportRequest{} }
 func (m *JobReportRequest) String() string { return proto.CompactTextString(m) }
 func (*JobReportRequest) ProtoMessage()    {}
 func (*JobReportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_131a439a3ff6540b, []int{6}
+	return fileDescriptor_131a439a3ff6540b, []int{9}
 }
 func (m *JobReportRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -394,15 +853,46 @@ func (m *JobReportRequest) GetJobId() string {
 	return ""
 }
 
+func (m *JobReportRequest) GetFormat() ReportFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ReportFormat_TEXT
+}
+
+func (m *JobReportRequest) GetSince() *time.Time {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *JobReportRequest) GetUntil() *time.Time {
+	if m != nil {
+		return m.Until
+	}
+	return nil
+}
+
+func (m *JobReportRequest) GetCompress() bool {
+	if m != nil {
+		return m.Compress
+	}
+	return false
+}
+
 type JobReport struct {
 	Report string `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	// Set instead of report if the request set compress.
+	CompressedReport []byte         `protobuf:"bytes,2,opt,name=compressed_report,json=compressedReport,proto3" json:"compressedReport,omitempty"`
+	Encoding         ReportEncoding `protobuf:"varint,3,opt,name=encoding,proto3,enum=schedulerobjects.ReportEncoding" json:"encoding,omitempty"`
 }
 
 func (m *JobReport) Reset()         { *m = JobReport{} }
 func (m *JobReport) String() string { return proto.CompactTextString(m) }
 func (*JobReport) ProtoMessage()    {}
 func (*JobReport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_131a439a3ff6540b, []int{7}
+	return fileDescriptor_131a439a3ff6540b, []int{10}
 }
 func (m *JobReport) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -438,662 +928,7626 @@ func (m *JobReport) GetReport() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*MostRecentForQueue)(nil), "schedulerobjects.MostRecentForQueue")
-	proto.RegisterType((*MostRecentForJob)(nil), "schedulerobjects.MostRecentForJob")
-	proto.RegisterType((*SchedulingReportRequest)(nil), "schedulerobjects.SchedulingReportRequest")
-	proto.RegisterType((*SchedulingReport)(nil), "schedulerobjects.SchedulingReport")
-	proto.RegisterType((*QueueReportRequest)(nil), "schedulerobjects.QueueReportRequest")
-	proto.RegisterType((*QueueReport)(nil), "schedulerobjects.QueueReport")
-	proto.RegisterType((*JobReportRequest)(nil), "schedulerobjects.JobReportRequest")
-	proto.RegisterType((*JobReport)(nil), "schedulerobjects.JobReport")
+func (m *JobReport) GetCompressedReport() []byte {
+	if m != nil {
+		return m.CompressedReport
+	}
+	return nil
 }
 
-func init() {
-	proto.RegisterFile("internal/scheduler/schedulerobjects/reporting.proto", fileDescriptor_131a439a3ff6540b)
+func (m *JobReport) GetEncoding() ReportEncoding {
+	if m != nil {
+		return m.Encoding
+	}
+	return ReportEncoding_IDENTITY
 }
 
-var fileDescriptor_131a439a3ff6540b = []byte{
-	// 529 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcf, 0x6a, 0x13, 0x5f,
-	0x14, 0xce, 0xe4, 0x47, 0xc3, 0x2f, 0xa7, 0xa2, 0xc3, 0x8d, 0xd2, 0x90, 0xea, 0x24, 0x0c, 0x2e,
-	0xaa, 0x94, 0x0c, 0xb4, 0x28, 0x88, 0x50, 0x24, 0x82, 0xd1, 0xe0, 0x1f, 0x9c, 0xe2, 0x46, 0x90,
-	0x30, 0x37, 0x39, 0x49, 0x27, 0x64, 0xe6, 0xa4, 0x77, 0xee, 0x08, 0xc5, 0xa5, 0x2f, 0xe0, 0x33,
-	0xf8, 0x34, 0x2e, 0x5c, 0x74, 0xe9, 0x2a, 0x48, 0xb2, 0x9b, 0xa7, 0x90, 0xde, 0x49, 0x93, 0xf9,
-	0x53, 0xdb, 0xc6, 0xdd, 0xcc, 0x77, 0xcf, 0xfd, 0xce, 0xf9, 0xce, 0x77, 0xce, 0x85, 0x7d, 0xd7,
-	0x97, 0x28, 0x7c, 0x67, 0x6c, 0x05, 0xbd, 0x23, 0xec, 0x87, 0x63, 0x14, 0xab, 0x2f, 0xe2, 0x23,
-	0xec, 0xc9, 0xc0, 0x12, 0x38, 0x21, 0x21, 0x5d, 0x7f, 0xd8, 0x9c, 0x08, 0x92, 0xc4, 0xf4, 0x6c,
-	0x44, 0x6d, 0x7b, 0x48, 0x34, 0x1c, 0xa3, 0xa5, 0xce, 0x79, 0x38, 0xb0, 0xd0, 0x9b, 0xc8, 0x93,
-	0x38, 0xdc, 0x7c, 0x0d, 0xec, 0x0d, 0x05, 0xd2, 0xc6, 0x1e, 0xfa, 0xf2, 0x05, 0x89, 0xf7, 0x21,
-	0x86, 0xc8, 0x1e, 0x03, 0x1c, 0x9f, 0x7d, 0x74, 0x7d, 0xc7, 0xc3, 0xaa, 0xd6, 0xd0, 0x76, 0xca,
-	0xad, 0xad, 0x68, 0x5a, 0xaf, 0x28, 0xf4, 0xad, 0xe3, 0xe1, 0x2e, 0x79, 0xae, 0x54, 0x44, 0x76,
-	0x79, 0x09, 0x9a, 0x07, 0xa0, 0xa7, 0xd8, 0x3a, 0xc4, 0xd9, 0x43, 0x28, 0x8d, 0x88, 0x77, 0xdd,
-	0xfe, 0x82, 0xa7, 0x12, 0x4d, 0xeb, 0xb7, 0x46, 0xc4, 0x5f, 0xf5, 0x13, 0x1c, 0x1b, 0x0a, 0x30,
-	0x7f, 0x16, 0x61, 0xeb, 0x30, 0xae, 0xdf, 0xf5, 0x87, 0xb6, 0x92, 0x66, 0xe3, 0x71, 0x88, 0x81,
-	0x64, 0x5f, 0xe0, 0x8e, 0x47, 0x81, 0xec, 0x0a, 0x45, 0xde, 0x1d, 0x90, 0xe8, 0xaa, 0xc4, 0x8a,
-	0x76, 0x73, 0xef, 0x7e, 0x33, 0x2b, 0xbc, 0x99, 0x17, 0xd6, 0x6a, 0x44, 0xd3, 0xfa, 0x5d, 0x2f,
-	0x87, 0xaf, 0x2a, 0x79, 0x59, 0xb0, 0x59, 0xfe, 0x9c, 0x05, 0x50, 0xc9, 0x26, 0x1f, 0x11, 0xaf,
-	0x16, 0x55, 0x6a, 0xf3, 0x8a, 0xd4, 0x1d, 0xe2, 0x2d, 0x23, 0x9a, 0xd6, 0x6b, 0x5e, 0x06, 0x4d,
-	0xa5, 0xd5, 0xb3, 0xa7, 0xec, 0x11, 0x94, 0x3f, 0xa3, 0xe0, 0x14, 0xb8, 0xf2, 0xa4, 0xfa, 0x5f,
-	0x43, 0xdb, 0xd9, 0x88, 0x4d, 0x58, 0x82, 0x49, 0x13, 0x96, 0x60, 0xeb, 0x7f, 0x28, 0x0d, 0xdc,
-	0xb1, 0x44, 0x61, 0x3e, 0x03, 0x3d, 0xdb, 0x4d, 0xb6, 0x0b, 0xa5, 0x78, 0x64, 0x16, 0x76, 0xdc,
-	0x8e, 0xa6, 0x75, 0x3d, 0x46, 0x12, 0x74, 0x8b, 0x18, 0xf3, 0xab, 0x06, 0x4c, 0x75, 0x20, 0xed,
-	0xc5, 0x3f, 0xce, 0x47, 0x5a, 0x51, 0xf1, 0xba, 0x8a, 0xcc, 0xa7, 0xb0, 0x99, 0x28, 0x62, 0x4d,
-	0x09, 0x07, 0xa0, 0x77, 0x88, 0xa7, 0xeb, 0x5f, 0x67, 0x26, 0x9f, 0x40, 0x79, 0x79, 0x7f, 0xbd,
-	0xd4, 0x7b, 0xdf, 0x8b, 0xc0, 0x0e, 0xcf, 0x47, 0xc3, 0x3e, 0x5f, 0x54, 0xd6, 0x87, 0x4a, 0x1b,
-	0x65, 0xce, 0x99, 0x07, 0xf9, 0x31, 0xfa, 0xcb, 0x2e, 0xd4, 0xcc, 0xab, 0x43, 0xd9, 0x07, 0xb8,
-	0xd9, 0x46, 0x99, 0xec, 0xdb, 0x05, 0x2b, 0x92, 0xf7, 0xb6, 0x76, 0xef, 0xd2, 0x28, 0xf6, 0x0e,
-	0x6e, 0xb4, 0x51, 0xae, 0x3a, 0x72, 0x41, 0x29, 0xd9, 0x76, 0xd7, 0xb6, 0x2f, 0x89, 0x69, 0x7d,
-	0xfa, 0x31, 0x33, 0xb4, 0xd3, 0x99, 0xa1, 0xfd, 0x9e, 0x19, 0xda, 0xb7, 0xb9, 0x51, 0x38, 0x9d,
-	0x1b, 0x85, 0x5f, 0x73, 0xa3, 0xf0, 0xf1, 0xf9, 0xd0, 0x95, 0x47, 0x21, 0x6f, 0xf6, 0xc8, 0xb3,
-	0x1c, 0xe1, 0x39, 0x7d, 0x67, 0x22, 0xe8, 0xec, 0xfa, 0xe2, 0xcf, 0xba, 0xc6, 0xfb, 0xc8, 0x4b,
-	0xea, 0x9d, 0xdb, 0xff, 0x13, 0x00, 0x00, 0xff, 0xff, 0xf1, 0xb4, 0x16, 0xb6, 0x4d, 0x05, 0x00,
-	0x00,
+type JobSchedulingSummariesRequest struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *JobSchedulingSummariesRequest) Reset()         { *m = JobSchedulingSummariesRequest{} }
+func (m *JobSchedulingSummariesRequest) String() string { return proto.CompactTextString(m) }
+func (*JobSchedulingSummariesRequest) ProtoMessage()    {}
+func (*JobSchedulingSummariesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{11}
+}
+func (m *JobSchedulingSummariesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *JobSchedulingSummariesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobSchedulingSummariesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *JobSchedulingSummariesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobSchedulingSummariesRequest.Merge(m, src)
+}
+func (m *JobSchedulingSummariesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobSchedulingSummariesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobSchedulingSummariesRequest.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_JobSchedulingSummariesRequest proto.InternalMessageInfo
 
-// SchedulerReportingClient is the client API for SchedulerReporting service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SchedulerReportingClient interface {
-	// Return the most recent scheduling report for each executor.
-	GetSchedulingReport(ctx context.Context, in *SchedulingReportRequest, opts ...grpc.CallOption) (*SchedulingReport, error)
-	// Return the most recent report scheduling for each executor for the given queue.
-	GetQueueReport(ctx context.Context, in *QueueReportRequest, opts ...grpc.CallOption) (*QueueReport, error)
-	// Return the most recent scheduling report for each executor for the given job.
-	GetJobReport(ctx context.Context, in *JobReportRequest, opts ...grpc.CallOption) (*JobReport, error)
+func (m *JobSchedulingSummariesRequest) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
 }
 
-type schedulerReportingClient struct {
-	cc *grpc.ClientConn
+type JobSchedulingSummaries struct {
+	// Summary of the most recent attempt to schedule the job for each executor that attempted to, sorted by executor id.
+	JobSchedulingSummaries []*JobSchedulingSummary `protobuf:"bytes,1,rep,name=job_scheduling_summaries,json=jobSchedulingSummaries,proto3" json:"jobSchedulingSummaries,omitempty"`
 }
 
-func NewSchedulerReportingClient(cc *grpc.ClientConn) SchedulerReportingClient {
-	return &schedulerReportingClient{cc}
+func (m *JobSchedulingSummaries) Reset()         { *m = JobSchedulingSummaries{} }
+func (m *JobSchedulingSummaries) String() string { return proto.CompactTextString(m) }
+func (*JobSchedulingSummaries) ProtoMessage()    {}
+func (*JobSchedulingSummaries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{12}
 }
-
-func (c *schedulerReportingClient) GetSchedulingReport(ctx context.Context, in *SchedulingReportRequest, opts ...grpc.CallOption) (*SchedulingReport, error) {
-	out := new(SchedulingReport)
-	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetSchedulingReport", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *JobSchedulingSummaries) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *schedulerReportingClient) GetQueueReport(ctx context.Context, in *QueueReportRequest, opts ...grpc.CallOption) (*QueueReport, error) {
-	out := new(QueueReport)
-	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetQueueReport", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *JobSchedulingSummaries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobSchedulingSummaries.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *JobSchedulingSummaries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobSchedulingSummaries.Merge(m, src)
+}
+func (m *JobSchedulingSummaries) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobSchedulingSummaries) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobSchedulingSummaries.DiscardUnknown(m)
 }
 
-func (c *schedulerReportingClient) GetJobReport(ctx context.Context, in *JobReportRequest, opts ...grpc.CallOption) (*JobReport, error) {
-	out := new(JobReport)
-	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetJobReport", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_JobSchedulingSummaries proto.InternalMessageInfo
+
+func (m *JobSchedulingSummaries) GetJobSchedulingSummaries() []*JobSchedulingSummary {
+	if m != nil {
+		return m.JobSchedulingSummaries
 	}
-	return out, nil
+	return nil
 }
 
-// SchedulerReportingServer is the server API for SchedulerReporting service.
-type SchedulerReportingServer interface {
-	// Return the most recent scheduling report for each executor.
-	GetSchedulingReport(context.Context, *SchedulingReportRequest) (*SchedulingReport, error)
-	// Return the most recent report scheduling for each executor for the given queue.
-	GetQueueReport(context.Context, *QueueReportRequest) (*QueueReport, error)
-	// Return the most recent scheduling report for each executor for the given job.
-	GetJobReport(context.Context, *JobReportRequest) (*JobReport, error)
+// Structured representation of an attempt to schedule a job onto an executor.
+type JobSchedulingSummary struct {
+	ExecutorId string `protobuf:"bytes,1,opt,name=executor_id,json=executorId,proto3" json:"executorId,omitempty"`
+	// Pool of the executor; empty if the scheduling context of the executor is no longer stored.
+	Pool  string `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+	JobId string `protobuf:"bytes,3,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+	// Empty if the job isn't part of a gang.
+	GangId            string    `protobuf:"bytes,4,opt,name=gang_id,json=gangId,proto3" json:"gangId,omitempty"`
+	Created           time.Time `protobuf:"bytes,5,opt,name=created,proto3,stdtime" json:"created"`
+	PriorityClassName string    `protobuf:"bytes,6,opt,name=priority_class_name,json=priorityClassName,proto3" json:"priorityClassName,omitempty"`
+	Scheduled         bool      `protobuf:"varint,7,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	// Id of the node the job was assigned to; empty if the job wasn't scheduled.
+	NodeId string `protobuf:"bytes,8,opt,name=node_id,json=nodeId,proto3" json:"nodeId,omitempty"`
+	// Empty if the job was scheduled.
+	UnschedulableReason string `protobuf:"bytes,9,opt,name=unschedulable_reason,json=unschedulableReason,proto3" json:"unschedulableReason,omitempty"`
+	// Short machine-readable identifier of the constraint that prevented the job from being scheduled, if any.
+	UnschedulableReasonCode string `protobuf:"bytes,10,opt,name=unschedulable_reason_code,json=unschedulableReasonCode,proto3" json:"unschedulableReasonCode,omitempty"`
 }
 
-// UnimplementedSchedulerReportingServer can be embedded to have forward compatible implementations.
-type UnimplementedSchedulerReportingServer struct {
+func (m *JobSchedulingSummary) Reset()         { *m = JobSchedulingSummary{} }
+func (m *JobSchedulingSummary) String() string { return proto.CompactTextString(m) }
+func (*JobSchedulingSummary) ProtoMessage()    {}
+func (*JobSchedulingSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{13}
 }
-
-func (*UnimplementedSchedulerReportingServer) GetSchedulingReport(ctx context.Context, req *SchedulingReportRequest) (*SchedulingReport, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSchedulingReport not implemented")
+func (m *JobSchedulingSummary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedSchedulerReportingServer) GetQueueReport(ctx context.Context, req *QueueReportRequest) (*QueueReport, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetQueueReport not implemented")
+func (m *JobSchedulingSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_JobSchedulingSummary.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedSchedulerReportingServer) GetJobReport(ctx context.Context, req *JobReportRequest) (*JobReport, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetJobReport not implemented")
+func (m *JobSchedulingSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobSchedulingSummary.Merge(m, src)
 }
-
-func RegisterSchedulerReportingServer(s *grpc.Server, srv SchedulerReportingServer) {
-	s.RegisterService(&_SchedulerReporting_serviceDesc, srv)
+func (m *JobSchedulingSummary) XXX_Size() int {
+	return m.Size()
+}
+func (m *JobSchedulingSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobSchedulingSummary.DiscardUnknown(m)
 }
 
-func _SchedulerReporting_GetSchedulingReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SchedulingReportRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SchedulerReportingServer).GetSchedulingReport(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/schedulerobjects.SchedulerReporting/GetSchedulingReport",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerReportingServer).GetSchedulingReport(ctx, req.(*SchedulingReportRequest))
+var xxx_messageInfo_JobSchedulingSummary proto.InternalMessageInfo
+
+func (m *JobSchedulingSummary) GetExecutorId() string {
+	if m != nil {
+		return m.ExecutorId
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _SchedulerReporting_GetQueueReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueueReportRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SchedulerReportingServer).GetQueueReport(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/schedulerobjects.SchedulerReporting/GetQueueReport",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerReportingServer).GetQueueReport(ctx, req.(*QueueReportRequest))
+func (m *JobSchedulingSummary) GetPool() string {
+	if m != nil {
+		return m.Pool
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _SchedulerReporting_GetJobReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JobReportRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SchedulerReportingServer).GetJobReport(ctx, in)
+func (m *JobSchedulingSummary) GetJobId() string {
+	if m != nil {
+		return m.JobId
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/schedulerobjects.SchedulerReporting/GetJobReport",
+	return ""
+}
+
+func (m *JobSchedulingSummary) GetGangId() string {
+	if m != nil {
+		return m.GangId
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerReportingServer).GetJobReport(ctx, req.(*JobReportRequest))
+	return ""
+}
+
+func (m *JobSchedulingSummary) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
 	}
-	return interceptor(ctx, in, info, handler)
+	return time.Time{}
 }
 
-var _SchedulerReporting_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "schedulerobjects.SchedulerReporting",
-	HandlerType: (*SchedulerReportingServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetSchedulingReport",
-			Handler:    _SchedulerReporting_GetSchedulingReport_Handler,
-		},
-		{
-			MethodName: "GetQueueReport",
-			Handler:    _SchedulerReporting_GetQueueReport_Handler,
-		},
-		{
-			MethodName: "GetJobReport",
-			Handler:    _SchedulerReporting_GetJobReport_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "internal/scheduler/schedulerobjects/reporting.proto",
+func (m *JobSchedulingSummary) GetPriorityClassName() string {
+	if m != nil {
+		return m.PriorityClassName
+	}
+	return ""
 }
 
-func (m *MostRecentForQueue) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *JobSchedulingSummary) GetScheduled() bool {
+	if m != nil {
+		return m.Scheduled
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *MostRecentForQueue) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *JobSchedulingSummary) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
 }
 
-func (m *MostRecentForQueue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.QueueName) > 0 {
-		i -= len(m.QueueName)
-		copy(dAtA[i:], m.QueueName)
-		i = encodeVarintReporting(dAtA, i, uint64(len(m.QueueName)))
-		i--
-		dAtA[i] = 0xa
+func (m *JobSchedulingSummary) GetUnschedulableReason() string {
+	if m != nil {
+		return m.UnschedulableReason
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *MostRecentForJob) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *JobSchedulingSummary) GetUnschedulableReasonCode() string {
+	if m != nil {
+		return m.UnschedulableReasonCode
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *MostRecentForJob) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ExecutorSchedulingContextRequest struct {
+	ExecutorId string `protobuf:"bytes,1,opt,name=executor_id,json=executorId,proto3" json:"executorId,omitempty"`
 }
 
-func (m *MostRecentForJob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.JobId) > 0 {
-		i -= len(m.JobId)
-		copy(dAtA[i:], m.JobId)
-		i = encodeVarintReporting(dAtA, i, uint64(len(m.JobId)))
-		i--
-		dAtA[i] = 0xa
+func (m *ExecutorSchedulingContextRequest) Reset()         { *m = ExecutorSchedulingContextRequest{} }
+func (m *ExecutorSchedulingContextRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecutorSchedulingContextRequest) ProtoMessage()    {}
+func (*ExecutorSchedulingContextRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{14}
+}
+func (m *ExecutorSchedulingContextRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExecutorSchedulingContextRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExecutorSchedulingContextRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ExecutorSchedulingContextRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutorSchedulingContextRequest.Merge(m, src)
+}
+func (m *ExecutorSchedulingContextRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExecutorSchedulingContextRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutorSchedulingContextRequest.DiscardUnknown(m)
 }
 
-func (m *SchedulingReportRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ExecutorSchedulingContextRequest proto.InternalMessageInfo
+
+func (m *ExecutorSchedulingContextRequest) GetExecutorId() string {
+	if m != nil {
+		return m.ExecutorId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *SchedulingReportRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Representation of the most recent scheduling context for an executor.
+type ExecutorSchedulingContext struct {
+	ExecutorId                   string                 `protobuf:"bytes,1,opt,name=executor_id,json=executorId,proto3" json:"executorId,omitempty"`
+	Pool                         string                 `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+	Started                      time.Time              `protobuf:"bytes,3,opt,name=started,proto3,stdtime" json:"started"`
+	Finished                     time.Time              `protobuf:"bytes,4,opt,name=finished,proto3,stdtime" json:"finished"`
+	TotalResources               ResourceList           `protobuf:"bytes,5,opt,name=total_resources,json=totalResources,proto3" json:"totalResources"`
+	ScheduledResourcesByPriority map[int32]ResourceList `protobuf:"bytes,6,rep,name=scheduled_resources_by_priority,json=scheduledResourcesByPriority,proto3" json:"scheduledResourcesByPriority" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	EvictedResourcesByPriority   map[int32]ResourceList `protobuf:"bytes,7,rep,name=evicted_resources_by_priority,json=evictedResourcesByPriority,proto3" json:"evictedResourcesByPriority" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	NumScheduledJobs             int32                  `protobuf:"varint,8,opt,name=num_scheduled_jobs,json=numScheduledJobs,proto3" json:"numScheduledJobs,omitempty"`
+	NumScheduledGangs            int32                  `protobuf:"varint,9,opt,name=num_scheduled_gangs,json=numScheduledGangs,proto3" json:"numScheduledGangs,omitempty"`
+	NumEvictedJobs               int32                  `protobuf:"varint,10,opt,name=num_evicted_jobs,json=numEvictedJobs,proto3" json:"numEvictedJobs,omitempty"`
+	TerminationReason            string                 `protobuf:"bytes,11,opt,name=termination_reason,json=terminationReason,proto3" json:"terminationReason,omitempty"`
+	// Maps queue name to a summary of the attempt to schedule jobs from that queue.
+	QueueSchedulingSummaries map[string]*QueueSchedulingSummary `protobuf:"bytes,12,rep,name=queue_scheduling_summaries,json=queueSchedulingSummaries,proto3" json:"queueSchedulingSummaries,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (m *SchedulingReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Verbosity != 0 {
-		i = encodeVarintReporting(dAtA, i, uint64(m.Verbosity))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Filter != nil {
-		{
-			size := m.Filter.Size()
-			i -= size
-			if _, err := m.Filter.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
+func (m *ExecutorSchedulingContext) Reset()         { *m = ExecutorSchedulingContext{} }
+func (m *ExecutorSchedulingContext) String() string { return proto.CompactTextString(m) }
+func (*ExecutorSchedulingContext) ProtoMessage()    {}
+func (*ExecutorSchedulingContext) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{15}
+}
+func (m *ExecutorSchedulingContext) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExecutorSchedulingContext) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExecutorSchedulingContext.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *SchedulingReportRequest_MostRecentForQueue) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ExecutorSchedulingContext) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutorSchedulingContext.Merge(m, src)
+}
+func (m *ExecutorSchedulingContext) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExecutorSchedulingContext) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutorSchedulingContext.DiscardUnknown(m)
 }
 
-func (m *SchedulingReportRequest_MostRecentForQueue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.MostRecentForQueue != nil {
-		{
-			size, err := m.MostRecentForQueue.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintReporting(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+var xxx_messageInfo_ExecutorSchedulingContext proto.InternalMessageInfo
+
+func (m *ExecutorSchedulingContext) GetExecutorId() string {
+	if m != nil {
+		return m.ExecutorId
 	}
-	return len(dAtA) - i, nil
-}
-func (m *SchedulingReportRequest_MostRecentForJob) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return ""
 }
 
-func (m *SchedulingReportRequest_MostRecentForJob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.MostRecentForJob != nil {
-		{
-			size, err := m.MostRecentForJob.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintReporting(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func (m *ExecutorSchedulingContext) GetPool() string {
+	if m != nil {
+		return m.Pool
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
-func (m *SchedulingReport) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+
+func (m *ExecutorSchedulingContext) GetStarted() time.Time {
+	if m != nil {
+		return m.Started
 	}
-	return dAtA[:n], nil
+	return time.Time{}
 }
 
-func (m *SchedulingReport) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ExecutorSchedulingContext) GetFinished() time.Time {
+	if m != nil {
+		return m.Finished
+	}
+	return time.Time{}
 }
 
-func (m *SchedulingReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Report) > 0 {
-		i -= len(m.Report)
-		copy(dAtA[i:], m.Report)
-		i = encodeVarintReporting(dAtA, i, uint64(len(m.Report)))
-		i--
-		dAtA[i] = 0xa
+func (m *ExecutorSchedulingContext) GetTotalResources() ResourceList {
+	if m != nil {
+		return m.TotalResources
 	}
-	return len(dAtA) - i, nil
+	return ResourceList{}
 }
 
-func (m *QueueReportRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ExecutorSchedulingContext) GetScheduledResourcesByPriority() map[int32]ResourceList {
+	if m != nil {
+		return m.ScheduledResourcesByPriority
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *QueueReportRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ExecutorSchedulingContext) GetEvictedResourcesByPriority() map[int32]ResourceList {
+	if m != nil {
+		return m.EvictedResourcesByPriority
+	}
+	return nil
 }
 
-func (m *QueueReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Verbosity != 0 {
-		i = encodeVarintReporting(dAtA, i, uint64(m.Verbosity))
-		i--
-		dAtA[i] = 0x10
+func (m *ExecutorSchedulingContext) GetNumScheduledJobs() int32 {
+	if m != nil {
+		return m.NumScheduledJobs
 	}
-	if len(m.QueueName) > 0 {
-		i -= len(m.QueueName)
-		copy(dAtA[i:], m.QueueName)
-		i = encodeVarintReporting(dAtA, i, uint64(len(m.QueueName)))
-		i--
-		dAtA[i] = 0xa
+	return 0
+}
+
+func (m *ExecutorSchedulingContext) GetNumScheduledGangs() int32 {
+	if m != nil {
+		return m.NumScheduledGangs
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *QueueReport) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ExecutorSchedulingContext) GetNumEvictedJobs() int32 {
+	if m != nil {
+		return m.NumEvictedJobs
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *QueueReport) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ExecutorSchedulingContext) GetTerminationReason() string {
+	if m != nil {
+		return m.TerminationReason
+	}
+	return ""
 }
 
-func (m *QueueReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Report) > 0 {
-		i -= len(m.Report)
-		copy(dAtA[i:], m.Report)
-		i = encodeVarintReporting(dAtA, i, uint64(len(m.Report)))
-		i--
-		dAtA[i] = 0xa
+func (m *ExecutorSchedulingContext) GetQueueSchedulingSummaries() map[string]*QueueSchedulingSummary {
+	if m != nil {
+		return m.QueueSchedulingSummaries
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *JobReportRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+type CompareExecutorsRequest struct {
+	ExecutorIdA string       `protobuf:"bytes,1,opt,name=executor_id_a,json=executorIdA,proto3" json:"executorIdA,omitempty"`
+	ExecutorIdB string       `protobuf:"bytes,2,opt,name=executor_id_b,json=executorIdB,proto3" json:"executorIdB,omitempty"`
+	Format      ReportFormat `protobuf:"varint,3,opt,name=format,proto3,enum=schedulerobjects.ReportFormat" json:"format,omitempty"`
+}
+
+func (m *CompareExecutorsRequest) Reset()         { *m = CompareExecutorsRequest{} }
+func (m *CompareExecutorsRequest) String() string { return proto.CompactTextString(m) }
+func (*CompareExecutorsRequest) ProtoMessage()    {}
+func (*CompareExecutorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{16}
+}
+func (m *CompareExecutorsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompareExecutorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompareExecutorsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *CompareExecutorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompareExecutorsRequest.Merge(m, src)
+}
+func (m *CompareExecutorsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompareExecutorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompareExecutorsRequest.DiscardUnknown(m)
 }
 
-func (m *JobReportRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_CompareExecutorsRequest proto.InternalMessageInfo
+
+func (m *CompareExecutorsRequest) GetExecutorIdA() string {
+	if m != nil {
+		return m.ExecutorIdA
+	}
+	return ""
 }
 
-func (m *JobReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.JobId) > 0 {
-		i -= len(m.JobId)
-		copy(dAtA[i:], m.JobId)
-		i = encodeVarintReporting(dAtA, i, uint64(len(m.JobId)))
-		i--
-		dAtA[i] = 0xa
+func (m *CompareExecutorsRequest) GetExecutorIdB() string {
+	if m != nil {
+		return m.ExecutorIdB
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *JobReport) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *CompareExecutorsRequest) GetFormat() ReportFormat {
+	if m != nil {
+		return m.Format
 	}
-	return dAtA[:n], nil
+	return ReportFormat_TEXT
 }
 
-func (m *JobReport) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type CompareExecutorsReport struct {
+	// Differences between the most recent scheduling contexts of the two executors.
+	// Deltas are computed as the value for executor b minus the value for executor a.
+	Report string `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
 }
 
-func (m *JobReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Report) > 0 {
-		i -= len(m.Report)
-		copy(dAtA[i:], m.Report)
-		i = encodeVarintReporting(dAtA, i, uint64(len(m.Report)))
-		i--
-		dAtA[i] = 0xa
+func (m *CompareExecutorsReport) Reset()         { *m = CompareExecutorsReport{} }
+func (m *CompareExecutorsReport) String() string { return proto.CompactTextString(m) }
+func (*CompareExecutorsReport) ProtoMessage()    {}
+func (*CompareExecutorsReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{17}
+}
+func (m *CompareExecutorsReport) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompareExecutorsReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompareExecutorsReport.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *CompareExecutorsReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompareExecutorsReport.Merge(m, src)
+}
+func (m *CompareExecutorsReport) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompareExecutorsReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompareExecutorsReport.DiscardUnknown(m)
 }
 
-func encodeVarintReporting(dAtA []byte, offset int, v uint64) int {
-	offset -= sovReporting(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_CompareExecutorsReport proto.InternalMessageInfo
+
+func (m *CompareExecutorsReport) GetReport() string {
+	if m != nil {
+		return m.Report
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return ""
 }
-func (m *MostRecentForQueue) Size() (n int) {
-	if m == nil {
-		return 0
+
+type ClusterScheduledResourcesRequest struct {
+}
+
+func (m *ClusterScheduledResourcesRequest) Reset()         { *m = ClusterScheduledResourcesRequest{} }
+func (m *ClusterScheduledResourcesRequest) String() string { return proto.CompactTextString(m) }
+func (*ClusterScheduledResourcesRequest) ProtoMessage()    {}
+func (*ClusterScheduledResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{18}
+}
+func (m *ClusterScheduledResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClusterScheduledResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ClusterScheduledResourcesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	l = len(m.QueueName)
-	if l > 0 {
-		n += 1 + l + sovReporting(uint64(l))
+}
+func (m *ClusterScheduledResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterScheduledResourcesRequest.Merge(m, src)
+}
+func (m *ClusterScheduledResourcesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClusterScheduledResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterScheduledResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterScheduledResourcesRequest proto.InternalMessageInfo
+
+// Totals across the most recent scheduling contexts of all executors.
+type ClusterScheduledResources struct {
+	ScheduledResources  ResourceList `protobuf:"bytes,1,opt,name=scheduled_resources,json=scheduledResources,proto3" json:"scheduledResources"`
+	EvictedResources    ResourceList `protobuf:"bytes,2,opt,name=evicted_resources,json=evictedResources,proto3" json:"evictedResources"`
+	NumSuccessfulJobs   int32        `protobuf:"varint,3,opt,name=num_successful_jobs,json=numSuccessfulJobs,proto3" json:"numSuccessfulJobs,omitempty"`
+	NumUnsuccessfulJobs int32        `protobuf:"varint,4,opt,name=num_unsuccessful_jobs,json=numUnsuccessfulJobs,proto3" json:"numUnsuccessfulJobs,omitempty"`
+	// Number of executors the totals are computed over.
+	NumExecutors int32 `protobuf:"varint,5,opt,name=num_executors,json=numExecutors,proto3" json:"numExecutors,omitempty"`
+}
+
+func (m *ClusterScheduledResources) Reset()         { *m = ClusterScheduledResources{} }
+func (m *ClusterScheduledResources) String() string { return proto.CompactTextString(m) }
+func (*ClusterScheduledResources) ProtoMessage()    {}
+func (*ClusterScheduledResources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{19}
+}
+func (m *ClusterScheduledResources) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ClusterScheduledResources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ClusterScheduledResources.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *ClusterScheduledResources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterScheduledResources.Merge(m, src)
+}
+func (m *ClusterScheduledResources) XXX_Size() int {
+	return m.Size()
+}
+func (m *ClusterScheduledResources) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterScheduledResources.DiscardUnknown(m)
 }
 
-func (m *MostRecentForJob) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_ClusterScheduledResources proto.InternalMessageInfo
+
+func (m *ClusterScheduledResources) GetScheduledResources() ResourceList {
+	if m != nil {
+		return m.ScheduledResources
 	}
-	var l int
-	_ = l
-	l = len(m.JobId)
-	if l > 0 {
-		n += 1 + l + sovReporting(uint64(l))
+	return ResourceList{}
+}
+
+func (m *ClusterScheduledResources) GetEvictedResources() ResourceList {
+	if m != nil {
+		return m.EvictedResources
 	}
-	return n
+	return ResourceList{}
 }
 
-func (m *SchedulingReportRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ClusterScheduledResources) GetNumSuccessfulJobs() int32 {
+	if m != nil {
+		return m.NumSuccessfulJobs
 	}
-	var l int
-	_ = l
-	if m.Filter != nil {
-		n += m.Filter.Size()
+	return 0
+}
+
+func (m *ClusterScheduledResources) GetNumUnsuccessfulJobs() int32 {
+	if m != nil {
+		return m.NumUnsuccessfulJobs
 	}
-	if m.Verbosity != 0 {
-		n += 1 + sovReporting(uint64(m.Verbosity))
+	return 0
+}
+
+func (m *ClusterScheduledResources) GetNumExecutors() int32 {
+	if m != nil {
+		return m.NumExecutors
 	}
-	return n
+	return 0
 }
 
-func (m *SchedulingReportRequest_MostRecentForQueue) Size() (n int) {
-	if m == nil {
-		return 0
+type QueuesRequest struct {
+}
+
+func (m *QueuesRequest) Reset()         { *m = QueuesRequest{} }
+func (m *QueuesRequest) String() string { return proto.CompactTextString(m) }
+func (*QueuesRequest) ProtoMessage()    {}
+func (*QueuesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{20}
+}
+func (m *QueuesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueuesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueuesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	if m.MostRecentForQueue != nil {
-		l = m.MostRecentForQueue.Size()
-		n += 1 + l + sovReporting(uint64(l))
+}
+func (m *QueuesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueuesRequest.Merge(m, src)
+}
+func (m *QueuesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueuesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueuesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueuesRequest proto.InternalMessageInfo
+
+type Queues struct {
+	// Sorted names of all queues for which scheduling contexts are stored.
+	QueueNames []string `protobuf:"bytes,1,rep,name=queue_names,json=queueNames,proto3" json:"queueNames,omitempty"`
+}
+
+func (m *Queues) Reset()         { *m = Queues{} }
+func (m *Queues) String() string { return proto.CompactTextString(m) }
+func (*Queues) ProtoMessage()    {}
+func (*Queues) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{21}
+}
+func (m *Queues) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Queues) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Queues.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
 }
-func (m *SchedulingReportRequest_MostRecentForJob) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Queues) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Queues.Merge(m, src)
+}
+func (m *Queues) XXX_Size() int {
+	return m.Size()
+}
+func (m *Queues) XXX_DiscardUnknown() {
+	xxx_messageInfo_Queues.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Queues proto.InternalMessageInfo
+
+func (m *Queues) GetQueueNames() []string {
+	if m != nil {
+		return m.QueueNames
 	}
-	var l int
-	_ = l
-	if m.MostRecentForJob != nil {
-		l = m.MostRecentForJob.Size()
-		n += 1 + l + sovReporting(uint64(l))
+	return nil
+}
+
+type WatchSchedulingReportsRequest struct {
+	// If non-empty, only contexts of these executors are sent.
+	ExecutorIds []string `protobuf:"bytes,1,rep,name=executor_ids,json=executorIds,proto3" json:"executorIds,omitempty"`
+}
+
+func (m *WatchSchedulingReportsRequest) Reset()         { *m = WatchSchedulingReportsRequest{} }
+func (m *WatchSchedulingReportsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchSchedulingReportsRequest) ProtoMessage()    {}
+func (*WatchSchedulingReportsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{22}
+}
+func (m *WatchSchedulingReportsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchSchedulingReportsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchSchedulingReportsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
 }
-func (m *SchedulingReport) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *WatchSchedulingReportsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchSchedulingReportsRequest.Merge(m, src)
+}
+func (m *WatchSchedulingReportsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchSchedulingReportsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchSchedulingReportsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchSchedulingReportsRequest proto.InternalMessageInfo
+
+func (m *WatchSchedulingReportsRequest) GetExecutorIds() []string {
+	if m != nil {
+		return m.ExecutorIds
 	}
-	var l int
-	_ = l
-	l = len(m.Report)
-	if l > 0 {
-		n += 1 + l + sovReporting(uint64(l))
+	return nil
+}
+
+type SetExecutorDrainingRequest struct {
+	ExecutorId string `protobuf:"bytes,1,opt,name=executor_id,json=executorId,proto3" json:"executorId,omitempty"`
+	// If true, the executor is marked as draining; otherwise, it's marked as no longer draining.
+	Draining bool `protobuf:"varint,2,opt,name=draining,proto3" json:"draining,omitempty"`
+}
+
+func (m *SetExecutorDrainingRequest) Reset()         { *m = SetExecutorDrainingRequest{} }
+func (m *SetExecutorDrainingRequest) String() string { return proto.CompactTextString(m) }
+func (*SetExecutorDrainingRequest) ProtoMessage()    {}
+func (*SetExecutorDrainingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{23}
+}
+func (m *SetExecutorDrainingRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetExecutorDrainingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetExecutorDrainingRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *SetExecutorDrainingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetExecutorDrainingRequest.Merge(m, src)
+}
+func (m *SetExecutorDrainingRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetExecutorDrainingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetExecutorDrainingRequest.DiscardUnknown(m)
 }
 
-func (m *QueueReportRequest) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_SetExecutorDrainingRequest proto.InternalMessageInfo
+
+func (m *SetExecutorDrainingRequest) GetExecutorId() string {
+	if m != nil {
+		return m.ExecutorId
 	}
-	var l int
-	_ = l
-	l = len(m.QueueName)
-	if l > 0 {
-		n += 1 + l + sovReporting(uint64(l))
+	return ""
+}
+
+func (m *SetExecutorDrainingRequest) GetDraining() bool {
+	if m != nil {
+		return m.Draining
 	}
-	if m.Verbosity != 0 {
-		n += 1 + sovReporting(uint64(m.Verbosity))
+	return false
+}
+
+type SetExecutorDrainingResponse struct {
+	// Sorted ids of all draining executors after the request has been applied.
+	DrainingExecutorIds []string `protobuf:"bytes,1,rep,name=draining_executor_ids,json=drainingExecutorIds,proto3" json:"drainingExecutorIds,omitempty"`
+}
+
+func (m *SetExecutorDrainingResponse) Reset()         { *m = SetExecutorDrainingResponse{} }
+func (m *SetExecutorDrainingResponse) String() string { return proto.CompactTextString(m) }
+func (*SetExecutorDrainingResponse) ProtoMessage()    {}
+func (*SetExecutorDrainingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{24}
+}
+func (m *SetExecutorDrainingResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetExecutorDrainingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetExecutorDrainingResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetExecutorDrainingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetExecutorDrainingResponse.Merge(m, src)
+}
+func (m *SetExecutorDrainingResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetExecutorDrainingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetExecutorDrainingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetExecutorDrainingResponse proto.InternalMessageInfo
+
+func (m *SetExecutorDrainingResponse) GetDrainingExecutorIds() []string {
+	if m != nil {
+		return m.DrainingExecutorIds
+	}
+	return nil
+}
+
+type ForgetJobRequest struct {
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"jobId,omitempty"`
+}
+
+func (m *ForgetJobRequest) Reset()         { *m = ForgetJobRequest{} }
+func (m *ForgetJobRequest) String() string { return proto.CompactTextString(m) }
+func (*ForgetJobRequest) ProtoMessage()    {}
+func (*ForgetJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{25}
+}
+func (m *ForgetJobRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ForgetJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ForgetJobRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ForgetJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForgetJobRequest.Merge(m, src)
+}
+func (m *ForgetJobRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ForgetJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForgetJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForgetJobRequest proto.InternalMessageInfo
+
+func (m *ForgetJobRequest) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type ForgetJobResponse struct {
+	// True if any scheduling context of the job was removed.
+	Removed bool `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (m *ForgetJobResponse) Reset()         { *m = ForgetJobResponse{} }
+func (m *ForgetJobResponse) String() string { return proto.CompactTextString(m) }
+func (*ForgetJobResponse) ProtoMessage()    {}
+func (*ForgetJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{26}
+}
+func (m *ForgetJobResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ForgetJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ForgetJobResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ForgetJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForgetJobResponse.Merge(m, src)
+}
+func (m *ForgetJobResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ForgetJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForgetJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForgetJobResponse proto.InternalMessageInfo
+
+func (m *ForgetJobResponse) GetRemoved() bool {
+	if m != nil {
+		return m.Removed
+	}
+	return false
+}
+
+type SetQueuePausedRequest struct {
+	Queue string `protobuf:"bytes,1,opt,name=queue,proto3" json:"queue,omitempty"`
+	// If true, scheduling is paused for the queue; otherwise, it's resumed.
+	Paused bool `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+}
+
+func (m *SetQueuePausedRequest) Reset()         { *m = SetQueuePausedRequest{} }
+func (m *SetQueuePausedRequest) String() string { return proto.CompactTextString(m) }
+func (*SetQueuePausedRequest) ProtoMessage()    {}
+func (*SetQueuePausedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{27}
+}
+func (m *SetQueuePausedRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetQueuePausedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetQueuePausedRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetQueuePausedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetQueuePausedRequest.Merge(m, src)
+}
+func (m *SetQueuePausedRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetQueuePausedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetQueuePausedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetQueuePausedRequest proto.InternalMessageInfo
+
+func (m *SetQueuePausedRequest) GetQueue() string {
+	if m != nil {
+		return m.Queue
+	}
+	return ""
+}
+
+func (m *SetQueuePausedRequest) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+type SetQueuePausedResponse struct {
+	// Sorted names of all paused queues after the request has been applied.
+	PausedQueues []string `protobuf:"bytes,1,rep,name=paused_queues,json=pausedQueues,proto3" json:"pausedQueues,omitempty"`
+}
+
+func (m *SetQueuePausedResponse) Reset()         { *m = SetQueuePausedResponse{} }
+func (m *SetQueuePausedResponse) String() string { return proto.CompactTextString(m) }
+func (*SetQueuePausedResponse) ProtoMessage()    {}
+func (*SetQueuePausedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_131a439a3ff6540b, []int{28}
+}
+func (m *SetQueuePausedResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetQueuePausedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetQueuePausedResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetQueuePausedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetQueuePausedResponse.Merge(m, src)
+}
+func (m *SetQueuePausedResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetQueuePausedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetQueuePausedResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetQueuePausedResponse proto.InternalMessageInfo
+
+func (m *SetQueuePausedResponse) GetPausedQueues() []string {
+	if m != nil {
+		return m.PausedQueues
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("schedulerobjects.ReportFormat", ReportFormat_name, ReportFormat_value)
+	proto.RegisterEnum("schedulerobjects.ReportVerbosity", ReportVerbosity_name, ReportVerbosity_value)
+	proto.RegisterEnum("schedulerobjects.ReportEncoding", ReportEncoding_name, ReportEncoding_value)
+	proto.RegisterType((*MostRecentForQueue)(nil), "schedulerobjects.MostRecentForQueue")
+	proto.RegisterType((*MostRecentForJob)(nil), "schedulerobjects.MostRecentForJob")
+	proto.RegisterType((*MostRecentForPool)(nil), "schedulerobjects.MostRecentForPool")
+	proto.RegisterType((*SchedulingReportRequest)(nil), "schedulerobjects.SchedulingReportRequest")
+	proto.RegisterType((*SchedulingReport)(nil), "schedulerobjects.SchedulingReport")
+	proto.RegisterType((*QueueReportRequest)(nil), "schedulerobjects.QueueReportRequest")
+	proto.RegisterType((*QueueReport)(nil), "schedulerobjects.QueueReport")
+	proto.RegisterType((*ExecutorQueueReport)(nil), "schedulerobjects.ExecutorQueueReport")
+	proto.RegisterType((*QueueSchedulingSummary)(nil), "schedulerobjects.QueueSchedulingSummary")
+	proto.RegisterMapType((map[int32]ResourceList)(nil), "schedulerobjects.QueueSchedulingSummary.EvictedResourcesByPriorityEntry")
+	proto.RegisterMapType((map[int32]ResourceList)(nil), "schedulerobjects.QueueSchedulingSummary.ScheduledResourcesByPriorityEntry")
+	proto.RegisterType((*JobReportRequest)(nil), "schedulerobjects.JobReportRequest")
+	proto.RegisterType((*JobReport)(nil), "schedulerobjects.JobReport")
+	proto.RegisterType((*JobSchedulingSummariesRequest)(nil), "schedulerobjects.JobSchedulingSummariesRequest")
+	proto.RegisterType((*JobSchedulingSummaries)(nil), "schedulerobjects.JobSchedulingSummaries")
+	proto.RegisterType((*JobSchedulingSummary)(nil), "schedulerobjects.JobSchedulingSummary")
+	proto.RegisterType((*ExecutorSchedulingContextRequest)(nil), "schedulerobjects.ExecutorSchedulingContextRequest")
+	proto.RegisterType((*ExecutorSchedulingContext)(nil), "schedulerobjects.ExecutorSchedulingContext")
+	proto.RegisterMapType((map[int32]ResourceList)(nil), "schedulerobjects.ExecutorSchedulingContext.EvictedResourcesByPriorityEntry")
+	proto.RegisterMapType((map[string]*QueueSchedulingSummary)(nil), "schedulerobjects.ExecutorSchedulingContext.QueueSchedulingSummariesEntry")
+	proto.RegisterMapType((map[int32]ResourceList)(nil), "schedulerobjects.ExecutorSchedulingContext.ScheduledResourcesByPriorityEntry")
+	proto.RegisterType((*CompareExecutorsRequest)(nil), "schedulerobjects.CompareExecutorsRequest")
+	proto.RegisterType((*CompareExecutorsReport)(nil), "schedulerobjects.CompareExecutorsReport")
+	proto.RegisterType((*ClusterScheduledResourcesRequest)(nil), "schedulerobjects.ClusterScheduledResourcesRequest")
+	proto.RegisterType((*ClusterScheduledResources)(nil), "schedulerobjects.ClusterScheduledResources")
+	proto.RegisterType((*QueuesRequest)(nil), "schedulerobjects.QueuesRequest")
+	proto.RegisterType((*Queues)(nil), "schedulerobjects.Queues")
+	proto.RegisterType((*WatchSchedulingReportsRequest)(nil), "schedulerobjects.WatchSchedulingReportsRequest")
+	proto.RegisterType((*SetExecutorDrainingRequest)(nil), "schedulerobjects.SetExecutorDrainingRequest")
+	proto.RegisterType((*SetExecutorDrainingResponse)(nil), "schedulerobjects.SetExecutorDrainingResponse")
+	proto.RegisterType((*ForgetJobRequest)(nil), "schedulerobjects.ForgetJobRequest")
+	proto.RegisterType((*ForgetJobResponse)(nil), "schedulerobjects.ForgetJobResponse")
+	proto.RegisterType((*SetQueuePausedRequest)(nil), "schedulerobjects.SetQueuePausedRequest")
+	proto.RegisterType((*SetQueuePausedResponse)(nil), "schedulerobjects.SetQueuePausedResponse")
+}
+
+func init() {
+	proto.RegisterFile("internal/scheduler/schedulerobjects/reporting.proto", fileDescriptor_131a439a3ff6540b)
+}
+
+var fileDescriptor_131a439a3ff6540b = []byte{
+	// 2609 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3a, 0xcd, 0x6f, 0x1b, 0xc7,
+	0xf5, 0x5a, 0x52, 0x94, 0xc8, 0xa7, 0x2f, 0x6a, 0x28, 0xcb, 0x14, 0x6d, 0x69, 0xe5, 0xb5, 0xe3,
+	0x9f, 0xe2, 0xd8, 0x52, 0x20, 0xe3, 0x17, 0xb4, 0x41, 0x9b, 0xc0, 0x94, 0x25, 0x59, 0xb2, 0x2c,
+	0x2b, 0xa4, 0xd4, 0xc6, 0x41, 0x0d, 0x62, 0x49, 0x8e, 0xa8, 0x95, 0xb9, 0x3b, 0xf4, 0xee, 0xd2,
+	0xb5, 0xd0, 0x43, 0x81, 0xa2, 0xed, 0xa1, 0x3d, 0x34, 0x97, 0xa2, 0xa7, 0x1e, 0x72, 0x28, 0xd0,
+	0x5b, 0x81, 0x5e, 0x0a, 0xf4, 0x92, 0x63, 0x73, 0x29, 0x90, 0x53, 0x91, 0x43, 0xb1, 0x2d, 0x6c,
+	0xf4, 0xb2, 0x7f, 0x45, 0xb1, 0x33, 0xfb, 0x31, 0xfb, 0x41, 0x91, 0xb4, 0x9d, 0x14, 0x28, 0x7a,
+	0xe3, 0xbe, 0xaf, 0x79, 0xf3, 0xe6, 0x7d, 0xcd, 0x1b, 0xc2, 0x6d, 0x45, 0x33, 0xb1, 0xae, 0xc9,
+	0xed, 0x35, 0xa3, 0x71, 0x82, 0x9b, 0xdd, 0x36, 0xd6, 0x83, 0x5f, 0xa4, 0x7e, 0x8a, 0x1b, 0xa6,
+	0xb1, 0xa6, 0xe3, 0x0e, 0xd1, 0x4d, 0x45, 0x6b, 0xad, 0x76, 0x74, 0x62, 0x12, 0x94, 0x8f, 0x52,
+	0x94, 0x2e, 0xb5, 0x08, 0x69, 0xb5, 0xf1, 0x1a, 0xc5, 0xd7, 0xbb, 0xc7, 0x6b, 0x58, 0xed, 0x98,
+	0x67, 0x8c, 0xbc, 0x24, 0x46, 0x91, 0xa6, 0xa2, 0x62, 0xc3, 0x94, 0xd5, 0x8e, 0x4b, 0x70, 0xab,
+	0xa5, 0x98, 0x27, 0xdd, 0xfa, 0x6a, 0x83, 0xa8, 0x6b, 0x2d, 0xd2, 0x22, 0x01, 0xa5, 0xf3, 0x45,
+	0x3f, 0xe8, 0x2f, 0x97, 0xfc, 0xfd, 0x41, 0x74, 0x8e, 0x02, 0x18, 0xaf, 0xb4, 0x07, 0xe8, 0x01,
+	0x31, 0xcc, 0x0a, 0x6e, 0x60, 0xcd, 0xdc, 0x22, 0xfa, 0x47, 0x5d, 0xdc, 0xc5, 0xe8, 0x3d, 0x80,
+	0xa7, 0xce, 0x8f, 0x9a, 0x26, 0xab, 0xb8, 0x28, 0x2c, 0x0b, 0x2b, 0xb9, 0xf2, 0x45, 0xdb, 0x12,
+	0x0b, 0x14, 0xba, 0x2f, 0xab, 0xf8, 0x26, 0x51, 0x15, 0x93, 0x6e, 0xaa, 0x92, 0xf3, 0x81, 0xd2,
+	0xcf, 0x04, 0xc8, 0x87, 0xc4, 0xed, 0x92, 0x3a, 0xba, 0x01, 0x63, 0xa7, 0xa4, 0x5e, 0x53, 0x9a,
+	0xae, 0xa0, 0x82, 0x6d, 0x89, 0x33, 0xa7, 0xa4, 0xbe, 0xd3, 0xe4, 0x84, 0x64, 0x28, 0x00, 0x6d,
+	0xc2, 0x0c, 0x7e, 0xde, 0x68, 0x77, 0x9b, 0xb8, 0x86, 0x9f, 0x29, 0x0d, 0x13, 0x37, 0x8b, 0xa9,
+	0x65, 0x61, 0x25, 0x5b, 0xbe, 0x6c, 0x5b, 0x62, 0xd1, 0x45, 0x6d, 0x32, 0x0c, 0xc7, 0x3d, 0x1d,
+	0xc6, 0x48, 0xf7, 0x60, 0x36, 0xa4, 0xc6, 0x01, 0x21, 0x6d, 0x74, 0x1b, 0x72, 0x1d, 0x42, 0xda,
+	0xfc, 0x9e, 0xe6, 0x6d, 0x4b, 0x44, 0x0e, 0x30, 0xb2, 0xa5, 0xac, 0x07, 0x93, 0x3e, 0x1b, 0x87,
+	0x8b, 0x55, 0x66, 0x3a, 0x45, 0x6b, 0x55, 0xe8, 0xc1, 0x57, 0xf0, 0xd3, 0x2e, 0x36, 0x4c, 0xf4,
+	0x23, 0xb8, 0xa0, 0x12, 0xc3, 0xac, 0xe9, 0x74, 0x99, 0xda, 0x31, 0xd1, 0x6b, 0xd4, 0x14, 0x54,
+	0xf8, 0xc4, 0xfa, 0xb5, 0xd5, 0x98, 0xcd, 0xe3, 0xa6, 0x2e, 0x2f, 0xdb, 0x96, 0x78, 0x59, 0x8d,
+	0xc1, 0x03, 0x65, 0xee, 0x8d, 0x54, 0x50, 0x1c, 0x8f, 0x0c, 0x28, 0x44, 0x17, 0x3f, 0x25, 0x75,
+	0x6a, 0xad, 0x89, 0x75, 0xa9, 0xcf, 0xd2, 0xbb, 0xa4, 0x5e, 0x5e, 0xb2, 0x2d, 0xb1, 0xa4, 0x46,
+	0xa0, 0xa1, 0x65, 0xf3, 0x51, 0x2c, 0xfa, 0x21, 0xcc, 0x45, 0x17, 0x75, 0x2c, 0x55, 0xcc, 0xd0,
+	0x55, 0xaf, 0xf6, 0x59, 0xd5, 0x39, 0x85, 0xb2, 0x68, 0x5b, 0xe2, 0x25, 0x35, 0x0a, 0x0e, 0xad,
+	0x3b, 0x1b, 0x43, 0xa3, 0x8f, 0x21, 0xf7, 0x0c, 0xeb, 0x75, 0x62, 0x28, 0xe6, 0x59, 0x31, 0xbd,
+	0x2c, 0xac, 0x4c, 0xaf, 0x5f, 0x89, 0xaf, 0xc6, 0x8e, 0xe7, 0x7b, 0x1e, 0x21, 0x73, 0x59, 0x9f,
+	0x8f, 0x77, 0x59, 0x1f, 0x88, 0xf6, 0x60, 0xec, 0x98, 0xe8, 0xaa, 0x6c, 0x16, 0x47, 0xa9, 0xd8,
+	0xa5, 0x5e, 0x62, 0xb7, 0x28, 0x55, 0x79, 0xce, 0xb6, 0xc4, 0x3c, 0xe3, 0xe0, 0x04, 0xba, 0x32,
+	0xd0, 0x1a, 0x8c, 0x9f, 0x28, 0x86, 0x49, 0xf4, 0xb3, 0xe2, 0xd8, 0xb2, 0xb0, 0x32, 0x55, 0xbe,
+	0x60, 0x5b, 0xe2, 0xac, 0x0b, 0xe2, 0xe8, 0x3d, 0x2a, 0x74, 0x0f, 0xf2, 0xf8, 0x39, 0x6e, 0x74,
+	0x4d, 0xc7, 0x94, 0xb2, 0xe9, 0xc4, 0x71, 0x71, 0x9c, 0xfa, 0xe6, 0xa2, 0x6d, 0x89, 0x0b, 0x1e,
+	0xee, 0x80, 0xa1, 0x38, 0x09, 0x33, 0x11, 0x14, 0xaa, 0xc1, 0x42, 0x54, 0x52, 0x4d, 0x31, 0x6a,
+	0x3a, 0x6e, 0xe1, 0xe7, 0xc5, 0x2c, 0x0d, 0xa2, 0x6b, 0xb6, 0x25, 0x2e, 0x47, 0xf8, 0x76, 0x8c,
+	0x8a, 0x43, 0xc1, 0x49, 0x9e, 0x4f, 0xa6, 0x40, 0x8f, 0x60, 0x4a, 0x55, 0xb4, 0x9a, 0x8e, 0x0d,
+	0xd2, 0xd5, 0x1b, 0xd8, 0x28, 0xe6, 0xe8, 0xa9, 0x27, 0x1a, 0x8c, 0x91, 0xec, 0x29, 0x86, 0x59,
+	0x9e, 0xfb, 0xc2, 0x12, 0x47, 0x6c, 0x4b, 0x9c, 0x54, 0x15, 0xcd, 0x43, 0x18, 0x95, 0xd0, 0x17,
+	0x5a, 0x87, 0x6c, 0x83, 0xa8, 0x1d, 0x1d, 0x1b, 0x46, 0x11, 0xa8, 0xaa, 0x34, 0x32, 0x3d, 0x18,
+	0x1f, 0x99, 0x1e, 0xac, 0x9c, 0x85, 0xb1, 0x63, 0xa5, 0x6d, 0x62, 0x5d, 0x7a, 0x29, 0x40, 0x3e,
+	0x1a, 0xa3, 0xe8, 0x26, 0x8c, 0xb1, 0x34, 0xed, 0x86, 0x3a, 0x3d, 0x37, 0x06, 0xe1, 0xcf, 0x8d,
+	0x41, 0xd0, 0x7d, 0x98, 0xf5, 0x04, 0xe3, 0x66, 0xcd, 0x65, 0x74, 0x62, 0x69, 0x92, 0xc5, 0x49,
+	0x80, 0xac, 0x44, 0x45, 0xe4, 0xa3, 0x38, 0x74, 0x08, 0x59, 0xac, 0x35, 0x48, 0x53, 0xd1, 0x5a,
+	0xae, 0xaf, 0x2e, 0xf7, 0x72, 0xaa, 0x4d, 0x97, 0x8e, 0xed, 0xd7, 0xe3, 0xe2, 0xf7, 0xeb, 0xc1,
+	0xa4, 0xdf, 0xa7, 0x00, 0xd1, 0xd0, 0x0f, 0x27, 0xa1, 0x57, 0x4c, 0xd5, 0xe1, 0x88, 0x4a, 0x7d,
+	0x3d, 0x11, 0x95, 0x7e, 0x03, 0x11, 0xc5, 0xbb, 0xc6, 0xe8, 0x60, 0xae, 0x21, 0xd9, 0x29, 0x98,
+	0xe0, 0x4c, 0x35, 0xa4, 0x2f, 0xfc, 0x00, 0x72, 0x5e, 0x04, 0x18, 0xc5, 0xd4, 0x72, 0x7a, 0x65,
+	0x62, 0xfd, 0xad, 0xf8, 0x16, 0x36, 0x5d, 0x12, 0x6e, 0x1d, 0x66, 0x1d, 0x9f, 0x97, 0xb7, 0x8e,
+	0x0f, 0x4c, 0xf6, 0xb4, 0xf4, 0x1b, 0xf0, 0xb4, 0xd1, 0x37, 0xe5, 0x69, 0x8e, 0xb9, 0x3a, 0x72,
+	0xd7, 0xc0, 0x4d, 0x9a, 0xd7, 0xb3, 0xcc, 0x5c, 0x0c, 0xc2, 0x9b, 0x8b, 0x41, 0xa4, 0xcf, 0xd3,
+	0x50, 0x48, 0x30, 0x06, 0xfa, 0x36, 0x4c, 0xf8, 0xf9, 0xc8, 0xaf, 0xfd, 0x45, 0xdb, 0x12, 0xe7,
+	0x3c, 0x70, 0xa8, 0x01, 0x80, 0x00, 0x8a, 0x1a, 0x30, 0xc1, 0x95, 0x19, 0xb7, 0xa6, 0xad, 0xc4,
+	0x77, 0x46, 0x97, 0x0b, 0x22, 0xbf, 0xda, 0x55, 0x55, 0x59, 0x3f, 0x63, 0x8b, 0x04, 0x35, 0x84,
+	0x5f, 0x24, 0x80, 0xa2, 0x9f, 0x08, 0x30, 0xcf, 0x17, 0x33, 0xa3, 0xdb, 0x68, 0x60, 0xc3, 0x38,
+	0xee, 0xb6, 0x8b, 0xe9, 0x21, 0x17, 0x94, 0x6c, 0x4b, 0x5c, 0x0a, 0x44, 0x57, 0x7d, 0x49, 0xdc,
+	0xd2, 0x73, 0x49, 0xf8, 0x98, 0x12, 0x1d, 0x1d, 0x3b, 0xe4, 0xde, 0x79, 0xbe, 0x86, 0x12, 0x07,
+	0xbe, 0xa4, 0x64, 0x25, 0x02, 0xbc, 0xf4, 0xd7, 0x2c, 0xcc, 0x27, 0x0b, 0x45, 0x3b, 0x30, 0xde,
+	0xd0, 0xb1, 0x6c, 0x62, 0x76, 0x80, 0x13, 0xeb, 0xa5, 0x55, 0xd6, 0xbc, 0xae, 0x7a, 0x2d, 0xe9,
+	0xea, 0xa1, 0xd7, 0xbc, 0x96, 0x0b, 0x6e, 0xa6, 0xf7, 0x58, 0x3e, 0xfd, 0x87, 0x28, 0x54, 0xbc,
+	0x0f, 0xf4, 0x27, 0x01, 0x44, 0x6f, 0x2f, 0xcd, 0xa0, 0x8a, 0xd4, 0xea, 0x67, 0xb5, 0x8e, 0xae,
+	0x10, 0x9d, 0xe5, 0x21, 0x27, 0xda, 0x76, 0x07, 0xdd, 0xf3, 0x6a, 0xd5, 0x93, 0xe7, 0x97, 0x92,
+	0xf2, 0xd9, 0x81, 0x2b, 0x6c, 0x53, 0x33, 0xf5, 0xb3, 0xf2, 0x35, 0x57, 0xa7, 0xcb, 0xc6, 0x39,
+	0xa4, 0x95, 0x73, 0xb1, 0xe8, 0x0f, 0x02, 0x2c, 0xba, 0xdd, 0x68, 0x0f, 0xbd, 0xd3, 0x54, 0xef,
+	0x7b, 0x03, 0xeb, 0xed, 0xf6, 0xa9, 0x3d, 0xb5, 0x96, 0x5c, 0xad, 0x4b, 0xb8, 0x27, 0x61, 0xe5,
+	0x1c, 0x1c, 0xfa, 0xa9, 0x00, 0xd7, 0xb5, 0xae, 0xca, 0xf9, 0xb4, 0xd3, 0x1c, 0xd6, 0x0c, 0x5f,
+	0x91, 0x5a, 0x83, 0x68, 0x26, 0x7e, 0x6e, 0xb2, 0x9c, 0x9a, 0x29, 0xbf, 0x6b, 0x5b, 0xe2, 0x4d,
+	0xad, 0xab, 0x06, 0xae, 0xb9, 0x4b, 0xea, 0x81, 0xde, 0x1b, 0x2e, 0x35, 0xe7, 0x4a, 0x52, 0x7f,
+	0x6a, 0xf4, 0x0b, 0x01, 0x56, 0x1c, 0x35, 0xba, 0xda, 0x00, 0x8a, 0x64, 0xa8, 0x22, 0xeb, 0xb6,
+	0x25, 0xae, 0x6a, 0x5d, 0xf5, 0x48, 0x33, 0xce, 0x17, 0xce, 0xa9, 0x72, 0x6d, 0x10, 0x7a, 0xa7,
+	0x50, 0x1e, 0xcb, 0x8a, 0x5e, 0x33, 0x4e, 0x64, 0x1d, 0xd3, 0xee, 0x4c, 0x60, 0x09, 0xdb, 0x81,
+	0x56, 0x1d, 0x20, 0x9f, 0xb0, 0x7d, 0x60, 0xe9, 0x37, 0x02, 0x5c, 0xe9, 0xeb, 0x67, 0xe8, 0x2a,
+	0xa4, 0x9f, 0xe0, 0x33, 0x1a, 0x24, 0x99, 0xf2, 0xac, 0x6d, 0x89, 0x53, 0x4f, 0x30, 0x5f, 0x1f,
+	0x1d, 0x2c, 0xda, 0x81, 0xcc, 0x33, 0xb9, 0xdd, 0xc5, 0x6e, 0x46, 0xeb, 0xd7, 0x39, 0xd1, 0x8b,
+	0x12, 0x65, 0xe0, 0x2f, 0x4a, 0x14, 0xf0, 0x7e, 0xea, 0x5b, 0x42, 0xe9, 0xd7, 0x02, 0x88, 0x7d,
+	0x3c, 0xe9, 0x3f, 0xa1, 0x97, 0xf4, 0xb7, 0x14, 0xe4, 0x77, 0x49, 0x3d, 0xdc, 0xa7, 0x0c, 0x73,
+	0x0b, 0x0c, 0x3a, 0x88, 0xd4, 0x1b, 0xe8, 0x20, 0x76, 0x20, 0x63, 0x28, 0x5a, 0x03, 0x17, 0xd3,
+	0x7d, 0x33, 0x98, 0xe3, 0x0f, 0x33, 0x94, 0x38, 0x90, 0x43, 0xb3, 0x18, 0x93, 0xe0, 0x88, 0xea,
+	0x6a, 0xa6, 0xd2, 0x2e, 0x8e, 0x0e, 0x26, 0x8a, 0x12, 0x47, 0x45, 0x51, 0x60, 0xa8, 0xaf, 0xc9,
+	0x0c, 0xd8, 0xd7, 0xfc, 0x5d, 0x80, 0x9c, 0x6f, 0xd8, 0xff, 0xbe, 0x0e, 0xf7, 0x3e, 0x2c, 0x86,
+	0x42, 0x97, 0x65, 0x4b, 0x05, 0x1b, 0xaf, 0xe0, 0x43, 0xd2, 0x67, 0x02, 0xcc, 0x27, 0x4b, 0x43,
+	0x3f, 0x17, 0xa0, 0x18, 0xc9, 0x42, 0x86, 0x87, 0x2c, 0x0a, 0x34, 0x95, 0x5f, 0x8f, 0x6f, 0x27,
+	0x41, 0xd8, 0x19, 0xbb, 0x51, 0x9d, 0x26, 0x2e, 0xc3, 0xdf, 0xa8, 0x92, 0x29, 0xa4, 0x5f, 0x65,
+	0x60, 0x2e, 0x49, 0xec, 0xeb, 0xf4, 0x4e, 0xd7, 0x61, 0x94, 0x5e, 0xc9, 0x53, 0x94, 0x07, 0xd9,
+	0x96, 0x38, 0xdd, 0x09, 0x5d, 0xb0, 0x2b, 0x14, 0xcf, 0xd9, 0x32, 0xdd, 0x37, 0x1e, 0x6f, 0xc1,
+	0x78, 0x4b, 0xd6, 0x5a, 0x0e, 0xf1, 0x68, 0xe0, 0x6a, 0x0e, 0x28, 0x44, 0x3d, 0xc6, 0x20, 0x7c,
+	0xd3, 0x90, 0x79, 0xcd, 0xa6, 0xe1, 0x21, 0x14, 0xbc, 0x22, 0x5b, 0x6b, 0xb4, 0x65, 0xc3, 0x60,
+	0xd7, 0x9c, 0x31, 0xaa, 0x05, 0x1d, 0x25, 0x78, 0xe8, 0x0d, 0x07, 0x1b, 0xb9, 0xee, 0xcc, 0xc6,
+	0x90, 0xe8, 0xff, 0x21, 0xe7, 0xd7, 0x7a, 0x7a, 0xd1, 0xce, 0xb2, 0x22, 0xe0, 0x03, 0xf9, 0x22,
+	0xe0, 0x03, 0x1d, 0x0b, 0x68, 0xa4, 0x89, 0x1d, 0x0b, 0x64, 0x03, 0x0b, 0x38, 0xa0, 0xb0, 0x05,
+	0x18, 0x04, 0x1d, 0xc2, 0x5c, 0x57, 0x73, 0xb9, 0xe5, 0x7a, 0x1b, 0xd7, 0x74, 0x2c, 0x1b, 0x44,
+	0xa3, 0x37, 0xe6, 0x5c, 0xf9, 0x8a, 0x6d, 0x89, 0x8b, 0x21, 0x7c, 0x85, 0xa2, 0x39, 0x41, 0x85,
+	0x04, 0x34, 0x92, 0x61, 0x21, 0x49, 0x6a, 0xad, 0x41, 0x9a, 0x98, 0x5e, 0x9b, 0x73, 0xe5, 0xb7,
+	0x6c, 0x4b, 0xbc, 0x92, 0xc0, 0xbb, 0x41, 0x9a, 0xbc, 0x61, 0x2e, 0xf6, 0x20, 0x91, 0x1e, 0xc3,
+	0xb2, 0xd7, 0xcb, 0xc7, 0x4a, 0xa8, 0x17, 0x85, 0xaf, 0xee, 0x9c, 0xd2, 0xef, 0xa6, 0x60, 0xa1,
+	0xa7, 0xfc, 0x6f, 0xc2, 0xeb, 0x77, 0x60, 0xdc, 0x30, 0x65, 0xdd, 0xc4, 0xcc, 0xed, 0x07, 0x74,
+	0x4d, 0x97, 0x85, 0xb9, 0xa6, 0xfb, 0x81, 0xf6, 0x20, 0x7b, 0xac, 0x68, 0x8a, 0x71, 0x82, 0x9b,
+	0x03, 0x94, 0x03, 0x6f, 0x0a, 0xe2, 0xf3, 0x50, 0x61, 0xfe, 0x17, 0xaa, 0xc1, 0x8c, 0x49, 0x4c,
+	0xb9, 0xcd, 0x8d, 0x57, 0x32, 0x03, 0x15, 0xe3, 0x79, 0x57, 0xf0, 0x34, 0x65, 0x0f, 0x06, 0x2c,
+	0x91, 0x6f, 0xf4, 0xe7, 0x01, 0xda, 0xef, 0x31, 0x9a, 0xfb, 0x1e, 0xf4, 0xbe, 0xec, 0xc6, 0xce,
+	0xec, 0x1b, 0xea, 0xc0, 0xff, 0xd8, 0xb7, 0x03, 0x1f, 0xa7, 0xaa, 0xdf, 0x1f, 0x46, 0xf5, 0xaf,
+	0xbb, 0x09, 0xdf, 0x03, 0x44, 0x7b, 0x70, 0xdf, 0xe8, 0xa7, 0xa4, 0x6e, 0xd0, 0xf4, 0x91, 0x61,
+	0x25, 0xd7, 0xe9, 0xa0, 0x3d, 0xe4, 0x2e, 0xa9, 0xf3, 0x15, 0x23, 0x1f, 0xc5, 0x39, 0x99, 0x30,
+	0x2c, 0xcd, 0x49, 0xb6, 0x6c, 0x06, 0x97, 0x61, 0x99, 0x90, 0x67, 0xd9, 0x76, 0x90, 0x7c, 0x26,
+	0x8c, 0x21, 0xd1, 0x16, 0x38, 0x8b, 0x78, 0x63, 0x76, 0xa6, 0x1c, 0x50, 0x69, 0x74, 0xd6, 0xae,
+	0x75, 0x55, 0xd7, 0x40, 0x11, 0xd5, 0xa6, 0xc3, 0x18, 0xb4, 0x0f, 0xc8, 0xc4, 0xba, 0xaa, 0x68,
+	0xb2, 0xa9, 0x10, 0xcd, 0xcb, 0x74, 0x13, 0x41, 0x86, 0xe6, 0xb0, 0xb1, 0x3c, 0x37, 0x1b, 0x43,
+	0x3a, 0xb7, 0xad, 0x12, 0x9b, 0x68, 0x25, 0xd6, 0xe7, 0x49, 0x7a, 0xd0, 0x3b, 0xc3, 0x1c, 0x74,
+	0xe2, 0x25, 0x4c, 0xc1, 0x06, 0x3b, 0xe6, 0xeb, 0xb6, 0x25, 0x4a, 0x4f, 0x7b, 0x90, 0x70, 0xaa,
+	0x16, 0x7b, 0xd1, 0xfc, 0xef, 0x86, 0x30, 0xb4, 0x5e, 0xbf, 0x15, 0x60, 0xf1, 0xdc, 0x53, 0xe1,
+	0xb5, 0xca, 0xf5, 0xd4, 0xaa, 0x1a, 0xd6, 0x6a, 0xf0, 0x59, 0x49, 0xbf, 0x1b, 0xcc, 0xbf, 0x04,
+	0xb8, 0xb8, 0x41, 0xd4, 0x8e, 0xac, 0x63, 0xcf, 0xad, 0xfc, 0x26, 0xf4, 0xbb, 0x30, 0xc5, 0x55,
+	0xa9, 0x9a, 0xec, 0xea, 0xb8, 0x60, 0x5b, 0xe2, 0x85, 0xa0, 0x22, 0xdd, 0xe1, 0x04, 0x4f, 0x70,
+	0xe0, 0x28, 0x7b, 0xbd, 0x98, 0x4a, 0x62, 0x2f, 0x27, 0xb3, 0x97, 0xdf, 0xec, 0x70, 0x55, 0xda,
+	0x82, 0xf9, 0xf8, 0x36, 0x87, 0xbf, 0x5c, 0x48, 0x12, 0x2c, 0x6f, 0xb4, 0xbb, 0x86, 0x89, 0xf5,
+	0x78, 0x1c, 0xb8, 0x76, 0x93, 0xbe, 0x4a, 0xc3, 0x42, 0x4f, 0x22, 0xf4, 0x04, 0x0a, 0x09, 0xd5,
+	0xc9, 0x1d, 0x3a, 0xf5, 0x73, 0xb7, 0x92, 0x9b, 0xa9, 0x51, 0xbc, 0x88, 0x54, 0x12, 0x60, 0x08,
+	0xc3, 0x6c, 0xac, 0x9a, 0x0c, 0xe8, 0xd9, 0x45, 0x77, 0xa9, 0x7c, 0x34, 0xf1, 0x57, 0x62, 0x10,
+	0x3f, 0x65, 0x87, 0x66, 0x1f, 0x46, 0x31, 0x1d, 0x4e, 0xd9, 0xfc, 0xd8, 0x22, 0x96, 0xb2, 0x43,
+	0x48, 0x74, 0x04, 0x17, 0x92, 0xc6, 0x29, 0xde, 0x10, 0x87, 0xf6, 0x95, 0xf1, 0x59, 0x08, 0x2f,
+	0xb4, 0x90, 0x80, 0x46, 0x1f, 0xc2, 0x94, 0x23, 0x36, 0x18, 0x7a, 0xb3, 0x51, 0x4c, 0xc9, 0xb6,
+	0xc4, 0x79, 0x27, 0xd9, 0x27, 0x0c, 0xb4, 0x27, 0x79, 0xb8, 0x34, 0x03, 0x53, 0x34, 0xd0, 0xfc,
+	0xb3, 0xde, 0x80, 0x31, 0x06, 0x70, 0x7a, 0xba, 0xe0, 0x79, 0x82, 0xdd, 0xae, 0xdc, 0x9e, 0xce,
+	0x7f, 0x8a, 0xe0, 0xe5, 0x42, 0x00, 0x95, 0x1e, 0xc3, 0xe2, 0xf7, 0x65, 0xb3, 0x71, 0x12, 0x7d,
+	0xda, 0xf1, 0x23, 0xf1, 0x3b, 0x30, 0xc9, 0x85, 0x92, 0x27, 0x3c, 0x12, 0x49, 0x46, 0x72, 0x24,
+	0x19, 0xd2, 0x2f, 0x05, 0x28, 0x55, 0xb1, 0xe9, 0xed, 0xe2, 0xae, 0x2e, 0x2b, 0x1a, 0x5d, 0xe3,
+	0x75, 0xbb, 0x5c, 0xe7, 0x6a, 0xdf, 0x74, 0xa5, 0xb9, 0xaf, 0xd7, 0xf4, 0xee, 0xeb, 0xc1, 0xf8,
+	0xbb, 0xaf, 0x07, 0x93, 0x4c, 0xb8, 0x94, 0xa8, 0x8c, 0xd1, 0x21, 0x9a, 0x81, 0x9d, 0x93, 0xf7,
+	0x48, 0x6b, 0x09, 0x7b, 0xa6, 0x27, 0xef, 0x11, 0x6c, 0x26, 0xee, 0xbd, 0x90, 0x80, 0x96, 0x3e,
+	0x80, 0xfc, 0x16, 0xd1, 0x5b, 0xd8, 0xa4, 0x53, 0x85, 0xe1, 0x2f, 0xd9, 0x77, 0x61, 0x96, 0xe3,
+	0x77, 0x75, 0x5d, 0x83, 0x71, 0x1d, 0xab, 0xe4, 0x99, 0x3b, 0x33, 0xce, 0xb2, 0x37, 0x50, 0x17,
+	0xc4, 0xbf, 0x81, 0xba, 0x20, 0xa9, 0x03, 0x17, 0xaa, 0xd8, 0xa4, 0x0e, 0x73, 0x40, 0xdf, 0x14,
+	0x3c, 0x55, 0xde, 0x86, 0x4c, 0xf0, 0xa0, 0xee, 0x6a, 0xf2, 0x34, 0xfc, 0x3a, 0x5e, 0x61, 0x14,
+	0xdc, 0x9b, 0x45, 0x6a, 0x80, 0x37, 0x8b, 0x47, 0x30, 0x1f, 0x5d, 0xd1, 0x55, 0xfe, 0x43, 0x98,
+	0x62, 0x34, 0xec, 0x29, 0xdf, 0x33, 0x30, 0x8d, 0x05, 0x86, 0xa0, 0x5c, 0xa1, 0x58, 0xe0, 0xe1,
+	0x37, 0xde, 0x83, 0x49, 0x3e, 0x01, 0xa3, 0x2c, 0x8c, 0x1e, 0x6e, 0x7e, 0x7c, 0x98, 0x1f, 0x71,
+	0x7e, 0xed, 0x56, 0x1f, 0xee, 0xe7, 0x05, 0x84, 0x60, 0xda, 0x81, 0xd5, 0x8e, 0xf6, 0xef, 0xec,
+	0xed, 0x6c, 0xef, 0x6f, 0xde, 0xcd, 0xa7, 0x6e, 0x7c, 0x00, 0x33, 0x91, 0xc7, 0x36, 0x34, 0x01,
+	0xe3, 0xd5, 0xa3, 0x07, 0x0f, 0xee, 0x54, 0x1e, 0xe5, 0x47, 0x10, 0xc0, 0xd8, 0x47, 0x47, 0x9b,
+	0x47, 0x9b, 0xd5, 0xbc, 0x40, 0x25, 0x3d, 0x2c, 0x57, 0xf3, 0x29, 0xe7, 0xd7, 0xd6, 0xd1, 0xde,
+	0x5e, 0x3e, 0x7d, 0x63, 0x05, 0xa6, 0xc3, 0x03, 0x17, 0x34, 0x09, 0xd9, 0x9d, 0xbb, 0x9b, 0xfb,
+	0x87, 0x3b, 0x87, 0x8f, 0xd8, 0xea, 0xdb, 0x9f, 0xec, 0x1c, 0xe4, 0x85, 0xf5, 0xbf, 0x8c, 0x03,
+	0xf2, 0x32, 0xb0, 0x5e, 0xf1, 0xfe, 0xca, 0x82, 0x9a, 0x50, 0xd8, 0xc6, 0x66, 0xec, 0x1d, 0xf5,
+	0xed, 0x78, 0x42, 0xec, 0xf1, 0x7f, 0x88, 0x92, 0xd4, 0x9f, 0x14, 0x1d, 0xc1, 0xf4, 0xb6, 0x6b,
+	0x79, 0x17, 0x72, 0xad, 0x47, 0xd5, 0x0e, 0xcb, 0x5e, 0x3c, 0x97, 0x0a, 0x3d, 0x84, 0xc9, 0x6d,
+	0xd7, 0x0b, 0xe9, 0xb7, 0x94, 0x38, 0xbf, 0x09, 0x8b, 0xbc, 0x74, 0x0e, 0x0d, 0x7a, 0x06, 0x0b,
+	0x4c, 0x60, 0xd2, 0x00, 0x69, 0x6d, 0xa0, 0xe9, 0x50, 0x30, 0xb8, 0x2a, 0xad, 0x0c, 0xca, 0x80,
+	0xb6, 0x20, 0xe7, 0xd9, 0xc7, 0x40, 0x62, 0x8f, 0x4d, 0xfb, 0x72, 0x8b, 0xbd, 0x08, 0xd0, 0x8f,
+	0xe1, 0xf2, 0x76, 0x90, 0x4f, 0xe2, 0x77, 0xed, 0xf5, 0x21, 0x1a, 0x68, 0x6f, 0xb5, 0x77, 0x86,
+	0xe0, 0x41, 0x2d, 0xc8, 0x47, 0x5b, 0x8b, 0x24, 0x5f, 0xea, 0xd1, 0x65, 0x95, 0x56, 0x06, 0x21,
+	0xa5, 0x27, 0xc5, 0x76, 0xda, 0xbb, 0xb3, 0x48, 0xd8, 0x69, 0xbf, 0x5e, 0xa5, 0xf4, 0xce, 0x10,
+	0x3c, 0xe8, 0x39, 0xcc, 0x27, 0xd7, 0xa9, 0x24, 0x3f, 0x39, 0xb7, 0xa2, 0x0d, 0x65, 0xe1, 0x77,
+	0x85, 0xf5, 0xcf, 0x53, 0x30, 0xed, 0x47, 0xf2, 0x9d, 0xa6, 0xaa, 0x68, 0x48, 0x87, 0x42, 0x42,
+	0x1d, 0x41, 0x37, 0x13, 0x42, 0xb3, 0x67, 0xed, 0x2b, 0xdd, 0x1a, 0x90, 0xda, 0xcd, 0x99, 0x87,
+	0x90, 0xf3, 0xab, 0x40, 0x52, 0xe4, 0x45, 0x4b, 0x4c, 0xe9, 0xea, 0xb9, 0x34, 0xae, 0xd4, 0x06,
+	0x4c, 0x87, 0x73, 0x34, 0xfa, 0xbf, 0x44, 0xb5, 0xe2, 0x75, 0xa3, 0xb4, 0xd2, 0x9f, 0x90, 0x2d,
+	0x52, 0x7e, 0xfc, 0xc5, 0x8b, 0x25, 0xe1, 0xcb, 0x17, 0x4b, 0xc2, 0x3f, 0x5f, 0x2c, 0x09, 0x9f,
+	0xbe, 0x5c, 0x1a, 0xf9, 0xf2, 0xe5, 0xd2, 0xc8, 0x57, 0x2f, 0x97, 0x46, 0x3e, 0xd9, 0xe0, 0xfe,
+	0x83, 0x27, 0xeb, 0xaa, 0xdc, 0x94, 0x3b, 0x3a, 0x71, 0x64, 0xb9, 0x5f, 0x6b, 0x03, 0xfc, 0xe9,
+	0xae, 0x3e, 0x46, 0x27, 0x41, 0xb7, 0xff, 0x3d, 0x00, 0xa3, 0x61, 0x69, 0x39, 0x56, 0x28, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SchedulerReportingClient is the client API for SchedulerReporting service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SchedulerReportingClient interface {
+	// Return the most recent scheduling report for each executor.
+	GetSchedulingReport(ctx context.Context, in *SchedulingReportRequest, opts ...grpc.CallOption) (*SchedulingReport, error)
+	// Return the most recent report scheduling for each executor for the given queue.
+	GetQueueReport(ctx context.Context, in *QueueReportRequest, opts ...grpc.CallOption) (*QueueReport, error)
+	// Return the most recent scheduling report for each executor for the given job.
+	GetJobReport(ctx context.Context, in *JobReportRequest, opts ...grpc.CallOption) (*JobReport, error)
+	// Return a structured summary of the most recent attempt to schedule the given job for each executor.
+	GetJobSchedulingSummaries(ctx context.Context, in *JobSchedulingSummariesRequest, opts ...grpc.CallOption) (*JobSchedulingSummaries, error)
+	// Return the names of all queues for which scheduling reports are available.
+	GetQueues(ctx context.Context, in *QueuesRequest, opts ...grpc.CallOption) (*Queues, error)
+	// Return the most recent scheduling context for the given executor.
+	GetExecutorSchedulingContext(ctx context.Context, in *ExecutorSchedulingContextRequest, opts ...grpc.CallOption) (*ExecutorSchedulingContext, error)
+	// Compare the most recent scheduling contexts of two executors.
+	CompareExecutors(ctx context.Context, in *CompareExecutorsRequest, opts ...grpc.CallOption) (*CompareExecutorsReport, error)
+	// Return the resources scheduled and evicted summed over the most recent scheduling contexts of all executors.
+	GetClusterScheduledResources(ctx context.Context, in *ClusterScheduledResourcesRequest, opts ...grpc.CallOption) (*ClusterScheduledResources, error)
+	// Stream the scheduling context of each executor as it's added, starting with the next one added.
+	// Subscribers that fall behind are disconnected with a ResourceExhausted error.
+	WatchSchedulingReports(ctx context.Context, in *WatchSchedulingReportsRequest, opts ...grpc.CallOption) (SchedulerReporting_WatchSchedulingReportsClient, error)
+}
+
+type schedulerReportingClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSchedulerReportingClient(cc *grpc.ClientConn) SchedulerReportingClient {
+	return &schedulerReportingClient{cc}
+}
+
+func (c *schedulerReportingClient) GetSchedulingReport(ctx context.Context, in *SchedulingReportRequest, opts ...grpc.CallOption) (*SchedulingReport, error) {
+	out := new(SchedulingReport)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetSchedulingReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerReportingClient) GetQueueReport(ctx context.Context, in *QueueReportRequest, opts ...grpc.CallOption) (*QueueReport, error) {
+	out := new(QueueReport)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetQueueReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerReportingClient) GetJobReport(ctx context.Context, in *JobReportRequest, opts ...grpc.CallOption) (*JobReport, error) {
+	out := new(JobReport)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetJobReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerReportingClient) GetJobSchedulingSummaries(ctx context.Context, in *JobSchedulingSummariesRequest, opts ...grpc.CallOption) (*JobSchedulingSummaries, error) {
+	out := new(JobSchedulingSummaries)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetJobSchedulingSummaries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerReportingClient) GetQueues(ctx context.Context, in *QueuesRequest, opts ...grpc.CallOption) (*Queues, error) {
+	out := new(Queues)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetQueues", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerReportingClient) GetExecutorSchedulingContext(ctx context.Context, in *ExecutorSchedulingContextRequest, opts ...grpc.CallOption) (*ExecutorSchedulingContext, error) {
+	out := new(ExecutorSchedulingContext)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetExecutorSchedulingContext", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerReportingClient) CompareExecutors(ctx context.Context, in *CompareExecutorsRequest, opts ...grpc.CallOption) (*CompareExecutorsReport, error) {
+	out := new(CompareExecutorsReport)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/CompareExecutors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerReportingClient) GetClusterScheduledResources(ctx context.Context, in *ClusterScheduledResourcesRequest, opts ...grpc.CallOption) (*ClusterScheduledResources, error) {
+	out := new(ClusterScheduledResources)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerReporting/GetClusterScheduledResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerReportingClient) WatchSchedulingReports(ctx context.Context, in *WatchSchedulingReportsRequest, opts ...grpc.CallOption) (SchedulerReporting_WatchSchedulingReportsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SchedulerReporting_serviceDesc.Streams[0], "/schedulerobjects.SchedulerReporting/WatchSchedulingReports", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &schedulerReportingWatchSchedulingReportsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SchedulerReporting_WatchSchedulingReportsClient interface {
+	Recv() (*ExecutorSchedulingContext, error)
+	grpc.ClientStream
+}
+
+type schedulerReportingWatchSchedulingReportsClient struct {
+	grpc.ClientStream
+}
+
+func (x *schedulerReportingWatchSchedulingReportsClient) Recv() (*ExecutorSchedulingContext, error) {
+	m := new(ExecutorSchedulingContext)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SchedulerReportingServer is the server API for SchedulerReporting service.
+type SchedulerReportingServer interface {
+	// Return the most recent scheduling report for each executor.
+	GetSchedulingReport(context.Context, *SchedulingReportRequest) (*SchedulingReport, error)
+	// Return the most recent report scheduling for each executor for the given queue.
+	GetQueueReport(context.Context, *QueueReportRequest) (*QueueReport, error)
+	// Return the most recent scheduling report for each executor for the given job.
+	GetJobReport(context.Context, *JobReportRequest) (*JobReport, error)
+	// Return a structured summary of the most recent attempt to schedule the given job for each executor.
+	GetJobSchedulingSummaries(context.Context, *JobSchedulingSummariesRequest) (*JobSchedulingSummaries, error)
+	// Return the names of all queues for which scheduling reports are available.
+	GetQueues(context.Context, *QueuesRequest) (*Queues, error)
+	// Return the most recent scheduling context for the given executor.
+	GetExecutorSchedulingContext(context.Context, *ExecutorSchedulingContextRequest) (*ExecutorSchedulingContext, error)
+	// Compare the most recent scheduling contexts of two executors.
+	CompareExecutors(context.Context, *CompareExecutorsRequest) (*CompareExecutorsReport, error)
+	// Return the resources scheduled and evicted summed over the most recent scheduling contexts of all executors.
+	GetClusterScheduledResources(context.Context, *ClusterScheduledResourcesRequest) (*ClusterScheduledResources, error)
+	// Stream the scheduling context of each executor as it's added, starting with the next one added.
+	// Subscribers that fall behind are disconnected with a ResourceExhausted error.
+	WatchSchedulingReports(*WatchSchedulingReportsRequest, SchedulerReporting_WatchSchedulingReportsServer) error
+}
+
+// UnimplementedSchedulerReportingServer can be embedded to have forward compatible implementations.
+type UnimplementedSchedulerReportingServer struct {
+}
+
+func (*UnimplementedSchedulerReportingServer) GetSchedulingReport(ctx context.Context, req *SchedulingReportRequest) (*SchedulingReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedulingReport not implemented")
+}
+func (*UnimplementedSchedulerReportingServer) GetQueueReport(ctx context.Context, req *QueueReportRequest) (*QueueReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueueReport not implemented")
+}
+func (*UnimplementedSchedulerReportingServer) GetJobReport(ctx context.Context, req *JobReportRequest) (*JobReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobReport not implemented")
+}
+func (*UnimplementedSchedulerReportingServer) GetJobSchedulingSummaries(ctx context.Context, req *JobSchedulingSummariesRequest) (*JobSchedulingSummaries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobSchedulingSummaries not implemented")
+}
+func (*UnimplementedSchedulerReportingServer) GetQueues(ctx context.Context, req *QueuesRequest) (*Queues, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueues not implemented")
+}
+func (*UnimplementedSchedulerReportingServer) GetExecutorSchedulingContext(ctx context.Context, req *ExecutorSchedulingContextRequest) (*ExecutorSchedulingContext, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExecutorSchedulingContext not implemented")
+}
+func (*UnimplementedSchedulerReportingServer) CompareExecutors(ctx context.Context, req *CompareExecutorsRequest) (*CompareExecutorsReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareExecutors not implemented")
+}
+func (*UnimplementedSchedulerReportingServer) GetClusterScheduledResources(ctx context.Context, req *ClusterScheduledResourcesRequest) (*ClusterScheduledResources, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterScheduledResources not implemented")
+}
+func (*UnimplementedSchedulerReportingServer) WatchSchedulingReports(req *WatchSchedulingReportsRequest, srv SchedulerReporting_WatchSchedulingReportsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSchedulingReports not implemented")
+}
+
+func RegisterSchedulerReportingServer(s *grpc.Server, srv SchedulerReportingServer) {
+	s.RegisterService(&_SchedulerReporting_serviceDesc, srv)
+}
+
+func _SchedulerReporting_GetSchedulingReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulingReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).GetSchedulingReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/GetSchedulingReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).GetSchedulingReport(ctx, req.(*SchedulingReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerReporting_GetQueueReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).GetQueueReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/GetQueueReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).GetQueueReport(ctx, req.(*QueueReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerReporting_GetJobReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).GetJobReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/GetJobReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).GetJobReport(ctx, req.(*JobReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerReporting_GetJobSchedulingSummaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobSchedulingSummariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).GetJobSchedulingSummaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/GetJobSchedulingSummaries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).GetJobSchedulingSummaries(ctx, req.(*JobSchedulingSummariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerReporting_GetQueues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).GetQueues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/GetQueues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).GetQueues(ctx, req.(*QueuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerReporting_GetExecutorSchedulingContext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecutorSchedulingContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).GetExecutorSchedulingContext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/GetExecutorSchedulingContext",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).GetExecutorSchedulingContext(ctx, req.(*ExecutorSchedulingContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerReporting_CompareExecutors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareExecutorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).CompareExecutors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/CompareExecutors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).CompareExecutors(ctx, req.(*CompareExecutorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerReporting_GetClusterScheduledResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterScheduledResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerReportingServer).GetClusterScheduledResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerReporting/GetClusterScheduledResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerReportingServer).GetClusterScheduledResources(ctx, req.(*ClusterScheduledResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerReporting_WatchSchedulingReports_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSchedulingReportsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SchedulerReportingServer).WatchSchedulingReports(m, &schedulerReportingWatchSchedulingReportsServer{stream})
+}
+
+type SchedulerReporting_WatchSchedulingReportsServer interface {
+	Send(*ExecutorSchedulingContext) error
+	grpc.ServerStream
+}
+
+type schedulerReportingWatchSchedulingReportsServer struct {
+	grpc.ServerStream
+}
+
+func (x *schedulerReportingWatchSchedulingReportsServer) Send(m *ExecutorSchedulingContext) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _SchedulerReporting_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "schedulerobjects.SchedulerReporting",
+	HandlerType: (*SchedulerReportingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSchedulingReport",
+			Handler:    _SchedulerReporting_GetSchedulingReport_Handler,
+		},
+		{
+			MethodName: "GetQueueReport",
+			Handler:    _SchedulerReporting_GetQueueReport_Handler,
+		},
+		{
+			MethodName: "GetJobReport",
+			Handler:    _SchedulerReporting_GetJobReport_Handler,
+		},
+		{
+			MethodName: "GetJobSchedulingSummaries",
+			Handler:    _SchedulerReporting_GetJobSchedulingSummaries_Handler,
+		},
+		{
+			MethodName: "GetQueues",
+			Handler:    _SchedulerReporting_GetQueues_Handler,
+		},
+		{
+			MethodName: "GetExecutorSchedulingContext",
+			Handler:    _SchedulerReporting_GetExecutorSchedulingContext_Handler,
+		},
+		{
+			MethodName: "CompareExecutors",
+			Handler:    _SchedulerReporting_CompareExecutors_Handler,
+		},
+		{
+			MethodName: "GetClusterScheduledResources",
+			Handler:    _SchedulerReporting_GetClusterScheduledResources_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSchedulingReports",
+			Handler:       _SchedulerReporting_WatchSchedulingReports_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/scheduler/schedulerobjects/reporting.proto",
+}
+
+// SchedulerAdminClient is the client API for SchedulerAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SchedulerAdminClient interface {
+	// Mark an executor as draining, such that no new jobs are scheduled onto it, or as no longer draining.
+	// Draining executors are marked as such in scheduling reports.
+	SetExecutorDraining(ctx context.Context, in *SetExecutorDrainingRequest, opts ...grpc.CallOption) (*SetExecutorDrainingResponse, error)
+	// Remove the most recent job scheduling contexts of a job from the scheduling context repository,
+	// e.g., to purge its scheduling traces on request.
+	ForgetJob(ctx context.Context, in *ForgetJobRequest, opts ...grpc.CallOption) (*ForgetJobResponse, error)
+	// Pause scheduling for a queue, such that no new jobs are scheduled from it, or resume scheduling for it.
+	// Paused queues are marked as such in queue reports.
+	SetQueuePaused(ctx context.Context, in *SetQueuePausedRequest, opts ...grpc.CallOption) (*SetQueuePausedResponse, error)
+}
+
+type schedulerAdminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSchedulerAdminClient(cc *grpc.ClientConn) SchedulerAdminClient {
+	return &schedulerAdminClient{cc}
+}
+
+func (c *schedulerAdminClient) SetExecutorDraining(ctx context.Context, in *SetExecutorDrainingRequest, opts ...grpc.CallOption) (*SetExecutorDrainingResponse, error) {
+	out := new(SetExecutorDrainingResponse)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerAdmin/SetExecutorDraining", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerAdminClient) ForgetJob(ctx context.Context, in *ForgetJobRequest, opts ...grpc.CallOption) (*ForgetJobResponse, error) {
+	out := new(ForgetJobResponse)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerAdmin/ForgetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerAdminClient) SetQueuePaused(ctx context.Context, in *SetQueuePausedRequest, opts ...grpc.CallOption) (*SetQueuePausedResponse, error) {
+	out := new(SetQueuePausedResponse)
+	err := c.cc.Invoke(ctx, "/schedulerobjects.SchedulerAdmin/SetQueuePaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchedulerAdminServer is the server API for SchedulerAdmin service.
+type SchedulerAdminServer interface {
+	// Mark an executor as draining, such that no new jobs are scheduled onto it, or as no longer draining.
+	// Draining executors are marked as such in scheduling reports.
+	SetExecutorDraining(context.Context, *SetExecutorDrainingRequest) (*SetExecutorDrainingResponse, error)
+	// Remove the most recent job scheduling contexts of a job from the scheduling context repository,
+	// e.g., to purge its scheduling traces on request.
+	ForgetJob(context.Context, *ForgetJobRequest) (*ForgetJobResponse, error)
+	// Pause scheduling for a queue, such that no new jobs are scheduled from it, or resume scheduling for it.
+	// Paused queues are marked as such in queue reports.
+	SetQueuePaused(context.Context, *SetQueuePausedRequest) (*SetQueuePausedResponse, error)
+}
+
+// UnimplementedSchedulerAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedSchedulerAdminServer struct {
+}
+
+func (*UnimplementedSchedulerAdminServer) SetExecutorDraining(ctx context.Context, req *SetExecutorDrainingRequest) (*SetExecutorDrainingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExecutorDraining not implemented")
+}
+func (*UnimplementedSchedulerAdminServer) ForgetJob(ctx context.Context, req *ForgetJobRequest) (*ForgetJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForgetJob not implemented")
+}
+func (*UnimplementedSchedulerAdminServer) SetQueuePaused(ctx context.Context, req *SetQueuePausedRequest) (*SetQueuePausedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetQueuePaused not implemented")
+}
+
+func RegisterSchedulerAdminServer(s *grpc.Server, srv SchedulerAdminServer) {
+	s.RegisterService(&_SchedulerAdmin_serviceDesc, srv)
+}
+
+func _SchedulerAdmin_SetExecutorDraining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetExecutorDrainingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerAdminServer).SetExecutorDraining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerAdmin/SetExecutorDraining",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerAdminServer).SetExecutorDraining(ctx, req.(*SetExecutorDrainingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerAdmin_ForgetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForgetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerAdminServer).ForgetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerAdmin/ForgetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerAdminServer).ForgetJob(ctx, req.(*ForgetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerAdmin_SetQueuePaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetQueuePausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerAdminServer).SetQueuePaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerobjects.SchedulerAdmin/SetQueuePaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerAdminServer).SetQueuePaused(ctx, req.(*SetQueuePausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SchedulerAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "schedulerobjects.SchedulerAdmin",
+	HandlerType: (*SchedulerAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetExecutorDraining",
+			Handler:    _SchedulerAdmin_SetExecutorDraining_Handler,
+		},
+		{
+			MethodName: "ForgetJob",
+			Handler:    _SchedulerAdmin_ForgetJob_Handler,
+		},
+		{
+			MethodName: "SetQueuePaused",
+			Handler:    _SchedulerAdmin_SetQueuePaused_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/scheduler/schedulerobjects/reporting.proto",
+}
+
+func (m *MostRecentForQueue) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MostRecentForQueue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MostRecentForQueue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.QueueName) > 0 {
+		i -= len(m.QueueName)
+		copy(dAtA[i:], m.QueueName)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.QueueName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MostRecentForJob) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MostRecentForJob) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MostRecentForJob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExcludeEvicted {
+		i--
+		if m.ExcludeEvicted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MostRecentForPool) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MostRecentForPool) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MostRecentForPool) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PoolName) > 0 {
+		i -= len(m.PoolName)
+		copy(dAtA[i:], m.PoolName)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.PoolName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SchedulingReportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SchedulingReportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SchedulingReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Compress {
+		i--
+		if m.Compress {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
+	{
+		size, err := m.MinResources.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintReporting(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x4a
+	if m.ExecutorPatternIsRegex {
+		i--
+		if m.ExecutorPatternIsRegex {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.ExecutorPattern) > 0 {
+		i -= len(m.ExecutorPattern)
+		copy(dAtA[i:], m.ExecutorPattern)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.ExecutorPattern)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.History != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.History))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Filter != nil {
+		{
+			size := m.Filter.Size()
+			i -= size
+			if _, err := m.Filter.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if m.Format != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.Format))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Verbosity != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.Verbosity))
+		i--
+		dAtA[i] = 0x18
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SchedulingReportRequest_MostRecentForQueue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SchedulingReportRequest_MostRecentForQueue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MostRecentForQueue != nil {
+		{
+			size, err := m.MostRecentForQueue.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintReporting(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *SchedulingReportRequest_MostRecentForJob) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SchedulingReportRequest_MostRecentForJob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MostRecentForJob != nil {
+		{
+			size, err := m.MostRecentForJob.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintReporting(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *SchedulingReportRequest_MostRecentForPool) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SchedulingReportRequest_MostRecentForPool) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MostRecentForPool != nil {
+		{
+			size, err := m.MostRecentForPool.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintReporting(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *SchedulingReport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SchedulingReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SchedulingReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Encoding != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.Encoding))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.CompressedReport) > 0 {
+		i -= len(m.CompressedReport)
+		copy(dAtA[i:], m.CompressedReport)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.CompressedReport)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Report) > 0 {
+		i -= len(m.Report)
+		copy(dAtA[i:], m.Report)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Report)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueueReportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueueReportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueueReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Compress {
+		i--
+		if m.Compress {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Format != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.Format))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Verbosity != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.Verbosity))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.QueueName) > 0 {
+		i -= len(m.QueueName)
+		copy(dAtA[i:], m.QueueName)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.QueueName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueueReport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueueReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueueReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Paused {
+		i--
+		if m.Paused {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Encoding != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.Encoding))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.CompressedReport) > 0 {
+		i -= len(m.CompressedReport)
+		copy(dAtA[i:], m.CompressedReport)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.CompressedReport)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Executors) > 0 {
+		for iNdEx := len(m.Executors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Executors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintReporting(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Report) > 0 {
+		i -= len(m.Report)
+		copy(dAtA[i:], m.Report)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Report)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExecutorQueueReport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExecutorQueueReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExecutorQueueReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MostRecentPreempting != nil {
+		{
+			size, err := m.MostRecentPreempting.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintReporting(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.MostRecentSuccessful != nil {
+		{
+			size, err := m.MostRecentSuccessful.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintReporting(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.MostRecent != nil {
+		{
+			size, err := m.MostRecent.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintReporting(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ExecutorId) > 0 {
+		i -= len(m.ExecutorId)
+		copy(dAtA[i:], m.ExecutorId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.ExecutorId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueueSchedulingSummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueueSchedulingSummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueueSchedulingSummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FairShare != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.FairShare))))
+		i--
+		dAtA[i] = 0x31
+	}
+	if m.NumUnsuccessfulJobSchedulingContexts != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.NumUnsuccessfulJobSchedulingContexts))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.NumSuccessfulJobSchedulingContexts != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.NumSuccessfulJobSchedulingContexts))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.EvictedResourcesByPriority) > 0 {
+		for k := range m.EvictedResourcesByPriority {
+			v := m.EvictedResourcesByPriority[k]
+			baseI := i
+			{
+				size, err := (&v).MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintReporting(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+			i = encodeVarintReporting(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintReporting(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.ScheduledResourcesByPriority) > 0 {
+		for k := range m.ScheduledResourcesByPriority {
+			v := m.ScheduledResourcesByPriority[k]
+			baseI := i
+			{
+				size, err := (&v).MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintReporting(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+			i = encodeVarintReporting(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintReporting(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	n10, err10 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
+	if err10 != nil {
+		return 0, err10
+	}
+	i -= n10
+	i = encodeVarintReporting(dAtA, i, uint64(n10))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *JobReportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobReportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Compress {
+		i--
+		if m.Compress {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Until != nil {
+		n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Until, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Until):])
+		if err11 != nil {
+			return 0, err11
+		}
+		i -= n11
+		i = encodeVarintReporting(dAtA, i, uint64(n11))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Since != nil {
+		n12, err12 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Since, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Since):])
+		if err12 != nil {
+			return 0, err12
+		}
+		i -= n12
+		i = encodeVarintReporting(dAtA, i, uint64(n12))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Format != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.Format))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobReport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Encoding != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.Encoding))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.CompressedReport) > 0 {
+		i -= len(m.CompressedReport)
+		copy(dAtA[i:], m.CompressedReport)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.CompressedReport)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Report) > 0 {
+		i -= len(m.Report)
+		copy(dAtA[i:], m.Report)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Report)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobSchedulingSummariesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobSchedulingSummariesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobSchedulingSummariesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobSchedulingSummaries) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobSchedulingSummaries) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobSchedulingSummaries) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.JobSchedulingSummaries) > 0 {
+		for iNdEx := len(m.JobSchedulingSummaries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.JobSchedulingSummaries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintReporting(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JobSchedulingSummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JobSchedulingSummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JobSchedulingSummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.UnschedulableReasonCode) > 0 {
+		i -= len(m.UnschedulableReasonCode)
+		copy(dAtA[i:], m.UnschedulableReasonCode)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.UnschedulableReasonCode)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.UnschedulableReason) > 0 {
+		i -= len(m.UnschedulableReason)
+		copy(dAtA[i:], m.UnschedulableReason)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.UnschedulableReason)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.NodeId) > 0 {
+		i -= len(m.NodeId)
+		copy(dAtA[i:], m.NodeId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.NodeId)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Scheduled {
+		i--
+		if m.Scheduled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.PriorityClassName) > 0 {
+		i -= len(m.PriorityClassName)
+		copy(dAtA[i:], m.PriorityClassName)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.PriorityClassName)))
+		i--
+		dAtA[i] = 0x32
+	}
+	n13, err13 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
+	if err13 != nil {
+		return 0, err13
+	}
+	i -= n13
+	i = encodeVarintReporting(dAtA, i, uint64(n13))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.GangId) > 0 {
+		i -= len(m.GangId)
+		copy(dAtA[i:], m.GangId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.GangId)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Pool) > 0 {
+		i -= len(m.Pool)
+		copy(dAtA[i:], m.Pool)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Pool)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ExecutorId) > 0 {
+		i -= len(m.ExecutorId)
+		copy(dAtA[i:], m.ExecutorId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.ExecutorId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExecutorSchedulingContextRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExecutorSchedulingContextRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExecutorSchedulingContextRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ExecutorId) > 0 {
+		i -= len(m.ExecutorId)
+		copy(dAtA[i:], m.ExecutorId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.ExecutorId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExecutorSchedulingContext) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExecutorSchedulingContext) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExecutorSchedulingContext) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.QueueSchedulingSummaries) > 0 {
+		for k := range m.QueueSchedulingSummaries {
+			v := m.QueueSchedulingSummaries[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintReporting(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintReporting(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintReporting(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if len(m.TerminationReason) > 0 {
+		i -= len(m.TerminationReason)
+		copy(dAtA[i:], m.TerminationReason)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.TerminationReason)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.NumEvictedJobs != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.NumEvictedJobs))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.NumScheduledGangs != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.NumScheduledGangs))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.NumScheduledJobs != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.NumScheduledJobs))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.EvictedResourcesByPriority) > 0 {
+		for k := range m.EvictedResourcesByPriority {
+			v := m.EvictedResourcesByPriority[k]
+			baseI := i
+			{
+				size, err := (&v).MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintReporting(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+			i = encodeVarintReporting(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintReporting(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.ScheduledResourcesByPriority) > 0 {
+		for k := range m.ScheduledResourcesByPriority {
+			v := m.ScheduledResourcesByPriority[k]
+			baseI := i
+			{
+				size, err := (&v).MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintReporting(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+			i = encodeVarintReporting(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintReporting(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	{
+		size, err := m.TotalResources.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintReporting(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	n18, err18 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Finished, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Finished):])
+	if err18 != nil {
+		return 0, err18
+	}
+	i -= n18
+	i = encodeVarintReporting(dAtA, i, uint64(n18))
+	i--
+	dAtA[i] = 0x22
+	n19, err19 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Started, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Started):])
+	if err19 != nil {
+		return 0, err19
+	}
+	i -= n19
+	i = encodeVarintReporting(dAtA, i, uint64(n19))
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Pool) > 0 {
+		i -= len(m.Pool)
+		copy(dAtA[i:], m.Pool)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Pool)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ExecutorId) > 0 {
+		i -= len(m.ExecutorId)
+		copy(dAtA[i:], m.ExecutorId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.ExecutorId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompareExecutorsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompareExecutorsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompareExecutorsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Format != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.Format))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ExecutorIdB) > 0 {
+		i -= len(m.ExecutorIdB)
+		copy(dAtA[i:], m.ExecutorIdB)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.ExecutorIdB)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ExecutorIdA) > 0 {
+		i -= len(m.ExecutorIdA)
+		copy(dAtA[i:], m.ExecutorIdA)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.ExecutorIdA)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompareExecutorsReport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompareExecutorsReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompareExecutorsReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Report) > 0 {
+		i -= len(m.Report)
+		copy(dAtA[i:], m.Report)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Report)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ClusterScheduledResourcesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterScheduledResourcesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClusterScheduledResourcesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ClusterScheduledResources) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterScheduledResources) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ClusterScheduledResources) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.NumExecutors != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.NumExecutors))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.NumUnsuccessfulJobs != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.NumUnsuccessfulJobs))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.NumSuccessfulJobs != 0 {
+		i = encodeVarintReporting(dAtA, i, uint64(m.NumSuccessfulJobs))
+		i--
+		dAtA[i] = 0x18
+	}
+	{
+		size, err := m.EvictedResources.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintReporting(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.ScheduledResources.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintReporting(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *QueuesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueuesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueuesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *Queues) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Queues) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Queues) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.QueueNames) > 0 {
+		for iNdEx := len(m.QueueNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.QueueNames[iNdEx])
+			copy(dAtA[i:], m.QueueNames[iNdEx])
+			i = encodeVarintReporting(dAtA, i, uint64(len(m.QueueNames[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchSchedulingReportsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchSchedulingReportsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchSchedulingReportsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ExecutorIds) > 0 {
+		for iNdEx := len(m.ExecutorIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ExecutorIds[iNdEx])
+			copy(dAtA[i:], m.ExecutorIds[iNdEx])
+			i = encodeVarintReporting(dAtA, i, uint64(len(m.ExecutorIds[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetExecutorDrainingRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetExecutorDrainingRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetExecutorDrainingRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Draining {
+		i--
+		if m.Draining {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ExecutorId) > 0 {
+		i -= len(m.ExecutorId)
+		copy(dAtA[i:], m.ExecutorId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.ExecutorId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetExecutorDrainingResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetExecutorDrainingResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetExecutorDrainingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DrainingExecutorIds) > 0 {
+		for iNdEx := len(m.DrainingExecutorIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DrainingExecutorIds[iNdEx])
+			copy(dAtA[i:], m.DrainingExecutorIds[iNdEx])
+			i = encodeVarintReporting(dAtA, i, uint64(len(m.DrainingExecutorIds[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ForgetJobRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ForgetJobRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ForgetJobRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.JobId) > 0 {
+		i -= len(m.JobId)
+		copy(dAtA[i:], m.JobId)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.JobId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ForgetJobResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ForgetJobResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ForgetJobResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Removed {
+		i--
+		if m.Removed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetQueuePausedRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetQueuePausedRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetQueuePausedRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Paused {
+		i--
+		if m.Paused {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Queue) > 0 {
+		i -= len(m.Queue)
+		copy(dAtA[i:], m.Queue)
+		i = encodeVarintReporting(dAtA, i, uint64(len(m.Queue)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetQueuePausedResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetQueuePausedResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetQueuePausedResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PausedQueues) > 0 {
+		for iNdEx := len(m.PausedQueues) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PausedQueues[iNdEx])
+			copy(dAtA[i:], m.PausedQueues[iNdEx])
+			i = encodeVarintReporting(dAtA, i, uint64(len(m.PausedQueues[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintReporting(dAtA []byte, offset int, v uint64) int {
+	offset -= sovReporting(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *MostRecentForQueue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.QueueName)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *MostRecentForJob) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.ExcludeEvicted {
+		n += 2
+	}
+	return n
+}
+
+func (m *MostRecentForPool) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PoolName)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *SchedulingReportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Filter != nil {
+		n += m.Filter.Size()
+	}
+	if m.Verbosity != 0 {
+		n += 1 + sovReporting(uint64(m.Verbosity))
+	}
+	if m.Format != 0 {
+		n += 1 + sovReporting(uint64(m.Format))
+	}
+	if m.History != 0 {
+		n += 1 + sovReporting(uint64(m.History))
+	}
+	l = len(m.ExecutorPattern)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.ExecutorPatternIsRegex {
+		n += 2
+	}
+	l = m.MinResources.Size()
+	n += 1 + l + sovReporting(uint64(l))
+	if m.Compress {
+		n += 2
+	}
+	return n
+}
+
+func (m *SchedulingReportRequest_MostRecentForQueue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MostRecentForQueue != nil {
+		l = m.MostRecentForQueue.Size()
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+func (m *SchedulingReportRequest_MostRecentForJob) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MostRecentForJob != nil {
+		l = m.MostRecentForJob.Size()
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+func (m *SchedulingReportRequest_MostRecentForPool) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MostRecentForPool != nil {
+		l = m.MostRecentForPool.Size()
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+func (m *SchedulingReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Report)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = len(m.CompressedReport)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Encoding != 0 {
+		n += 1 + sovReporting(uint64(m.Encoding))
+	}
+	return n
+}
+
+func (m *QueueReportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.QueueName)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Verbosity != 0 {
+		n += 1 + sovReporting(uint64(m.Verbosity))
+	}
+	if m.Format != 0 {
+		n += 1 + sovReporting(uint64(m.Format))
+	}
+	if m.Compress {
+		n += 2
+	}
+	return n
+}
+
+func (m *QueueReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Report)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if len(m.Executors) > 0 {
+		for _, e := range m.Executors {
+			l = e.Size()
+			n += 1 + l + sovReporting(uint64(l))
+		}
+	}
+	l = len(m.CompressedReport)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Encoding != 0 {
+		n += 1 + sovReporting(uint64(m.Encoding))
+	}
+	if m.Paused {
+		n += 2
+	}
+	return n
+}
+
+func (m *ExecutorQueueReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ExecutorId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.MostRecent != nil {
+		l = m.MostRecent.Size()
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.MostRecentSuccessful != nil {
+		l = m.MostRecentSuccessful.Size()
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.MostRecentPreempting != nil {
+		l = m.MostRecentPreempting.Size()
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *QueueSchedulingSummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
+	n += 1 + l + sovReporting(uint64(l))
+	if len(m.ScheduledResourcesByPriority) > 0 {
+		for k, v := range m.ScheduledResourcesByPriority {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + sovReporting(uint64(k)) + 1 + l + sovReporting(uint64(l))
+			n += mapEntrySize + 1 + sovReporting(uint64(mapEntrySize))
+		}
+	}
+	if len(m.EvictedResourcesByPriority) > 0 {
+		for k, v := range m.EvictedResourcesByPriority {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + sovReporting(uint64(k)) + 1 + l + sovReporting(uint64(l))
+			n += mapEntrySize + 1 + sovReporting(uint64(mapEntrySize))
+		}
+	}
+	if m.NumSuccessfulJobSchedulingContexts != 0 {
+		n += 1 + sovReporting(uint64(m.NumSuccessfulJobSchedulingContexts))
+	}
+	if m.NumUnsuccessfulJobSchedulingContexts != 0 {
+		n += 1 + sovReporting(uint64(m.NumUnsuccessfulJobSchedulingContexts))
+	}
+	if m.FairShare != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *JobReportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Format != 0 {
+		n += 1 + sovReporting(uint64(m.Format))
+	}
+	if m.Since != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Since)
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Until != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Until)
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Compress {
+		n += 2
+	}
+	return n
+}
+
+func (m *JobReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Report)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = len(m.CompressedReport)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Encoding != 0 {
+		n += 1 + sovReporting(uint64(m.Encoding))
+	}
+	return n
+}
+
+func (m *JobSchedulingSummariesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *JobSchedulingSummaries) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.JobSchedulingSummaries) > 0 {
+		for _, e := range m.JobSchedulingSummaries {
+			l = e.Size()
+			n += 1 + l + sovReporting(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *JobSchedulingSummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ExecutorId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = len(m.Pool)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = len(m.GangId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
+	n += 1 + l + sovReporting(uint64(l))
+	l = len(m.PriorityClassName)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Scheduled {
+		n += 2
+	}
+	l = len(m.NodeId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = len(m.UnschedulableReason)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = len(m.UnschedulableReasonCode)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *ExecutorSchedulingContextRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ExecutorId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *ExecutorSchedulingContext) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ExecutorId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = len(m.Pool)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Started)
+	n += 1 + l + sovReporting(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Finished)
+	n += 1 + l + sovReporting(uint64(l))
+	l = m.TotalResources.Size()
+	n += 1 + l + sovReporting(uint64(l))
+	if len(m.ScheduledResourcesByPriority) > 0 {
+		for k, v := range m.ScheduledResourcesByPriority {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + sovReporting(uint64(k)) + 1 + l + sovReporting(uint64(l))
+			n += mapEntrySize + 1 + sovReporting(uint64(mapEntrySize))
+		}
+	}
+	if len(m.EvictedResourcesByPriority) > 0 {
+		for k, v := range m.EvictedResourcesByPriority {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + sovReporting(uint64(k)) + 1 + l + sovReporting(uint64(l))
+			n += mapEntrySize + 1 + sovReporting(uint64(mapEntrySize))
+		}
+	}
+	if m.NumScheduledJobs != 0 {
+		n += 1 + sovReporting(uint64(m.NumScheduledJobs))
+	}
+	if m.NumScheduledGangs != 0 {
+		n += 1 + sovReporting(uint64(m.NumScheduledGangs))
+	}
+	if m.NumEvictedJobs != 0 {
+		n += 1 + sovReporting(uint64(m.NumEvictedJobs))
+	}
+	l = len(m.TerminationReason)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if len(m.QueueSchedulingSummaries) > 0 {
+		for k, v := range m.QueueSchedulingSummaries {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovReporting(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovReporting(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovReporting(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *CompareExecutorsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ExecutorIdA)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	l = len(m.ExecutorIdB)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Format != 0 {
+		n += 1 + sovReporting(uint64(m.Format))
+	}
+	return n
+}
+
+func (m *CompareExecutorsReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Report)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *ClusterScheduledResourcesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ClusterScheduledResources) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ScheduledResources.Size()
+	n += 1 + l + sovReporting(uint64(l))
+	l = m.EvictedResources.Size()
+	n += 1 + l + sovReporting(uint64(l))
+	if m.NumSuccessfulJobs != 0 {
+		n += 1 + sovReporting(uint64(m.NumSuccessfulJobs))
+	}
+	if m.NumUnsuccessfulJobs != 0 {
+		n += 1 + sovReporting(uint64(m.NumUnsuccessfulJobs))
+	}
+	if m.NumExecutors != 0 {
+		n += 1 + sovReporting(uint64(m.NumExecutors))
+	}
+	return n
+}
+
+func (m *QueuesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *Queues) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.QueueNames) > 0 {
+		for _, s := range m.QueueNames {
+			l = len(s)
+			n += 1 + l + sovReporting(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *WatchSchedulingReportsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ExecutorIds) > 0 {
+		for _, s := range m.ExecutorIds {
+			l = len(s)
+			n += 1 + l + sovReporting(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetExecutorDrainingRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ExecutorId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Draining {
+		n += 2
+	}
+	return n
+}
+
+func (m *SetExecutorDrainingResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.DrainingExecutorIds) > 0 {
+		for _, s := range m.DrainingExecutorIds {
+			l = len(s)
+			n += 1 + l + sovReporting(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ForgetJobRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.JobId)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	return n
+}
+
+func (m *ForgetJobResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Removed {
+		n += 2
+	}
+	return n
+}
+
+func (m *SetQueuePausedRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Queue)
+	if l > 0 {
+		n += 1 + l + sovReporting(uint64(l))
+	}
+	if m.Paused {
+		n += 2
+	}
+	return n
+}
+
+func (m *SetQueuePausedResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.PausedQueues) > 0 {
+		for _, s := range m.PausedQueues {
+			l = len(s)
+			n += 1 + l + sovReporting(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovReporting(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozReporting(x uint64) (n int) {
+	return sovReporting(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *MostRecentForQueue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MostRecentForQueue: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MostRecentForQueue: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QueueName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MostRecentForJob) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MostRecentForJob: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MostRecentForJob: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeEvicted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExcludeEvicted = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MostRecentForPool) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MostRecentForPool: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MostRecentForPool: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchedulingReportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchedulingReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentForQueue", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MostRecentForQueue{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Filter = &SchedulingReportRequest_MostRecentForQueue{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentForJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MostRecentForJob{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Filter = &SchedulingReportRequest_MostRecentForJob{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verbosity", wireType)
+			}
+			m.Verbosity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Verbosity |= ReportVerbosity(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			m.Format = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Format |= ReportFormat(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentForPool", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MostRecentForPool{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Filter = &SchedulingReportRequest_MostRecentForPool{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field History", wireType)
+			}
+			m.History = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.History |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorPattern", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorPattern = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorPatternIsRegex", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExecutorPatternIsRegex = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MinResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compress", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Compress = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SchedulingReport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchedulingReport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchedulingReport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Report = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressedReport", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompressedReport = append(m.CompressedReport[:0], dAtA[iNdEx:postIndex]...)
+			if m.CompressedReport == nil {
+				m.CompressedReport = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encoding", wireType)
+			}
+			m.Encoding = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Encoding |= ReportEncoding(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueueReportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueueReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QueueName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verbosity", wireType)
+			}
+			m.Verbosity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Verbosity |= ReportVerbosity(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			m.Format = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Format |= ReportFormat(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compress", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Compress = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueueReport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueueReport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueueReport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Report = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Executors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Executors = append(m.Executors, &ExecutorQueueReport{})
+			if err := m.Executors[len(m.Executors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressedReport", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompressedReport = append(m.CompressedReport[:0], dAtA[iNdEx:postIndex]...)
+			if m.CompressedReport == nil {
+				m.CompressedReport = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encoding", wireType)
+			}
+			m.Encoding = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Encoding |= ReportEncoding(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Paused", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Paused = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExecutorQueueReport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExecutorQueueReport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExecutorQueueReport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MostRecent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MostRecent == nil {
+				m.MostRecent = &QueueSchedulingSummary{}
+			}
+			if err := m.MostRecent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentSuccessful", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MostRecentSuccessful == nil {
+				m.MostRecentSuccessful = &QueueSchedulingSummary{}
+			}
+			if err := m.MostRecentSuccessful.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentPreempting", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MostRecentPreempting == nil {
+				m.MostRecentPreempting = &QueueSchedulingSummary{}
+			}
+			if err := m.MostRecentPreempting.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueueSchedulingSummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueueSchedulingSummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueueSchedulingSummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Created, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduledResourcesByPriority", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ScheduledResourcesByPriority == nil {
+				m.ScheduledResourcesByPriority = make(map[int32]ResourceList)
+			}
+			var mapkey int32
+			mapvalue := &ResourceList{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowReporting
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowReporting
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowReporting
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthReporting
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &ResourceList{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipReporting(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ScheduledResourcesByPriority[mapkey] = *mapvalue
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvictedResourcesByPriority", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EvictedResourcesByPriority == nil {
+				m.EvictedResourcesByPriority = make(map[int32]ResourceList)
+			}
+			var mapkey int32
+			mapvalue := &ResourceList{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowReporting
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowReporting
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowReporting
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthReporting
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &ResourceList{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipReporting(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.EvictedResourcesByPriority[mapkey] = *mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumSuccessfulJobSchedulingContexts", wireType)
+			}
+			m.NumSuccessfulJobSchedulingContexts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumSuccessfulJobSchedulingContexts |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumUnsuccessfulJobSchedulingContexts", wireType)
+			}
+			m.NumUnsuccessfulJobSchedulingContexts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumUnsuccessfulJobSchedulingContexts |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FairShare", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.FairShare = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobReportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobReportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			m.Format = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Format |= ReportFormat(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Since == nil {
+				m.Since = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Since, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Until", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Until == nil {
+				m.Until = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Until, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compress", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Compress = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobReport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobReport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobReport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Report = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressedReport", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompressedReport = append(m.CompressedReport[:0], dAtA[iNdEx:postIndex]...)
+			if m.CompressedReport == nil {
+				m.CompressedReport = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encoding", wireType)
+			}
+			m.Encoding = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Encoding |= ReportEncoding(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobSchedulingSummariesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobSchedulingSummariesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobSchedulingSummariesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobSchedulingSummaries) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobSchedulingSummaries: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobSchedulingSummaries: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobSchedulingSummaries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobSchedulingSummaries = append(m.JobSchedulingSummaries, &JobSchedulingSummary{})
+			if err := m.JobSchedulingSummaries[len(m.JobSchedulingSummaries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JobSchedulingSummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JobSchedulingSummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JobSchedulingSummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pool = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.JobId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GangId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GangId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Created, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PriorityClassName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PriorityClassName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scheduled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Scheduled = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnschedulableReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UnschedulableReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnschedulableReasonCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UnschedulableReasonCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExecutorSchedulingContextRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExecutorSchedulingContextRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExecutorSchedulingContextRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExecutorSchedulingContext) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExecutorSchedulingContext: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExecutorSchedulingContext: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pool = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Started", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Started, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Finished", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Finished, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TotalResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduledResourcesByPriority", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ScheduledResourcesByPriority == nil {
+				m.ScheduledResourcesByPriority = make(map[int32]ResourceList)
+			}
+			var mapkey int32
+			mapvalue := &ResourceList{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowReporting
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowReporting
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowReporting
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthReporting
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &ResourceList{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipReporting(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ScheduledResourcesByPriority[mapkey] = *mapvalue
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvictedResourcesByPriority", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EvictedResourcesByPriority == nil {
+				m.EvictedResourcesByPriority = make(map[int32]ResourceList)
+			}
+			var mapkey int32
+			mapvalue := &ResourceList{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowReporting
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowReporting
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowReporting
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthReporting
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &ResourceList{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipReporting(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.EvictedResourcesByPriority[mapkey] = *mapvalue
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumScheduledJobs", wireType)
+			}
+			m.NumScheduledJobs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumScheduledJobs |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumScheduledGangs", wireType)
+			}
+			m.NumScheduledGangs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumScheduledGangs |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumEvictedJobs", wireType)
+			}
+			m.NumEvictedJobs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumEvictedJobs |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TerminationReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TerminationReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueSchedulingSummaries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.QueueSchedulingSummaries == nil {
+				m.QueueSchedulingSummaries = make(map[string]*QueueSchedulingSummary)
+			}
+			var mapkey string
+			var mapvalue *QueueSchedulingSummary
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowReporting
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowReporting
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthReporting
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowReporting
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthReporting
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &QueueSchedulingSummary{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipReporting(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthReporting
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.QueueSchedulingSummaries[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompareExecutorsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompareExecutorsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompareExecutorsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorIdA", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorIdA = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorIdB", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecutorIdB = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			m.Format = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Format |= ReportFormat(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompareExecutorsReport) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompareExecutorsReport: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompareExecutorsReport: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Report = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ClusterScheduledResourcesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClusterScheduledResourcesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClusterScheduledResourcesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ClusterScheduledResources) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClusterScheduledResources: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClusterScheduledResources: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduledResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ScheduledResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvictedResources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthReporting
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.EvictedResources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumSuccessfulJobs", wireType)
+			}
+			m.NumSuccessfulJobs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumSuccessfulJobs |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumUnsuccessfulJobs", wireType)
+			}
+			m.NumUnsuccessfulJobs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumUnsuccessfulJobs |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumExecutors", wireType)
+			}
+			m.NumExecutors = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumExecutors |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *QueueReport) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Report)
-	if l > 0 {
-		n += 1 + l + sovReporting(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *JobReportRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.JobId)
-	if l > 0 {
-		n += 1 + l + sovReporting(uint64(l))
+func (m *QueuesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowReporting
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueuesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipReporting(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthReporting
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *JobReport) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Report)
-	if l > 0 {
-		n += 1 + l + sovReporting(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovReporting(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozReporting(x uint64) (n int) {
-	return sovReporting(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *MostRecentForQueue) Unmarshal(dAtA []byte) error {
+func (m *Queues) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1116,15 +8570,15 @@ func (m *MostRecentForQueue) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MostRecentForQueue: wiretype end group for non-group")
+			return fmt.Errorf("proto: Queues: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MostRecentForQueue: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Queues: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field QueueName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueNames", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1152,7 +8606,7 @@ func (m *MostRecentForQueue) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.QueueName = string(dAtA[iNdEx:postIndex])
+			m.QueueNames = append(m.QueueNames, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1175,7 +8629,7 @@ func (m *MostRecentForQueue) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MostRecentForJob) Unmarshal(dAtA []byte) error {
+func (m *WatchSchedulingReportsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1198,15 +8652,15 @@ func (m *MostRecentForJob) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MostRecentForJob: wiretype end group for non-group")
+			return fmt.Errorf("proto: WatchSchedulingReportsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MostRecentForJob: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WatchSchedulingReportsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorIds", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1234,7 +8688,7 @@ func (m *MostRecentForJob) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.JobId = string(dAtA[iNdEx:postIndex])
+			m.ExecutorIds = append(m.ExecutorIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1257,7 +8711,7 @@ func (m *MostRecentForJob) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
+func (m *SetExecutorDrainingRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1280,17 +8734,17 @@ func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SchedulingReportRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetExecutorDrainingRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchedulingReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetExecutorDrainingRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentForQueue", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecutorId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowReporting
@@ -1300,67 +8754,29 @@ func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthReporting
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthReporting
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &MostRecentForQueue{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Filter = &SchedulingReportRequest_MostRecentForQueue{v}
+			m.ExecutorId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MostRecentForJob", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowReporting
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthReporting
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthReporting
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &MostRecentForJob{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Filter = &SchedulingReportRequest_MostRecentForJob{v}
-			iNdEx = postIndex
-		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Verbosity", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Draining", wireType)
 			}
-			m.Verbosity = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowReporting
@@ -1370,11 +8786,12 @@ func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Verbosity |= int32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Draining = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipReporting(dAtA[iNdEx:])
@@ -1396,7 +8813,7 @@ func (m *SchedulingReportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SchedulingReport) Unmarshal(dAtA []byte) error {
+func (m *SetExecutorDrainingResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1419,15 +8836,15 @@ func (m *SchedulingReport) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SchedulingReport: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetExecutorDrainingResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchedulingReport: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetExecutorDrainingResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DrainingExecutorIds", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1455,7 +8872,7 @@ func (m *SchedulingReport) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Report = string(dAtA[iNdEx:postIndex])
+			m.DrainingExecutorIds = append(m.DrainingExecutorIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1478,7 +8895,7 @@ func (m *SchedulingReport) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
+func (m *ForgetJobRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1501,15 +8918,15 @@ func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueueReportRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ForgetJobRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueueReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ForgetJobRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field QueueName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1537,27 +8954,8 @@ func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.QueueName = string(dAtA[iNdEx:postIndex])
+			m.JobId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Verbosity", wireType)
-			}
-			m.Verbosity = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowReporting
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Verbosity |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipReporting(dAtA[iNdEx:])
@@ -1579,7 +8977,7 @@ func (m *QueueReportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueueReport) Unmarshal(dAtA []byte) error {
+func (m *ForgetJobResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1602,17 +9000,17 @@ func (m *QueueReport) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueueReport: wiretype end group for non-group")
+			return fmt.Errorf("proto: ForgetJobResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueueReport: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ForgetJobResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowReporting
@@ -1622,24 +9020,12 @@ func (m *QueueReport) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthReporting
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthReporting
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Report = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.Removed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipReporting(dAtA[iNdEx:])
@@ -1661,7 +9047,7 @@ func (m *QueueReport) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JobReportRequest) Unmarshal(dAtA []byte) error {
+func (m *SetQueuePausedRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1684,15 +9070,15 @@ func (m *JobReportRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JobReportRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetQueuePausedRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetQueuePausedRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field JobId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Queue", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1720,8 +9106,28 @@ func (m *JobReportRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.JobId = string(dAtA[iNdEx:postIndex])
+			m.Queue = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Paused", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowReporting
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Paused = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipReporting(dAtA[iNdEx:])
@@ -1743,7 +9149,7 @@ func (m *JobReportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JobReport) Unmarshal(dAtA []byte) error {
+func (m *SetQueuePausedResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1766,15 +9172,15 @@ func (m *JobReport) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JobReport: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetQueuePausedResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JobReport: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetQueuePausedResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Report", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PausedQueues", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1802,7 +9208,7 @@ func (m *JobReport) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Report = string(dAtA[iNdEx:postIndex])
+			m.PausedQueues = append(m.PausedQueues, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -49,15 +51,18 @@ func (a QuantityByPriorityAndResourceType) DeepCopy() QuantityByPriorityAndResou
 	return rv
 }
 
+// String returns a string representation of a, with priorities in increasing order,
+// such that the same value always results in the same string.
 func (a QuantityByPriorityAndResourceType) String() string {
+	priorities := maps.Keys(a)
+	slices.Sort(priorities)
 	var sb strings.Builder
-	i := 0
 	sb.WriteString("{")
-	for p, rl := range a {
-		if i < len(a)-1 {
-			sb.WriteString(fmt.Sprintf("%d: %s, ", p, rl.CompactString()))
+	for i, p := range priorities {
+		if i < len(priorities)-1 {
+			sb.WriteString(fmt.Sprintf("%d: %s, ", p, a[p].CompactString()))
 		} else {
-			sb.WriteString(fmt.Sprintf("%d: %s", p, rl.CompactString()))
+			sb.WriteString(fmt.Sprintf("%d: %s", p, a[p].CompactString()))
 		}
 	}
 	sb.WriteString("}")
@@ -285,17 +290,19 @@ func (a ResourceList) IsStrictlyLessOrEqual(b ResourceList) bool {
 	return true
 }
 
+// CompactString returns a single-line string representation of rl, with resource types in sorted order.
 func (rl ResourceList) CompactString() string {
+	resourceTypes := maps.Keys(rl.Resources)
+	slices.Sort(resourceTypes)
 	var sb strings.Builder
 	sb.WriteString("{")
-	i := 0
-	for t, q := range rl.Resources {
-		if i < len(rl.Resources)-1 {
+	for i, t := range resourceTypes {
+		q := rl.Resources[t]
+		if i < len(resourceTypes)-1 {
 			sb.WriteString(fmt.Sprintf("%s: %s, ", t, q.String()))
 		} else {
 			sb.WriteString(fmt.Sprintf("%s: %s", t, q.String()))
 		}
-		i++
 	}
 	sb.WriteString("}")
 	return sb.String()
@@ -889,6 +889,27 @@ func TestResourceListZero(t *testing.T) {
 	assert.True(t, rl.Equal(ResourceList{}))
 }
 
+func TestResourceListCompactString(t *testing.T) {
+	rl := ResourceList{
+		Resources: map[string]resource.Quantity{
+			"foo": resource.MustParse("1"),
+			"bar": resource.MustParse("10Gi"),
+			"baz": resource.MustParse("0"),
+		},
+	}
+	assert.Equal(t, "{bar: 10Gi, baz: 0, foo: 1}", rl.CompactString())
+	assert.Equal(t, "{}", ResourceList{}.CompactString())
+}
+
+func TestQuantityByPriorityAndResourceTypeString(t *testing.T) {
+	a := QuantityByPriorityAndResourceType{
+		2: ResourceList{Resources: map[string]resource.Quantity{"foo": resource.MustParse("1")}},
+		0: ResourceList{Resources: map[string]resource.Quantity{"foo": resource.MustParse("2")}},
+		1: ResourceList{Resources: map[string]resource.Quantity{"foo": resource.MustParse("3")}},
+	}
+	assert.Equal(t, "{0: {foo: 2}, 1: {foo: 3}, 2: {foo: 1}}", a.String())
+}
+
 func BenchmarkResourceListZero(b *testing.B) {
 	rl := ResourceList{
 		Resources: map[string]resource.Quantity{
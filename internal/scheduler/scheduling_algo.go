@@ -322,6 +322,9 @@ func (l *FairSchedulingAlgo) scheduleOnExecutor(
 		l.config.ResourceScarcity,
 		accounting.totalCapacity,
 	)
+	if err := sctx.SetFairnessModel(l.config.FairnessModel); err != nil {
+		return nil, nil, err
+	}
 	for queue, priorityFactor := range accounting.priorityFactorByQueue {
 		var allocatedByPriority schedulerobjects.QuantityByPriorityAndResourceType
 		if allocatedByQueueAndPriority := accounting.totalAllocationByPoolAndQueue[executor.Pool]; allocatedByQueueAndPriority != nil {
@@ -357,6 +360,16 @@ func (l *FairSchedulingAlgo) scheduleOnExecutor(
 	if l.config.EnableAssertions {
 		scheduler.EnableAssertions()
 	}
+	if l.config.Preemption.EnableGangPreemption {
+		scheduler.EnableGangPreemption(
+			l.config.Preemption.GangPreemptionPriorityThreshold,
+			l.config.Preemption.GangPreemptionMinimumRuntime,
+		)
+	}
+	if l.schedulingContextRepository != nil {
+		scheduler.SetDrainingExecutors(l.schedulingContextRepository.DrainingExecutors())
+		scheduler.SetPausedQueues(l.schedulingContextRepository.PausedQueues())
+	}
 	result, err := scheduler.Schedule(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -455,6 +468,9 @@ func (l *FairSchedulingAlgo) constructNodeDb(nodes []*schedulerobjects.Node, job
 	if err != nil {
 		return nil, err
 	}
+	if err := nodeDb.SetTieBreakPolicy(l.config.NodeTieBreakPolicy); err != nil {
+		return nil, err
+	}
 	if err := nodeDb.UpsertMany(maps.Values(nodesByName)); err != nil {
 		return nil, err
 	}
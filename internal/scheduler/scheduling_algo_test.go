@@ -338,7 +338,7 @@ func TestLegacySchedulingAlgo_TestSchedule(t *testing.T) {
 			mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
 			mockQueueRepo.EXPECT().GetAllQueues().Return(tc.queues, nil).AnyTimes()
 
-			schedulingContextRepo, err := NewSchedulingContextRepository(1024)
+			schedulingContextRepo, err := NewSchedulingContextRepository(1024, 0)
 			require.NoError(t, err)
 			algo, err := NewFairSchedulingAlgo(
 				tc.schedulingConfig,
@@ -492,6 +492,58 @@ func TestLegacySchedulingAlgo_TestSchedule(t *testing.T) {
 	}
 }
 
+func TestFairSchedulingAlgo_SkipsPausedQueues(t *testing.T) {
+	ctx := testfixtures.ContextWithDefaultLogger(context.Background())
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	schedulingConfig := testfixtures.TestSchedulingConfig()
+	executors := []*schedulerobjects.Executor{testfixtures.Test1Node32CoreExecutor("executor1")}
+	mockExecutorRepo := schedulermocks.NewMockExecutorRepository(ctrl)
+	mockExecutorRepo.EXPECT().GetExecutors(ctx).Return(executors, nil).AnyTimes()
+	mockQueueRepo := schedulermocks.NewMockQueueRepository(ctrl)
+	mockQueueRepo.EXPECT().GetAllQueues().Return([]*database.Queue{{Name: "A", Weight: 100}, {Name: "B", Weight: 100}}, nil).AnyTimes()
+	schedulingContextRepo, err := NewSchedulingContextRepository(1024, 0)
+	require.NoError(t, err)
+	algo, err := NewFairSchedulingAlgo(schedulingConfig, 5*time.Second, mockExecutorRepo, mockQueueRepo, schedulingContextRepo)
+	require.NoError(t, err)
+	algo.clock = clock.NewFakeClock(testfixtures.BaseTime)
+
+	// Pause queue A the way an operator would, i.e., via the admin API.
+	admin := NewSchedulerAdminServer(allowAllPermissionChecker{}, schedulingContextRepo)
+	_, err = admin.SetQueuePaused(ctx, &schedulerobjects.SetQueuePausedRequest{Queue: "A", Paused: true})
+	require.NoError(t, err)
+
+	jobsA := testfixtures.N1CpuJobs("A", testfixtures.PriorityClass0, 2)
+	jobsB := testfixtures.N1CpuJobs("B", testfixtures.PriorityClass0, 2)
+	jobDb := jobdb.NewJobDb()
+	txn := jobDb.WriteTxn()
+	for _, job := range append(jobsA, jobsB...) {
+		require.NoError(t, jobDb.Upsert(txn, []*jobdb.Job{job.WithQueued(true)}))
+	}
+
+	result, err := algo.Schedule(ctx, txn, jobDb)
+	require.NoError(t, err)
+	scheduledQueues := make([]string, 0, len(result.ScheduledJobs))
+	for _, job := range result.ScheduledJobs {
+		scheduledQueues = append(scheduledQueues, job.GetQueue())
+	}
+	assert.Equal(t, []string{"B", "B"}, scheduledQueues)
+
+	// Resuming the queue allows its jobs to be scheduled again.
+	_, err = admin.SetQueuePaused(ctx, &schedulerobjects.SetQueuePausedRequest{Queue: "A", Paused: false})
+	require.NoError(t, err)
+	for _, job := range jobsB {
+		require.NoError(t, jobDb.BatchDelete(txn, []string{job.Id()}))
+	}
+	result, err = algo.Schedule(ctx, txn, jobDb)
+	require.NoError(t, err)
+	assert.Len(t, result.ScheduledJobs, 2)
+	for _, job := range result.ScheduledJobs {
+		assert.Equal(t, "A", job.GetQueue())
+	}
+}
+
 func TestGetExecutorsToSchedule(t *testing.T) {
 	executorA := testfixtures.Test1Node32CoreExecutor("a")
 	executorA1 := testfixtures.Test1Node32CoreExecutor("a1")
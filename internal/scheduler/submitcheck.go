@@ -220,7 +220,7 @@ func (srv *SubmitChecker) check(reqs []*schedulerobjects.PodRequirements) schedu
 	for id, executor := range executorById {
 		nodeDb := executor.nodeDb
 		txn := nodeDb.Txn(true)
-		reports, ok, err := nodeDb.ScheduleManyWithTxn(txn, reqs)
+		reports, ok, err := nodeDb.ScheduleManyWithTxn(context.Background(), txn, reqs)
 		txn.Abort()
 
 		sb.WriteString(id)
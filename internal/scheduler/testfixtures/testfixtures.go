@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/armadaproject/armada/internal/armada/configuration"
+	armadaresource "github.com/armadaproject/armada/internal/common/resource"
 	"github.com/armadaproject/armada/internal/common/util"
 	schedulerconfig "github.com/armadaproject/armada/internal/scheduler/configuration"
 	"github.com/armadaproject/armada/internal/scheduler/database"
@@ -52,6 +53,8 @@ var (
 		{Name: "cpu", Resolution: resource.MustParse("1")},
 		{Name: "memory", Resolution: resource.MustParse("128Mi")},
 		{Name: "gpu", Resolution: resource.MustParse("1")},
+		{Name: "nvidia.com/gpu", Resolution: resource.MustParse("1")},
+		{Name: "ephemeral-storage", Resolution: resource.MustParse("1Gi")},
 	}
 	TestResourceNames = util.Map(
 		TestResources,
@@ -116,6 +119,22 @@ func WithNodeOversubscriptionEvictionProbabilityConfig(p float64, config configu
 	return config
 }
 
+func WithGangPreemptionConfig(priorityThreshold int32, config configuration.SchedulingConfig) configuration.SchedulingConfig {
+	config.Preemption.EnableGangPreemption = true
+	config.Preemption.GangPreemptionPriorityThreshold = priorityThreshold
+	return config
+}
+
+func WithResourceScarcityConfig(scarcity map[string]float64, config configuration.SchedulingConfig) configuration.SchedulingConfig {
+	config.ResourceScarcity = scarcity
+	return config
+}
+
+func WithPoolMinimumJobSizeConfig(pool string, minimumJobSize map[string]resource.Quantity, config configuration.SchedulingConfig) configuration.SchedulingConfig {
+	config.MinimumJobSizeByPool = map[string]armadaresource.ComputeResources{pool: minimumJobSize}
+	return config
+}
+
 func WithRoundLimitsConfig(limits map[string]float64, config configuration.SchedulingConfig) configuration.SchedulingConfig {
 	config.MaximumResourceFractionToSchedule = limits
 	return config
@@ -129,9 +148,37 @@ func WithRoundLimitsPoolConfig(limits map[string]map[string]float64, config conf
 func WithPerPriorityLimitsConfig(limits map[int32]map[string]float64, config configuration.SchedulingConfig) configuration.SchedulingConfig {
 	for k, v := range config.Preemption.PriorityClasses {
 		config.Preemption.PriorityClasses[k] = configuration.PriorityClass{
-			Priority:                        v.Priority,
-			Preemptible:                     v.Preemptible,
-			MaximumResourceFractionPerQueue: limits[v.Priority],
+			Priority:                          v.Priority,
+			Preemptible:                       v.Preemptible,
+			MaximumResourceFractionPerQueue:   limits[v.Priority],
+			MaximumResourcesPerQueue:          v.MaximumResourcesPerQueue,
+			MaximumResourceFractionToSchedule: v.MaximumResourceFractionToSchedule,
+		}
+	}
+	return config
+}
+
+func WithPerPriorityAbsoluteLimitsConfig(limits map[int32]map[string]resource.Quantity, config configuration.SchedulingConfig) configuration.SchedulingConfig {
+	for k, v := range config.Preemption.PriorityClasses {
+		config.Preemption.PriorityClasses[k] = configuration.PriorityClass{
+			Priority:                          v.Priority,
+			Preemptible:                       v.Preemptible,
+			MaximumResourceFractionPerQueue:   v.MaximumResourceFractionPerQueue,
+			MaximumResourcesPerQueue:          limits[v.Priority],
+			MaximumResourceFractionToSchedule: v.MaximumResourceFractionToSchedule,
+		}
+	}
+	return config
+}
+
+func WithPerPriorityRoundLimitsConfig(limits map[int32]map[string]float64, config configuration.SchedulingConfig) configuration.SchedulingConfig {
+	for k, v := range config.Preemption.PriorityClasses {
+		config.Preemption.PriorityClasses[k] = configuration.PriorityClass{
+			Priority:                          v.Priority,
+			Preemptible:                       v.Preemptible,
+			MaximumResourceFractionPerQueue:   v.MaximumResourceFractionPerQueue,
+			MaximumResourcesPerQueue:          v.MaximumResourcesPerQueue,
+			MaximumResourceFractionToSchedule: limits[v.Priority],
 		}
 	}
 	return config
@@ -142,6 +189,16 @@ func WithIndexedResourcesConfig(indexResources []configuration.IndexedResource,
 	return config
 }
 
+func WithNodeTieBreakPolicyConfig(policy string, config configuration.SchedulingConfig) configuration.SchedulingConfig {
+	config.NodeTieBreakPolicy = policy
+	return config
+}
+
+func WithFairnessModelConfig(model string, config configuration.SchedulingConfig) configuration.SchedulingConfig {
+	config.FairnessModel = model
+	return config
+}
+
 func WithMaxJobsToScheduleConfig(n uint, config configuration.SchedulingConfig) configuration.SchedulingConfig {
 	config.MaximumJobsToSchedule = n
 	return config
@@ -180,6 +237,15 @@ func WithUsedResourcesNodes(p int32, rl schedulerobjects.ResourceList, nodes []*
 	return nodes
 }
 
+// WithIdsNodes sets the id of the i-th node to ids[i].
+func WithIdsNodes(ids []string, nodes []*schedulerobjects.Node) []*schedulerobjects.Node {
+	for i, node := range nodes {
+		node.Id = ids[i]
+		node.Labels[TestHostnameLabel] = ids[i]
+	}
+	return nodes
+}
+
 func WithNodeTypeIdNodes(nodeTypeId uint64, nodes []*schedulerobjects.Node) []*schedulerobjects.Node {
 	for _, node := range nodes {
 		node.NodeTypeId = nodeTypeId
@@ -320,6 +386,16 @@ func N1GpuJobs(queue string, priorityClassName string, n int) []*jobdb.Job {
 	return rv
 }
 
+// N1NvidiaGpuJobs returns n jobs each requesting one "nvidia.com/gpu" and some "ephemeral-storage",
+// i.e., resources named as by the Nvidia device plugin and the kubelet, respectively; see N8NvidiaGpuNodes.
+func N1NvidiaGpuJobs(queue string, priorityClassName string, n int) []*jobdb.Job {
+	rv := make([]*jobdb.Job, n)
+	for i := 0; i < n; i++ {
+		rv[i] = Test1NvidiaGpuJob(queue, priorityClassName)
+	}
+	return rv
+}
+
 func extractPriority(priorityClassName string) int32 {
 	priorityClass, ok := TestPriorityClasses[priorityClassName]
 	if !ok {
@@ -377,6 +453,11 @@ func Test1GpuJob(queue string, priorityClassName string) *jobdb.Job {
 	return TestJob(queue, jobId, priorityClassName, Test1GpuPodReqs(queue, jobId, extractPriority(priorityClassName)))
 }
 
+func Test1NvidiaGpuJob(queue string, priorityClassName string) *jobdb.Job {
+	jobId := util.ULID()
+	return TestJob(queue, jobId, priorityClassName, Test1NvidiaGpuPodReqs(queue, jobId, extractPriority(priorityClassName)))
+}
+
 func N1CpuPodReqs(queue string, priority int32, n int) []*schedulerobjects.PodRequirements {
 	rv := make([]*schedulerobjects.PodRequirements, n)
 	for i := 0; i < n; i++ {
@@ -409,6 +490,14 @@ func N1GpuPodReqs(queue string, priority int32, n int) []*schedulerobjects.PodRe
 	return rv
 }
 
+func N1NvidiaGpuPodReqs(queue string, priority int32, n int) []*schedulerobjects.PodRequirements {
+	rv := make([]*schedulerobjects.PodRequirements, n)
+	for i := 0; i < n; i++ {
+		rv[i] = Test1NvidiaGpuPodReqs(queue, util.ULID(), priority)
+	}
+	return rv
+}
+
 func TestPodReqs(queue string, jobId ulid.ULID, priority int32, requests v1.ResourceList) *schedulerobjects.PodRequirements {
 	return &schedulerobjects.PodRequirements{
 		Priority:             priority,
@@ -491,6 +580,20 @@ func Test1GpuPodReqs(queue string, jobId ulid.ULID, priority int32) *schedulerob
 	return req
 }
 
+func Test1NvidiaGpuPodReqs(queue string, jobId ulid.ULID, priority int32) *schedulerobjects.PodRequirements {
+	return TestPodReqs(
+		queue,
+		jobId,
+		priority,
+		v1.ResourceList{
+			"cpu":               resource.MustParse("4"),
+			"memory":            resource.MustParse("16Gi"),
+			"nvidia.com/gpu":    resource.MustParse("1"),
+			"ephemeral-storage": resource.MustParse("100Gi"),
+		},
+	)
+}
+
 func TestUnitReqs(priority int32) *schedulerobjects.PodRequirements {
 	return &schedulerobjects.PodRequirements{
 		Priority: priority,
@@ -594,6 +697,14 @@ func N8GpuNodes(n int, priorities []int32) []*schedulerobjects.Node {
 	return rv
 }
 
+func N8NvidiaGpuNodes(n int, priorities []int32) []*schedulerobjects.Node {
+	rv := make([]*schedulerobjects.Node, n)
+	for i := 0; i < n; i++ {
+		rv[i] = Test8NvidiaGpuNode(priorities)
+	}
+	return rv
+}
+
 func TestNode(priorities []int32, resources map[string]resource.Quantity) *schedulerobjects.Node {
 	id := uuid.NewString()
 	return &schedulerobjects.Node{
@@ -646,6 +757,18 @@ func Test8GpuNode(priorities []int32) *schedulerobjects.Node {
 	return node
 }
 
+func Test8NvidiaGpuNode(priorities []int32) *schedulerobjects.Node {
+	return TestNode(
+		priorities,
+		map[string]resource.Quantity{
+			"cpu":               resource.MustParse("64"),
+			"memory":            resource.MustParse("1024Gi"),
+			"nvidia.com/gpu":    resource.MustParse("8"),
+			"ephemeral-storage": resource.MustParse("1Ti"),
+		},
+	)
+}
+
 func WithLastUpdateTimeExecutor(lastUpdateTime time.Time, executor *schedulerobjects.Executor) *schedulerobjects.Executor {
 	executor.LastUpdateTime = lastUpdateTime
 	return executor
@@ -95,6 +95,10 @@ type LeaseRequest struct {
 	Nodes []*api.NodeInfo `protobuf:"bytes,5,rep,name=nodes,proto3" json:"nodes,omitempty"`
 	// Run Ids of jobs owned by the executor but not currently assigned to a node.
 	UnassignedJobRunIds []armadaevents.Uuid `protobuf:"bytes,6,rep,name=unassigned_job_run_ids,json=unassignedJobRunIds,proto3" json:"unassignedJobRunIds"`
+	// If true, further LeaseRequest messages follow on the same stream.
+	// The server joins the nodes and unassigned job run ids of all such messages into a single request.
+	// Only the first message needs to set the other fields.
+	MoreToFollow bool `protobuf:"varint,7,opt,name=more_to_follow,json=moreToFollow,proto3" json:"moreToFollow,omitempty"`
 }
 
 func (m *LeaseRequest) Reset()      { *m = LeaseRequest{} }
@@ -171,6 +175,13 @@ func (m *LeaseRequest) GetUnassignedJobRunIds() []armadaevents.Uuid {
 	return nil
 }
 
+func (m *LeaseRequest) GetMoreToFollow() bool {
+	if m != nil {
+		return m.MoreToFollow
+	}
+	return false
+}
+
 // Indicates that a job run is now leased.
 type JobRunLease struct {
 	JobRunId *armadaevents.Uuid      `protobuf:"bytes,1,opt,name=job_run_id,json=jobRunId,proto3" json:"jobRunId,omitempty"`
@@ -504,69 +515,71 @@ func init() {
 func init() { proto.RegisterFile("pkg/executorapi/executorapi.proto", fileDescriptor_57e0d9d0e484e459) }
 
 var fileDescriptor_57e0d9d0e484e459 = []byte{
-	// 987 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0x4f, 0x6f, 0xdc, 0x44,
-	0x14, 0x5f, 0x67, 0xb3, 0x0b, 0x79, 0x4e, 0x43, 0x33, 0x69, 0xb7, 0xee, 0x06, 0xd6, 0x61, 0x91,
-	0x50, 0x90, 0x5a, 0x1b, 0x05, 0x0e, 0x01, 0x01, 0x12, 0x8b, 0x56, 0x6a, 0xa2, 0xa6, 0xa2, 0x4e,
-	0x41, 0x94, 0xcb, 0xca, 0x5e, 0xbf, 0x3a, 0xde, 0x8d, 0x3d, 0xae, 0xc7, 0x2e, 0x6c, 0x4f, 0x7c,
-	0x84, 0x1e, 0xb8, 0x70, 0xe0, 0xdb, 0x70, 0xe8, 0xb1, 0x12, 0x97, 0x9e, 0x2c, 0x48, 0x6e, 0xfe,
-	0x14, 0xc8, 0x33, 0x76, 0x3c, 0x4e, 0xb6, 0x9c, 0x7b, 0xda, 0x7d, 0xbf, 0xf7, 0xe6, 0xf7, 0xfe,
-	0xcf, 0x18, 0x3e, 0x8c, 0xe6, 0x9e, 0x89, 0xbf, 0xe2, 0x34, 0x4d, 0x68, 0x6c, 0x47, 0xbe, 0xfc,
-	0xdf, 0x88, 0x62, 0x9a, 0x50, 0xa2, 0x4a, 0x50, 0xff, 0x83, 0xc2, 0xde, 0x8e, 0x03, 0xdb, 0xb5,
-	0xf1, 0x19, 0x86, 0x09, 0x33, 0xc5, 0x8f, 0xb0, 0xed, 0x6f, 0x71, 0x75, 0xe4, 0x9b, 0x4f, 0x53,
-	0x4c, 0xb1, 0x04, 0xb7, 0x3d, 0x4a, 0xbd, 0x53, 0x34, 0xb9, 0xe4, 0xa4, 0x4f, 0x4c, 0x0c, 0xa2,
-	0x64, 0x51, 0x2a, 0xef, 0x7a, 0x7e, 0x72, 0x92, 0x3a, 0xc6, 0x94, 0x06, 0xa6, 0x47, 0x3d, 0x5a,
-	0x5b, 0x15, 0x12, 0x17, 0xf8, 0xbf, 0xd2, 0xfc, 0xf3, 0xf9, 0x3e, 0x33, 0x7c, 0x5a, 0xf8, 0x08,
-	0xec, 0xe9, 0x89, 0x1f, 0x62, 0xbc, 0x30, 0x2b, 0xa7, 0x31, 0x32, 0x9a, 0xc6, 0x53, 0x34, 0x3d,
-	0x0c, 0x31, 0xb6, 0x13, 0x74, 0xc5, 0xa9, 0xe1, 0x8f, 0xb0, 0x36, 0x2e, 0xc2, 0xbc, 0xef, 0xb3,
-	0x84, 0x1c, 0x40, 0x57, 0xc4, 0xac, 0x29, 0x3b, 0xed, 0x5d, 0x75, 0x6f, 0xdb, 0x90, 0xf3, 0x31,
-	0xb8, 0xe1, 0x31, 0x3e, 0x4d, 0x31, 0x9c, 0xe2, 0xe8, 0x46, 0x9e, 0xe9, 0xd7, 0x85, 0xe6, 0x0e,
-	0x0d, 0xfc, 0x84, 0x87, 0x6e, 0x95, 0x04, 0xc3, 0x17, 0x5d, 0x58, 0xbf, 0x8f, 0x36, 0x43, 0xab,
-	0xb0, 0x67, 0x09, 0xf9, 0x02, 0x2e, 0xaa, 0x35, 0xf1, 0x5d, 0x4d, 0xd9, 0x51, 0x76, 0xd7, 0x46,
-	0x5a, 0x9e, 0xe9, 0x37, 0x2a, 0xf8, 0xc0, 0x95, 0x78, 0xa0, 0x46, 0xc9, 0xc7, 0xb0, 0x1a, 0x51,
-	0x7a, 0xaa, 0xad, 0xf0, 0x33, 0x24, 0xcf, 0xf4, 0x8d, 0x42, 0x96, 0xac, 0xb9, 0x9e, 0x3c, 0x86,
-	0xb5, 0x2a, 0x4f, 0xa6, 0xb5, 0x79, 0x06, 0xbb, 0x86, 0xdc, 0x35, 0x39, 0x20, 0xc3, 0xaa, 0x4c,
-	0xc7, 0x61, 0x12, 0x2f, 0x46, 0x9b, 0x2f, 0x33, 0xbd, 0x95, 0x67, 0x7a, 0x4d, 0x61, 0xd5, 0x7f,
-	0x09, 0x85, 0xeb, 0x81, 0x1f, 0xfa, 0x41, 0x1a, 0x4c, 0x66, 0xd4, 0x99, 0x30, 0xff, 0x39, 0x6a,
-	0xab, 0xdc, 0xc3, 0xdd, 0x37, 0x7b, 0x38, 0x12, 0x27, 0x0e, 0xa9, 0x73, 0xec, 0x3f, 0x47, 0xe1,
-	0xa6, 0x57, 0xba, 0xd9, 0x08, 0x1a, 0x4a, 0xeb, 0x92, 0x4c, 0xf6, 0xa1, 0x13, 0x52, 0x17, 0x99,
-	0xd6, 0xe1, 0x5e, 0xae, 0x19, 0x05, 0xfb, 0x03, 0xea, 0xe2, 0x41, 0xf8, 0x84, 0x8e, 0xb6, 0xf2,
-	0x4c, 0x7f, 0x8f, 0xeb, 0xa5, 0x22, 0x88, 0x03, 0xc4, 0x85, 0x5e, 0x1a, 0xda, 0x8c, 0xf9, 0x5e,
-	0x88, 0x2e, 0x8f, 0x36, 0x4e, 0xc3, 0x89, 0xef, 0x32, 0xad, 0xcb, 0xa9, 0x48, 0xb3, 0xa9, 0x3f,
-	0xa4, 0xbe, 0x3b, 0xda, 0x2e, 0xa3, 0xda, 0xaa, 0x4f, 0x1e, 0x52, 0xc7, 0x4a, 0xc3, 0x03, 0x97,
-	0x59, 0xcb, 0xc0, 0xfe, 0xef, 0x0a, 0x6c, 0x34, 0x2b, 0x48, 0x3e, 0x82, 0xf6, 0x1c, 0x17, 0x65,
-	0x67, 0x37, 0xf3, 0x4c, 0xbf, 0x36, 0xc7, 0x85, 0x14, 0x5f, 0xa1, 0x25, 0x8f, 0xa1, 0xf3, 0xcc,
-	0x3e, 0x4d, 0x91, 0x37, 0x53, 0xdd, 0x33, 0x0c, 0x31, 0xb5, 0x86, 0x3c, 0xb5, 0x46, 0x34, 0xf7,
-	0x78, 0xbe, 0x55, 0xfd, 0x8d, 0x87, 0xa9, 0x1d, 0x26, 0x7e, 0xb2, 0x10, 0x89, 0x73, 0x02, 0x39,
-	0x71, 0x0e, 0x7c, 0xb9, 0xb2, 0xaf, 0xf4, 0xff, 0x50, 0x60, 0x6b, 0x49, 0xd9, 0xdf, 0x86, 0xd8,
-	0x86, 0x7f, 0xad, 0x80, 0x2a, 0x0a, 0xc8, 0xa7, 0x84, 0xdc, 0x03, 0xa8, 0xbb, 0xc3, 0x43, 0x5b,
-	0xde, 0x9c, 0x5e, 0x9e, 0xe9, 0x64, 0x56, 0x56, 0x5e, 0xa2, 0x7e, 0xb7, 0xc2, 0xc8, 0x27, 0xd0,
-	0xe1, 0xb7, 0x4a, 0xb9, 0x21, 0x3c, 0x10, 0x0e, 0xc8, 0x81, 0x70, 0x80, 0xdc, 0x81, 0xee, 0x8c,
-	0x3a, 0x0c, 0x13, 0xad, 0xcd, 0x6d, 0xf9, 0x16, 0x0b, 0x44, 0xde, 0x62, 0x81, 0x14, 0x9b, 0x97,
-	0x32, 0x8c, 0xb5, 0xd5, 0x7a, 0xf3, 0x0a, 0x59, 0xde, 0xbc, 0x42, 0x2e, 0x58, 0xbd, 0x98, 0xa6,
-	0x91, 0x18, 0xd7, 0x92, 0x55, 0x20, 0x32, 0xab, 0x40, 0xc8, 0x57, 0xd0, 0x9e, 0x51, 0x47, 0xeb,
-	0xf2, 0x8c, 0x6f, 0x35, 0x33, 0x3e, 0x4e, 0x9d, 0xc0, 0x4f, 0x0e, 0xa9, 0x23, 0xba, 0x34, 0xa3,
-	0x8e, 0xdc, 0xa5, 0x19, 0x75, 0x86, 0x0c, 0xe0, 0x3b, 0x3b, 0x9c, 0xe2, 0xa9, 0x95, 0x86, 0x8c,
-	0x20, 0xdc, 0x94, 0x46, 0x7c, 0x92, 0xd0, 0xc9, 0x94, 0x2b, 0xcb, 0x1b, 0x6c, 0x59, 0x3d, 0xf5,
-	0x3c, 0xd3, 0xb7, 0xab, 0xda, 0xb1, 0x47, 0x54, 0xb0, 0x49, 0x6e, 0x36, 0xaf, 0x28, 0x87, 0xbf,
-	0x80, 0xfa, 0x7d, 0x8c, 0x85, 0x9a, 0x7b, 0x3d, 0x81, 0xde, 0x25, 0xaf, 0x91, 0xd0, 0xfe, 0x8f,
-	0xdb, 0x9d, 0x3c, 0xd3, 0xdf, 0x97, 0x98, 0x4b, 0x3e, 0xc9, 0x2f, 0xb9, 0xaa, 0x1d, 0xaa, 0xb0,
-	0x36, 0x0e, 0xdd, 0x23, 0x3b, 0x9e, 0x63, 0x3c, 0xfc, 0x7b, 0x05, 0x08, 0x9f, 0x9d, 0xe3, 0x24,
-	0x46, 0x3b, 0x38, 0x42, 0xc6, 0x6c, 0x0f, 0xc9, 0x18, 0x3a, 0xa7, 0x05, 0x5a, 0xce, 0x90, 0xd6,
-	0xb8, 0x91, 0xa4, 0x89, 0x13, 0x83, 0xc1, 0x4d, 0x6b, 0xaf, 0xf7, 0x5a, 0x96, 0x38, 0x4d, 0x1e,
-	0x81, 0x2a, 0x6a, 0x57, 0xe4, 0xc5, 0xca, 0x25, 0xb8, 0xd5, 0x20, 0xab, 0x0b, 0x2f, 0xae, 0xee,
-	0xe9, 0x85, 0xdc, 0x20, 0x84, 0x1a, 0x27, 0x5f, 0x43, 0x1b, 0x43, 0x97, 0x4f, 0x9b, 0xba, 0xd7,
-	0x6b, 0xb0, 0x5d, 0x24, 0x26, 0x7a, 0x8d, 0xa1, 0xdb, 0x60, 0x29, 0xce, 0x91, 0x9f, 0x60, 0xbd,
-	0x2c, 0xad, 0x88, 0x6a, 0x75, 0x49, 0x8a, 0x52, 0x67, 0x46, 0xb7, 0xf3, 0x4c, 0xbf, 0x19, 0xd5,
-	0x40, 0x83, 0x51, 0x95, 0x14, 0xa3, 0x77, 0xa0, 0xc3, 0xdb, 0xb3, 0xf7, 0xa7, 0x02, 0xea, 0xb8,
-	0xa4, 0xfb, 0x36, 0xf2, 0xc9, 0x83, 0xf2, 0xe5, 0x12, 0x95, 0x63, 0xe4, 0xf6, 0x1b, 0x6f, 0xf8,
-	0xbe, 0x7e, 0x55, 0xd5, 0x68, 0xcd, 0xae, 0xf2, 0xa9, 0x42, 0xbe, 0x81, 0x75, 0x0b, 0x23, 0x1a,
-	0x27, 0xfc, 0xfd, 0x64, 0xe4, 0x52, 0x11, 0xaa, 0xd7, 0xb7, 0xdf, 0x33, 0xc4, 0xd7, 0x80, 0x51,
-	0xbd, 0xf3, 0xc6, 0xb8, 0x88, 0x7b, 0xf4, 0xf0, 0xf5, 0xbf, 0x83, 0xd6, 0x6f, 0x67, 0x03, 0xe5,
-	0xe5, 0xd9, 0x40, 0x79, 0x75, 0x36, 0x50, 0xfe, 0x39, 0x1b, 0x28, 0x2f, 0xce, 0x07, 0xad, 0x57,
-	0xe7, 0x83, 0xd6, 0xeb, 0xf3, 0x41, 0xeb, 0x67, 0x53, 0xfa, 0x52, 0x10, 0x83, 0x17, 0xc5, 0x74,
-	0x86, 0xd3, 0xa4, 0x94, 0xcc, 0x4b, 0x9f, 0x32, 0x4e, 0x97, 0xbb, 0xf8, 0xec, 0xbf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xb0, 0x41, 0x63, 0x24, 0xe4, 0x08, 0x00, 0x00,
+	// 1013 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0x4f, 0x73, 0xdb, 0x44,
+	0x14, 0xb7, 0xe2, 0xd8, 0x6d, 0x56, 0x69, 0x68, 0x36, 0xad, 0xab, 0x3a, 0x60, 0x05, 0xc3, 0x30,
+	0x66, 0xa6, 0x95, 0x98, 0xc0, 0x21, 0x30, 0xc0, 0x0c, 0x66, 0xcc, 0x34, 0x99, 0xa6, 0x43, 0x95,
+	0xc0, 0x50, 0x2e, 0x1e, 0xc9, 0x7a, 0x51, 0x64, 0x5b, 0x5a, 0x55, 0x2b, 0xb5, 0xb8, 0x27, 0x3e,
+	0x02, 0x07, 0x2e, 0x1c, 0xf8, 0x1a, 0x7c, 0x02, 0x0e, 0x3d, 0x76, 0x86, 0x4b, 0x4f, 0x1a, 0x48,
+	0x6e, 0xfa, 0x14, 0xcc, 0xee, 0x4a, 0xd1, 0x2a, 0x71, 0x39, 0x73, 0xb2, 0xdf, 0xef, 0xbd, 0xfd,
+	0xbd, 0xff, 0xda, 0x45, 0xef, 0x46, 0x33, 0xcf, 0x84, 0x9f, 0x60, 0x92, 0x26, 0x24, 0xb6, 0x23,
+	0x5f, 0xfe, 0x6f, 0x44, 0x31, 0x49, 0x08, 0x56, 0x25, 0xa8, 0xfb, 0x0e, 0xb3, 0xb7, 0xe3, 0xc0,
+	0x76, 0x6d, 0x78, 0x06, 0x61, 0x42, 0x4d, 0xf1, 0x23, 0x6c, 0xbb, 0x5b, 0x5c, 0x1d, 0xf9, 0xe6,
+	0xd3, 0x14, 0x52, 0x28, 0xc0, 0x6d, 0x8f, 0x10, 0x6f, 0x0e, 0x26, 0x97, 0x9c, 0xf4, 0xc4, 0x84,
+	0x20, 0x4a, 0x16, 0x85, 0xf2, 0xbe, 0xe7, 0x27, 0xa7, 0xa9, 0x63, 0x4c, 0x48, 0x60, 0x7a, 0xc4,
+	0x23, 0x95, 0x15, 0x93, 0xb8, 0xc0, 0xff, 0x15, 0xe6, 0x9f, 0xcc, 0xf6, 0xa8, 0xe1, 0x13, 0xe6,
+	0x23, 0xb0, 0x27, 0xa7, 0x7e, 0x08, 0xf1, 0xc2, 0x2c, 0x9d, 0xc6, 0x40, 0x49, 0x1a, 0x4f, 0xc0,
+	0xf4, 0x20, 0x84, 0xd8, 0x4e, 0xc0, 0x15, 0xa7, 0xfa, 0xdf, 0xa3, 0xb5, 0x11, 0x0b, 0xf3, 0xa1,
+	0x4f, 0x13, 0xbc, 0x8f, 0xda, 0x22, 0x66, 0x4d, 0xd9, 0x69, 0x0e, 0xd4, 0xdd, 0x6d, 0x43, 0xce,
+	0xc7, 0xe0, 0x86, 0x47, 0xf0, 0x34, 0x85, 0x70, 0x02, 0xc3, 0x5b, 0x79, 0xa6, 0xdf, 0x14, 0x9a,
+	0x7b, 0x24, 0xf0, 0x13, 0x1e, 0xba, 0x55, 0x10, 0xf4, 0xff, 0x68, 0xa3, 0xf5, 0x87, 0x60, 0x53,
+	0xb0, 0x98, 0x3d, 0x4d, 0xf0, 0xa7, 0xe8, 0xa2, 0x5a, 0x63, 0xdf, 0xd5, 0x94, 0x1d, 0x65, 0xb0,
+	0x36, 0xd4, 0xf2, 0x4c, 0xbf, 0x55, 0xc2, 0xfb, 0xae, 0xc4, 0x83, 0x2a, 0x14, 0x7f, 0x80, 0x56,
+	0x23, 0x42, 0xe6, 0xda, 0x0a, 0x3f, 0x83, 0xf3, 0x4c, 0xdf, 0x60, 0xb2, 0x64, 0xcd, 0xf5, 0xf8,
+	0x09, 0x5a, 0x2b, 0xf3, 0xa4, 0x5a, 0x93, 0x67, 0x30, 0x30, 0xe4, 0xae, 0xc9, 0x01, 0x19, 0x56,
+	0x69, 0x3a, 0x0a, 0x93, 0x78, 0x31, 0xdc, 0x7c, 0x99, 0xe9, 0x8d, 0x3c, 0xd3, 0x2b, 0x0a, 0xab,
+	0xfa, 0x8b, 0x09, 0xba, 0x19, 0xf8, 0xa1, 0x1f, 0xa4, 0xc1, 0x78, 0x4a, 0x9c, 0x31, 0xf5, 0x5f,
+	0x80, 0xb6, 0xca, 0x3d, 0xdc, 0x7f, 0xb3, 0x87, 0x43, 0x71, 0xe2, 0x80, 0x38, 0x47, 0xfe, 0x0b,
+	0x10, 0x6e, 0x3a, 0x85, 0x9b, 0x8d, 0xa0, 0xa6, 0xb4, 0x2e, 0xc9, 0x78, 0x0f, 0xb5, 0x42, 0xe2,
+	0x02, 0xd5, 0x5a, 0xdc, 0xcb, 0x0d, 0x83, 0xb1, 0x3f, 0x22, 0x2e, 0xec, 0x87, 0x27, 0x64, 0xb8,
+	0x95, 0x67, 0xfa, 0x5b, 0x5c, 0x2f, 0x15, 0x41, 0x1c, 0xc0, 0x2e, 0xea, 0xa4, 0xa1, 0x4d, 0xa9,
+	0xef, 0x85, 0xe0, 0xf2, 0x68, 0xe3, 0x34, 0x1c, 0xfb, 0x2e, 0xd5, 0xda, 0x9c, 0x0a, 0xd7, 0x9b,
+	0xfa, 0x5d, 0xea, 0xbb, 0xc3, 0xed, 0x22, 0xaa, 0xad, 0xea, 0xe4, 0x01, 0x71, 0xac, 0x34, 0xdc,
+	0x77, 0xa9, 0xb5, 0x0c, 0xc4, 0xef, 0xa3, 0x8d, 0x80, 0xc4, 0x30, 0x4e, 0xc8, 0xf8, 0x84, 0xcc,
+	0xe7, 0xe4, 0xb9, 0x76, 0x6d, 0x47, 0x19, 0x5c, 0xb7, 0xd6, 0x19, 0x7a, 0x4c, 0xbe, 0xe1, 0x58,
+	0xf7, 0x57, 0x05, 0x6d, 0xd4, 0xeb, 0x8c, 0xdf, 0x43, 0xcd, 0x19, 0x2c, 0x8a, 0xfe, 0x6f, 0xe6,
+	0x99, 0x7e, 0x63, 0x06, 0x0b, 0x29, 0x0b, 0xa6, 0xc5, 0x4f, 0x50, 0xeb, 0x99, 0x3d, 0x4f, 0x81,
+	0xb7, 0x5c, 0xdd, 0x35, 0x0c, 0x31, 0xdb, 0x86, 0x3c, 0xdb, 0x46, 0x34, 0xf3, 0x78, 0x55, 0xca,
+	0x2e, 0x19, 0x8f, 0x53, 0x3b, 0x4c, 0xfc, 0x64, 0x21, 0xca, 0xc3, 0x09, 0xe4, 0xf2, 0x70, 0xe0,
+	0xb3, 0x95, 0x3d, 0xa5, 0xfb, 0x9b, 0x82, 0xb6, 0x96, 0x34, 0xe7, 0xff, 0x10, 0x5b, 0xff, 0xcf,
+	0x15, 0xa4, 0x8a, 0x32, 0xf3, 0x59, 0xc2, 0x0f, 0x10, 0xaa, 0x7a, 0xc8, 0x43, 0x5b, 0xde, 0xc2,
+	0x4e, 0x9e, 0xe9, 0x78, 0x5a, 0xf4, 0x47, 0xa2, 0xbe, 0x5e, 0x62, 0xf8, 0x43, 0xd4, 0xe2, 0xdf,
+	0x9e, 0x62, 0x8f, 0x78, 0x20, 0x1c, 0x90, 0x03, 0xe1, 0x00, 0xbe, 0x87, 0xda, 0x53, 0xe2, 0x50,
+	0x48, 0xb4, 0x26, 0xb7, 0xe5, 0xbb, 0x2e, 0x10, 0x79, 0xd7, 0x05, 0xc2, 0xf6, 0x33, 0xa5, 0x10,
+	0x6b, 0xab, 0xd5, 0x7e, 0x32, 0x59, 0xde, 0x4f, 0x26, 0x33, 0x56, 0x2f, 0x26, 0x69, 0x24, 0x86,
+	0xba, 0x60, 0x15, 0x88, 0xcc, 0x2a, 0x10, 0xfc, 0x39, 0x6a, 0x4e, 0x89, 0xa3, 0xb5, 0x79, 0xc6,
+	0x77, 0xea, 0x19, 0x1f, 0xa5, 0x4e, 0xe0, 0x27, 0x07, 0xc4, 0x11, 0x5d, 0x9a, 0x12, 0x47, 0xee,
+	0xd2, 0x94, 0x38, 0x7d, 0x8a, 0xd0, 0xd7, 0x76, 0x38, 0x81, 0xb9, 0x95, 0x86, 0x14, 0x03, 0xba,
+	0x2d, 0x2d, 0x02, 0x1b, 0xda, 0x09, 0x57, 0x16, 0xdf, 0xb9, 0x65, 0xf5, 0xd4, 0xf3, 0x4c, 0xdf,
+	0x2e, 0x6b, 0x47, 0x8f, 0x89, 0x60, 0x93, 0xdc, 0x6c, 0x5e, 0x51, 0xf6, 0x9f, 0x23, 0xf5, 0xdb,
+	0x18, 0x98, 0x9a, 0x7b, 0x3d, 0x45, 0x9d, 0x4b, 0x5e, 0x23, 0xa1, 0xfd, 0x0f, 0xb7, 0x3b, 0x79,
+	0xa6, 0xbf, 0x2d, 0x31, 0x17, 0x7c, 0x92, 0x5f, 0x7c, 0x55, 0xdb, 0x57, 0xd1, 0xda, 0x28, 0x74,
+	0x0f, 0xed, 0x78, 0x06, 0x71, 0xff, 0xaf, 0x15, 0x84, 0xf9, 0xec, 0x1c, 0x25, 0x31, 0xd8, 0xc1,
+	0x21, 0x50, 0x6a, 0x7b, 0x80, 0x47, 0xa8, 0x35, 0x67, 0x68, 0x31, 0x43, 0x5a, 0xed, 0xbb, 0x25,
+	0x4d, 0x9c, 0x18, 0x0c, 0x6e, 0x5a, 0x79, 0x7d, 0xd0, 0xb0, 0xc4, 0x69, 0x7c, 0x8c, 0x54, 0x51,
+	0x3b, 0x96, 0x17, 0x2d, 0x96, 0xe0, 0x4e, 0x8d, 0xac, 0x2a, 0xbc, 0xf8, 0xc0, 0x4f, 0x2e, 0xe4,
+	0x1a, 0x21, 0xaa, 0x70, 0xfc, 0x05, 0x6a, 0x42, 0xe8, 0xf2, 0x69, 0x53, 0x77, 0x3b, 0x35, 0xb6,
+	0x8b, 0xc4, 0x44, 0xaf, 0x21, 0x74, 0x6b, 0x2c, 0xec, 0x1c, 0xfe, 0x01, 0xad, 0x17, 0xa5, 0x15,
+	0x51, 0xad, 0x2e, 0x49, 0x51, 0xea, 0xcc, 0xf0, 0x6e, 0x9e, 0xe9, 0xb7, 0xa3, 0x0a, 0xa8, 0x31,
+	0xaa, 0x92, 0x62, 0x78, 0x0d, 0xb5, 0x78, 0x7b, 0x76, 0x7f, 0x57, 0x90, 0x3a, 0x2a, 0xe8, 0xbe,
+	0x8a, 0x7c, 0xfc, 0xa8, 0xb8, 0xdf, 0x44, 0xe5, 0x28, 0xbe, 0xfb, 0xc6, 0x7b, 0xa0, 0xab, 0x5f,
+	0x55, 0xd5, 0x5a, 0x33, 0x50, 0x3e, 0x52, 0xf0, 0x97, 0x68, 0xdd, 0x82, 0x88, 0xc4, 0x09, 0xbf,
+	0x65, 0x29, 0xbe, 0x54, 0x84, 0xf2, 0x8e, 0xee, 0x76, 0x0c, 0xf1, 0x66, 0x30, 0xca, 0xd7, 0x80,
+	0x31, 0x62, 0x71, 0x0f, 0x1f, 0xbf, 0xfe, 0xa7, 0xd7, 0xf8, 0xf9, 0xac, 0xa7, 0xbc, 0x3c, 0xeb,
+	0x29, 0xaf, 0xce, 0x7a, 0xca, 0xdf, 0x67, 0x3d, 0xe5, 0x97, 0xf3, 0x5e, 0xe3, 0xd5, 0x79, 0xaf,
+	0xf1, 0xfa, 0xbc, 0xd7, 0xf8, 0xd1, 0x94, 0xde, 0x13, 0x62, 0xf0, 0xa2, 0x98, 0x4c, 0x61, 0x92,
+	0x14, 0x92, 0x79, 0xe9, 0xc1, 0xe3, 0xb4, 0xb9, 0x8b, 0x8f, 0xff, 0x1d, 0x00, 0x89, 0xf5, 0xa3,
+	0x24, 0x0a, 0x09, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -789,6 +802,16 @@ func (m *LeaseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MoreToFollow {
+		i--
+		if m.MoreToFollow {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
 	if len(m.UnassignedJobRunIds) > 0 {
 		for iNdEx := len(m.UnassignedJobRunIds) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1242,6 +1265,9 @@ func (m *LeaseRequest) Size() (n int) {
 			n += 1 + l + sovExecutorapi(uint64(l))
 		}
 	}
+	if m.MoreToFollow {
+		n += 2
+	}
 	return n
 }
 
@@ -2061,6 +2087,26 @@ func (m *LeaseRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MoreToFollow", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExecutorapi
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MoreToFollow = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipExecutorapi(dAtA[iNdEx:])